@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/adapters/repositories"
+	"github/be2bag/leave-management-system/internal/config"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/services"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+	"github/be2bag/leave-management-system/pkg/spreadsheet"
+)
+
+// ─── Import Command ─────────────────────────────────────────────────────
+// นำเข้าผู้ใช้และยอดวันลายกมาจากไฟล์ CSV (รูปแบบเดียวกับ POST /api/v1/admin/users/import)
+// บันทึกผ่าน repository และบันทึก audit log ในนามระบบ — ไม่ส่งอีเมลหรือ webhook
+//
+// วิธีใช้:
+//
+//	go run ./cmd/import -file users.csv -dry-run   # ตรวจอย่างเดียว
+//	go run ./cmd/import -file users.csv -upsert    # นำเข้าและแก้ผู้ใช้ที่มีอยู่แล้ว
+//
+// คืน exit code 1 เมื่อมีแถวที่ไม่ผ่านหรือการนำเข้าล้มเหลว
+// ─────────────────────────────────────────────────────────────────────────
+
+// errRowsFailed มีแถวที่นำเข้าไม่สำเร็จ — รายละเอียดถูกพิมพ์ในตารางผลลัพธ์แล้ว
+var errRowsFailed = errors.New("มีแถวที่นำเข้าไม่สำเร็จ")
+
+// importTimeout เวลาสูงสุดของการนำเข้าหนึ่งไฟล์
+const importTimeout = 30 * time.Minute
+
+func main() {
+	path := flag.String("file", "", "ไฟล์ CSV ที่จะนำเข้า (บังคับ)")
+	dryRun := flag.Bool("dry-run", false, "ตรวจอย่างเดียว ไม่บันทึก")
+	upsert := flag.Bool("upsert", false, "แก้ชื่อ แผนก บทบาท และยอดวันลาของผู้ใช้ที่มีอีเมลอยู่แล้ว")
+	year := flag.Int("year", 0, "ปีของยอดวันลาสำหรับแถวที่ไม่ระบุ year (ค่าเริ่มต้นคือปีปัจจุบัน)")
+	flag.Parse()
+
+	if *path == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	opts := domain.UserImportOptions{DryRun: *dryRun, Upsert: *upsert, Year: *year}
+	if err := run(*path, opts); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func run(path string, opts domain.UserImportOptions) error {
+	file, err := readFile(path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("โหลด configuration ล้มเหลว: %w", err)
+	}
+	db, err := database.NewMongoDB(cfg)
+	if err != nil {
+		return fmt.Errorf("เชื่อมต่อ MongoDB ล้มเหลว: %w", err)
+	}
+	defer closeDB(db)
+
+	ctx, cancel := context.WithTimeout(context.Background(), importTimeout)
+	defer cancel()
+	ctx = domain.ContextWithRequestMeta(ctx, &domain.RequestMeta{
+		ActorType: domain.AuditActorSystem,
+		UserAgent: "cmd/import",
+	})
+
+	userRepo := repositories.NewUserRepository(db)
+	roleRepo := repositories.NewRoleRepository(db)
+	audit := services.NewAuditService(repositories.NewAuditEventRepository(db))
+	roleService := services.NewRoleService(roleRepo, userRepo, audit)
+	if err := roleService.EnsureDefaultRoles(ctx); err != nil {
+		return fmt.Errorf("สร้างบทบาทเริ่มต้นล้มเหลว: %w", err)
+	}
+
+	importService := services.NewUserImportService(
+		userRepo, repositories.NewUserProfileRepository(db), repositories.NewLeaveBalanceRepository(db),
+		roleRepo, roleService, audit, repositories.NewTransactionManager(db),
+	)
+	report, err := importService.Import(ctx, file, opts)
+	if err != nil {
+		return err
+	}
+
+	printReport(report)
+	if report.Failed > 0 {
+		return errRowsFailed
+	}
+	return nil
+}
+
+// readFile อ่านไฟล์ CSV ก่อนเชื่อมต่อฐานข้อมูล — ไฟล์ผิดรูปแบบจะรู้ทันที
+func readFile(path string) (domain.UserImportFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return domain.UserImportFile{}, fmt.Errorf("เปิดไฟล์ล้มเหลว: %w", err)
+	}
+	defer f.Close()
+
+	header, rows, err := spreadsheet.ReadCSV(f, domain.MaxImportRows)
+	if err != nil {
+		return domain.UserImportFile{}, fmt.Errorf("%w: %w", domain.ErrInvalidImportFile, err)
+	}
+	file := domain.UserImportFile{Header: header, Records: make([]domain.UserImportRecord, 0, len(rows))}
+	for _, r := range rows {
+		file.Records = append(file.Records, domain.UserImportRecord{Line: r.Line, Values: r.Values})
+	}
+	return file, nil
+}
+
+func printReport(report *domain.UserImportReport) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tEMAIL\tACTION\tERROR")
+	for _, r := range report.Rows {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", r.Line, r.Email, r.Action, r.Error)
+	}
+	w.Flush()
+
+	mode := "นำเข้า"
+	if report.DryRun {
+		mode = "ตรวจ (dry run — ยังไม่บันทึก)"
+	}
+	fmt.Printf("\n%s: สร้าง %d, แก้ไข %d, ไม่ผ่าน %d\n", mode, report.Created, report.Updated, report.Failed)
+}
+
+func closeDB(db *database.MongoDB) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := db.Close(ctx); err != nil {
+		log.Printf("ปิดการเชื่อมต่อ MongoDB ไม่สำเร็จ: %v", err)
+	}
+}
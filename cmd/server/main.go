@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -19,8 +21,14 @@ import (
 	_ "github/be2bag/leave-management-system/docs"
 	"github/be2bag/leave-management-system/internal/adapters/handlers"
 	apphttp "github/be2bag/leave-management-system/internal/adapters/http"
+	"github/be2bag/leave-management-system/internal/adapters/ldap"
+	"github/be2bag/leave-management-system/internal/adapters/mailer"
+	"github/be2bag/leave-management-system/internal/adapters/oidc"
 	"github/be2bag/leave-management-system/internal/adapters/repositories"
+	"github/be2bag/leave-management-system/internal/adapters/webhook"
 	"github/be2bag/leave-management-system/internal/config"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
 	"github/be2bag/leave-management-system/internal/core/services"
 	"github/be2bag/leave-management-system/internal/infrastructure/database"
 	"github/be2bag/leave-management-system/pkg/validator"
@@ -44,6 +52,11 @@ import (
 // @name Authorization
 // @description กรุณาใส่ Bearer token เช่น "Bearer eyJhbGciOiJIUzI1NiIs..."
 
+// @securityDefinitions.apikey APIKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key ของ service account สำหรับ /api/v1/integrations เช่น "lms_..."
+
 const shutdownTimeout = 10 * time.Second
 
 func main() {
@@ -64,32 +77,182 @@ func run() error {
 	defer closeDB(db)
 	log.Println("✅ เชื่อมต่อ MongoDB สำเร็จ")
 
-	userRepo := repositories.NewUserRepository(db)
-	balanceRepo := repositories.NewLeaveBalanceRepository(db)
-	requestRepo := repositories.NewLeaveRequestRepository(db)
-
-	jwtExpireHours := parseJWTExpireHours(cfg.JWTExpireHours)
-	tokenService := services.NewTokenService(cfg.JWTSecret, jwtExpireHours)
-	authService := services.NewAuthService(userRepo, tokenService)
-	leaveService := services.NewLeaveService(requestRepo, balanceRepo)
+	accessTTL := time.Duration(parsePositiveInt(cfg.JWTAccessExpireMinutes, 15)) * time.Minute
+	revocationStore := newTokenRevocationStore(cfg.TokenRevocationStore, db, accessTTL)
 
-	validate := validator.New()
+	keyRing, err := loadKeyRing(cfg)
+	if err != nil {
+		return fmt.Errorf("โหลดคีย์สำหรับ JWT ล้มเหลว: %w", err)
+	}
+	userRepo := repositories.NewUserRepository(db)
+	auditService := services.NewAuditService(repositories.NewAuditEventRepository(db))
+	roleService, err := newRoleService(db, userRepo, auditService)
+	if err != nil {
+		return err
+	}
+	mail, err := newMailer(cfg)
+	if err != nil {
+		return err
+	}
+	notifier := newNotifier(cfg, userRepo, roleService, mail)
+	webhookService, stopWebhooks := startWebhooks(db, roleService, auditService)
+	defer stopWebhooks()
+	outboxRepo := repositories.NewOutboxRepository(db)
+	// หยุดก่อน webhook dispatcher และระบบแจ้งเตือน (defer ทำงานย้อนลำดับ)
+	defer startEventDispatcher(outboxRepo, notifier, webhookService)()
+	eventStream := services.NewEventStream(outboxRepo, roleService, revocationStore, services.EventStreamOptions{
+		OnError: func(err error) { log.Printf("⚠️  %v", err) },
+	})
+	defer startBackground(eventStream.Run)()
 
-	authHandler := handlers.NewAuthHandler(authService, validate)
-	leaveHandler := handlers.NewLeaveHandler(leaveService, validate)
+	core := coreServices{
+		keyRing:         keyRing,
+		tokenService:    services.NewTokenService(keyRing, accessTTL, revocationStore),
+		revocationStore: revocationStore,
+		apiKeyService:   services.NewAPIKeyService(repositories.NewServiceAccountRepository(db), auditService),
+		roleService:     roleService,
+		auditService:    auditService,
+		userRepo:        userRepo,
+		mailer:          mail,
+		outboxRepo:      outboxRepo,
+		txManager:       repositories.NewTransactionManager(db),
+		webhookService:  webhookService,
+		eventStream:     eventStream,
+	}
+	hs, err := newHandlers(cfg, db, core)
+	if err != nil {
+		return err
+	}
+	mfaPolicy, err := newMFAPolicy(cfg)
+	if err != nil {
+		return err
+	}
 
 	app := createFiberApp(cfg.CORSOrigins)
 
 	app.Get("/swagger/*", swagger.HandlerDefault)
-	apphttp.SetupRouter(app, authHandler, leaveHandler, tokenService)
+	idempotencyTTL := time.Duration(parsePositiveInt(cfg.IdempotencyKeyTTLHours, 24)) * time.Hour
+	idempotency := services.NewIdempotencyService(repositories.NewIdempotencyRepository(db), idempotencyTTL)
+	apphttp.SetupRouter(app, hs, core.tokenService, core.apiKeyService, core.roleService, idempotency, mfaPolicy)
 
-	go gracefulShutdown(app)
+	go gracefulShutdown(app, eventStream)
 
 	log.Printf(" Swagger UI: http://localhost:%s/swagger/index.html", cfg.ServerPort)
 	log.Printf("🚀 Leave Management System API กำลังทำงานที่พอร์ต %s", cfg.ServerPort)
 	return app.Listen(":" + cfg.ServerPort)
 }
 
+// coreServices service ที่ router และ handler หลายตัวใช้ร่วมกัน — สร้างครั้งเดียวใน run
+type coreServices struct {
+	keyRing         *services.KeyRing
+	tokenService    ports.TokenService
+	revocationStore ports.TokenRevocationStore
+	apiKeyService   ports.APIKeyService
+	roleService     ports.RoleService
+	auditService    ports.AuditService
+	userRepo        ports.UserRepository
+	mailer          ports.Mailer
+	outboxRepo      ports.OutboxRepository
+	txManager       ports.TransactionManager
+	webhookService  ports.WebhookService
+	eventStream     ports.EventStream
+}
+
+// newRoleService สร้าง RoleService และบทบาทเริ่มต้นที่ยังไม่มีในฐานข้อมูล
+func newRoleService(db *database.MongoDB, userRepo ports.UserRepository, audit ports.AuditLogger) (ports.RoleService, error) {
+	roleService := services.NewRoleService(repositories.NewRoleRepository(db), userRepo, audit)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := roleService.EnsureDefaultRoles(ctx); err != nil {
+		return nil, fmt.Errorf("สร้างบทบาทเริ่มต้นล้มเหลว: %w", err)
+	}
+	return roleService, nil
+}
+
+// newReportService สร้างบริการรายงาน — จำนวนวันที่ยกไปปีถัดไปได้ใช้คัดยอดวันลาที่เสี่ยงหาย
+func newReportService(cfg *config.Config, db *database.MongoDB) ports.ReportService {
+	carryOverDays := float64(parsePositiveInt(cfg.LeaveCarryOverMaxDays, 5))
+	return services.NewReportService(repositories.NewReportRepository(db), carryOverDays)
+}
+
+// newUserImportService สร้างบริการนำเข้าผู้ใช้ — ใช้ RoleService เป็นตัวตรวจสิทธิ์
+func newUserImportService(db *database.MongoDB, core coreServices, balanceRepo ports.LeaveBalanceRepository) ports.UserImportService {
+	return services.NewUserImportService(
+		core.userRepo, repositories.NewUserProfileRepository(db), balanceRepo,
+		repositories.NewRoleRepository(db), core.roleService, core.auditService, core.txManager,
+	)
+}
+
+// newHandlers สร้าง repository, service และ handler ทั้งหมดของระบบ
+func newHandlers(cfg *config.Config, db *database.MongoDB, core coreServices) (apphttp.Handlers, error) {
+	userRepo, tokenService, audit, mail := core.userRepo, core.tokenService, core.auditService, core.mailer
+	refreshTokenRepo := repositories.NewRefreshTokenRepository(db)
+	loginAttemptRepo := repositories.NewLoginAttemptRepository(db)
+	securityEventRepo := repositories.NewSecurityEventRepository(db)
+	mfaRepo := repositories.NewMFARepository(db)
+
+	directory, err := newDirectoryOptions(cfg)
+	if err != nil {
+		return apphttp.Handlers{}, err
+	}
+	refreshTTL := time.Duration(parsePositiveInt(cfg.JWTRefreshExpireHours, 168)) * time.Hour
+	authService := services.NewAuthService(
+		userRepo, refreshTokenRepo, loginAttemptRepo, securityEventRepo, mfaRepo, repositories.NewMFAChallengeRepository(db),
+		tokenService, audit, services.AuthOptions{LockoutPolicy: lockoutPolicy(cfg), RefreshTTL: refreshTTL, Directory: directory},
+	)
+	mfaService := services.NewMFAService(mfaRepo, userRepo, cfg.MFAIssuer)
+	accountLockService := services.NewAccountLockService(userRepo, loginAttemptRepo, securityEventRepo, core.roleService, audit)
+	sessionService := services.NewSessionService(userRepo, refreshTokenRepo, core.revocationStore, audit)
+	requestRepo, historyRepo := repositories.NewLeaveRequestRepository(db), repositories.NewLeaveHistoryRepository(db)
+	balanceRepo := repositories.NewLeaveBalanceRepository(db)
+	leaveService := services.NewLeaveService(
+		requestRepo, historyRepo, balanceRepo, userRepo, core.roleService, audit, core.outboxRepo, core.txManager,
+	)
+	readService := services.NewLeaveReadService(requestRepo, balanceRepo, userRepo, core.roleService)
+	amendService := services.NewLeaveAmendService(requestRepo, historyRepo, balanceRepo, audit, core.txManager)
+
+	commentService := services.NewLeaveCommentService(
+		requestRepo, repositories.NewLeaveCommentRepository(db), historyRepo, userRepo, core.roleService,
+		core.outboxRepo, core.txManager,
+	)
+	resetTTL := time.Duration(parsePositiveInt(cfg.PasswordResetExpireMinutes, 30)) * time.Minute
+	passwordService := services.NewPasswordService(
+		userRepo, repositories.NewPasswordResetRepository(db), sessionService, mail, resetTTL, cfg.PasswordResetURL,
+	)
+
+	validate, err := newValidator(cfg)
+	if err != nil {
+		return apphttp.Handlers{}, err
+	}
+
+	oidcHandler, err := newOIDCHandler(cfg, db, core, refreshTokenRepo, refreshTTL)
+	if err != nil {
+		return apphttp.Handlers{}, err
+	}
+
+	return apphttp.Handlers{
+		Auth:     handlers.NewAuthHandler(authService, sessionService, validate),
+		Password: handlers.NewPasswordHandler(passwordService, validate),
+		MFA:      handlers.NewMFAHandler(mfaService, validate),
+		OIDC:     oidcHandler,
+		Leave:    handlers.NewLeaveHandler(leaveService, readService, amendService, validate),
+		Comment:  handlers.NewLeaveCommentHandler(commentService, validate),
+		Admin:    handlers.NewAdminHandler(sessionService, accountLockService),
+		Role:     handlers.NewRoleHandler(core.roleService, validate),
+		Audit:    handlers.NewAuditHandler(audit),
+		Report:   handlers.NewReportHandler(newReportService(cfg, db)),
+		Export:   handlers.NewExportHandler(services.NewExportService(repositories.NewExportRepository(db))),
+		Import:   handlers.NewUserImportHandler(newUserImportService(db, core, balanceRepo)),
+		JWKS:     handlers.NewJWKSHandler(core.keyRing),
+
+		ServiceAccount: handlers.NewServiceAccountHandler(core.apiKeyService, validate),
+		Integration:    handlers.NewIntegrationHandler(leaveService, validate),
+		Webhook:        handlers.NewWebhookHandler(core.webhookService, validate),
+		EventStream:    handlers.NewEventStreamHandler(core.eventStream),
+	}, nil
+}
+
 func createFiberApp(corsOrigins string) *fiber.App {
 	app := fiber.New(fiber.Config{
 		AppName:   "Leave Management System API",
@@ -99,20 +262,22 @@ func createFiberApp(corsOrigins string) *fiber.App {
 	app.Use(recover.New()) // จับ panic ป้องกัน server crash
 	app.Use(logger.New())  // บันทึก HTTP request log
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: corsOrigins,
-		AllowMethods: "GET,POST,PUT,DELETE,PATCH,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+		AllowOrigins:  corsOrigins,
+		AllowMethods:  "GET,POST,PUT,DELETE,PATCH,OPTIONS",
+		AllowHeaders:  "Origin,Content-Type,Accept,Authorization,Last-Event-ID,Idempotency-Key",
+		ExposeHeaders: "Idempotent-Replayed",
 	}))
 
 	return app
 }
 
-func gracefulShutdown(app *fiber.App) {
+func gracefulShutdown(app *fiber.App, eventStream ports.EventStream) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Println("⏳ กำลังปิด server...")
+	eventStream.Close() // SSE ไม่จบเอง — ปิดก่อนเพื่อไม่ให้ต้องรอจนหมดเวลา
 	if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
 		log.Printf("ปิด server ไม่สำเร็จ: %v", err)
 	}
@@ -127,10 +292,272 @@ func closeDB(db *database.MongoDB) {
 	}
 }
 
-func parseJWTExpireHours(s string) int {
-	hours, err := strconv.Atoi(s)
+// loadKeyRing โหลดคีย์สำหรับ sign/verify JWT
+// - มี JWT_SIGNING_KEY_FILE: sign ด้วย RS256/EdDSA, JWT_SECRET ใช้ตรวจสอบ token HS256 เดิมเฉพาะเมื่อตั้ง JWT_ACCEPT_LEGACY_HS256=true
+// - ไม่มี: sign ด้วย HS256 จาก JWT_SECRET เหมือนเดิม
+func loadKeyRing(cfg *config.Config) (*services.KeyRing, error) {
+	var verificationKeys []services.SigningKey
+	for _, path := range strings.Split(cfg.JWTVerificationKeys, ",") {
+		if path = strings.TrimSpace(path); path == "" {
+			continue
+		}
+		key, err := loadPEMSigningKey(path)
+		if err != nil {
+			return nil, err
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	if cfg.JWTSigningKeyFile == "" {
+		return services.NewKeyRing(services.NewHMACSigningKey(cfg.JWTSecret), verificationKeys...)
+	}
+
+	active, err := loadPEMSigningKey(cfg.JWTSigningKeyFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.JWTSecret != "" && cfg.JWTAcceptLegacyHS256 == "true" {
+		log.Printf("⚠️  JWT_ACCEPT_LEGACY_HS256=true — ยังยอมรับ token HS256 จาก JWT_SECRET ให้ปิดเมื่อ token เดิมหมดอายุแล้ว")
+		verificationKeys = append(verificationKeys, services.NewHMACSigningKey(cfg.JWTSecret))
+	}
+
+	log.Printf("🔑 sign JWT ด้วย %s (kid: %s)", active.Method.Alg(), active.ID)
+	return services.NewKeyRing(active, verificationKeys...)
+}
+
+// loadPEMSigningKey อ่านไฟล์คีย์ PEM
+func loadPEMSigningKey(path string) (services.SigningKey, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path มาจาก configuration ของผู้ดูแลระบบ
+	if err != nil {
+		return services.SigningKey{}, fmt.Errorf("อ่านไฟล์คีย์ %s ล้มเหลว: %w", path, err)
+	}
+
+	key, err := services.ParsePEMSigningKey(data)
 	if err != nil {
-		return 24
+		return services.SigningKey{}, fmt.Errorf("ไฟล์คีย์ %s: %w", path, err)
+	}
+	return key, nil
+}
+
+// lockoutPolicy สร้างนโยบายล็อกบัญชีจาก configuration (ค่าที่ไม่ได้กำหนดใช้ค่าเริ่มต้น)
+func lockoutPolicy(cfg *config.Config) domain.LockoutPolicy {
+	policy := domain.DefaultLockoutPolicy()
+	policy.MaxFailedAttempts = parsePositiveInt(cfg.LoginMaxFailedAttempts, policy.MaxFailedAttempts)
+	policy.LockoutDuration = time.Duration(parsePositiveInt(cfg.LoginLockoutMinutes, 15)) * time.Minute
+	return policy
+}
+
+// newMFAPolicy อ่านสิทธิ์ที่ต้องใช้ 2FA จาก configuration ("none" = ไม่บังคับ)
+func newMFAPolicy(cfg *config.Config) (domain.MFAPolicy, error) {
+	var policy domain.MFAPolicy
+	if cfg.MFARequiredRoles != "" {
+		// ปล่อยผ่านเงียบๆ จะทำให้บทบาทที่เคยถูกบังคับ 2FA หลุดจากนโยบายโดยไม่รู้ตัว
+		return policy, errors.New("MFA_REQUIRED_ROLES ยกเลิกแล้ว — ใช้ MFA_REQUIRED_PERMISSIONS แทน")
+	}
+	if cfg.MFARequiredPermissions == "none" {
+		return policy, nil
+	}
+
+	for _, name := range strings.Split(cfg.MFARequiredPermissions, ",") {
+		permission := domain.Permission(strings.TrimSpace(name))
+		if !permission.IsValid() {
+			return policy, fmt.Errorf("MFA_REQUIRED_PERMISSIONS มีสิทธิ์ไม่ถูกต้อง: %q", name)
+		}
+		policy.RequiredPermissions = append(policy.RequiredPermissions, permission)
+	}
+	return policy, nil
+}
+
+// newOIDCHandler สร้าง handler สำหรับ SSO ผ่าน OpenID Connect — คืน nil เมื่อไม่ได้กำหนด OIDC_ISSUER_URL
+func newOIDCHandler(
+	cfg *config.Config,
+	db *database.MongoDB,
+	core coreServices,
+	refreshRepo ports.RefreshTokenRepository,
+	refreshTTL time.Duration,
+) (*handlers.OIDCHandler, error) {
+	if cfg.OIDCIssuerURL == "" {
+		return nil, nil
+	}
+
+	roles, err := parseGroupRoleMapping("OIDC", cfg.OIDCRoleMapping, cfg.OIDCDefaultRole)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := oidc.NewProvider(oidc.Config{
+		IssuerURL:    cfg.OIDCIssuerURL,
+		ClientID:     cfg.OIDCClientID,
+		ClientSecret: cfg.OIDCClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL,
+		GroupsClaim:  cfg.OIDCGroupsClaim,
+		Scopes:       strings.Fields(cfg.OIDCScopes),
+	})
+	oidcService := services.NewOIDCService(
+		provider, repositories.NewOIDCStateRepository(db), core.userRepo, refreshRepo, core.tokenService, core.auditService,
+		roles, refreshTTL,
+	)
+
+	log.Printf("🔐 เปิดใช้ SSO ผ่าน %s", cfg.OIDCIssuerURL)
+	return handlers.NewOIDCHandler(oidcService), nil
+}
+
+// newDirectoryOptions ตั้งค่าการยืนยันตัวตนกับ LDAP/Active Directory — ปิดใช้เมื่อไม่ได้กำหนด LDAP_URL
+func newDirectoryOptions(cfg *config.Config) (services.DirectoryOptions, error) {
+	if cfg.LDAPURL == "" {
+		return services.DirectoryOptions{}, nil
+	}
+
+	roles, err := parseGroupRoleMapping("LDAP", cfg.LDAPRoleMapping, cfg.LDAPDefaultRole)
+	if err != nil {
+		return services.DirectoryOptions{}, err
+	}
+
+	var domains []string
+	for _, d := range strings.Split(cfg.LDAPEmailDomains, ",") {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+
+	log.Printf("📒 ยืนยันตัวตนกับ LDAP ที่ %s (โดเมน: %s)", cfg.LDAPURL, strings.Join(domains, ", "))
+	return services.DirectoryOptions{
+		Authenticator: ldap.NewAuthenticator(ldap.Config{
+			URL:          cfg.LDAPURL,
+			BindDN:       cfg.LDAPBindDN,
+			BindPassword: cfg.LDAPBindPassword,
+			BaseDN:       cfg.LDAPBaseDN,
+			UserFilter:   cfg.LDAPUserFilter,
+			IDAttribute:  cfg.LDAPIDAttribute,
+			GroupBaseDN:  cfg.LDAPGroupBaseDN,
+			GroupFilter:  cfg.LDAPGroupFilter,
+			StartTLS:     cfg.LDAPStartTLS == "true",
+		}),
+		EmailDomains: domains,
+		Roles:        roles,
+	}, nil
+}
+
+// parseGroupRoleMapping อ่านการแปลงกลุ่มเป็นบทบาทในรูปแบบ "กลุ่ม=บทบาท,กลุ่ม=บทบาท" — prefix คือชื่อกลุ่มของ environment variable
+func parseGroupRoleMapping(prefix, mapping, defaultRole string) (domain.GroupRoleMapping, error) {
+	roles := domain.GroupRoleMapping{
+		Groups:      make(map[string]domain.Role),
+		DefaultRole: domain.Role(defaultRole),
+	}
+	if !roles.DefaultRole.IsValid() {
+		return roles, fmt.Errorf("%s_DEFAULT_ROLE ไม่ถูกต้อง: %q", prefix, defaultRole)
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=")
+		r := domain.Role(strings.TrimSpace(role))
+		if !ok || strings.TrimSpace(group) == "" || !r.IsValid() {
+			return roles, fmt.Errorf("%s_ROLE_MAPPING ไม่ถูกต้อง: %q", prefix, pair)
+		}
+		roles.Groups[strings.ToLower(strings.TrimSpace(group))] = r
+	}
+	return roles, nil
+}
+
+// newMailer เลือกวิธีส่งอีเมลตาม configuration
+func newMailer(cfg *config.Config) (ports.Mailer, error) {
+	switch cfg.Mailer {
+	case "smtp":
+		log.Printf("📧 ส่งอีเมลผ่าน SMTP ที่ %s:%s", cfg.SMTPHost, cfg.SMTPPort)
+		return mailer.NewSMTPMailer(mailer.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.MailFrom,
+		}), nil
+	case "file":
+		m, err := mailer.NewFileMailer(cfg.MailerFileDir, cfg.MailFrom)
+		if err != nil {
+			return nil, fmt.Errorf("สร้าง file mailer ล้มเหลว: %w", err)
+		}
+		log.Printf("📧 เขียนอีเมลเป็นไฟล์ที่ %s", cfg.MailerFileDir)
+		return m, nil
+	default:
+		return mailer.NewConsoleMailer(cfg.MailFrom), nil
+	}
+}
+
+// newNotifier สร้างระบบแจ้งเตือนใบลาทางอีเมล — ส่งไม่สำเร็จ outbox ลองใหม่และเขียน log ผ่าน dispatcher
+func newNotifier(
+	cfg *config.Config,
+	userRepo ports.UserRepository,
+	roles ports.RoleService,
+	mail ports.Mailer,
+) ports.NotificationService {
+	return services.NewNotificationService(userRepo, roles, mail, services.NotificationOptions{
+		DefaultLocale: domain.Locale(cfg.NotificationLocale),
+	})
+}
+
+// startWebhooks สร้าง WebhookService และเริ่ม dispatcher ที่ส่งรายการใน outbox — คืนฟังก์ชันสำหรับหยุด dispatcher
+func startWebhooks(db *database.MongoDB, authorizer ports.Authorizer, audit ports.AuditLogger) (ports.WebhookService, func()) {
+	subscriptionRepo := repositories.NewWebhookSubscriptionRepository(db)
+	deliveryRepo := repositories.NewWebhookDeliveryRepository(db)
+
+	webhookService := services.NewWebhookService(subscriptionRepo, deliveryRepo, authorizer, audit)
+	dispatcher := services.NewWebhookDispatcher(
+		subscriptionRepo, deliveryRepo, webhook.NewHTTPSender("LeaveManagementSystem-Webhook/1.0"),
+		services.WebhookDispatcherOptions{
+			OnError: func(err error) { log.Printf("⚠️  ส่ง webhook ไม่สำเร็จ: %v", err) },
+		},
+	)
+	return webhookService, startBackground(dispatcher.Run)
+}
+
+// startEventDispatcher เริ่ม dispatcher ที่ส่งต่อเหตุการณ์ใน outbox ให้อีเมลและ webhook — คืนฟังก์ชันสำหรับหยุด dispatcher
+func startEventDispatcher(outboxRepo ports.OutboxRepository, eventHandlers ...ports.EventHandler) func() {
+	dispatcher := services.NewEventDispatcher(outboxRepo, eventHandlers, services.EventDispatcherOptions{
+		OnError: func(err error) { log.Printf("⚠️  %v", err) },
+	})
+	return startBackground(dispatcher.Run)
+}
+
+// startBackground รัน run ใน goroutine — คืนฟังก์ชันที่ยกเลิก ctx และรอจน run จบ
+func startBackground(run func(ctx context.Context)) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		run(ctx)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// newValidator สร้าง validator พร้อมนโยบายความแข็งแรงของรหัสผ่านตาม configuration
+func newValidator(cfg *config.Config) (*validator.Validator, error) {
+	policy, err := validator.ParsePasswordPolicy(parsePositiveInt(cfg.PasswordMinLength, 10), cfg.PasswordRequire)
+	if err != nil {
+		return nil, fmt.Errorf("PASSWORD_REQUIRE ไม่ถูกต้อง: %w", err)
+	}
+	return validator.NewWithPasswordPolicy(policy), nil
+}
+
+// newTokenRevocationStore เลือกที่เก็บ token denylist ตาม configuration
+func newTokenRevocationStore(kind string, db *database.MongoDB, accessTTL time.Duration) ports.TokenRevocationStore {
+	if kind == "memory" {
+		log.Println("⚠️  ใช้ token denylist แบบ in-memory — ไม่แชร์ระหว่างหลาย instance และหายเมื่อ restart")
+		return repositories.NewMemoryTokenRevocationStore(accessTTL)
+	}
+	return repositories.NewTokenRevocationRepository(db, accessTTL)
+}
+
+// parsePositiveInt แปลงข้อความเป็นจำนวนเต็มบวก — คืนค่า fallback ถ้าแปลงไม่ได้หรือไม่เป็นบวก
+func parsePositiveInt(s string, fallback int) int {
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return fallback
 	}
-	return hours
+	return n
 }
@@ -22,112 +22,109 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/auth/login": {
-            "post": {
-                "description": "ยืนยันตัวตนด้วยอีเมลและรหัสผ่าน จะได้รับ JWT token กลับมา",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "public keys (RS256/EdDSA) ที่ใช้ตรวจสอบ access token รวมคีย์เก่าที่ยังอยู่ระหว่าง rotate — เลือกคีย์ด้วย header kid ของ token (คีย์ HS256 จะไม่ถูกเผยแพร่)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Authentication"
                 ],
-                "summary": "เข้าสู่ระบบ",
-                "parameters": [
-                    {
-                        "description": "ข้อมูลสำหรับเข้าสู่ระบบ",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.LoginRequest"
-                        }
-                    }
-                ],
+                "summary": "JSON Web Key Set",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.APIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/dto.AuthResponse"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
+                            "$ref": "#/definitions/domain.JSONWebKeySet"
                         }
                     }
                 }
             }
         },
-        "/api/v1/leaves": {
-            "post": {
+        "/api/v1/admin/audit-events": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "สร้างคำขอลาใหม่ ระบบจะตรวจสอบวันลาซ้ำซ้อนและยอดวันลาคงเหลือโดยอัตโนมัติ",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "ดึงบันทึกว่าใครทำอะไรกับอะไร เรียงจากใหม่ไปเก่า กรองตามผู้กระทำ การกระทำ สิ่งที่ถูกกระทำ และช่วงเวลาได้ รองรับ pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Leave"
+                    "Admin"
                 ],
-                "summary": "ยื่นใบลาใหม่",
+                "summary": "ค้นหา audit log",
                 "parameters": [
                     {
-                        "description": "ข้อมูลสำหรับยื่นใบลา",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.SubmitLeaveRequest"
-                        }
+                        "type": "string",
+                        "description": "รหัสผู้กระทำ (UUID ของผู้ใช้หรือ service account)",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "การกระทำ เช่น leave.approved",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทสิ่งที่ถูกกระทำ (user/leave_request/leave_balance/role/service_account)",
+                        "name": "target_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "รหัสสิ่งที่ถูกกระทำ",
+                        "name": "target_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ตั้งแต่เวลา (RFC3339, รวม)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ถึงเวลา (RFC3339, ไม่รวม)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/dto.APIResponse"
+                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.AuditEventResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -146,14 +143,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    },
-                    "422": {
-                        "description": "Unprocessable Entity",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -167,21 +158,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/leaves/my-balance": {
+        "/api/v1/admin/audit-events/verify": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ดึงข้อมูลยอดวันลาคงเหลือทุกประเภทของผู้ใช้ที่เข้าสู่ระบบ",
+                "description": "คำนวณ hash ของทุก event ใหม่ตั้งแต่ event แรก และรายงาน sequence แรกที่ถูกแก้ไข ลบ หรือแทรก",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Leave"
+                    "Admin"
                 ],
-                "summary": "ดูยอดวันลาคงเหลือ",
+                "summary": "ตรวจความถูกต้องของ audit log",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -194,10 +185,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.LeaveBalanceResponse"
-                                            }
+                                            "$ref": "#/definitions/dto.AuditChainReportResponse"
                                         }
                                     }
                                 }
@@ -210,6 +198,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -219,102 +213,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/leaves/my-requests": {
+        "/api/v1/admin/exports/balances.{format}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ดึงข้อมูลใบลาทั้งหมดของผู้ใช้ที่เข้าสู่ระบบ (รองรับ pagination)",
+                "description": "ส่งออกยอดวันลาพร้อมชื่อ อีเมล และแผนกของพนักงาน เรียงตามปี พนักงาน และประเภทการลา — CSV มี UTF-8 BOM เพื่อให้ Excel แสดงภาษาไทยถูกต้อง",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Leave"
+                    "Admin"
                 ],
-                "summary": "ดูประวัติใบลา",
+                "summary": "ส่งออกยอดวันลา",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
-                        "name": "page",
-                        "in": "query"
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "รูปแบบไฟล์",
+                        "name": "format",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
-                        "name": "page_size",
+                        "type": "string",
+                        "description": "รหัสพนักงาน (UUID)",
+                        "name": "user_id",
                         "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.LeaveRequestResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/api/v1/manager/pending-requests": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "ดึงข้อมูลใบลาทั้งหมดที่มีสถานะ pending สำหรับผู้จัดการ (รองรับ pagination)",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Manager"
-                ],
-                "summary": "ดูใบลารอการอนุมัติ",
-                "parameters": [
                     {
                         "type": "integer",
-                        "default": 1,
-                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
-                        "name": "page",
+                        "description": "ปีของยอดวันลา",
+                        "name": "year",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
-                        "name": "page_size",
+                        "type": "string",
+                        "description": "ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)",
+                        "name": "leave_type",
                         "in": "query"
                     }
                 ],
@@ -322,22 +264,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/dto.LeaveRequestResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "401": {
@@ -352,8 +285,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -361,47 +294,101 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manager/requests/{id}/approve": {
-            "post": {
+        "/api/v1/admin/exports/requests.{format}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ผู้จัดการอนุมัติคำขอลา ระบบจะหักยอดวันลาของพนักงานโดยอัตโนมัติ",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "ส่งออกใบลาพร้อมผู้ยื่นและผู้อนุมัติ ใช้เงื่อนไขเดียวกับ /manager/requests (ไม่แบ่งหน้า) ไฟล์ถูกเขียนจาก MongoDB cursor ทีละแถว — CSV มี UTF-8 BOM เพื่อให้ Excel แสดงภาษาไทยถูกต้อง",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Manager"
+                    "Admin"
                 ],
-                "summary": "อนุมัติใบลา",
+                "summary": "ส่งออกใบลา",
                 "parameters": [
                     {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
                         "type": "string",
-                        "description": "รหัสใบลา (UUID)",
-                        "name": "id",
+                        "description": "รูปแบบไฟล์",
+                        "name": "format",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "หมายเหตุจากผู้อนุมัติ",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ReviewLeaveRequest"
-                        }
+                        "type": "string",
+                        "description": "รหัสพนักงาน (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "สถานะ คั่นด้วย , (pending,approved,rejected,cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)",
+                        "name": "leave_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ใบลาที่คาบเกี่ยวตั้งแต่วันที่ (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ใบลาที่คาบเกี่ยวถึงวันที่ (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้อนุมัติ/ปฏิเสธ (UUID)",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "จำนวนวันลาขั้นต่ำ",
+                        "name": "min_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "จำนวนวันลาสูงสุด",
+                        "name": "max_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "คำค้นในเหตุผลการลา (full-text)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "start_date",
+                        "description": "เรียงตาม field คั่นด้วย , ขึ้นต้น - คือมากไปน้อย (created_at,start_date,end_date,total_days,reviewed_at) สูงสุด 3 field",
+                        "name": "sort",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -427,9 +414,55 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงรายการบทบาททั้งหมดในระบบพร้อมสิทธิ์ของแต่ละบทบาท",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ดูบทบาททั้งหมด",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -443,14 +476,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/manager/requests/{id}/reject": {
-            "post": {
+        "/api/v1/admin/roles/{name}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "ผู้จัดการปฏิเสธคำขอลา ยอดวันลาของพนักงานจะไม่เปลี่ยนแปลง",
+                "description": "กำหนดสิทธิ์ทั้งหมดของบทบาท (แทนที่สิทธิ์เดิม) ชื่อบทบาทใหม่ต้องเป็นตัวพิมพ์เล็ก ตัวเลข หรือ _ ยาว 2–32 ตัวอักษร บทบาท admin ต้องมีสิทธิ์ user.manage เสมอ",
                 "consumes": [
                     "application/json"
                 ],
@@ -458,24 +491,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Manager"
+                    "Admin"
                 ],
-                "summary": "ปฏิเสธใบลา",
+                "summary": "สร้างหรือแก้ไขบทบาท",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "รหัสใบลา (UUID)",
-                        "name": "id",
+                        "description": "ชื่อบทบาท",
+                        "name": "name",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "หมายเหตุจากผู้อนุมัติ",
+                        "description": "คำอธิบายและสิทธิ์ของบทบาท",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.ReviewLeaveRequest"
+                            "$ref": "#/definitions/dto.SaveRoleRequest"
                         }
                     }
                 ],
@@ -483,7 +516,19 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.APIResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RoleResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
@@ -504,6 +549,62 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ลบบทบาทที่สร้างเอง บทบาทเริ่มต้นของระบบและบทบาทที่ยังมีผู้ใช้อยู่ลบไม่ได้",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ลบบทบาท",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ชื่อบทบาท",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -524,88 +625,4447 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.APIResponse": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "description": "ข้อมูลที่ส่งกลับ (ถ้ามี)"
-                },
-                "message": {
-                    "description": "ข้อความอธิบาย",
-                    "type": "string"
-                },
-                "success": {
-                    "description": "สถานะความสำเร็จ",
-                    "type": "boolean"
-                }
-            }
         },
-        "dto.AuthResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "description": "JWT access token",
-                    "type": "string"
-                },
-                "user": {
-                    "description": "ข้อมูลผู้ใช้",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.UserResponse"
+        "/api/v1/admin/service-accounts": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงรายการ service account ทั้งหมด รวมที่ถูกยกเลิกแล้ว พร้อมเวลาที่ใช้ API key ล่าสุด",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ดู service account ทั้งหมด",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ServiceAccountResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
-                    ]
-                }
-            }
-        },
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "description": "รายละเอียดข้อผิดพลาด (ถ้ามี)",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
                     }
-                },
-                "message": {
-                    "description": "ข้อความอธิบายข้อผิดพลาด",
-                    "type": "string"
-                },
-                "success": {
-                    "description": "สถานะ (false เสมอ)",
-                    "type": "boolean"
                 }
-            }
-        },
-        "dto.LeaveBalanceResponse": {
-            "type": "object",
-            "properties": {
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "สร้าง API key สำหรับระบบภายนอก (เช่น HR, payroll) พร้อม scope ที่อนุญาต API key จะแสดงเพียงครั้งเดียว ระบบเก็บเฉพาะ hash",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "สร้าง service account",
+                "parameters": [
+                    {
+                        "description": "ชื่อและ scope ของ service account",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.CreateServiceAccountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/service-accounts/{id}/revoke": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ยกเลิก API key ของ service account ระบบภายนอกที่ใช้ key นี้จะเรียก API ไม่ได้ทันที",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ยกเลิก service account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัส service account (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "หนึ่งแถวต่อผู้ใช้หนึ่งคน คอลัมน์ email, first_name, last_name (บังคับ), department, role, year และยอดวันลา \u003cleave_type\u003e กับ \u003cleave_type\u003e_used เช่น annual_leave, annual_leave_used\nตรวจทีละแถว แถวที่ไม่ผ่านถูกรายงานใน rows และไม่หยุดแถวอื่น — dry_run=true ตรวจอย่างเดียว, upsert=true แก้ผู้ใช้ที่มีอีเมลอยู่แล้ว\nผู้ใช้ใหม่ไม่มีรหัสผ่าน ให้ตั้งรหัสผ่านผ่านลืมรหัสผ่าน หรือ login ผ่าน SSO/LDAP",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "นำเข้าผู้ใช้และยอดวันลายกมาจาก CSV",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "ไฟล์ CSV (UTF-8, ไม่เกิน 5,000 แถว)",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "ตรวจอย่างเดียว ไม่บันทึก",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "แก้ชื่อ แผนก บทบาท และยอดวันลาของผู้ใช้ที่มีอีเมลอยู่แล้ว",
+                        "name": "upsert",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ปีของยอดวันลาสำหรับแถวที่ไม่ระบุ year (ค่าเริ่มต้นคือปีปัจจุบัน)",
+                        "name": "year",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.UserImportResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/balances": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "กำหนดจำนวนวันลาทั้งหมดของพนักงานตามประเภทและปี (สร้างใหม่ถ้ายังไม่มี) ต้องไม่น้อยกว่าวันที่ใช้และจองไว้แล้ว",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "กำหนดจำนวนวันลาของพนักงาน",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้ใช้ (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ประเภท ปี และจำนวนวันลา",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetEntitlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveBalanceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/revoke-sessions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ยกเลิก access token ทุกตัวที่ออกก่อนหน้านี้และ refresh token ทั้งหมดของผู้ใช้ ผู้ใช้ต้อง login ใหม่",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ยกเลิกทุก session ของผู้ใช้",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้ใช้ (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/role": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "กำหนดบทบาทใหม่ให้ผู้ใช้ บทบาทต้องมีอยู่ในระบบ สิทธิ์ใหม่มีผลกับการตรวจใน service ทันที และกับ token ของผู้ใช้หลัง login ใหม่หรือ refresh",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "เปลี่ยนบทบาทของผู้ใช้",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้ใช้ (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "บทบาทใหม่",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AssignRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ล้างจำนวนครั้งที่ login ผิดและยกเลิกการล็อกบัญชี ผู้ใช้ login ได้ทันที การปลดล็อกจะถูกบันทึกเป็น security event",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ปลดล็อกบัญชีผู้ใช้",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้ใช้ (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhook-deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงรายการส่ง webhook เรียงจากใหม่ไปเก่า พร้อมจำนวนครั้งที่ส่งและสาเหตุที่ส่งไม่สำเร็จ ใช้หารายการ dead เพื่อสั่งส่งใหม่ รองรับ pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ดูรายการส่ง webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัส webhook (UUID)",
+                        "name": "subscription_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "สถานะ (pending/delivered/dead)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhook-deliveries/{id}/redeliver": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "นำรายการที่ dead หรือส่งสำเร็จแล้วกลับเข้าคิวส่ง ใช้ payload และ X-Webhook-Event-Id เดิม ปลายทางจึงตัดรายการซ้ำได้",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ส่ง webhook ใหม่",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสรายการส่ง (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookDeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงรายการ webhook ทั้งหมด (ไม่แสดง secret)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ดู webhook ทั้งหมด",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.WebhookResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ลงทะเบียนปลายทางที่รับเหตุการณ์ของใบลา (leave.submitted, leave.approved, leave.rejected, balance.adjusted) ทาง HTTP POST ทุก request มี X-Webhook-Signature = sha256=HMAC-SHA256(secret, X-Webhook-Timestamp + \".\" + body)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "สร้าง webhook",
+                "parameters": [
+                    {
+                        "description": "ปลายทาง secret และเหตุการณ์",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/webhooks/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "แก้ปลายทาง เหตุการณ์ และสถานะเปิด/ปิด ไม่ส่ง secret = ใช้ secret เดิม ปิดใช้งานแล้วรายการที่ค้างอยู่จะถูกย้ายเป็น dead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "แก้ webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัส webhook (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ค่าใหม่ของ webhook",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ลบ webhook รายการที่ยังไม่ได้ส่งจะถูกย้ายเป็น dead และส่งใหม่ไม่ได้",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "ลบ webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัส webhook (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/change-password": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "เปลี่ยนรหัสผ่านโดยยืนยันรหัสผ่านปัจจุบัน รหัสผ่านใหม่ต้องผ่านนโยบายความแข็งแรง เมื่อสำเร็จ token ทุกตัวของผู้ใช้ (รวมถึง token ที่ใช้เรียก) จะถูกยกเลิก ต้อง login ใหม่",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "เปลี่ยนรหัสผ่าน",
+                "parameters": [
+                    {
+                        "description": "รหัสผ่านปัจจุบันและรหัสผ่านใหม่",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/forgot-password": {
+            "post": {
+                "description": "ส่งลิงก์ตั้งรหัสผ่านใหม่ (ใช้ได้ครั้งเดียวและมีอายุจำกัด) ไปยังอีเมลที่ระบุ ตอบกลับเหมือนกันทุกกรณีเพื่อไม่ให้รู้ว่าอีเมลมีอยู่ในระบบหรือไม่",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "ขอลิงก์ตั้งรหัสผ่านใหม่",
+                "parameters": [
+                    {
+                        "description": "อีเมลของบัญชี",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/login": {
+            "post": {
+                "description": "ยืนยันตัวตนด้วยอีเมลและรหัสผ่าน จะได้รับ JWT access token (อายุสั้น) และ refresh token กลับมา หากผู้ใช้เปิด 2FA จะได้ challenge token (202) เพื่อยืนยันรหัสที่ /auth/mfa/verify แทน หาก login ผิดติดต่อกันจะถูกหน่วงเวลาและล็อกชั่วคราว (429)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "เข้าสู่ระบบ",
+                "parameters": [
+                    {
+                        "description": "ข้อมูลสำหรับเข้าสู่ระบบ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MFAChallengeResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ยกเลิก access token ที่ใช้เรียก endpoint นี้ทันที (เพิ่ม jti ลง denylist) และยกเลิก refresh token ที่ส่งมาด้วย (ถ้ามี)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "ออกจากระบบ",
+                "parameters": [
+                    {
+                        "description": "refresh token ของ session นี้ (ไม่บังคับ)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/confirm": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ยืนยันรหัส TOTP 6 หลักจากแอป authenticator เพื่อเปิดใช้ 2FA จะได้รับ recovery codes ซึ่งแสดงเพียงครั้งเดียว token ที่ใช้อยู่ยังไม่นับว่ายืนยัน 2FA ต้อง login ใหม่",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "ยืนยันการลงทะเบียน 2FA",
+                "parameters": [
+                    {
+                        "description": "รหัส TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RecoveryCodesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ปิดใช้ 2FA โดยยืนยันด้วยรหัส TOTP หรือ recovery code — บทบาทที่นโยบายบังคับ 2FA จะเข้าถึง endpoint ของบทบาทนั้นไม่ได้จนกว่าจะลงทะเบียนใหม่",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "ปิดใช้ 2FA",
+                "parameters": [
+                    {
+                        "description": "รหัส TOTP หรือ recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "สร้าง TOTP secret ใหม่และ otpauth URI สำหรับสแกนในแอป authenticator ยังไม่เปิดใช้จนกว่าจะยืนยันรหัสที่ /auth/mfa/confirm (เรียกซ้ำได้ secret เดิมที่ยังไม่ยืนยันจะถูกแทนที่)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "เริ่มลงทะเบียน 2FA",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.MFAEnrollmentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ยืนยันด้วยรหัส TOTP (ไม่รับ recovery code) แล้วสร้าง recovery codes ชุดใหม่แทนชุดเดิมทั้งหมด",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Two-Factor Authentication"
+                ],
+                "summary": "สร้าง recovery codes ใหม่",
+                "parameters": [
+                    {
+                        "description": "รหัส TOTP",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.RecoveryCodesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/mfa/verify": {
+            "post": {
+                "description": "ส่ง challenge token จาก login ขั้นแรกพร้อมรหัส TOTP 6 หลักหรือ recovery code เพื่อรับ access token และ refresh token (รหัสแต่ละตัวใช้ได้ครั้งเดียว) รหัสที่ผิดนับรวมกับการ login ผิด",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "ยืนยันรหัส 2FA",
+                "parameters": [
+                    {
+                        "description": "challenge token และรหัส 2FA",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VerifyMFARequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/oidc/callback": {
+            "get": {
+                "description": "IdP redirect กลับมาพร้อม code และ state — ตรวจ state กับ cookie แลก code เป็น ID token จับคู่หรือสร้างผู้ใช้ (just-in-time) กำหนดบทบาทจากกลุ่ม แล้วออก access token และ refresh token ของระบบ",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "callback จาก SSO (OIDC)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "authorization code จาก IdP",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "state ที่ส่งไปตอนเริ่ม login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/oidc/login": {
+            "get": {
+                "description": "redirect ไปหน้า login ของ identity provider (authorization code flow + PKCE) และผูก state กับ browser ด้วย cookie",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "เข้าสู่ระบบผ่าน SSO (OIDC)",
+                "responses": {
+                    "302": {
+                        "description": "Found"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/refresh": {
+            "post": {
+                "description": "แลก refresh token เป็น access token และ refresh token ชุดใหม่ (refresh token เดิมจะใช้ไม่ได้อีก) หากนำ refresh token ที่ใช้ไปแล้วมาใช้ซ้ำ ระบบจะยกเลิก session นั้นทั้งหมด",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "ขอ access token ใหม่",
+                "parameters": [
+                    {
+                        "description": "refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/auth/reset-password": {
+            "post": {
+                "description": "ตั้งรหัสผ่านใหม่ด้วย token จากลิงก์ในอีเมล token ใช้ได้ครั้งเดียว เมื่อสำเร็จ session ทั้งหมดของผู้ใช้จะถูกยกเลิก",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "ตั้งรหัสผ่านใหม่",
+                "parameters": [
+                    {
+                        "description": "reset token และรหัสผ่านใหม่",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events/stream": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "เปิด Server-Sent Events — ผู้มีสิทธิ์ leave.view_team ได้ใบลาใหม่และการอนุมัติ/ปฏิเสธของทุกคน เจ้าของได้เหตุการณ์ของใบลาและยอดวันลาของตัวเอง แต่ละเหตุการณ์มี id, event (ประเภทเหตุการณ์) และ data (dto.LeaveEventResponse) ส่ง comment \": heartbeat\" ทุก 15 วินาที ต่อใหม่ด้วย header Last-Event-ID เพื่อรับเหตุการณ์ที่พลาดไป ถ้าต่อจากเดิมไม่ได้จะได้ event: reset ให้โหลดข้อมูลใหม่ การเชื่อมต่อถูกปิดเมื่อ token หมดอายุหรือถูกยกเลิก (ต่อใหม่ด้วย token ใหม่)",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "รับเหตุการณ์แบบ real-time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id ของเหตุการณ์ล่าสุดที่ได้รับ",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LeaveEventResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/integrations/leaves": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "ดึงใบลาของพนักงานทุกคนตามสถานะ (เช่น approved สำหรับคำนวณเงินเดือน) รองรับ pagination",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integration"
+                ],
+                "summary": "ดูใบลาตามสถานะ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "approved",
+                        "description": "สถานะใบลา (pending/approved/rejected/cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "นับจำนวนรายการทั้งหมดในโหมด cursor",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/integrations/users/{id}/balances": {
+            "get": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "ดึงยอดวันลาทุกประเภทและทุกปีของพนักงานที่ระบุ",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integration"
+                ],
+                "summary": "ดูยอดวันลาของพนักงาน",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้ใช้ (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveBalanceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "กำหนดจำนวนวันลาทั้งหมดของพนักงานตามประเภทและปี (สร้างใหม่ถ้ายังไม่มี) ต้องไม่น้อยกว่าวันที่ใช้และจองไว้แล้ว",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Integration"
+                ],
+                "summary": "กำหนดจำนวนวันลาที่ได้รับ",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้ใช้ (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ประเภท ปี และจำนวนวันลา",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetEntitlementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveBalanceResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "สร้างคำขอลาใหม่ ระบบจะตรวจสอบวันลาซ้ำซ้อนและยอดวันลาคงเหลือโดยอัตโนมัติ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ยื่นใบลาใหม่",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "คีย์กันการส่งซ้ำ (retry ด้วยคีย์เดิมจะได้ response เดิม)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "ข้อมูลสำหรับยื่นใบลา",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubmitLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves/my-balance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงข้อมูลยอดวันลาคงเหลือทุกประเภทของผู้ใช้ที่เข้าสู่ระบบ",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ดูยอดวันลาคงเหลือ",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveBalanceResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves/my-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงข้อมูลใบลาของผู้ใช้ที่เข้าสู่ระบบ กรองตามสถานะ ประเภท ช่วงวันลา ผู้อนุมัติ จำนวนวัน และคำค้นในเหตุผล เรียงได้หลาย field (รองรับ pagination)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ดูประวัติใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "สถานะ คั่นด้วย , (pending,approved,rejected,cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)",
+                        "name": "leave_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ใบลาที่คาบเกี่ยวตั้งแต่วันที่ (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ใบลาที่คาบเกี่ยวถึงวันที่ (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้อนุมัติ/ปฏิเสธ (UUID)",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "จำนวนวันลาขั้นต่ำ",
+                        "name": "min_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "จำนวนวันลาสูงสุด",
+                        "name": "max_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "คำค้นในเหตุผลการลา (full-text)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "เรียงตาม field คั่นด้วย , ขึ้นต้น - คือมากไปน้อย (created_at,start_date,end_date,total_days,reviewed_at) สูงสุด 3 field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ใช้ได้เมื่อเรียงตาม created_at, ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "นับจำนวนรายการทั้งหมดในโหมด cursor",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงใบลาพร้อมชื่อผู้ยื่น ชื่อผู้อนุมัติ และผลต่อยอดวันลาของประเภทและปีเดียวกัน — ผู้ที่ไม่ใช่เจ้าของและไม่มีสิทธิ์ leave.view_team หรือ user.manage ได้ 404 เพื่อไม่เปิดเผยว่ามีใบลานี้ — leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ดูรายละเอียดใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveRequestDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "เจ้าของแก้ประเภท ช่วงวัน และเหตุผลของใบลาที่ยังรออนุมัติ (ข้อมูลเดียวกับการยื่นใบลา) ระบบปล่อยวันลาที่จองไว้เดิมแล้วจองใหม่ตามใบลาที่แก้ และบันทึก edited ในประวัติใบลา — ใบลาของผู้อื่นได้ 404 ใบลาที่พิจารณาหรือยกเลิกแล้วได้ 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "แก้ไขใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ข้อมูลใบลาที่แก้",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SubmitLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves/{id}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "เจ้าของยกเลิกใบลาที่ยังรออนุมัติ ระบบปล่อยวันลาที่จองไว้กลับคืนและบันทึก cancelled พร้อมเหตุผลในประวัติใบลา — ใบลาของผู้อื่นได้ 404 ใบลาที่พิจารณาหรือยกเลิกแล้วได้ 409",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ยกเลิกใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "เหตุผลที่ยกเลิก",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CancelLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves/{id}/comments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงความคิดเห็นทั้งหมดของใบลาเรียงตามเวลา — บันทึกภายในแสดงเฉพาะผู้อนุมัติที่ไม่ใช่เจ้าของใบลา ผู้ที่ไม่มีสิทธิ์เห็นใบลาได้ 404 — leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ดูความคิดเห็นของใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveCommentResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "เจ้าของใบลาและผู้มีสิทธิ์ leave.view_team หรือ user.manage แสดงความคิดเห็นได้ (คนอื่นได้ 404) leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู) — internal = true เป็นบันทึกที่เขียนและเห็นได้เฉพาะผู้มีสิทธิ์ leave.approve ผู้ที่อยู่ใน mentions ได้รับอีเมลแจ้งเตือนและต้องเห็นความคิดเห็นนี้ได้",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "แสดงความคิดเห็นในใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ข้อความ บันทึกภายใน และผู้ที่กล่าวถึง",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AddCommentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/dto.LeaveCommentResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/leaves/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงเหตุการณ์ทั้งหมดของใบลาเรียงตามเวลา (ยื่น แก้ไข ความคิดเห็น อนุมัติ ปฏิเสธ rollback ยกเลิก) — ผู้ที่ไม่ใช่เจ้าของและไม่มีสิทธิ์ leave.view_team หรือ user.manage ได้ 404 — leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Leave"
+                ],
+                "summary": "ดูประวัติของใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveHistoryEntryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manager/pending-requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ดึงข้อมูลใบลาทั้งหมดที่มีสถานะ pending สำหรับผู้ที่มีสิทธิ์ leave.view_team (รองรับ pagination)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "ดูใบลารอการอนุมัติ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ใช้ได้เมื่อเรียงตาม created_at, ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "นับจำนวนรายการทั้งหมดในโหมด cursor",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manager/requests": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ค้นหาใบลาของพนักงานทุกคนสำหรับผู้ที่มีสิทธิ์ leave.view_team กรองตามพนักงาน สถานะ ประเภท ช่วงวันลา ผู้อนุมัติ จำนวนวัน และคำค้นในเหตุผล เรียงได้หลาย field (รองรับ pagination)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "ค้นหาใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสพนักงาน (UUID)",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "สถานะ คั่นด้วย , (pending,approved,rejected,cancelled)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)",
+                        "name": "leave_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ใบลาที่คาบเกี่ยวตั้งแต่วันที่ (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ใบลาที่คาบเกี่ยวถึงวันที่ (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "รหัสผู้อนุมัติ/ปฏิเสธ (UUID)",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "จำนวนวันลาขั้นต่ำ",
+                        "name": "min_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "จำนวนวันลาสูงสุด",
+                        "name": "max_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "คำค้นในเหตุผลการลา (full-text)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "เรียงตาม field คั่นด้วย , ขึ้นต้น - คือมากไปน้อย (created_at,start_date,end_date,total_days,reviewed_at) สูงสุด 3 field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "หน้าที่ต้องการ (เริ่มจาก 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนรายการต่อหน้า (สูงสุด 100)",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ใช้ได้เมื่อเรียงตาม created_at, ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": false,
+                        "description": "นับจำนวนรายการทั้งหมดในโหมด cursor",
+                        "name": "include_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.PaginatedAPIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.LeaveRequestResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manager/requests/{id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ผู้ที่มีสิทธิ์ leave.approve อนุมัติคำขอลา ระบบจะหักยอดวันลาของพนักงานโดยอัตโนมัติ",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "อนุมัติใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "คีย์กันการส่งซ้ำ (retry ด้วยคีย์เดิมจะได้ response เดิม)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "หมายเหตุจากผู้อนุมัติ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/manager/requests/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "ผู้ที่มีสิทธิ์ leave.approve ปฏิเสธคำขอลา ยอดวันลาของพนักงานจะไม่เปลี่ยนแปลง",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Manager"
+                ],
+                "summary": "ปฏิเสธใบลา",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "รหัสใบลา (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "คีย์กันการส่งซ้ำ (retry ด้วยคีย์เดิมจะได้ response เดิม)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "หมายเหตุจากผู้อนุมัติ",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewLeaveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.APIResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/absenteeism": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "อัตราการขาดงาน = วันลาที่อนุมัติ ÷ (จำนวนพนักงานในแผนก × จำนวนวันในปี)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "รายงานอัตราการขาดงานรายแผนก",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แผนก (ทีม) ของพนักงาน",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา (sick/annual/personal)",
+                        "name": "leave_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.TeamAbsenteeismResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/approval-turnaround": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "เวลาเฉลี่ยและนานที่สุดตั้งแต่ยื่นใบลาจนอนุมัติหรือปฏิเสธ (ชั่วโมง) แยกตามประเภทการลา",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "รายงานเวลาพิจารณาใบลา",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แผนก (ทีม) ของผู้ยื่นใบลา",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา (sick/annual/personal)",
+                        "name": "leave_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ApprovalTurnaroundResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/carry-over-risks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "พนักงานที่มีวันลาคงเหลือ (หักที่ใช้และรออนุมัติ) เกินจำนวนที่ยกไปปีถัดไปได้ เรียงจากคงเหลือมากสุด — ไม่ระบุประเภทการลาคือลาพักร้อน",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "รายงานวันลาที่เสี่ยงหายเมื่อขึ้นปีใหม่",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ปีของยอดวันลา (ค่าเริ่มต้นคือปีปัจจุบัน)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แผนก (ทีม) ของพนักงาน",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา (ค่าเริ่มต้น annual)",
+                        "name": "leave_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "จำนวนพนักงาน (สูงสุด 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.CarryOverRiskResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/leave-usage": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "รวมวันลาที่อนุมัติแล้วแยกตามประเภทการลาและเดือน — ใบลานับเข้าเดือนของวันเริ่มลา",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "รายงานวันลารายเดือน",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แผนก (ทีม) ของพนักงาน",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา (sick/annual/personal)",
+                        "name": "leave_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.MonthlyLeaveUsageResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reports/rejection-rates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "จำนวนใบลาที่พิจารณา ที่ปฏิเสธ และอัตราการปฏิเสธของผู้อนุมัติแต่ละคน เรียงจากพิจารณามากสุด",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "รายงานอัตราการปฏิเสธรายผู้อนุมัติ",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "แผนก (ทีม) ของผู้ยื่นใบลา",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ประเภทการลา (sick/annual/personal)",
+                        "name": "leave_type",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/dto.APIResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/dto.ReviewerRejectionRateResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "description": "algorithm (RS256 / EdDSA)",
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "curve ของ OKP (Ed25519)",
+                    "type": "string"
+                },
+                "e": {
+                    "description": "RSA exponent (base64url)",
+                    "type": "string"
+                },
+                "kid": {
+                    "description": "รหัสคีย์ — ตรงกับ header kid ของ JWT",
+                    "type": "string"
+                },
+                "kty": {
+                    "description": "ประเภทคีย์ (RSA / OKP)",
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA modulus (base64url)",
+                    "type": "string"
+                },
+                "use": {
+                    "description": "การใช้งาน (sig)",
+                    "type": "string"
+                },
+                "x": {
+                    "description": "public key ของ OKP (base64url)",
+                    "type": "string"
+                }
+            }
+        },
+        "domain.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.JSONWebKey"
+                    }
+                }
+            }
+        },
+        "dto.APIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "ข้อมูลที่ส่งกลับ (ถ้ามี)"
+                },
+                "message": {
+                    "description": "ข้อความอธิบาย",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "สถานะความสำเร็จ",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AddCommentRequest": {
+            "type": "object",
+            "required": [
+                "body"
+            ],
+            "properties": {
+                "body": {
+                    "description": "ข้อความ",
+                    "type": "string",
+                    "maxLength": 2000,
+                    "minLength": 1
+                },
+                "internal": {
+                    "description": "บันทึกภายในที่เห็นเฉพาะผู้อนุมัติ (ไม่บังคับ)",
+                    "type": "boolean"
+                },
+                "mentions": {
+                    "description": "รหัสผู้ใช้ที่กล่าวถึง (ไม่บังคับ)",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ApprovalTurnaroundResponse": {
+            "type": "object",
+            "properties": {
+                "average_hours": {
+                    "description": "เวลาเฉลี่ยตั้งแต่ยื่นจนพิจารณา (ชั่วโมง)",
+                    "type": "number"
+                },
+                "leave_type": {
+                    "description": "ประเภทการลา",
+                    "type": "string"
+                },
+                "max_hours": {
+                    "description": "เวลานานที่สุด (ชั่วโมง)",
+                    "type": "number"
+                },
+                "reviewed": {
+                    "description": "จำนวนใบลาที่พิจารณาแล้ว",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.AssignRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "description": "ชื่อบทบาทที่มีอยู่ในระบบ",
+                    "type": "string",
+                    "maxLength": 32
+                }
+            }
+        },
+        "dto.AuditChainReportResponse": {
+            "type": "object",
+            "properties": {
+                "broken_at": {
+                    "description": "sequence แรกที่ไม่ถูกต้อง",
+                    "type": "integer"
+                },
+                "checked": {
+                    "description": "จำนวน event ที่ตรวจแล้ว",
+                    "type": "integer"
+                },
+                "reason": {
+                    "description": "สาเหตุที่ไม่ถูกต้อง",
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "chain ถูกต้องทั้งหมด",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AuditChangeResponse": {
+            "type": "object",
+            "properties": {
+                "after": {
+                    "description": "ค่าหลัง (ว่าง = ถูกลบ)",
+                    "type": "string"
+                },
+                "before": {
+                    "description": "ค่าก่อน (ว่าง = ไม่มีค่า)",
+                    "type": "string"
+                },
+                "field": {
+                    "description": "ชื่อ field",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuditEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "การกระทำ",
+                    "type": "string"
+                },
+                "actor_id": {
+                    "description": "ผู้กระทำ (ว่าง = anonymous)",
+                    "type": "string"
+                },
+                "actor_type": {
+                    "description": "ประเภทผู้กระทำ (user/service_account/anonymous)",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "ค่าที่เปลี่ยน",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditChangeResponse"
+                    }
+                },
+                "hash": {
+                    "description": "hash ของ event นี้",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "รหัส event",
+                    "type": "string"
+                },
+                "ip": {
+                    "description": "IP ของผู้เรียก",
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "description": "เวลาที่เกิด",
+                    "type": "string"
+                },
+                "prev_hash": {
+                    "description": "hash ของ event ก่อนหน้า",
+                    "type": "string"
+                },
+                "sequence": {
+                    "description": "ลำดับใน hash chain",
+                    "type": "integer"
+                },
+                "target_id": {
+                    "description": "รหัสสิ่งที่ถูกกระทำ",
+                    "type": "string"
+                },
+                "target_type": {
+                    "description": "ประเภทสิ่งที่ถูกกระทำ",
+                    "type": "string"
+                },
+                "user_agent": {
+                    "description": "User-Agent ของผู้เรียก",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "expires_in": {
+                    "description": "อายุคงเหลือของ access token (วินาที)",
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "description": "refresh token (ใช้ได้ครั้งเดียว)",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "JWT access token",
+                    "type": "string"
+                },
+                "token_type": {
+                    "description": "ประเภท token (Bearer)",
+                    "type": "string"
+                },
+                "user": {
+                    "description": "ข้อมูลผู้ใช้",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CancelLeaveRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "description": "เหตุผลที่ยกเลิก (ไม่บังคับ) — บันทึกในประวัติใบลา",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.CarryOverRiskResponse": {
+            "type": "object",
+            "properties": {
+                "days_at_risk": {
+                    "description": "วันลาที่จะหายถ้าไม่ใช้ภายในปี",
+                    "type": "number"
+                },
+                "leave_type": {
+                    "description": "ประเภทการลา",
+                    "type": "string"
+                },
+                "remaining_days": {
+                    "description": "วันลาคงเหลือ (หักที่ใช้และรออนุมัติ)",
+                    "type": "number"
+                },
+                "total_days": {
+                    "description": "วันลาทั้งหมดที่ได้รับ",
+                    "type": "number"
+                },
+                "user": {
+                    "description": "ข้อมูลย่อของพนักงาน",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
+                },
+                "user_id": {
+                    "description": "รหัสพนักงาน",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "description": "รหัสผ่านปัจจุบัน",
+                    "type": "string"
+                },
+                "new_password": {
+                    "description": "รหัสผ่านใหม่ (ตามนโยบายความแข็งแรง)",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "name": {
+                    "description": "ชื่อระบบที่ใช้ key เช่น payroll-sync",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3
+                },
+                "scopes": {
+                    "description": "สิทธิ์ที่ได้รับ",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateServiceAccountResponse": {
+            "type": "object",
+            "properties": {
+                "api_key": {
+                    "description": "API key — แสดงครั้งเดียว ระบบเก็บเฉพาะ hash",
+                    "type": "string"
+                },
+                "service_account": {
+                    "description": "ข้อมูล service account",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.ServiceAccountResponse"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "secret",
+                "url"
+            ],
+            "properties": {
+                "events": {
+                    "description": "เหตุการณ์ที่ต้องการรับ",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "secret สำหรับ HMAC-SHA256",
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "url": {
+                    "description": "ปลายทาง (http/https)",
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "รายละเอียดข้อผิดพลาด (ถ้ามี)",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "message": {
+                    "description": "ข้อความอธิบายข้อผิดพลาด",
+                    "type": "string"
+                },
+                "success": {
+                    "description": "สถานะ (false เสมอ)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "อีเมลของบัญชีที่ต้องการตั้งรหัสผ่านใหม่",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeaveBalanceImpactResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "จำนวนวันที่ใบลานี้จองหรือหักไว้",
+                    "type": "number"
+                },
+                "effect": {
+                    "description": "ผลต่อยอดวันลา (reserved/deducted/none)",
+                    "type": "string"
+                },
+                "pending_days": {
+                    "description": "วันลาที่จองไว้",
+                    "type": "number"
+                },
+                "remaining_days": {
+                    "description": "วันลาคงเหลือ",
+                    "type": "number"
+                },
+                "total_days": {
+                    "description": "วันลาทั้งหมดของประเภทและปีนี้ (ว่าง = ยังไม่มียอดวันลา)",
+                    "type": "number"
+                },
+                "used_days": {
+                    "description": "วันลาที่ใช้ไป",
+                    "type": "number"
+                },
+                "year": {
+                    "description": "ปีของยอดวันลา",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaveBalanceResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "รหัสยอดวันลา",
+                    "type": "string"
+                },
+                "leave_type": {
+                    "description": "ประเภทการลา",
+                    "type": "string"
+                },
+                "remaining_days": {
+                    "description": "วันลาคงเหลือ",
+                    "type": "number"
+                },
+                "total_days": {
+                    "description": "วันลาทั้งหมด",
+                    "type": "number"
+                },
+                "used_days": {
+                    "description": "วันลาที่ใช้ไป",
+                    "type": "number"
+                },
+                "year": {
+                    "description": "ปี",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.LeaveCommentResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "ผู้แสดงความคิดเห็น",
+                    "type": "string"
+                },
+                "body": {
+                    "description": "ข้อความ",
+                    "type": "string"
+                },
+                "created_at": {
+                    "description": "เวลาที่แสดงความคิดเห็น",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "รหัสความคิดเห็น",
+                    "type": "string"
+                },
+                "internal": {
+                    "description": "บันทึกภายในของผู้อนุมัติ",
+                    "type": "boolean"
+                },
+                "mentions": {
+                    "description": "ผู้ใช้ที่ถูกกล่าวถึง",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "request_id": {
+                    "description": "รหัสใบลา",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeaveEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "description": "ผู้ยื่นหรือผู้พิจารณา",
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "ยอดวันลาหลังปรับ (เฉพาะ balance.adjusted)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.LeaveBalanceResponse"
+                        }
+                    ]
+                },
+                "id": {
+                    "description": "รหัสเหตุการณ์ (เท่ากับ id ของ SSE)",
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "description": "เวลาที่เกิด",
+                    "type": "string"
+                },
+                "request": {
+                    "description": "ใบลาหลังเกิดเหตุการณ์",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.LeaveRequestResponse"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "ประเภทเหตุการณ์",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeaveHistoryEntryResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "ประเภทเหตุการณ์ (created/edited/commented/approved/rejected/rolled_back/cancelled)",
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "ข้อมูลย่อของผู้กระทำ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
+                },
+                "actor_id": {
+                    "description": "ผู้กระทำ (ว่าง = ระบบ)",
+                    "type": "string"
+                },
+                "changes": {
+                    "description": "ค่าที่เปลี่ยน",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AuditChangeResponse"
+                    }
+                },
+                "from_status": {
+                    "description": "สถานะก่อนเกิดเหตุการณ์",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "รหัสเหตุการณ์",
+                    "type": "string"
+                },
+                "note": {
+                    "description": "หมายเหตุ",
+                    "type": "string"
+                },
+                "occurred_at": {
+                    "description": "เวลาที่เกิด",
+                    "type": "string"
+                },
+                "to_status": {
+                    "description": "สถานะหลังเกิดเหตุการณ์",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LeaveRequestDetailResponse": {
+            "type": "object",
+            "properties": {
+                "balance_impact": {
+                    "description": "ผลต่อยอดวันลา",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.LeaveBalanceImpactResponse"
+                        }
+                    ]
+                },
+                "created_at": {
+                    "description": "วันที่ยื่นใบลา",
+                    "type": "string"
+                },
+                "end_date": {
+                    "description": "วันสิ้นสุด",
+                    "type": "string"
+                },
                 "id": {
-                    "description": "รหัสยอดวันลา",
+                    "description": "รหัสใบลา",
                     "type": "string"
                 },
                 "leave_type": {
                     "description": "ประเภทการลา",
                     "type": "string"
                 },
-                "remaining_days": {
-                    "description": "วันลาคงเหลือ",
-                    "type": "number"
+                "reason": {
+                    "description": "เหตุผลการลา",
+                    "type": "string"
+                },
+                "requester": {
+                    "description": "ผู้ยื่นใบลา (เฉพาะรายการที่แนบข้อมูลผู้ใช้)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
+                },
+                "review_note": {
+                    "description": "หมายเหตุจากผู้อนุมัติ",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "description": "วันที่อนุมัติ/ปฏิเสธ",
+                    "type": "string"
+                },
+                "reviewer": {
+                    "description": "ผู้อนุมัติ/ปฏิเสธ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
+                },
+                "reviewer_id": {
+                    "description": "รหัสผู้อนุมัติ",
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "วันเริ่มต้น",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "สถานะ (pending/approved/rejected/cancelled)",
+                    "type": "string"
                 },
                 "total_days": {
-                    "description": "วันลาทั้งหมด",
+                    "description": "จำนวนวันลาทั้งหมด",
                     "type": "number"
                 },
-                "used_days": {
-                    "description": "วันลาที่ใช้ไป",
-                    "type": "number"
+                "updated_at": {
+                    "description": "วันที่แก้ไขล่าสุด",
+                    "type": "string"
                 },
-                "year": {
-                    "description": "ปี",
-                    "type": "integer"
+                "user_id": {
+                    "description": "รหัสพนักงาน",
+                    "type": "string"
                 }
             }
         },
@@ -632,109 +5092,386 @@ const docTemplate = `{
                     "description": "เหตุผลการลา",
                     "type": "string"
                 },
-                "review_note": {
-                    "description": "หมายเหตุจากผู้อนุมัติ",
-                    "type": "string"
+                "requester": {
+                    "description": "ผู้ยื่นใบลา (เฉพาะรายการที่แนบข้อมูลผู้ใช้)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
+                },
+                "review_note": {
+                    "description": "หมายเหตุจากผู้อนุมัติ",
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "description": "วันที่อนุมัติ/ปฏิเสธ",
+                    "type": "string"
+                },
+                "reviewer": {
+                    "description": "ผู้อนุมัติ/ปฏิเสธ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
+                },
+                "reviewer_id": {
+                    "description": "รหัสผู้อนุมัติ",
+                    "type": "string"
+                },
+                "start_date": {
+                    "description": "วันเริ่มต้น",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "สถานะ (pending/approved/rejected/cancelled)",
+                    "type": "string"
+                },
+                "total_days": {
+                    "description": "จำนวนวันลาทั้งหมด",
+                    "type": "number"
+                },
+                "updated_at": {
+                    "description": "วันที่แก้ไขล่าสุด",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "รหัสพนักงาน",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "description": "อีเมล",
+                    "type": "string"
+                },
+                "password": {
+                    "description": "รหัสผ่าน",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "refresh token ของ session นี้ (ไม่บังคับ — ถ้าส่งมาจะถูกยกเลิกด้วย)",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MFAChallengeResponse": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
+                    "description": "ใช้คู่กับรหัส 2FA ที่ /auth/mfa/verify",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "อายุคงเหลือของ challenge (วินาที)",
+                    "type": "integer"
+                },
+                "mfa_required": {
+                    "description": "ต้องยืนยัน 2FA ก่อนจึงจะได้ token (true เสมอ)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "รหัส TOTP 6 หลัก (หรือ recovery code สำหรับปิดใช้ 2FA)",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MFAEnrollmentResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
+                    "description": "otpauth:// URI สำหรับสร้าง QR code",
+                    "type": "string"
+                },
+                "secret": {
+                    "description": "TOTP secret แบบ base32 (สำหรับกรอกเองในแอป)",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MonthlyLeaveUsageResponse": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "description": "จำนวนวันลาที่อนุมัติรวม",
+                    "type": "number"
+                },
+                "leave_type": {
+                    "description": "ประเภทการลา",
+                    "type": "string"
+                },
+                "month": {
+                    "description": "เดือน (1–12) ของวันเริ่มลา",
+                    "type": "integer"
+                },
+                "requests": {
+                    "description": "จำนวนใบลา",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PaginatedAPIResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "รายการข้อมูล"
+                },
+                "message": {
+                    "description": "ข้อความอธิบาย",
+                    "type": "string"
+                },
+                "pagination": {
+                    "description": "ข้อมูล pagination",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PaginationMeta"
+                        }
+                    ]
+                },
+                "success": {
+                    "description": "สถานะความสำเร็จ",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.PaginationMeta": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "description": "ส่งเป็น ?cursor= เพื่ออ่านหน้าถัดไปแบบ cursor (เฉพาะรายการที่รองรับ)",
+                    "type": "string"
+                },
+                "page": {
+                    "description": "หน้าปัจจุบัน",
+                    "type": "integer"
+                },
+                "page_size": {
+                    "description": "จำนวนรายการต่อหน้า",
+                    "type": "integer"
+                },
+                "total": {
+                    "description": "จำนวนรายการทั้งหมด",
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "description": "จำนวนหน้าทั้งหมด",
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "ใช้ได้ครั้งละหนึ่งรหัส — แสดงครั้งเดียว ควรเก็บไว้ในที่ปลอดภัย",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "refresh token ที่ได้จากการ login หรือ refresh ครั้งก่อน",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "new_password",
+                "token"
+            ],
+            "properties": {
+                "new_password": {
+                    "description": "รหัสผ่านใหม่ (ตามนโยบายความแข็งแรง)",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "reset token จากลิงก์ในอีเมล",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ReviewLeaveRequest": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "description": "หมายเหตุจากผู้อนุมัติ (ไม่บังคับ)",
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "dto.ReviewerRejectionRateResponse": {
+            "type": "object",
+            "properties": {
+                "rate": {
+                    "description": "อัตราการปฏิเสธ (0–1)",
+                    "type": "number"
+                },
+                "rejected": {
+                    "description": "จำนวนใบลาที่ปฏิเสธ",
+                    "type": "integer"
+                },
+                "reviewed": {
+                    "description": "จำนวนใบลาที่พิจารณา",
+                    "type": "integer"
                 },
-                "reviewed_at": {
-                    "description": "วันที่อนุมัติ/ปฏิเสธ",
-                    "type": "string"
+                "reviewer": {
+                    "description": "ข้อมูลย่อของผู้อนุมัติ",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.UserSummaryResponse"
+                        }
+                    ]
                 },
                 "reviewer_id": {
                     "description": "รหัสผู้อนุมัติ",
                     "type": "string"
+                }
+            }
+        },
+        "dto.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "built_in": {
+                    "description": "บทบาทเริ่มต้นของระบบ (ลบไม่ได้)",
+                    "type": "boolean"
                 },
-                "start_date": {
-                    "description": "วันเริ่มต้น",
+                "description": {
+                    "description": "คำอธิบายบทบาท",
                     "type": "string"
                 },
-                "status": {
-                    "description": "สถานะ (pending/approved/rejected)",
+                "name": {
+                    "description": "ชื่อบทบาท",
                     "type": "string"
                 },
-                "total_days": {
-                    "description": "จำนวนวันลาทั้งหมด",
-                    "type": "number"
+                "permissions": {
+                    "description": "สิทธิ์ทั้งหมดของบทบาท",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
                 "updated_at": {
                     "description": "วันที่แก้ไขล่าสุด",
                     "type": "string"
-                },
-                "user_id": {
-                    "description": "รหัสพนักงาน",
-                    "type": "string"
                 }
             }
         },
-        "dto.LoginRequest": {
+        "dto.SaveRoleRequest": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
-                    "description": "อีเมล",
-                    "type": "string"
+                "description": {
+                    "description": "คำอธิบายบทบาท",
+                    "type": "string",
+                    "maxLength": 200
                 },
-                "password": {
-                    "description": "รหัสผ่าน",
-                    "type": "string"
+                "permissions": {
+                    "description": "สิทธิ์ทั้งหมดของบทบาท",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.PaginatedAPIResponse": {
+        "dto.ServiceAccountResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "รายการข้อมูล"
+                "created_at": {
+                    "description": "วันที่สร้าง",
+                    "type": "string"
                 },
-                "message": {
-                    "description": "ข้อความอธิบาย",
+                "created_by": {
+                    "description": "ผู้ดูแลระบบที่สร้าง",
                     "type": "string"
                 },
-                "pagination": {
-                    "description": "ข้อมูล pagination",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dto.PaginationMeta"
-                        }
-                    ]
+                "id": {
+                    "description": "รหัส service account",
+                    "type": "string"
                 },
-                "success": {
-                    "description": "สถานะความสำเร็จ",
-                    "type": "boolean"
-                }
-            }
-        },
-        "dto.PaginationMeta": {
-            "type": "object",
-            "properties": {
-                "page": {
-                    "description": "หน้าปัจจุบัน",
-                    "type": "integer"
+                "key_prefix": {
+                    "description": "ส่วนต้นของ key สำหรับระบุตัว key",
+                    "type": "string"
                 },
-                "page_size": {
-                    "description": "จำนวนรายการต่อหน้า",
-                    "type": "integer"
+                "last_used_at": {
+                    "description": "เวลาที่ใช้ key ล่าสุด",
+                    "type": "string"
                 },
-                "total": {
-                    "description": "จำนวนรายการทั้งหมด",
-                    "type": "integer"
+                "name": {
+                    "description": "ชื่อระบบที่ใช้ key",
+                    "type": "string"
                 },
-                "total_pages": {
-                    "description": "จำนวนหน้าทั้งหมด",
-                    "type": "integer"
+                "revoked_at": {
+                    "description": "เวลาที่ถูกยกเลิก",
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "สิทธิ์ที่ได้รับ",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "dto.ReviewLeaveRequest": {
+        "dto.SetEntitlementRequest": {
             "type": "object",
+            "required": [
+                "leave_type",
+                "year"
+            ],
             "properties": {
-                "note": {
-                    "description": "หมายเหตุจากผู้อนุมัติ (ไม่บังคับ)",
+                "leave_type": {
+                    "description": "ประเภทการลา",
                     "type": "string",
-                    "maxLength": 500
+                    "enum": [
+                        "sick_leave",
+                        "annual_leave",
+                        "personal_leave"
+                    ]
+                },
+                "total_days": {
+                    "description": "จำนวนวันลาทั้งหมดที่ได้รับ",
+                    "type": "number",
+                    "maximum": 366,
+                    "minimum": 0
+                },
+                "year": {
+                    "description": "ปีที่ยอดวันลาใช้ได้",
+                    "type": "integer",
+                    "maximum": 2100,
+                    "minimum": 2000
                 }
             }
         },
@@ -772,6 +5509,112 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TeamAbsenteeismResponse": {
+            "type": "object",
+            "properties": {
+                "days_taken": {
+                    "description": "วันลาที่อนุมัติรวม",
+                    "type": "number"
+                },
+                "department": {
+                    "description": "แผนก (ว่าง = ไม่ระบุแผนก)",
+                    "type": "string"
+                },
+                "headcount": {
+                    "description": "จำนวนพนักงาน",
+                    "type": "integer"
+                },
+                "rate": {
+                    "description": "อัตราการขาดงาน (0–1)",
+                    "type": "number"
+                }
+            }
+        },
+        "dto.UpdateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
+            "properties": {
+                "active": {
+                    "description": "false = หยุดส่งชั่วคราว",
+                    "type": "boolean"
+                },
+                "events": {
+                    "description": "เหตุการณ์ที่ต้องการรับ",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "secret": {
+                    "description": "secret ใหม่ (ว่าง = ใช้เดิม)",
+                    "type": "string",
+                    "maxLength": 256,
+                    "minLength": 16
+                },
+                "url": {
+                    "description": "ปลายทาง (http/https)",
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "dto.UserImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "จำนวนผู้ใช้ที่สร้าง (หรือจะสร้าง)",
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "description": "true = ตรวจอย่างเดียว ยังไม่บันทึก",
+                    "type": "boolean"
+                },
+                "failed": {
+                    "description": "จำนวนแถวที่ไม่ผ่าน",
+                    "type": "integer"
+                },
+                "rows": {
+                    "description": "ผลรายแถวตามลำดับในไฟล์",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UserImportRowResponse"
+                    }
+                },
+                "updated": {
+                    "description": "จำนวนผู้ใช้ที่แก้ (หรือจะแก้)",
+                    "type": "integer"
+                },
+                "upsert": {
+                    "description": "แก้ผู้ใช้ที่มีอีเมลอยู่แล้วได้",
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.UserImportRowResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created / updated / failed",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "อีเมลในแถว (ว่างเมื่ออ่านแถวไม่ได้)",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "สาเหตุที่แถวไม่ผ่าน",
+                    "type": "string"
+                },
+                "line": {
+                    "description": "บรรทัดในไฟล์ (หัวตารางคือบรรทัด 1)",
+                    "type": "integer"
+                }
+            }
+        },
         "dto.UserResponse": {
             "type": "object",
             "properties": {
@@ -804,9 +5647,142 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "dto.UserSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "department": {
+                    "description": "แผนก",
+                    "type": "string"
+                },
+                "email": {
+                    "description": "อีเมล",
+                    "type": "string"
+                },
+                "full_name": {
+                    "description": "ชื่อเต็ม",
+                    "type": "string"
+                },
+                "user_id": {
+                    "description": "รหัสผู้ใช้",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.VerifyMFARequest": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "description": "challenge token ที่ได้จาก login ขั้นแรก",
+                    "type": "string"
+                },
+                "code": {
+                    "description": "รหัส TOTP 6 หลัก หรือ recovery code",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "description": "จำนวนครั้งที่ส่งแล้ว",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "description": "เวลาที่สร้างรายการ",
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "เวลาที่ส่งสำเร็จ",
+                    "type": "string"
+                },
+                "event_id": {
+                    "description": "รหัสเหตุการณ์ (X-Webhook-Event-Id)",
+                    "type": "string"
+                },
+                "event_type": {
+                    "description": "ประเภทเหตุการณ์",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "รหัสรายการส่ง",
+                    "type": "string"
+                },
+                "last_error": {
+                    "description": "สาเหตุที่ส่งไม่สำเร็จครั้งล่าสุด",
+                    "type": "string"
+                },
+                "last_status_code": {
+                    "description": "HTTP status ครั้งล่าสุด",
+                    "type": "integer"
+                },
+                "next_attempt_at": {
+                    "description": "เวลาที่จะส่งครั้งถัดไป (เฉพาะ pending)",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "JSON ที่ส่งให้ปลายทาง",
+                    "type": "object"
+                },
+                "status": {
+                    "description": "pending, delivered หรือ dead",
+                    "type": "string"
+                },
+                "subscription_id": {
+                    "description": "รหัส webhook ปลายทาง",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "เปิดใช้งานอยู่หรือไม่",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "description": "วันที่สร้าง",
+                    "type": "string"
+                },
+                "created_by": {
+                    "description": "ผู้ดูแลระบบที่สร้าง",
+                    "type": "string"
+                },
+                "events": {
+                    "description": "เหตุการณ์ที่รับ",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "description": "รหัส webhook",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "description": "วันที่แก้ไขล่าสุด",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "ปลายทาง",
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
+        "APIKeyAuth": {
+            "description": "API key ของ service account สำหรับ /api/v1/integrations เช่น \"lms_...\"",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "กรุณาใส่ Bearer token เช่น \"Bearer eyJhbGciOiJIUzI1NiIs...\"",
             "type": "apiKey",
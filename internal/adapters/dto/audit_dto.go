@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type AuditChangeResponse struct {
+	Field  string `json:"field"`  // ชื่อ field
+	Before string `json:"before"` // ค่าก่อน (ว่าง = ไม่มีค่า)
+	After  string `json:"after"`  // ค่าหลัง (ว่าง = ถูกลบ)
+}
+
+type AuditEventResponse struct {
+	ID         string                `json:"id"`                   // รหัส event
+	OccurredAt string                `json:"occurred_at"`          // เวลาที่เกิด
+	ActorID    string                `json:"actor_id,omitempty"`   // ผู้กระทำ (ว่าง = anonymous)
+	ActorType  string                `json:"actor_type"`           // ประเภทผู้กระทำ (user/service_account/anonymous)
+	Action     string                `json:"action"`               // การกระทำ
+	TargetType string                `json:"target_type"`          // ประเภทสิ่งที่ถูกกระทำ
+	TargetID   string                `json:"target_id"`            // รหัสสิ่งที่ถูกกระทำ
+	IP         string                `json:"ip,omitempty"`         // IP ของผู้เรียก
+	UserAgent  string                `json:"user_agent,omitempty"` // User-Agent ของผู้เรียก
+	PrevHash   string                `json:"prev_hash"`            // hash ของ event ก่อนหน้า
+	Hash       string                `json:"hash"`                 // hash ของ event นี้
+	Changes    []AuditChangeResponse `json:"changes"`              // ค่าที่เปลี่ยน
+	Sequence   int64                 `json:"sequence"`             // ลำดับใน hash chain
+}
+
+type AuditChainReportResponse struct {
+	BrokenAt *int64 `json:"broken_at,omitempty"` // sequence แรกที่ไม่ถูกต้อง
+	Reason   string `json:"reason,omitempty"`    // สาเหตุที่ไม่ถูกต้อง
+	Checked  int64  `json:"checked"`             // จำนวน event ที่ตรวจแล้ว
+	Valid    bool   `json:"valid"`               // chain ถูกต้องทั้งหมด
+}
+
+func ToAuditEventResponse(e *domain.AuditEvent) AuditEventResponse {
+	resp := AuditEventResponse{
+		ID:         e.ID.String(),
+		OccurredAt: e.OccurredAt.Format(time.RFC3339Nano),
+		ActorType:  string(e.ActorType),
+		Action:     string(e.Action),
+		TargetType: string(e.TargetType),
+		TargetID:   e.TargetID,
+		IP:         e.IP,
+		UserAgent:  e.UserAgent,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+		Changes:    toAuditChangeResponses(e.Changes),
+		Sequence:   e.Sequence,
+	}
+
+	if e.ActorID != nil {
+		resp.ActorID = e.ActorID.String()
+	}
+
+	return resp
+}
+
+func toAuditChangeResponses(changes []domain.AuditChange) []AuditChangeResponse {
+	responses := make([]AuditChangeResponse, 0, len(changes))
+	for _, c := range changes {
+		responses = append(responses, AuditChangeResponse{Field: c.Field, Before: c.Before, After: c.After})
+	}
+	return responses
+}
+
+func ToAuditEventResponses(events []domain.AuditEvent) []AuditEventResponse {
+	responses := make([]AuditEventResponse, 0, len(events))
+	for i := range events {
+		responses = append(responses, ToAuditEventResponse(&events[i]))
+	}
+	return responses
+}
+
+func ToAuditChainReportResponse(r *domain.AuditChainReport) AuditChainReportResponse {
+	return AuditChainReportResponse{
+		BrokenAt: r.BrokenAt,
+		Reason:   r.Reason,
+		Checked:  r.Checked,
+		Valid:    r.Valid,
+	}
+}
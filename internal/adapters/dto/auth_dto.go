@@ -11,9 +11,58 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`       // รหัสผ่าน
 }
 
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required"` // refresh token ที่ได้จากการ login หรือ refresh ครั้งก่อน
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // refresh token ของ session นี้ (ไม่บังคับ — ถ้าส่งมาจะถูกยกเลิกด้วย)
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`          // รหัสผ่านปัจจุบัน
+	NewPassword     string `json:"new_password"     validate:"required,password"` // รหัสผ่านใหม่ (ตามนโยบายความแข็งแรง)
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email" validate:"required,email"` // อีเมลของบัญชีที่ต้องการตั้งรหัสผ่านใหม่
+}
+
+type ResetPasswordRequest struct {
+	Token       string `json:"token"        validate:"required"`          // reset token จากลิงก์ในอีเมล
+	NewPassword string `json:"new_password" validate:"required,password"` // รหัสผ่านใหม่ (ตามนโยบายความแข็งแรง)
+}
+
+type VerifyMFARequest struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"` // challenge token ที่ได้จาก login ขั้นแรก
+	Code           string `json:"code"            validate:"required"` // รหัส TOTP 6 หลัก หรือ recovery code
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"` // รหัส TOTP 6 หลัก (หรือ recovery code สำหรับปิดใช้ 2FA)
+}
+
 type AuthResponse struct {
-	Token string       `json:"token"` // JWT access token
-	User  UserResponse `json:"user"`  // ข้อมูลผู้ใช้
+	Token        string       `json:"token"`         // JWT access token
+	RefreshToken string       `json:"refresh_token"` // refresh token (ใช้ได้ครั้งเดียว)
+	TokenType    string       `json:"token_type"`    // ประเภท token (Bearer)
+	User         UserResponse `json:"user"`          // ข้อมูลผู้ใช้
+	ExpiresIn    int64        `json:"expires_in"`    // อายุคงเหลือของ access token (วินาที)
+}
+
+type MFAChallengeResponse struct {
+	MFARequired    bool   `json:"mfa_required"`    // ต้องยืนยัน 2FA ก่อนจึงจะได้ token (true เสมอ)
+	ChallengeToken string `json:"challenge_token"` // ใช้คู่กับรหัส 2FA ที่ /auth/mfa/verify
+	ExpiresIn      int64  `json:"expires_in"`      // อายุคงเหลือของ challenge (วินาที)
+}
+
+type MFAEnrollmentResponse struct {
+	Secret     string `json:"secret"`      // TOTP secret แบบ base32 (สำหรับกรอกเองในแอป)
+	OTPAuthURI string `json:"otpauth_uri"` // otpauth:// URI สำหรับสร้าง QR code
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"` // ใช้ได้ครั้งละหนึ่งรหัส — แสดงครั้งเดียว ควรเก็บไว้ในที่ปลอดภัย
 }
 
 type UserResponse struct {
@@ -26,6 +75,24 @@ type UserResponse struct {
 	CreatedAt string `json:"created_at"` // วันที่สร้างบัญชี
 }
 
+func ToAuthResponse(tokens *domain.AuthTokens, user *domain.User) AuthResponse {
+	return AuthResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(tokens.AccessExpiresAt).Seconds()),
+		User:         ToUserResponse(user),
+	}
+}
+
+func ToMFAChallengeResponse(challenge *domain.MFAChallenge) MFAChallengeResponse {
+	return MFAChallengeResponse{
+		MFARequired:    true,
+		ChallengeToken: challenge.Token,
+		ExpiresIn:      int64(time.Until(challenge.ExpiresAt).Seconds()),
+	}
+}
+
 func ToUserResponse(user *domain.User) UserResponse {
 	return UserResponse{
 		ID:        user.ID.String(),
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// LeaveEventResponse ข้อมูลใน field data ของเหตุการณ์ใน event stream
+type LeaveEventResponse struct {
+	ID         string                `json:"id"`                 // รหัสเหตุการณ์ (เท่ากับ id ของ SSE)
+	Type       string                `json:"type"`               // ประเภทเหตุการณ์
+	OccurredAt string                `json:"occurred_at"`        // เวลาที่เกิด
+	ActorID    string                `json:"actor_id,omitempty"` // ผู้ยื่นหรือผู้พิจารณา
+	Request    *LeaveRequestResponse `json:"request,omitempty"`  // ใบลาหลังเกิดเหตุการณ์
+	Balance    *LeaveBalanceResponse `json:"balance,omitempty"`  // ยอดวันลาหลังปรับ (เฉพาะ balance.adjusted)
+}
+
+func ToLeaveEventResponse(e *domain.LeaveEvent) LeaveEventResponse {
+	resp := LeaveEventResponse{
+		ID:         e.ID.String(),
+		Type:       string(e.Type),
+		OccurredAt: e.OccurredAt.Format(time.RFC3339),
+	}
+	if e.ActorID != (domain.ID{}) {
+		resp.ActorID = e.ActorID.String()
+	}
+	if e.Request != nil {
+		request := ToLeaveRequestResponse(e.Request)
+		resp.Request = &request
+	}
+	if e.Balance != nil {
+		balance := ToLeaveBalanceResponse(e.Balance)
+		resp.Balance = &balance
+	}
+	return resp
+}
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// LeaveRequestExportHeader หัวคอลัมน์ของไฟล์ส่งออกใบลา — ชื่อเดียวกับ field ใน JSON เพื่อให้ระบบปลายทาง map ได้คงที่
+var LeaveRequestExportHeader = []any{
+	"id", "user_id", "full_name", "email", "department", "leave_type", "start_date", "end_date", "total_days",
+	"status", "reason", "reviewer_id", "reviewer_name", "reviewed_at", "review_note", "created_at",
+}
+
+// LeaveBalanceExportHeader หัวคอลัมน์ของไฟล์ส่งออกยอดวันลา
+var LeaveBalanceExportHeader = []any{
+	"user_id", "full_name", "email", "department", "leave_type", "year",
+	"total_days", "used_days", "pending_days", "remaining_days",
+}
+
+// ToLeaveRequestExportRow แปลงใบลาเป็นแถวตามลำดับของ LeaveRequestExportHeader — วันลาเป็นตัวเลข วันที่เป็นข้อความ
+func ToLeaveRequestExportRow(v *domain.LeaveRequestView) []any {
+	fullName, email, department := userSummaryColumns(v.Requester)
+	reviewerID, reviewerName, reviewedAt := "", "", ""
+	if v.ReviewerID != nil {
+		reviewerID = v.ReviewerID.String()
+	}
+	if v.Reviewer != nil {
+		reviewerName = v.Reviewer.FullName
+	}
+	if v.ReviewedAt != nil {
+		reviewedAt = v.ReviewedAt.UTC().Format(time.RFC3339)
+	}
+
+	return []any{
+		v.ID.String(), v.UserID.String(), fullName, email, department, string(v.LeaveType),
+		v.StartDate.Format("2006-01-02"), v.EndDate.Format("2006-01-02"), v.TotalDays,
+		string(v.Status), v.Reason, reviewerID, reviewerName, reviewedAt, v.ReviewNote,
+		v.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ToLeaveBalanceExportRow แปลงยอดวันลาเป็นแถวตามลำดับของ LeaveBalanceExportHeader
+func ToLeaveBalanceExportRow(v *domain.LeaveBalanceView) []any {
+	fullName, email, department := userSummaryColumns(v.User)
+	return []any{
+		v.UserID.String(), fullName, email, department, string(v.LeaveType), v.Year,
+		v.TotalDays, v.UsedDays, v.PendingDays, v.RemainingDays(),
+	}
+}
+
+// userSummaryColumns ชื่อ อีเมล และแผนก — ผู้ใช้ที่ถูกลบได้ช่องว่าง
+func userSummaryColumns(u *domain.UserSummary) (fullName, email, department string) {
+	if u == nil {
+		return "", "", ""
+	}
+	return u.FullName, u.Email, u.Department
+}
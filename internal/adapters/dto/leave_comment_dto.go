@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type AddCommentRequest struct {
+	Body     string   `json:"body"     validate:"required,min=1,max=2000"` // ข้อความ
+	Internal bool     `json:"internal"`                                    // บันทึกภายในที่เห็นเฉพาะผู้อนุมัติ (ไม่บังคับ)
+	Mentions []string `json:"mentions" validate:"max=10,dive,uuid"`        // รหัสผู้ใช้ที่กล่าวถึง (ไม่บังคับ)
+}
+
+type LeaveCommentResponse struct {
+	ID        string   `json:"id"`                 // รหัสความคิดเห็น
+	RequestID string   `json:"request_id"`         // รหัสใบลา
+	AuthorID  string   `json:"author_id"`          // ผู้แสดงความคิดเห็น
+	Body      string   `json:"body"`               // ข้อความ
+	Mentions  []string `json:"mentions,omitempty"` // ผู้ใช้ที่ถูกกล่าวถึง
+	CreatedAt string   `json:"created_at"`         // เวลาที่แสดงความคิดเห็น
+	Internal  bool     `json:"internal"`           // บันทึกภายในของผู้อนุมัติ
+}
+
+func ToLeaveCommentResponse(c *domain.LeaveComment) LeaveCommentResponse {
+	resp := LeaveCommentResponse{
+		ID:        c.ID.String(),
+		RequestID: c.RequestID.String(),
+		AuthorID:  c.AuthorID.String(),
+		Body:      c.Body,
+		CreatedAt: c.CreatedAt.Format(time.RFC3339),
+		Internal:  c.Internal,
+	}
+	for _, id := range c.Mentions {
+		resp.Mentions = append(resp.Mentions, id.String())
+	}
+	return resp
+}
+
+func ToLeaveCommentResponses(comments []domain.LeaveComment) []LeaveCommentResponse {
+	responses := make([]LeaveCommentResponse, 0, len(comments))
+	for i := range comments {
+		responses = append(responses, ToLeaveCommentResponse(&comments[i]))
+	}
+	return responses
+}
@@ -13,10 +13,20 @@ type SubmitLeaveRequest struct {
 	Reason    string `json:"reason"     validate:"required,min=5,max=500"`                                // เหตุผลการลา
 }
 
+type CancelLeaveRequest struct {
+	Reason string `json:"reason" validate:"max=500"` // เหตุผลที่ยกเลิก (ไม่บังคับ) — บันทึกในประวัติใบลา
+}
+
 type ReviewLeaveRequest struct {
 	Note string `json:"note" validate:"max=500"` // หมายเหตุจากผู้อนุมัติ (ไม่บังคับ)
 }
 
+type SetEntitlementRequest struct {
+	LeaveType string  `json:"leave_type" validate:"required,oneof=sick_leave annual_leave personal_leave"` // ประเภทการลา
+	Year      int     `json:"year"       validate:"required,gte=2000,lte=2100"`                            // ปีที่ยอดวันลาใช้ได้
+	TotalDays float64 `json:"total_days" validate:"gte=0,lte=366"`                                         // จำนวนวันลาทั้งหมดที่ได้รับ
+}
+
 type LeaveRequestResponse struct {
 	ID         string  `json:"id"`                    // รหัสใบลา
 	UserID     string  `json:"user_id"`               // รหัสพนักงาน
@@ -24,13 +34,36 @@ type LeaveRequestResponse struct {
 	StartDate  string  `json:"start_date"`            // วันเริ่มต้น
 	EndDate    string  `json:"end_date"`              // วันสิ้นสุด
 	Reason     string  `json:"reason"`                // เหตุผลการลา
-	Status     string  `json:"status"`                // สถานะ (pending/approved/rejected)
+	Status     string  `json:"status"`                // สถานะ (pending/approved/rejected/cancelled)
 	ReviewerID string  `json:"reviewer_id,omitempty"` // รหัสผู้อนุมัติ
 	ReviewNote string  `json:"review_note,omitempty"` // หมายเหตุจากผู้อนุมัติ
 	ReviewedAt string  `json:"reviewed_at,omitempty"` // วันที่อนุมัติ/ปฏิเสธ
 	CreatedAt  string  `json:"created_at"`            // วันที่ยื่นใบลา
 	UpdatedAt  string  `json:"updated_at"`            // วันที่แก้ไขล่าสุด
 	TotalDays  float64 `json:"total_days"`            // จำนวนวันลาทั้งหมด
+
+	Requester *UserSummaryResponse `json:"requester,omitempty"` // ผู้ยื่นใบลา (เฉพาะรายการที่แนบข้อมูลผู้ใช้)
+	Reviewer  *UserSummaryResponse `json:"reviewer,omitempty"`  // ผู้อนุมัติ/ปฏิเสธ
+}
+
+// UserSummaryResponse ข้อมูลย่อของผู้ใช้ที่แนบไปกับใบลา
+type UserSummaryResponse struct {
+	UserID     string `json:"user_id"`              // รหัสผู้ใช้
+	FullName   string `json:"full_name"`            // ชื่อเต็ม
+	Email      string `json:"email"`                // อีเมล
+	Department string `json:"department,omitempty"` // แผนก
+}
+
+type LeaveHistoryEntryResponse struct {
+	ID         string                `json:"id"`                    // รหัสเหตุการณ์
+	Action     string                `json:"action"`                // ประเภทเหตุการณ์ (created/edited/commented/approved/rejected/rolled_back/cancelled)
+	ActorID    string                `json:"actor_id,omitempty"`    // ผู้กระทำ (ว่าง = ระบบ)
+	FromStatus string                `json:"from_status,omitempty"` // สถานะก่อนเกิดเหตุการณ์
+	ToStatus   string                `json:"to_status"`             // สถานะหลังเกิดเหตุการณ์
+	Note       string                `json:"note,omitempty"`        // หมายเหตุ
+	OccurredAt string                `json:"occurred_at"`           // เวลาที่เกิด
+	Changes    []AuditChangeResponse `json:"changes"`               // ค่าที่เปลี่ยน
+	Actor      *UserSummaryResponse  `json:"actor,omitempty"`       // ข้อมูลย่อของผู้กระทำ
 }
 
 type LeaveBalanceResponse struct {
@@ -42,6 +75,67 @@ type LeaveBalanceResponse struct {
 	Year          int     `json:"year"`           // ปี
 }
 
+// LeaveRequestDetailResponse ใบลาพร้อมผู้ยื่น ผู้อนุมัติ และผลต่อยอดวันลา
+type LeaveRequestDetailResponse struct {
+	LeaveRequestResponse
+	BalanceImpact LeaveBalanceImpactResponse `json:"balance_impact"` // ผลต่อยอดวันลา
+}
+
+type LeaveBalanceImpactResponse struct {
+	Effect        string   `json:"effect"`                   // ผลต่อยอดวันลา (reserved/deducted/none)
+	Days          float64  `json:"days"`                     // จำนวนวันที่ใบลานี้จองหรือหักไว้
+	Year          int      `json:"year"`                     // ปีของยอดวันลา
+	TotalDays     *float64 `json:"total_days,omitempty"`     // วันลาทั้งหมดของประเภทและปีนี้ (ว่าง = ยังไม่มียอดวันลา)
+	UsedDays      *float64 `json:"used_days,omitempty"`      // วันลาที่ใช้ไป
+	PendingDays   *float64 `json:"pending_days,omitempty"`   // วันลาที่จองไว้
+	RemainingDays *float64 `json:"remaining_days,omitempty"` // วันลาคงเหลือ
+}
+
+func ToLeaveRequestDetailResponse(d *domain.LeaveRequestDetail) LeaveRequestDetailResponse {
+	impact := LeaveBalanceImpactResponse{
+		Effect: string(d.BalanceImpact.Effect),
+		Days:   d.BalanceImpact.Days,
+		Year:   d.BalanceImpact.Year,
+	}
+	if b := d.BalanceImpact.Balance; b != nil {
+		remaining := b.RemainingDays()
+		impact.TotalDays, impact.UsedDays, impact.PendingDays = &b.TotalDays, &b.UsedDays, &b.PendingDays
+		impact.RemainingDays = &remaining
+	}
+	return LeaveRequestDetailResponse{
+		LeaveRequestResponse: ToLeaveRequestViewResponse(&d.LeaveRequestView),
+		BalanceImpact:        impact,
+	}
+}
+
+// ToLeaveRequestViewResponse ใบลาพร้อมข้อมูลย่อของผู้ยื่นและผู้อนุมัติ
+func ToLeaveRequestViewResponse(v *domain.LeaveRequestView) LeaveRequestResponse {
+	resp := ToLeaveRequestResponse(&v.LeaveRequest)
+	resp.Requester = toUserSummaryResponse(v.Requester)
+	resp.Reviewer = toUserSummaryResponse(v.Reviewer)
+	return resp
+}
+
+func ToLeaveRequestViewResponses(views []domain.LeaveRequestView) []LeaveRequestResponse {
+	responses := make([]LeaveRequestResponse, 0, len(views))
+	for i := range views {
+		responses = append(responses, ToLeaveRequestViewResponse(&views[i]))
+	}
+	return responses
+}
+
+func toUserSummaryResponse(u *domain.UserSummary) *UserSummaryResponse {
+	if u == nil {
+		return nil
+	}
+	return &UserSummaryResponse{
+		UserID:     u.ID.String(),
+		FullName:   u.FullName,
+		Email:      u.Email,
+		Department: u.Department,
+	}
+}
+
 func ToLeaveRequestResponse(r *domain.LeaveRequest) LeaveRequestResponse {
 	resp := LeaveRequestResponse{
 		ID:         r.ID.String(),
@@ -93,3 +187,25 @@ func ToLeaveBalanceResponses(balances []domain.LeaveBalance) []LeaveBalanceRespo
 	}
 	return responses
 }
+
+func ToLeaveHistoryResponses(entries []domain.LeaveHistoryView) []LeaveHistoryEntryResponse {
+	responses := make([]LeaveHistoryEntryResponse, 0, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		resp := LeaveHistoryEntryResponse{
+			ID:         e.ID.String(),
+			Action:     string(e.Action),
+			FromStatus: string(e.FromStatus),
+			ToStatus:   string(e.ToStatus),
+			Note:       e.Note,
+			OccurredAt: e.OccurredAt.Format(time.RFC3339Nano),
+			Changes:    toAuditChangeResponses(e.Changes),
+			Actor:      toUserSummaryResponse(e.Actor),
+		}
+		if e.ActorID != nil {
+			resp.ActorID = e.ActorID.String()
+		}
+		responses = append(responses, resp)
+	}
+	return responses
+}
@@ -0,0 +1,100 @@
+package dto
+
+import "github/be2bag/leave-management-system/internal/core/domain"
+
+type MonthlyLeaveUsageResponse struct {
+	LeaveType string  `json:"leave_type"` // ประเภทการลา
+	Month     int     `json:"month"`      // เดือน (1–12) ของวันเริ่มลา
+	Days      float64 `json:"days"`       // จำนวนวันลาที่อนุมัติรวม
+	Requests  int64   `json:"requests"`   // จำนวนใบลา
+}
+
+type TeamAbsenteeismResponse struct {
+	Department string  `json:"department"` // แผนก (ว่าง = ไม่ระบุแผนก)
+	Headcount  int64   `json:"headcount"`  // จำนวนพนักงาน
+	DaysTaken  float64 `json:"days_taken"` // วันลาที่อนุมัติรวม
+	Rate       float64 `json:"rate"`       // อัตราการขาดงาน (0–1)
+}
+
+type ApprovalTurnaroundResponse struct {
+	LeaveType    string  `json:"leave_type"`    // ประเภทการลา
+	Reviewed     int64   `json:"reviewed"`      // จำนวนใบลาที่พิจารณาแล้ว
+	AverageHours float64 `json:"average_hours"` // เวลาเฉลี่ยตั้งแต่ยื่นจนพิจารณา (ชั่วโมง)
+	MaxHours     float64 `json:"max_hours"`     // เวลานานที่สุด (ชั่วโมง)
+}
+
+type ReviewerRejectionRateResponse struct {
+	ReviewerID string               `json:"reviewer_id"`        // รหัสผู้อนุมัติ
+	Reviewer   *UserSummaryResponse `json:"reviewer,omitempty"` // ข้อมูลย่อของผู้อนุมัติ
+	Reviewed   int64                `json:"reviewed"`           // จำนวนใบลาที่พิจารณา
+	Rejected   int64                `json:"rejected"`           // จำนวนใบลาที่ปฏิเสธ
+	Rate       float64              `json:"rate"`               // อัตราการปฏิเสธ (0–1)
+}
+
+type CarryOverRiskResponse struct {
+	UserID        string               `json:"user_id"`        // รหัสพนักงาน
+	User          *UserSummaryResponse `json:"user,omitempty"` // ข้อมูลย่อของพนักงาน
+	LeaveType     string               `json:"leave_type"`     // ประเภทการลา
+	TotalDays     float64              `json:"total_days"`     // วันลาทั้งหมดที่ได้รับ
+	RemainingDays float64              `json:"remaining_days"` // วันลาคงเหลือ (หักที่ใช้และรออนุมัติ)
+	DaysAtRisk    float64              `json:"days_at_risk"`   // วันลาที่จะหายถ้าไม่ใช้ภายในปี
+}
+
+func ToMonthlyLeaveUsageResponses(rows []domain.MonthlyLeaveUsage) []MonthlyLeaveUsageResponse {
+	responses := make([]MonthlyLeaveUsageResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, MonthlyLeaveUsageResponse{
+			LeaveType: string(r.LeaveType), Month: r.Month, Days: r.Days, Requests: r.Requests,
+		})
+	}
+	return responses
+}
+
+func ToTeamAbsenteeismResponses(rows []domain.TeamAbsenteeism) []TeamAbsenteeismResponse {
+	responses := make([]TeamAbsenteeismResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, TeamAbsenteeismResponse{
+			Department: r.Department, Headcount: r.Headcount, DaysTaken: r.DaysTaken, Rate: r.Rate,
+		})
+	}
+	return responses
+}
+
+func ToApprovalTurnaroundResponses(rows []domain.ApprovalTurnaround) []ApprovalTurnaroundResponse {
+	responses := make([]ApprovalTurnaroundResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, ApprovalTurnaroundResponse{
+			LeaveType: string(r.LeaveType), Reviewed: r.Reviewed, AverageHours: r.AverageHours, MaxHours: r.MaxHours,
+		})
+	}
+	return responses
+}
+
+func ToReviewerRejectionRateResponses(rows []domain.ReviewerRejectionRate) []ReviewerRejectionRateResponse {
+	responses := make([]ReviewerRejectionRateResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, ReviewerRejectionRateResponse{
+			ReviewerID: r.ReviewerID.String(),
+			Reviewer:   toUserSummaryResponse(r.Reviewer),
+			Reviewed:   r.Reviewed,
+			Rejected:   r.Rejected,
+			Rate:       r.Rate,
+		})
+	}
+	return responses
+}
+
+func ToCarryOverRiskResponses(rows []domain.CarryOverRisk) []CarryOverRiskResponse {
+	responses := make([]CarryOverRiskResponse, 0, len(rows))
+	for _, r := range rows {
+		responses = append(responses, CarryOverRiskResponse{
+			UserID:        r.UserID.String(),
+			User:          toUserSummaryResponse(r.User),
+			LeaveType:     string(r.LeaveType),
+			TotalDays:     r.TotalDays,
+			RemainingDays: r.RemainingDays,
+			DaysAtRisk:    r.DaysAtRisk,
+		})
+	}
+	return responses
+}
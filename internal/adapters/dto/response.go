@@ -1,5 +1,7 @@
 package dto
 
+import "github/be2bag/leave-management-system/internal/core/domain"
+
 type APIResponse struct {
 	Data    any    `json:"data,omitempty"` // ข้อมูลที่ส่งกลับ (ถ้ามี)
 	Message string `json:"message"`        // ข้อความอธิบาย
@@ -13,10 +15,26 @@ type ErrorResponse struct {
 }
 
 type PaginationMeta struct {
-	Page       int   `json:"page"`        // หน้าปัจจุบัน
-	PageSize   int   `json:"page_size"`   // จำนวนรายการต่อหน้า
-	Total      int64 `json:"total"`       // จำนวนรายการทั้งหมด
-	TotalPages int   `json:"total_pages"` // จำนวนหน้าทั้งหมด
+	NextCursor string `json:"next_cursor,omitempty"` // ส่งเป็น ?cursor= เพื่ออ่านหน้าถัดไปแบบ cursor (เฉพาะรายการที่รองรับ)
+	Page       int    `json:"page"`                  // หน้าปัจจุบัน
+	PageSize   int    `json:"page_size"`             // จำนวนรายการต่อหน้า
+	Total      int64  `json:"total"`                 // จำนวนรายการทั้งหมด
+	TotalPages int    `json:"total_pages"`           // จำนวนหน้าทั้งหมด
+}
+
+// CursorPaginationMeta ข้อมูลการแบ่งหน้าแบบ cursor — ไม่มีเลขหน้า
+type CursorPaginationMeta struct {
+	Total      *int64 `json:"total,omitempty"`       // จำนวนรายการทั้งหมด (เฉพาะเมื่อขอด้วย include_total=true)
+	NextCursor string `json:"next_cursor,omitempty"` // ส่งเป็น ?cursor= เพื่ออ่านหน้าถัดไป (ว่าง = หน้าสุดท้าย)
+	PageSize   int    `json:"page_size"`             // จำนวนรายการต่อหน้า
+	HasMore    bool   `json:"has_more"`              // มีหน้าถัดไปหรือไม่
+}
+
+type CursorPaginatedAPIResponse struct {
+	Data       any                  `json:"data"`       // รายการข้อมูล
+	Message    string               `json:"message"`    // ข้อความอธิบาย
+	Pagination CursorPaginationMeta `json:"pagination"` // ข้อมูล pagination
+	Success    bool                 `json:"success"`    // สถานะความสำเร็จ
 }
 
 type PaginatedAPIResponse struct {
@@ -48,6 +66,25 @@ func NewPaginatedResponse(message string, data any, page, pageSize int, total in
 	}
 }
 
+// NewPageResponse สร้าง response ตามวิธีแบ่งหน้าของผลลัพธ์ — แบบเลขหน้าได้ PaginatedAPIResponse, แบบ cursor ได้ CursorPaginatedAPIResponse
+func NewPageResponse[T any](message string, data any, result *domain.PaginatedResult[T]) any {
+	nextCursor := ""
+	if result.NextCursor != nil {
+		nextCursor = result.NextCursor.Encode()
+	}
+	if !result.Keyset {
+		resp := NewPaginatedResponse(message, data, result.Page, result.PageSize, result.Total, result.TotalPages)
+		resp.Pagination.NextCursor = nextCursor
+		return resp
+	}
+
+	meta := CursorPaginationMeta{PageSize: result.PageSize, NextCursor: nextCursor, HasMore: result.HasMore()}
+	if result.TotalCounted {
+		meta.Total = &result.Total
+	}
+	return CursorPaginatedAPIResponse{Success: true, Message: message, Data: data, Pagination: meta}
+}
+
 func NewErrorResponse(message string, errors ...string) ErrorResponse {
 	return ErrorResponse{
 		Success: false,
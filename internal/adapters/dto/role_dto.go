@@ -0,0 +1,48 @@
+package dto
+
+import (
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type SaveRoleRequest struct {
+	Description string   `json:"description" validate:"max=200"`                                                             // คำอธิบายบทบาท
+	Permissions []string `json:"permissions" validate:"dive,oneof=leave.approve leave.view_team balance.adjust user.manage"` // สิทธิ์ทั้งหมดของบทบาท
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" validate:"required,max=32"` // ชื่อบทบาทที่มีอยู่ในระบบ
+}
+
+type RoleResponse struct {
+	Name        string   `json:"name"`        // ชื่อบทบาท
+	Description string   `json:"description"` // คำอธิบายบทบาท
+	Permissions []string `json:"permissions"` // สิทธิ์ทั้งหมดของบทบาท
+	BuiltIn     bool     `json:"built_in"`    // บทบาทเริ่มต้นของระบบ (ลบไม่ได้)
+	UpdatedAt   string   `json:"updated_at"`  // วันที่แก้ไขล่าสุด
+}
+
+func ToRoleResponse(r *domain.RoleDefinition) RoleResponse {
+	resp := RoleResponse{
+		Name:        string(r.Name),
+		Description: r.Description,
+		Permissions: make([]string, 0, len(r.Permissions)),
+		BuiltIn:     r.BuiltIn,
+		UpdatedAt:   r.UpdatedAt.Format(time.RFC3339),
+	}
+
+	for _, p := range r.Permissions {
+		resp.Permissions = append(resp.Permissions, string(p))
+	}
+
+	return resp
+}
+
+func ToRoleResponses(roles []domain.RoleDefinition) []RoleResponse {
+	responses := make([]RoleResponse, 0, len(roles))
+	for i := range roles {
+		responses = append(responses, ToRoleResponse(&roles[i]))
+	}
+	return responses
+}
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type CreateServiceAccountRequest struct {
+	Name   string   `json:"name"   validate:"required,min=3,max=100"`                                       // ชื่อระบบที่ใช้ key เช่น payroll-sync
+	Scopes []string `json:"scopes" validate:"required,dive,oneof=leaves:read balances:read balances:write"` // สิทธิ์ที่ได้รับ
+}
+
+type ServiceAccountResponse struct {
+	ID         string   `json:"id"`                     // รหัส service account
+	Name       string   `json:"name"`                   // ชื่อระบบที่ใช้ key
+	KeyPrefix  string   `json:"key_prefix"`             // ส่วนต้นของ key สำหรับระบุตัว key
+	Scopes     []string `json:"scopes"`                 // สิทธิ์ที่ได้รับ
+	CreatedBy  string   `json:"created_by"`             // ผู้ดูแลระบบที่สร้าง
+	CreatedAt  string   `json:"created_at"`             // วันที่สร้าง
+	LastUsedAt string   `json:"last_used_at,omitempty"` // เวลาที่ใช้ key ล่าสุด
+	RevokedAt  string   `json:"revoked_at,omitempty"`   // เวลาที่ถูกยกเลิก
+}
+
+type CreateServiceAccountResponse struct {
+	ServiceAccount ServiceAccountResponse `json:"service_account"` // ข้อมูล service account
+	APIKey         string                 `json:"api_key"`         // API key — แสดงครั้งเดียว ระบบเก็บเฉพาะ hash
+}
+
+func ToServiceAccountResponse(a *domain.ServiceAccount) ServiceAccountResponse {
+	resp := ServiceAccountResponse{
+		ID:        a.ID.String(),
+		Name:      a.Name,
+		KeyPrefix: a.KeyPrefix,
+		Scopes:    make([]string, 0, len(a.Scopes)),
+		CreatedBy: a.CreatedBy.String(),
+		CreatedAt: a.CreatedAt.Format(time.RFC3339),
+	}
+
+	for _, scope := range a.Scopes {
+		resp.Scopes = append(resp.Scopes, string(scope))
+	}
+	if a.LastUsedAt != nil {
+		resp.LastUsedAt = a.LastUsedAt.Format(time.RFC3339)
+	}
+	if a.RevokedAt != nil {
+		resp.RevokedAt = a.RevokedAt.Format(time.RFC3339)
+	}
+
+	return resp
+}
+
+func ToServiceAccountResponses(accounts []domain.ServiceAccount) []ServiceAccountResponse {
+	responses := make([]ServiceAccountResponse, 0, len(accounts))
+	for i := range accounts {
+		responses = append(responses, ToServiceAccountResponse(&accounts[i]))
+	}
+	return responses
+}
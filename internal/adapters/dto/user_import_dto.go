@@ -0,0 +1,39 @@
+package dto
+
+import "github/be2bag/leave-management-system/internal/core/domain"
+
+type UserImportRowResponse struct {
+	Line   int    `json:"line"`            // บรรทัดในไฟล์ (หัวตารางคือบรรทัด 1)
+	Email  string `json:"email,omitempty"` // อีเมลในแถว (ว่างเมื่ออ่านแถวไม่ได้)
+	Action string `json:"action"`          // created / updated / failed
+	Error  string `json:"error,omitempty"` // สาเหตุที่แถวไม่ผ่าน
+}
+
+type UserImportResponse struct {
+	DryRun  bool                    `json:"dry_run"` // true = ตรวจอย่างเดียว ยังไม่บันทึก
+	Upsert  bool                    `json:"upsert"`  // แก้ผู้ใช้ที่มีอีเมลอยู่แล้วได้
+	Created int                     `json:"created"` // จำนวนผู้ใช้ที่สร้าง (หรือจะสร้าง)
+	Updated int                     `json:"updated"` // จำนวนผู้ใช้ที่แก้ (หรือจะแก้)
+	Failed  int                     `json:"failed"`  // จำนวนแถวที่ไม่ผ่าน
+	Rows    []UserImportRowResponse `json:"rows"`    // ผลรายแถวตามลำดับในไฟล์
+}
+
+func ToUserImportResponse(report *domain.UserImportReport) UserImportResponse {
+	rows := make([]UserImportRowResponse, 0, len(report.Rows))
+	for _, r := range report.Rows {
+		rows = append(rows, UserImportRowResponse{
+			Line:   r.Line,
+			Email:  r.Email,
+			Action: string(r.Action),
+			Error:  r.Error,
+		})
+	}
+	return UserImportResponse{
+		DryRun:  report.DryRun,
+		Upsert:  report.Upsert,
+		Created: report.Created,
+		Updated: report.Updated,
+		Failed:  report.Failed,
+		Rows:    rows,
+	}
+}
@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"    validate:"required,url,max=2048"`                                                                    // ปลายทาง (http/https)
+	Secret string   `json:"secret" validate:"required,min=16,max=256"`                                                                  // secret สำหรับ HMAC-SHA256
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=leave.submitted leave.approved leave.rejected balance.adjusted"` // เหตุการณ์ที่ต้องการรับ
+}
+
+type UpdateWebhookRequest struct {
+	URL    string   `json:"url"    validate:"required,url,max=2048"`                                                                    // ปลายทาง (http/https)
+	Secret string   `json:"secret" validate:"omitempty,min=16,max=256"`                                                                 // secret ใหม่ (ว่าง = ใช้เดิม)
+	Events []string `json:"events" validate:"required,min=1,dive,oneof=leave.submitted leave.approved leave.rejected balance.adjusted"` // เหตุการณ์ที่ต้องการรับ
+	Active bool     `json:"active"`                                                                                                     // false = หยุดส่งชั่วคราว
+}
+
+type WebhookResponse struct {
+	ID        string   `json:"id"`         // รหัส webhook
+	URL       string   `json:"url"`        // ปลายทาง
+	Events    []string `json:"events"`     // เหตุการณ์ที่รับ
+	Active    bool     `json:"active"`     // เปิดใช้งานอยู่หรือไม่
+	CreatedBy string   `json:"created_by"` // ผู้ดูแลระบบที่สร้าง
+	CreatedAt string   `json:"created_at"` // วันที่สร้าง
+	UpdatedAt string   `json:"updated_at"` // วันที่แก้ไขล่าสุด
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string          `json:"id"`                           // รหัสรายการส่ง
+	SubscriptionID string          `json:"subscription_id"`              // รหัส webhook ปลายทาง
+	EventID        string          `json:"event_id"`                     // รหัสเหตุการณ์ (X-Webhook-Event-Id)
+	EventType      string          `json:"event_type"`                   // ประเภทเหตุการณ์
+	Status         string          `json:"status"`                       // pending, delivered หรือ dead
+	Attempts       int             `json:"attempts"`                     // จำนวนครั้งที่ส่งแล้ว
+	LastStatusCode int             `json:"last_status_code,omitempty"`   // HTTP status ครั้งล่าสุด
+	LastError      string          `json:"last_error,omitempty"`         // สาเหตุที่ส่งไม่สำเร็จครั้งล่าสุด
+	NextAttemptAt  string          `json:"next_attempt_at,omitempty"`    // เวลาที่จะส่งครั้งถัดไป (เฉพาะ pending)
+	DeliveredAt    string          `json:"delivered_at,omitempty"`       // เวลาที่ส่งสำเร็จ
+	CreatedAt      string          `json:"created_at"`                   // เวลาที่สร้างรายการ
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"` // JSON ที่ส่งให้ปลายทาง
+}
+
+// ToLeaveEventTypes แปลงชื่อเหตุการณ์จาก request เป็น domain type
+func ToLeaveEventTypes(events []string) []domain.LeaveEventType {
+	types := make([]domain.LeaveEventType, 0, len(events))
+	for _, e := range events {
+		types = append(types, domain.LeaveEventType(e))
+	}
+	return types
+}
+
+func ToWebhookResponse(s *domain.WebhookSubscription) WebhookResponse {
+	resp := WebhookResponse{
+		ID:        s.ID.String(),
+		URL:       s.URL,
+		Events:    make([]string, 0, len(s.Events)),
+		Active:    s.Active,
+		CreatedBy: s.CreatedBy.String(),
+		CreatedAt: s.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: s.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, e := range s.Events {
+		resp.Events = append(resp.Events, string(e))
+	}
+	return resp
+}
+
+func ToWebhookResponses(subs []domain.WebhookSubscription) []WebhookResponse {
+	responses := make([]WebhookResponse, 0, len(subs))
+	for i := range subs {
+		responses = append(responses, ToWebhookResponse(&subs[i]))
+	}
+	return responses
+}
+
+func ToWebhookDeliveryResponse(d *domain.WebhookDelivery) WebhookDeliveryResponse {
+	resp := WebhookDeliveryResponse{
+		ID:             d.ID.String(),
+		SubscriptionID: d.SubscriptionID.String(),
+		EventID:        d.EventID.String(),
+		EventType:      string(d.EventType),
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.Format(time.RFC3339),
+		Payload:        json.RawMessage(d.Payload),
+	}
+	if d.Status == domain.WebhookDeliveryPending {
+		resp.NextAttemptAt = d.NextAttemptAt.Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		resp.DeliveredAt = d.DeliveredAt.Format(time.RFC3339)
+	}
+	return resp
+}
+
+func ToWebhookDeliveryResponses(deliveries []domain.WebhookDelivery) []WebhookDeliveryResponse {
+	responses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		responses = append(responses, ToWebhookDeliveryResponse(&deliveries[i]))
+	}
+	return responses
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type AdminHandler struct {
+	sessionService     ports.SessionService
+	accountLockService ports.AccountLockService
+}
+
+func NewAdminHandler(sessionService ports.SessionService, accountLockService ports.AccountLockService) *AdminHandler {
+	return &AdminHandler{
+		sessionService:     sessionService,
+		accountLockService: accountLockService,
+	}
+}
+
+// RevokeUserSessions ยกเลิกทุก session ของผู้ใช้ (สิทธิ์ user.manage)
+//
+//	@Summary		ยกเลิกทุก session ของผู้ใช้
+//	@Description	ยกเลิก access token ทุกตัวที่ออกก่อนหน้านี้และ refresh token ทั้งหมดของผู้ใช้ ผู้ใช้ต้อง login ใหม่
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัสผู้ใช้ (UUID)"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/users/{id}/revoke-sessions [post]
+func (h *AdminHandler) RevokeUserSessions(c *fiber.Ctx) error {
+	userID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสผู้ใช้ไม่ถูกต้อง"),
+		)
+	}
+
+	if err = h.sessionService.RevokeAllSessions(c.Context(), userID); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ยกเลิก session ทั้งหมดของผู้ใช้สำเร็จ", nil),
+	)
+}
+
+// UnlockUser ปลดล็อกบัญชีที่ถูกล็อกจากการ login ผิดติดต่อกัน (สิทธิ์ user.manage)
+//
+//	@Summary		ปลดล็อกบัญชีผู้ใช้
+//	@Description	ล้างจำนวนครั้งที่ login ผิดและยกเลิกการล็อกบัญชี ผู้ใช้ login ได้ทันที การปลดล็อกจะถูกบันทึกเป็น security event
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัสผู้ใช้ (UUID)"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/users/{id}/unlock [post]
+func (h *AdminHandler) UnlockUser(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	userID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสผู้ใช้ไม่ถูกต้อง"),
+		)
+	}
+
+	if err = h.accountLockService.UnlockAccount(c.Context(), actorID, userID); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ปลดล็อกบัญชีผู้ใช้สำเร็จ", nil),
+	)
+}
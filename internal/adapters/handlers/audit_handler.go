@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type AuditHandler struct {
+	auditService ports.AuditService
+}
+
+func NewAuditHandler(auditService ports.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: auditService}
+}
+
+// Search ค้นหา audit log (สิทธิ์ user.manage)
+//
+//	@Summary		ค้นหา audit log
+//	@Description	ดึงบันทึกว่าใครทำอะไรกับอะไร เรียงจากใหม่ไปเก่า กรองตามผู้กระทำ การกระทำ สิ่งที่ถูกกระทำ และช่วงเวลาได้ รองรับ pagination
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			actor_id	query	string	false	"รหัสผู้กระทำ (UUID ของผู้ใช้หรือ service account)"
+//	@Param			action		query	string	false	"การกระทำ เช่น leave.approved"
+//	@Param			target_type	query	string	false	"ประเภทสิ่งที่ถูกกระทำ (user/leave_request/leave_balance/role/service_account)"
+//	@Param			target_id	query	string	false	"รหัสสิ่งที่ถูกกระทำ"
+//	@Param			from		query	string	false	"ตั้งแต่เวลา (RFC3339, รวม)"
+//	@Param			to			query	string	false	"ถึงเวลา (RFC3339, ไม่รวม)"
+//	@Param			page		query	int		false	"หน้าที่ต้องการ (เริ่มจาก 1)"	default(1)
+//	@Param			page_size	query	int		false	"จำนวนรายการต่อหน้า (สูงสุด 100)"	default(10)
+//	@Success		200	{object}	dto.PaginatedAPIResponse{data=[]dto.AuditEventResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/audit-events [get]
+func (h *AuditHandler) Search(c *fiber.Ctx) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	result, err := h.auditService.Search(c.Context(), filter, parsePaginationParams(c))
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewPaginatedResponse(
+			"ดึงข้อมูล audit log สำเร็จ",
+			dto.ToAuditEventResponses(result.Items),
+			result.Page, result.PageSize, result.Total, result.TotalPages,
+		),
+	)
+}
+
+// Verify ตรวจ hash chain ของ audit log (สิทธิ์ user.manage)
+//
+//	@Summary		ตรวจความถูกต้องของ audit log
+//	@Description	คำนวณ hash ของทุก event ใหม่ตั้งแต่ event แรก และรายงาน sequence แรกที่ถูกแก้ไข ลบ หรือแทรก
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.APIResponse{data=dto.AuditChainReportResponse}
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/audit-events/verify [get]
+func (h *AuditHandler) Verify(c *fiber.Ctx) error {
+	report, err := h.auditService.VerifyChain(c.Context())
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	message := "audit log ถูกต้อง"
+	if !report.Valid {
+		message = "พบ audit log ที่ถูกแก้ไข"
+	}
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse(message, dto.ToAuditChainReportResponse(report)),
+	)
+}
+
+// parseAuditFilter อ่านเงื่อนไขค้นหาจาก query string — คืน ErrInvalidAuditFilter ถ้ารหัสหรือเวลาไม่ถูกต้อง
+func parseAuditFilter(c *fiber.Ctx) (domain.AuditFilter, error) {
+	filter := domain.AuditFilter{
+		Action:     domain.AuditAction(c.Query("action")),
+		TargetType: domain.AuditTargetType(c.Query("target_type")),
+		TargetID:   c.Query("target_id"),
+	}
+
+	if raw := c.Query("actor_id"); raw != "" {
+		actorID, err := domain.ParseID(raw)
+		if err != nil {
+			return filter, domain.ErrInvalidAuditFilter
+		}
+		filter.ActorID = &actorID
+	}
+
+	var err error
+	if filter.From, err = parseQueryTime(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseQueryTime(c, "to"); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// parseQueryTime อ่านเวลาแบบ RFC3339 จาก query string — คืน nil ถ้าไม่ได้ระบุ
+func parseQueryTime(c *fiber.Ctx, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, domain.ErrInvalidAuditFilter
+	}
+	return &t, nil
+}
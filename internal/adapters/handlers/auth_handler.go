@@ -9,28 +9,36 @@ import (
 )
 
 type AuthHandler struct {
-	authService ports.AuthService
-	validate    *validator.Validator
+	authService    ports.AuthService
+	sessionService ports.SessionService
+	validate       *validator.Validator
 }
 
-func NewAuthHandler(authService ports.AuthService, validate *validator.Validator) *AuthHandler {
+func NewAuthHandler(
+	authService ports.AuthService,
+	sessionService ports.SessionService,
+	validate *validator.Validator,
+) *AuthHandler {
 	return &AuthHandler{
-		authService: authService,
-		validate:    validate,
+		authService:    authService,
+		sessionService: sessionService,
+		validate:       validate,
 	}
 }
 
 // Login เข้าสู่ระบบ
 //
 //	@Summary		เข้าสู่ระบบ
-//	@Description	ยืนยันตัวตนด้วยอีเมลและรหัสผ่าน จะได้รับ JWT token กลับมา
+//	@Description	ยืนยันตัวตนด้วยอีเมลและรหัสผ่าน จะได้รับ JWT access token (อายุสั้น) และ refresh token กลับมา หากผู้ใช้เปิด 2FA จะได้ challenge token (202) เพื่อยืนยันรหัสที่ /auth/mfa/verify แทน หาก login ผิดติดต่อกันจะถูกหน่วงเวลาและล็อกชั่วคราว (429)
 //	@Tags			Authentication
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body	dto.LoginRequest	true	"ข้อมูลสำหรับเข้าสู่ระบบ"
 //	@Success		200	{object}	dto.APIResponse{data=dto.AuthResponse}
+//	@Success		202	{object}	dto.APIResponse{data=dto.MFAChallengeResponse}
 //	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		429	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Router			/api/v1/auth/login [post]
 func (h *AuthHandler) Login(c *fiber.Ctx) error {
@@ -43,13 +51,120 @@ func (h *AuthHandler) Login(c *fiber.Ctx) error {
 		return handleValidationError(c, errs)
 	}
 
-	token, user, err := h.authService.Login(c.Context(), req.Email, req.Password)
+	result, err := h.authService.Login(c.Context(), req.Email, req.Password)
 	if err != nil {
 		return handleDomainError(c, err)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(dto.NewSuccessResponse("เข้าสู่ระบบสำเร็จ", dto.AuthResponse{
-		Token: token,
-		User:  dto.ToUserResponse(user),
-	}))
+	if result.Challenge != nil {
+		return c.Status(fiber.StatusAccepted).JSON(
+			dto.NewSuccessResponse("กรุณายืนยันรหัส 2FA", dto.ToMFAChallengeResponse(result.Challenge)),
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("เข้าสู่ระบบสำเร็จ", dto.ToAuthResponse(result.Tokens, result.User)),
+	)
+}
+
+// VerifyMFA ยืนยันรหัส 2FA เพื่อจบขั้นตอน login
+//
+//	@Summary		ยืนยันรหัส 2FA
+//	@Description	ส่ง challenge token จาก login ขั้นแรกพร้อมรหัส TOTP 6 หลักหรือ recovery code เพื่อรับ access token และ refresh token (รหัสแต่ละตัวใช้ได้ครั้งเดียว) รหัสที่ผิดนับรวมกับการ login ผิด
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	dto.VerifyMFARequest	true	"challenge token และรหัส 2FA"
+//	@Success		200	{object}	dto.APIResponse{data=dto.AuthResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		429	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/mfa/verify [post]
+func (h *AuthHandler) VerifyMFA(c *fiber.Ctx) error {
+	var req dto.VerifyMFARequest
+	if err := c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	tokens, user, err := h.authService.VerifyMFA(c.Context(), req.ChallengeToken, req.Code)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("เข้าสู่ระบบสำเร็จ", dto.ToAuthResponse(tokens, user)),
+	)
+}
+
+// Refresh ขอ access token ใหม่ด้วย refresh token
+//
+//	@Summary		ขอ access token ใหม่
+//	@Description	แลก refresh token เป็น access token และ refresh token ชุดใหม่ (refresh token เดิมจะใช้ไม่ได้อีก) หากนำ refresh token ที่ใช้ไปแล้วมาใช้ซ้ำ ระบบจะยกเลิก session นั้นทั้งหมด
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	dto.RefreshTokenRequest	true	"refresh token"
+//	@Success		200	{object}	dto.APIResponse{data=dto.AuthResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/refresh [post]
+func (h *AuthHandler) Refresh(c *fiber.Ctx) error {
+	var req dto.RefreshTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	tokens, user, err := h.authService.Refresh(c.Context(), req.RefreshToken)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ออก token ใหม่สำเร็จ", dto.ToAuthResponse(tokens, user)),
+	)
+}
+
+// Logout ออกจากระบบ
+//
+//	@Summary		ออกจากระบบ
+//	@Description	ยกเลิก access token ที่ใช้เรียก endpoint นี้ทันที (เพิ่ม jti ลง denylist) และยกเลิก refresh token ที่ส่งมาด้วย (ถ้ามี)
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.LogoutRequest	false	"refresh token ของ session นี้ (ไม่บังคับ)"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/logout [post]
+func (h *AuthHandler) Logout(c *fiber.Ctx) error {
+	claims, err := getClaimsFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	// body ไม่บังคับ — logout ได้ด้วย access token อย่างเดียว
+	var req dto.LogoutRequest
+	if len(c.Body()) > 0 {
+		if err = c.BodyParser(&req); err != nil {
+			return handleBodyParseError(c)
+		}
+	}
+
+	if err = h.sessionService.Logout(c.Context(), claims, req.RefreshToken); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.NewSuccessResponse("ออกจากระบบสำเร็จ", nil))
 }
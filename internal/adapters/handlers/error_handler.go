@@ -11,28 +11,74 @@ import (
 
 var errorStatusMap = map[error]int{
 	// 400 Bad Request — ข้อมูลที่ส่งมาไม่ถูกต้อง
-	domain.ErrInvalidLeaveType: fiber.StatusBadRequest,
-	domain.ErrInvalidDateRange: fiber.StatusBadRequest,
+	domain.ErrInvalidLeaveType:   fiber.StatusBadRequest,
+	domain.ErrInvalidDateRange:   fiber.StatusBadRequest,
+	domain.ErrIncorrectPassword:  fiber.StatusBadRequest,
+	domain.ErrPasswordReused:     fiber.StatusBadRequest,
+	domain.ErrPasswordTooLong:    fiber.StatusBadRequest,
+	domain.ErrInvalidResetToken:  fiber.StatusBadRequest,
+	domain.ErrInvalidLeaveStatus: fiber.StatusBadRequest,
+	domain.ErrInvalidAPIScope:    fiber.StatusBadRequest,
+	domain.ErrInvalidRole:        fiber.StatusBadRequest,
+	domain.ErrInvalidAuditFilter: fiber.StatusBadRequest,
+	domain.ErrInvalidMention:     fiber.StatusBadRequest,
+	domain.ErrInvalidLeaveQuery:  fiber.StatusBadRequest,
+	domain.ErrInvalidCursor:      fiber.StatusBadRequest,
+
+	domain.ErrInvalidReportFilter: fiber.StatusBadRequest,
+	domain.ErrInvalidImportFile:   fiber.StatusBadRequest,
+
+	domain.ErrInvalidWebhookURL:    fiber.StatusBadRequest,
+	domain.ErrInvalidWebhookSecret: fiber.StatusBadRequest,
+	domain.ErrInvalidWebhookEvent:  fiber.StatusBadRequest,
+	domain.ErrInvalidWebhookFilter: fiber.StatusBadRequest,
 
 	// 401 Unauthorized — ยืนยันตัวตนไม่สำเร็จ
-	domain.ErrInvalidCredentials: fiber.StatusUnauthorized,
-	domain.ErrUnauthorized:       fiber.StatusUnauthorized,
+	domain.ErrInvalidCredentials:  fiber.StatusUnauthorized,
+	domain.ErrUnauthorized:        fiber.StatusUnauthorized,
+	domain.ErrInvalidRefreshToken: fiber.StatusUnauthorized,
+	domain.ErrRefreshTokenReused:  fiber.StatusUnauthorized,
+	domain.ErrInvalidMFAChallenge: fiber.StatusUnauthorized,
+	domain.ErrInvalidMFACode:      fiber.StatusUnauthorized,
+	domain.ErrInvalidOIDCState:    fiber.StatusUnauthorized,
+	domain.ErrOIDCLoginFailed:     fiber.StatusUnauthorized,
+	domain.ErrInvalidAPIKey:       fiber.StatusUnauthorized,
 
 	// 403 Forbidden — ไม่มีสิทธิ์ดำเนินการ
-	domain.ErrSelfApproval: fiber.StatusForbidden,
+	domain.ErrSelfApproval:             fiber.StatusForbidden,
+	domain.ErrOIDCEmailNotVerified:     fiber.StatusForbidden,
+	domain.ErrPermissionDenied:         fiber.StatusForbidden,
+	domain.ErrInternalCommentForbidden: fiber.StatusForbidden,
 
 	// 404 Not Found — ไม่พบข้อมูล
-	domain.ErrUserNotFound:         fiber.StatusNotFound,
-	domain.ErrRequestNotFound:      fiber.StatusNotFound,
-	domain.ErrLeaveBalanceNotFound: fiber.StatusNotFound,
+	domain.ErrUserNotFound:           fiber.StatusNotFound,
+	domain.ErrRequestNotFound:        fiber.StatusNotFound,
+	domain.ErrLeaveBalanceNotFound:   fiber.StatusNotFound,
+	domain.ErrServiceAccountNotFound: fiber.StatusNotFound,
+	domain.ErrRoleNotFound:           fiber.StatusNotFound,
+	domain.ErrWebhookNotFound:        fiber.StatusNotFound,
+
+	domain.ErrWebhookDeliveryNotFound: fiber.StatusNotFound,
 
 	// 409 Conflict — ข้อมูลขัดแย้ง
 	domain.ErrOverlappingLeave:        fiber.StatusConflict,
 	domain.ErrRequestNotPending:       fiber.StatusConflict,
 	domain.ErrRequestAlreadyProcessed: fiber.StatusConflict,
+	domain.ErrMFANotEnrolled:          fiber.StatusConflict,
+	domain.ErrMFAAlreadyEnabled:       fiber.StatusConflict,
+	domain.ErrMFANotEnabled:           fiber.StatusConflict,
+	domain.ErrEmailAlreadyExists:      fiber.StatusConflict,
+	domain.ErrOIDCAccountConflict:     fiber.StatusConflict,
+	domain.ErrBuiltInRole:             fiber.StatusConflict,
+	domain.ErrRoleInUse:               fiber.StatusConflict,
+	domain.ErrWebhookDeliveryPending:  fiber.StatusConflict,
 
 	// 422 Unprocessable Entity — เงื่อนไขทาง business ไม่ผ่าน
-	domain.ErrInsufficientBalance: fiber.StatusUnprocessableEntity,
+	domain.ErrInsufficientBalance:   fiber.StatusUnprocessableEntity,
+	domain.ErrEntitlementBelowUsage: fiber.StatusUnprocessableEntity,
+
+	// 429 Too Many Requests — ถูกหน่วงหรือล็อกจากการ login ผิดติดต่อกัน
+	domain.ErrTooManyLoginAttempts: fiber.StatusTooManyRequests,
 }
 
 // handleDomainError แปลง domain error เป็น HTTP response
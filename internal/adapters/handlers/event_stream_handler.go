@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	eventStreamHeartbeat = 15 * time.Second // ส่ง comment กันไม่ให้ proxy ตัดการเชื่อมต่อที่เงียบ
+	eventStreamRetry     = 3 * time.Second  // เวลาที่ EventSource รอก่อนต่อใหม่
+)
+
+type EventStreamHandler struct {
+	stream ports.EventStream
+}
+
+func NewEventStreamHandler(stream ports.EventStream) *EventStreamHandler {
+	return &EventStreamHandler{stream: stream}
+}
+
+// Stream รับเหตุการณ์ของใบลาแบบ real-time (Server-Sent Events)
+//
+//	@Summary		รับเหตุการณ์แบบ real-time
+//	@Description	เปิด Server-Sent Events — ผู้มีสิทธิ์ leave.view_team ได้ใบลาใหม่และการอนุมัติ/ปฏิเสธของทุกคน เจ้าของได้เหตุการณ์ของใบลาและยอดวันลาของตัวเอง แต่ละเหตุการณ์มี id, event (ประเภทเหตุการณ์) และ data (dto.LeaveEventResponse) ส่ง comment ": heartbeat" ทุก 15 วินาที ต่อใหม่ด้วย header Last-Event-ID เพื่อรับเหตุการณ์ที่พลาดไป ถ้าต่อจากเดิมไม่ได้จะได้ event: reset ให้โหลดข้อมูลใหม่ การเชื่อมต่อถูกปิดเมื่อ token หมดอายุหรือถูกยกเลิก (ต่อใหม่ด้วย token ใหม่)
+//	@Tags			Leave
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			Last-Event-ID	header		string	false	"id ของเหตุการณ์ล่าสุดที่ได้รับ"
+//	@Success		200				{object}	dto.LeaveEventResponse
+//	@Failure		401				{object}	dto.ErrorResponse
+//	@Failure		403				{object}	dto.ErrorResponse
+//	@Failure		500				{object}	dto.ErrorResponse
+//	@Router			/api/v1/events/stream [get]
+func (h *EventStreamHandler) Stream(c *fiber.Ctx) error {
+	claims, err := getClaimsFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	// ctx ของ request ใช้ไม่ได้หลัง handler คืนค่า — ยกเลิกเองเมื่อเขียนไม่สำเร็จ (client ปิดการเชื่อมต่อ)
+	ctx, cancel := context.WithCancel(context.Background())
+	events, resumed, err := h.stream.Subscribe(ctx, claims, c.Get("Last-Event-ID"))
+	if err != nil {
+		cancel()
+		return handleDomainError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // ไม่ให้ reverse proxy (nginx) buffer
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer cancel()
+		writeEventStream(w, events, resumed)
+	})
+	return nil
+}
+
+// writeEventStream เขียนเหตุการณ์และ heartbeat จนกว่า channel ถูกปิดหรือเขียนไม่สำเร็จ
+func writeEventStream(w *bufio.Writer, events <-chan domain.LeaveEvent, resumed bool) {
+	fmt.Fprintf(w, "retry: %d\n\n", eventStreamRetry.Milliseconds())
+	if !resumed {
+		fmt.Fprint(w, "event: reset\ndata: {}\n\n")
+	}
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(eventStreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(dto.ToLeaveEventResponse(&event))
+			if err != nil {
+				return
+			}
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/spreadsheet"
+)
+
+// exportTimeout เวลาสูงสุดของการส่งออกหนึ่งไฟล์ — stream ทำงานหลัง handler คืนค่าแล้วจึงใช้ context ของ request ไม่ได้
+const exportTimeout = 10 * time.Minute
+
+type ExportHandler struct {
+	exportService ports.ExportService
+}
+
+func NewExportHandler(exportService ports.ExportService) *ExportHandler {
+	return &ExportHandler{exportService: exportService}
+}
+
+// ExportRequests ส่งออกใบลาเป็น CSV หรือ XLSX (สิทธิ์ report.view)
+//
+//	@Summary		ส่งออกใบลา
+//	@Description	ส่งออกใบลาพร้อมผู้ยื่นและผู้อนุมัติ ใช้เงื่อนไขเดียวกับ /manager/requests (ไม่แบ่งหน้า) ไฟล์ถูกเขียนจาก MongoDB cursor ทีละแถว — CSV มี UTF-8 BOM เพื่อให้ Excel แสดงภาษาไทยถูกต้อง
+//	@Tags			Admin
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		BearerAuth
+//	@Param			format		path	string	true	"รูปแบบไฟล์"	Enums(csv, xlsx)
+//	@Param			user_id		query	string	false	"รหัสพนักงาน (UUID)"
+//	@Param			status		query	string	false	"สถานะ คั่นด้วย , (pending,approved,rejected,cancelled)"
+//	@Param			leave_type	query	string	false	"ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)"
+//	@Param			from		query	string	false	"ใบลาที่คาบเกี่ยวตั้งแต่วันที่ (YYYY-MM-DD)"
+//	@Param			to			query	string	false	"ใบลาที่คาบเกี่ยวถึงวันที่ (YYYY-MM-DD)"
+//	@Param			reviewer_id	query	string	false	"รหัสผู้อนุมัติ/ปฏิเสธ (UUID)"
+//	@Param			min_days	query	number	false	"จำนวนวันลาขั้นต่ำ"
+//	@Param			max_days	query	number	false	"จำนวนวันลาสูงสุด"
+//	@Param			q			query	string	false	"คำค้นในเหตุผลการลา (full-text)"
+//	@Param			sort		query	string	false	"เรียงตาม field คั่นด้วย , ขึ้นต้น - คือมากไปน้อย (created_at,start_date,end_date,total_days,reviewed_at) สูงสุด 3 field"	default(start_date)
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/exports/requests.{format} [get]
+func (h *ExportHandler) ExportRequests(c *fiber.Ctx) error {
+	format := spreadsheet.Format(c.Params("format"))
+	if !format.IsValid() {
+		return unsupportedExportFormat(c)
+	}
+
+	query, err := parseLeaveRequestQuery(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	if query.UserID, err = parseQueryID(c, "user_id"); err != nil {
+		return handleDomainError(c, err)
+	}
+	// ตรวจก่อนเริ่ม stream — หลังส่ง header แล้วตอบ 400 ไม่ได้
+	if err := query.Validate(); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return streamExport(c, format, "leave-requests", dto.LeaveRequestExportHeader,
+		func(ctx context.Context, write func([]any) error) error {
+			return h.exportService.ExportRequests(ctx, query, func(v *domain.LeaveRequestView) error {
+				return write(dto.ToLeaveRequestExportRow(v))
+			})
+		},
+	)
+}
+
+// ExportBalances ส่งออกยอดวันลาเป็น CSV หรือ XLSX (สิทธิ์ report.view)
+//
+//	@Summary		ส่งออกยอดวันลา
+//	@Description	ส่งออกยอดวันลาพร้อมชื่อ อีเมล และแผนกของพนักงาน เรียงตามปี พนักงาน และประเภทการลา — CSV มี UTF-8 BOM เพื่อให้ Excel แสดงภาษาไทยถูกต้อง
+//	@Tags			Admin
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Security		BearerAuth
+//	@Param			format		path	string	true	"รูปแบบไฟล์"	Enums(csv, xlsx)
+//	@Param			user_id		query	string	false	"รหัสพนักงาน (UUID)"
+//	@Param			year		query	int		false	"ปีของยอดวันลา"
+//	@Param			leave_type	query	string	false	"ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)"
+//	@Success		200	{file}		file
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/exports/balances.{format} [get]
+func (h *ExportHandler) ExportBalances(c *fiber.Ctx) error {
+	format := spreadsheet.Format(c.Params("format"))
+	if !format.IsValid() {
+		return unsupportedExportFormat(c)
+	}
+
+	query, err := parseLeaveBalanceQuery(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	if err := query.Validate(); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return streamExport(c, format, "leave-balances", dto.LeaveBalanceExportHeader,
+		func(ctx context.Context, write func([]any) error) error {
+			return h.exportService.ExportBalances(ctx, query, func(v *domain.LeaveBalanceView) error {
+				return write(dto.ToLeaveBalanceExportRow(v))
+			})
+		},
+	)
+}
+
+// streamExport ส่ง header ของไฟล์แล้วเขียนแถวลง response ระหว่างอ่านจาก cursor
+// error ระหว่าง stream ตอบ client ไม่ได้แล้ว (status 200 ถูกส่งไปก่อน) จึงบันทึก log และไฟล์จะไม่สมบูรณ์
+func streamExport(
+	c *fiber.Ctx,
+	format spreadsheet.Format,
+	name string,
+	header []any,
+	export func(ctx context.Context, write func([]any) error) error,
+) error {
+	filename := fmt.Sprintf("%s-%s.%s", name, time.Now().UTC().Format("20060102"), format)
+	c.Set(fiber.HeaderContentType, format.ContentType())
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		if err := writeSpreadsheet(ctx, w, format, name, header, export); err != nil {
+			log.Printf("ส่งออก %s ไม่สำเร็จ: %v", filename, err)
+		}
+	})
+	return nil
+}
+
+func writeSpreadsheet(
+	ctx context.Context,
+	w *bufio.Writer,
+	format spreadsheet.Format,
+	sheetName string,
+	header []any,
+	export func(ctx context.Context, write func([]any) error) error,
+) error {
+	sw, err := spreadsheet.NewWriter(format, w, sheetName)
+	if err != nil {
+		return err
+	}
+	if err := sw.WriteRow(header); err != nil {
+		return err
+	}
+	if err := export(ctx, sw.WriteRow); err != nil {
+		return err
+	}
+	return sw.Close()
+}
+
+func unsupportedExportFormat(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusNotFound).JSON(
+		dto.NewErrorResponse("ไม่รองรับรูปแบบไฟล์นี้ (csv หรือ xlsx)"),
+	)
+}
+
+// parseLeaveBalanceQuery อ่านเงื่อนไขส่งออกยอดวันลาจาก query string
+func parseLeaveBalanceQuery(c *fiber.Ctx) (domain.LeaveBalanceQuery, error) {
+	var query domain.LeaveBalanceQuery
+	for _, t := range splitQueryList(c.Query("leave_type")) {
+		query.LeaveTypes = append(query.LeaveTypes, domain.LeaveType(t))
+	}
+
+	var err error
+	if query.UserID, err = parseQueryID(c, "user_id"); err != nil {
+		return query, err
+	}
+	if raw := c.Query("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			return query, domain.ErrInvalidLeaveQuery
+		}
+		query.Year = &year
+	}
+	return query, nil
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+// IntegrationHandler endpoint สำหรับระบบภายนอกที่ยืนยันตัวตนด้วย API key ของ service account
+type IntegrationHandler struct {
+	leaveService ports.LeaveService
+	validate     *validator.Validator
+}
+
+func NewIntegrationHandler(leaveService ports.LeaveService, validate *validator.Validator) *IntegrationHandler {
+	return &IntegrationHandler{
+		leaveService: leaveService,
+		validate:     validate,
+	}
+}
+
+// ListLeaves ดูใบลาทั้งหมดตามสถานะ (scope: leaves:read)
+//
+//	@Summary		ดูใบลาตามสถานะ
+//	@Description	ดึงใบลาของพนักงานทุกคนตามสถานะ (เช่น approved สำหรับคำนวณเงินเดือน) รองรับ pagination
+//	@Tags			Integration
+//	@Produce		json
+//	@Security		APIKeyAuth
+//	@Param			status		query	string	false	"สถานะใบลา (pending/approved/rejected/cancelled)"	default(approved)
+//	@Param			page		query	int		false	"หน้าที่ต้องการ (เริ่มจาก 1)"				default(1)
+//	@Param			page_size	query	int		false	"จำนวนรายการต่อหน้า (สูงสุด 100)"			default(10)
+//	@Param			cursor		query	string	false	"แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta"
+//	@Param			include_total	query	bool	false	"นับจำนวนรายการทั้งหมดในโหมด cursor"	default(false)
+//	@Success		200	{object}	dto.PaginatedAPIResponse{data=[]dto.LeaveRequestResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/integrations/leaves [get]
+func (h *IntegrationHandler) ListLeaves(c *fiber.Ctx) error {
+	status := domain.LeaveStatus(c.Query("status", string(domain.LeaveStatusApproved)))
+	params, err := parseCursorPaginationParams(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	result, err := h.leaveService.GetRequestsByStatus(c.Context(), status, params)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewPageResponse(
+			"ดึงข้อมูลใบลาสำเร็จ",
+			dto.ToLeaveRequestResponses(result.Items),
+			result,
+		),
+	)
+}
+
+// GetUserBalances ดูยอดวันลาของพนักงาน (scope: balances:read)
+//
+//	@Summary		ดูยอดวันลาของพนักงาน
+//	@Description	ดึงยอดวันลาทุกประเภทและทุกปีของพนักงานที่ระบุ
+//	@Tags			Integration
+//	@Produce		json
+//	@Security		APIKeyAuth
+//	@Param			id	path	string	true	"รหัสผู้ใช้ (UUID)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.LeaveBalanceResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/integrations/users/{id}/balances [get]
+func (h *IntegrationHandler) GetUserBalances(c *fiber.Ctx) error {
+	userID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสผู้ใช้ไม่ถูกต้อง"),
+		)
+	}
+
+	balances, err := h.leaveService.GetMyBalance(c.Context(), userID)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงข้อมูลยอดวันลาสำเร็จ", dto.ToLeaveBalanceResponses(balances)),
+	)
+}
+
+// SetEntitlement กำหนดจำนวนวันลาที่พนักงานได้รับ (scope: balances:write)
+//
+//	@Summary		กำหนดจำนวนวันลาที่ได้รับ
+//	@Description	กำหนดจำนวนวันลาทั้งหมดของพนักงานตามประเภทและปี (สร้างใหม่ถ้ายังไม่มี) ต้องไม่น้อยกว่าวันที่ใช้และจองไว้แล้ว
+//	@Tags			Integration
+//	@Accept			json
+//	@Produce		json
+//	@Security		APIKeyAuth
+//	@Param			id		path	string						true	"รหัสผู้ใช้ (UUID)"
+//	@Param			request	body	dto.SetEntitlementRequest	true	"ประเภท ปี และจำนวนวันลา"
+//	@Success		200	{object}	dto.APIResponse{data=dto.LeaveBalanceResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		422	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/integrations/users/{id}/balances [put]
+func (h *IntegrationHandler) SetEntitlement(c *fiber.Ctx) error {
+	userID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสผู้ใช้ไม่ถูกต้อง"),
+		)
+	}
+
+	var req dto.SetEntitlementRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	balance, err := h.leaveService.SetEntitlement(
+		c.Context(), userID, domain.LeaveType(req.LeaveType), req.Year, req.TotalDays,
+	)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("กำหนดจำนวนวันลาสำเร็จ", dto.ToLeaveBalanceResponse(balance)),
+	)
+}
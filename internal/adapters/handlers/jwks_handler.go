@@ -0,0 +1,30 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const jwksCacheControl = "public, max-age=300" // ให้ service อื่น cache ได้ 5 นาที — สั้นกว่าระยะเวลา rotate คีย์
+
+type JWKSHandler struct {
+	provider ports.JWKSProvider
+}
+
+func NewJWKSHandler(provider ports.JWKSProvider) *JWKSHandler {
+	return &JWKSHandler{provider: provider}
+}
+
+// GetJWKS เผยแพร่ public keys สำหรับตรวจสอบ JWT
+//
+//	@Summary		JSON Web Key Set
+//	@Description	public keys (RS256/EdDSA) ที่ใช้ตรวจสอบ access token รวมคีย์เก่าที่ยังอยู่ระหว่าง rotate — เลือกคีย์ด้วย header kid ของ token (คีย์ HS256 จะไม่ถูกเผยแพร่)
+//	@Tags			Authentication
+//	@Produce		json
+//	@Success		200	{object}	domain.JSONWebKeySet
+//	@Router			/.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, jwksCacheControl)
+	return c.Status(fiber.StatusOK).JSON(h.provider.PublicJWKS())
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+type LeaveCommentHandler struct {
+	commentService ports.LeaveCommentService
+	validate       *validator.Validator
+}
+
+func NewLeaveCommentHandler(commentService ports.LeaveCommentService, validate *validator.Validator) *LeaveCommentHandler {
+	return &LeaveCommentHandler{
+		commentService: commentService,
+		validate:       validate,
+	}
+}
+
+// Add แสดงความคิดเห็นในใบลา (เจ้าของใบลาหรือผู้มีสิทธิ์ leave.view_team/user.manage)
+//
+//	@Summary		แสดงความคิดเห็นในใบลา
+//	@Description	เจ้าของใบลาและผู้มีสิทธิ์ leave.view_team หรือ user.manage แสดงความคิดเห็นได้ (คนอื่นได้ 404) leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู) — internal = true เป็นบันทึกที่เขียนและเห็นได้เฉพาะผู้มีสิทธิ์ leave.approve ผู้ที่อยู่ใน mentions ได้รับอีเมลแจ้งเตือนและต้องเห็นความคิดเห็นนี้ได้
+//	@Tags			Leave
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string					true	"รหัสใบลา (UUID)"
+//	@Param			request	body	dto.AddCommentRequest	true	"ข้อความ บันทึกภายใน และผู้ที่กล่าวถึง"
+//	@Success		201	{object}	dto.APIResponse{data=dto.LeaveCommentResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/leaves/{id}/comments [post]
+func (h *LeaveCommentHandler) Add(c *fiber.Ctx) error {
+	authorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	requestID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสใบลาไม่ถูกต้อง"),
+		)
+	}
+
+	var req dto.AddCommentRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	mentions := make([]domain.ID, 0, len(req.Mentions))
+	for _, s := range req.Mentions {
+		id, parseErr := domain.ParseID(s)
+		if parseErr != nil {
+			return handleDomainError(c, domain.ErrInvalidMention)
+		}
+		mentions = append(mentions, id)
+	}
+
+	comment, err := h.commentService.AddComment(c.Context(), requestID, authorID, req.Body, req.Internal, mentions)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(
+		dto.NewSuccessResponse("แสดงความคิดเห็นสำเร็จ", dto.ToLeaveCommentResponse(comment)),
+	)
+}
+
+// List ดูความคิดเห็นของใบลา (เจ้าของใบลาหรือผู้มีสิทธิ์ leave.view_team/user.manage)
+//
+//	@Summary		ดูความคิดเห็นของใบลา
+//	@Description	ดึงความคิดเห็นทั้งหมดของใบลาเรียงตามเวลา — บันทึกภายในแสดงเฉพาะผู้อนุมัติที่ไม่ใช่เจ้าของใบลา ผู้ที่ไม่มีสิทธิ์เห็นใบลาได้ 404 — leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู)
+//	@Tags			Leave
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัสใบลา (UUID)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.LeaveCommentResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/leaves/{id}/comments [get]
+func (h *LeaveCommentHandler) List(c *fiber.Ctx) error {
+	viewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	requestID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสใบลาไม่ถูกต้อง"),
+		)
+	}
+
+	comments, err := h.commentService.ListComments(c.Context(), requestID, viewerID)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงความคิดเห็นสำเร็จ", dto.ToLeaveCommentResponses(comments)),
+	)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -16,12 +17,21 @@ const dateFormat = "2006-01-02"
 
 type LeaveHandler struct {
 	leaveService ports.LeaveService
+	readService  ports.LeaveReadService
+	amendService ports.LeaveAmendService
 	validate     *validator.Validator
 }
 
-func NewLeaveHandler(leaveService ports.LeaveService, validate *validator.Validator) *LeaveHandler {
+func NewLeaveHandler(
+	leaveService ports.LeaveService,
+	readService ports.LeaveReadService,
+	amendService ports.LeaveAmendService,
+	validate *validator.Validator,
+) *LeaveHandler {
 	return &LeaveHandler{
 		leaveService: leaveService,
+		readService:  readService,
+		amendService: amendService,
 		validate:     validate,
 	}
 }
@@ -34,7 +44,8 @@ func NewLeaveHandler(leaveService ports.LeaveService, validate *validator.Valida
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			request	body	dto.SubmitLeaveRequest	true	"ข้อมูลสำหรับยื่นใบลา"
+//	@Param			Idempotency-Key	header	string					false	"คีย์กันการส่งซ้ำ (retry ด้วยคีย์เดิมจะได้ response เดิม)"
+//	@Param			request			body	dto.SubmitLeaveRequest	true	"ข้อมูลสำหรับยื่นใบลา"
 //	@Success		201	{object}	dto.APIResponse{data=dto.LeaveRequestResponse}
 //	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
@@ -78,16 +89,140 @@ func (h *LeaveHandler) Submit(c *fiber.Ctx) error {
 	)
 }
 
+// Edit แก้ไขใบลาของตนเองที่ยังรออนุมัติ
+//
+//	@Summary		แก้ไขใบลา
+//	@Description	เจ้าของแก้ประเภท ช่วงวัน และเหตุผลของใบลาที่ยังรออนุมัติ (ข้อมูลเดียวกับการยื่นใบลา) ระบบปล่อยวันลาที่จองไว้เดิมแล้วจองใหม่ตามใบลาที่แก้ และบันทึก edited ในประวัติใบลา — ใบลาของผู้อื่นได้ 404 ใบลาที่พิจารณาหรือยกเลิกแล้วได้ 409
+//	@Tags			Leave
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string					true	"รหัสใบลา (UUID)"
+//	@Param			request	body	dto.SubmitLeaveRequest	true	"ข้อมูลใบลาที่แก้"
+//	@Success		200	{object}	dto.APIResponse{data=dto.LeaveRequestResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		422	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/leaves/{id} [put]
+func (h *LeaveHandler) Edit(c *fiber.Ctx) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	requestID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสใบลาไม่ถูกต้อง"),
+		)
+	}
+
+	var req dto.SubmitLeaveRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	startDate, endDate, err := parseDateRange(req.StartDate, req.EndDate)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รูปแบบวันที่ไม่ถูกต้อง กรุณาใช้ YYYY-MM-DD"),
+		)
+	}
+
+	request, err := h.amendService.Edit(
+		c.Context(), requestID, userID, domain.LeaveType(req.LeaveType),
+		startDate, endDate, req.Reason,
+	)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("แก้ไขใบลาสำเร็จ", dto.ToLeaveRequestResponse(request)),
+	)
+}
+
+// Cancel ยกเลิกใบลาของตนเองที่ยังรออนุมัติ
+//
+//	@Summary		ยกเลิกใบลา
+//	@Description	เจ้าของยกเลิกใบลาที่ยังรออนุมัติ ระบบปล่อยวันลาที่จองไว้กลับคืนและบันทึก cancelled พร้อมเหตุผลในประวัติใบลา — ใบลาของผู้อื่นได้ 404 ใบลาที่พิจารณาหรือยกเลิกแล้วได้ 409
+//	@Tags			Leave
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string					true	"รหัสใบลา (UUID)"
+//	@Param			request	body	dto.CancelLeaveRequest	false	"เหตุผลที่ยกเลิก"
+//	@Success		200	{object}	dto.APIResponse{data=dto.LeaveRequestResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/leaves/{id}/cancel [post]
+func (h *LeaveHandler) Cancel(c *fiber.Ctx) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	requestID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสใบลาไม่ถูกต้อง"),
+		)
+	}
+
+	// body ไม่บังคับ — ยกเลิกได้โดยไม่ระบุเหตุผล
+	var req dto.CancelLeaveRequest
+	if len(c.Body()) > 0 {
+		if err = c.BodyParser(&req); err != nil {
+			return handleBodyParseError(c)
+		}
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	request, err := h.amendService.Cancel(c.Context(), requestID, userID, req.Reason)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ยกเลิกใบลาสำเร็จ", dto.ToLeaveRequestResponse(request)),
+	)
+}
+
 // GetMyRequests ดูประวัติใบลาทั้งหมดของตนเอง
 //
 //	@Summary		ดูประวัติใบลา
-//	@Description	ดึงข้อมูลใบลาทั้งหมดของผู้ใช้ที่เข้าสู่ระบบ (รองรับ pagination)
+//	@Description	ดึงข้อมูลใบลาของผู้ใช้ที่เข้าสู่ระบบ กรองตามสถานะ ประเภท ช่วงวันลา ผู้อนุมัติ จำนวนวัน และคำค้นในเหตุผล เรียงได้หลาย field (รองรับ pagination)
 //	@Tags			Leave
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			page		query	int	false	"หน้าที่ต้องการ (เริ่มจาก 1)"		default(1)
-//	@Param			page_size	query	int	false	"จำนวนรายการต่อหน้า (สูงสุด 100)"	default(10)
+//	@Param			status		query	string	false	"สถานะ คั่นด้วย , (pending,approved,rejected,cancelled)"
+//	@Param			leave_type	query	string	false	"ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)"
+//	@Param			from		query	string	false	"ใบลาที่คาบเกี่ยวตั้งแต่วันที่ (YYYY-MM-DD)"
+//	@Param			to			query	string	false	"ใบลาที่คาบเกี่ยวถึงวันที่ (YYYY-MM-DD)"
+//	@Param			reviewer_id	query	string	false	"รหัสผู้อนุมัติ/ปฏิเสธ (UUID)"
+//	@Param			min_days	query	number	false	"จำนวนวันลาขั้นต่ำ"
+//	@Param			max_days	query	number	false	"จำนวนวันลาสูงสุด"
+//	@Param			q			query	string	false	"คำค้นในเหตุผลการลา (full-text)"
+//	@Param			sort		query	string	false	"เรียงตาม field คั่นด้วย , ขึ้นต้น - คือมากไปน้อย (created_at,start_date,end_date,total_days,reviewed_at) สูงสุด 3 field"	default(-created_at)
+//	@Param			page		query	int		false	"หน้าที่ต้องการ (เริ่มจาก 1)"		default(1)
+//	@Param			page_size	query	int		false	"จำนวนรายการต่อหน้า (สูงสุด 100)"	default(10)
+//	@Param			cursor		query	string	false	"แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ใช้ได้เมื่อเรียงตาม created_at, ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta"
+//	@Param			include_total	query	bool	false	"นับจำนวนรายการทั้งหมดในโหมด cursor"	default(false)
 //	@Success		200	{object}	dto.PaginatedAPIResponse{data=[]dto.LeaveRequestResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Router			/api/v1/leaves/my-requests [get]
@@ -97,22 +232,103 @@ func (h *LeaveHandler) GetMyRequests(c *fiber.Ctx) error {
 		return handleDomainError(c, err)
 	}
 
-	params := parsePaginationParams(c)
+	query, err := parseLeaveRequestQuery(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	params, err := parseCursorPaginationParams(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
 
-	result, err := h.leaveService.GetMyRequests(c.Context(), userID, params)
+	result, err := h.leaveService.GetMyRequests(c.Context(), userID, query, params)
 	if err != nil {
 		return handleDomainError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(
-		dto.NewPaginatedResponse(
+		dto.NewPageResponse(
 			"ดึงข้อมูลใบลาสำเร็จ",
-			dto.ToLeaveRequestResponses(result.Items),
-			result.Page, result.PageSize, result.Total, result.TotalPages,
+			dto.ToLeaveRequestViewResponses(result.Items),
+			result,
 		),
 	)
 }
 
+// GetRequest ดูรายละเอียดใบลา (เจ้าของใบลา ผู้จัดการ หรือผู้ดูแลระบบ)
+//
+//	@Summary		ดูรายละเอียดใบลา
+//	@Description	ดึงใบลาพร้อมชื่อผู้ยื่น ชื่อผู้อนุมัติ และผลต่อยอดวันลาของประเภทและปีเดียวกัน — ผู้ที่ไม่ใช่เจ้าของและไม่มีสิทธิ์ leave.view_team หรือ user.manage ได้ 404 เพื่อไม่เปิดเผยว่ามีใบลานี้ — leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู)
+//	@Tags			Leave
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัสใบลา (UUID)"
+//	@Success		200	{object}	dto.APIResponse{data=dto.LeaveRequestDetailResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/leaves/{id} [get]
+func (h *LeaveHandler) GetRequest(c *fiber.Ctx) error {
+	viewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	requestID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสใบลาไม่ถูกต้อง"),
+		)
+	}
+
+	detail, err := h.readService.GetRequest(c.Context(), requestID, viewerID)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงข้อมูลใบลาสำเร็จ", dto.ToLeaveRequestDetailResponse(detail)),
+	)
+}
+
+// GetHistory ดูประวัติของใบลา (เจ้าของใบลาหรือผู้มีสิทธิ์ leave.view_team/user.manage)
+//
+//	@Summary		ดูประวัติของใบลา
+//	@Description	ดึงเหตุการณ์ทั้งหมดของใบลาเรียงตามเวลา (ยื่น แก้ไข ความคิดเห็น อนุมัติ ปฏิเสธ rollback ยกเลิก) — ผู้ที่ไม่ใช่เจ้าของและไม่มีสิทธิ์ leave.view_team หรือ user.manage ได้ 404 — leave.view_team ยังเห็นใบลาของทุกแผนก (ยังไม่จำกัดตาม department ของผู้ดู)
+//	@Tags			Leave
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัสใบลา (UUID)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.LeaveHistoryEntryResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/leaves/{id}/history [get]
+func (h *LeaveHandler) GetHistory(c *fiber.Ctx) error {
+	viewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	requestID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสใบลาไม่ถูกต้อง"),
+		)
+	}
+
+	entries, err := h.leaveService.GetHistory(c.Context(), requestID, viewerID)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงประวัติใบลาสำเร็จ", dto.ToLeaveHistoryResponses(entries)),
+	)
+}
+
 // GetMyBalance ดูยอดวันลาคงเหลือของตนเอง
 //
 //	@Summary		ดูยอดวันลาคงเหลือ
@@ -140,81 +356,206 @@ func (h *LeaveHandler) GetMyBalance(c *fiber.Ctx) error {
 	)
 }
 
-// GetPendingRequests ดูใบลาที่รอการอนุมัติ (เฉพาะ Manager)
+// GetPendingRequests ดูใบลาที่รอการอนุมัติ (สิทธิ์ leave.view_team)
 //
 //	@Summary		ดูใบลารอการอนุมัติ
-//	@Description	ดึงข้อมูลใบลาทั้งหมดที่มีสถานะ pending สำหรับผู้จัดการ (รองรับ pagination)
+//	@Description	ดึงข้อมูลใบลาทั้งหมดที่มีสถานะ pending สำหรับผู้ที่มีสิทธิ์ leave.view_team (รองรับ pagination)
 //	@Tags			Manager
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			page		query	int	false	"หน้าที่ต้องการ (เริ่มจาก 1)"		default(1)
 //	@Param			page_size	query	int	false	"จำนวนรายการต่อหน้า (สูงสุด 100)"	default(10)
+//	@Param			cursor		query	string	false	"แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ใช้ได้เมื่อเรียงตาม created_at, ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta"
+//	@Param			include_total	query	bool	false	"นับจำนวนรายการทั้งหมดในโหมด cursor"	default(false)
 //	@Success		200	{object}	dto.PaginatedAPIResponse{data=[]dto.LeaveRequestResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
 //	@Failure		403	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Router			/api/v1/manager/pending-requests [get]
 func (h *LeaveHandler) GetPendingRequests(c *fiber.Ctx) error {
-	params := parsePaginationParams(c)
+	viewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	params, err := parseCursorPaginationParams(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
 
-	result, err := h.leaveService.GetPendingRequests(c.Context(), params)
+	result, err := h.leaveService.GetPendingRequests(c.Context(), viewerID, params)
 	if err != nil {
 		return handleDomainError(c, err)
 	}
 
 	return c.Status(fiber.StatusOK).JSON(
-		dto.NewPaginatedResponse(
+		dto.NewPageResponse(
 			"ดึงข้อมูลใบลารอการอนุมัติสำเร็จ",
-			dto.ToLeaveRequestResponses(result.Items),
-			result.Page, result.PageSize, result.Total, result.TotalPages,
+			dto.ToLeaveRequestViewResponses(result.Items),
+			result,
 		),
 	)
 }
 
-// Approve อนุมัติใบลา (เฉพาะ Manager)
+// SearchRequests ค้นหาใบลาของทุกคน (สิทธิ์ leave.view_team)
+//
+//	@Summary		ค้นหาใบลา
+//	@Description	ค้นหาใบลาของพนักงานทุกคนสำหรับผู้ที่มีสิทธิ์ leave.view_team กรองตามพนักงาน สถานะ ประเภท ช่วงวันลา ผู้อนุมัติ จำนวนวัน และคำค้นในเหตุผล เรียงได้หลาย field (รองรับ pagination)
+//	@Tags			Manager
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			user_id		query	string	false	"รหัสพนักงาน (UUID)"
+//	@Param			status		query	string	false	"สถานะ คั่นด้วย , (pending,approved,rejected,cancelled)"
+//	@Param			leave_type	query	string	false	"ประเภทการลา คั่นด้วย , (sick_leave,annual_leave,personal_leave)"
+//	@Param			from		query	string	false	"ใบลาที่คาบเกี่ยวตั้งแต่วันที่ (YYYY-MM-DD)"
+//	@Param			to			query	string	false	"ใบลาที่คาบเกี่ยวถึงวันที่ (YYYY-MM-DD)"
+//	@Param			reviewer_id	query	string	false	"รหัสผู้อนุมัติ/ปฏิเสธ (UUID)"
+//	@Param			min_days	query	number	false	"จำนวนวันลาขั้นต่ำ"
+//	@Param			max_days	query	number	false	"จำนวนวันลาสูงสุด"
+//	@Param			q			query	string	false	"คำค้นในเหตุผลการลา (full-text)"
+//	@Param			sort		query	string	false	"เรียงตาม field คั่นด้วย , ขึ้นต้น - คือมากไปน้อย (created_at,start_date,end_date,total_days,reviewed_at) สูงสุด 3 field"	default(-created_at)
+//	@Param			page		query	int		false	"หน้าที่ต้องการ (เริ่มจาก 1)"		default(1)
+//	@Param			page_size	query	int		false	"จำนวนรายการต่อหน้า (สูงสุด 100)"	default(10)
+//	@Param			cursor		query	string	false	"แบ่งหน้าแบบ cursor — ส่งว่างเพื่อเริ่มหน้าแรก หรือ next_cursor จากหน้าก่อน (ใช้ได้เมื่อเรียงตาม created_at, ไม่ใช้ page) — pagination เป็น dto.CursorPaginationMeta"
+//	@Param			include_total	query	bool	false	"นับจำนวนรายการทั้งหมดในโหมด cursor"	default(false)
+//	@Success		200	{object}	dto.PaginatedAPIResponse{data=[]dto.LeaveRequestResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/manager/requests [get]
+func (h *LeaveHandler) SearchRequests(c *fiber.Ctx) error {
+	viewerID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	query, err := parseLeaveRequestQuery(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	if query.UserID, err = parseQueryID(c, "user_id"); err != nil {
+		return handleDomainError(c, err)
+	}
+	params, err := parseCursorPaginationParams(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	result, err := h.leaveService.SearchRequests(c.Context(), viewerID, query, params)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewPageResponse(
+			"ค้นหาใบลาสำเร็จ",
+			dto.ToLeaveRequestViewResponses(result.Items),
+			result,
+		),
+	)
+}
+
+// Approve อนุมัติใบลา (สิทธิ์ leave.approve)
 //
 //	@Summary		อนุมัติใบลา
-//	@Description	ผู้จัดการอนุมัติคำขอลา ระบบจะหักยอดวันลาของพนักงานโดยอัตโนมัติ
+//	@Description	ผู้ที่มีสิทธิ์ leave.approve อนุมัติคำขอลา ระบบจะหักยอดวันลาของพนักงานโดยอัตโนมัติ
 //	@Tags			Manager
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id		path	string					true	"รหัสใบลา (UUID)"
-//	@Param			request	body	dto.ReviewLeaveRequest	true	"หมายเหตุจากผู้อนุมัติ"
+//	@Param			id				path	string					true	"รหัสใบลา (UUID)"
+//	@Param			Idempotency-Key	header	string					false	"คีย์กันการส่งซ้ำ (retry ด้วยคีย์เดิมจะได้ response เดิม)"
+//	@Param			request			body	dto.ReviewLeaveRequest	true	"หมายเหตุจากผู้อนุมัติ"
 //	@Success		200	{object}	dto.APIResponse
 //	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
 //	@Failure		403	{object}	dto.ErrorResponse
 //	@Failure		404	{object}	dto.ErrorResponse
 //	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		422	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Router			/api/v1/manager/requests/{id}/approve [post]
 func (h *LeaveHandler) Approve(c *fiber.Ctx) error {
 	return h.reviewRequest(c, true)
 }
 
-// Reject ปฏิเสธใบลา (เฉพาะ Manager)
+// Reject ปฏิเสธใบลา (สิทธิ์ leave.approve)
 //
 //	@Summary		ปฏิเสธใบลา
-//	@Description	ผู้จัดการปฏิเสธคำขอลา ยอดวันลาของพนักงานจะไม่เปลี่ยนแปลง
+//	@Description	ผู้ที่มีสิทธิ์ leave.approve ปฏิเสธคำขอลา ยอดวันลาของพนักงานจะไม่เปลี่ยนแปลง
 //	@Tags			Manager
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
-//	@Param			id		path	string					true	"รหัสใบลา (UUID)"
-//	@Param			request	body	dto.ReviewLeaveRequest	true	"หมายเหตุจากผู้อนุมัติ"
+//	@Param			id				path	string					true	"รหัสใบลา (UUID)"
+//	@Param			Idempotency-Key	header	string					false	"คีย์กันการส่งซ้ำ (retry ด้วยคีย์เดิมจะได้ response เดิม)"
+//	@Param			request			body	dto.ReviewLeaveRequest	true	"หมายเหตุจากผู้อนุมัติ"
 //	@Success		200	{object}	dto.APIResponse
 //	@Failure		400	{object}	dto.ErrorResponse
 //	@Failure		401	{object}	dto.ErrorResponse
 //	@Failure		403	{object}	dto.ErrorResponse
 //	@Failure		404	{object}	dto.ErrorResponse
 //	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		422	{object}	dto.ErrorResponse
 //	@Failure		500	{object}	dto.ErrorResponse
 //	@Router			/api/v1/manager/requests/{id}/reject [post]
 func (h *LeaveHandler) Reject(c *fiber.Ctx) error {
 	return h.reviewRequest(c, false)
 }
 
+// AdjustEntitlement กำหนดจำนวนวันลาที่พนักงานได้รับ (สิทธิ์ balance.adjust)
+//
+//	@Summary		กำหนดจำนวนวันลาของพนักงาน
+//	@Description	กำหนดจำนวนวันลาทั้งหมดของพนักงานตามประเภทและปี (สร้างใหม่ถ้ายังไม่มี) ต้องไม่น้อยกว่าวันที่ใช้และจองไว้แล้ว
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string						true	"รหัสผู้ใช้ (UUID)"
+//	@Param			request	body	dto.SetEntitlementRequest	true	"ประเภท ปี และจำนวนวันลา"
+//	@Success		200	{object}	dto.APIResponse{data=dto.LeaveBalanceResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		422	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/users/{id}/balances [put]
+func (h *LeaveHandler) AdjustEntitlement(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	userID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสผู้ใช้ไม่ถูกต้อง"),
+		)
+	}
+
+	var req dto.SetEntitlementRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	balance, err := h.leaveService.AdjustEntitlement(
+		c.Context(), actorID, userID, domain.LeaveType(req.LeaveType), req.Year, req.TotalDays,
+	)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("กำหนดจำนวนวันลาสำเร็จ", dto.ToLeaveBalanceResponse(balance)),
+	)
+}
+
 func (h *LeaveHandler) reviewRequest(c *fiber.Ctx, approve bool) error {
 	reviewerID, err := getUserIDFromContext(c)
 	if err != nil {
@@ -264,6 +605,14 @@ func getUserIDFromContext(c *fiber.Ctx) (domain.ID, error) {
 	return userID, nil
 }
 
+func getClaimsFromContext(c *fiber.Ctx) (*domain.TokenClaims, error) {
+	claims, ok := c.Locals("claims").(*domain.TokenClaims)
+	if !ok || claims == nil {
+		return nil, domain.ErrUnauthorized
+	}
+	return claims, nil
+}
+
 func parseDateRange(startStr, endStr string) (startDate, endDate time.Time, err error) {
 	startDate, err = time.Parse(dateFormat, startStr)
 	if err != nil {
@@ -278,6 +627,26 @@ func parseDateRange(startStr, endStr string) (startDate, endDate time.Time, err
 	return startDate, endDate, nil
 }
 
+// parseCursorPaginationParams อ่านการแบ่งหน้าของรายการใบลา — มี ?cursor (ว่าง = หน้าแรก) คือแบบ cursor ไม่เช่นนั้นแบบเลขหน้า
+func parseCursorPaginationParams(c *fiber.Ctx) (domain.PaginationParams, error) {
+	if !c.Context().QueryArgs().Has("cursor") {
+		return parsePaginationParams(c), nil
+	}
+
+	var after *domain.Cursor
+	if raw := c.Query("cursor"); raw != "" {
+		var err error
+		if after, err = domain.DecodeCursor(raw); err != nil {
+			return domain.PaginationParams{}, err
+		}
+	}
+	pageSize, err := strconv.Atoi(c.Query("page_size", "10"))
+	if err != nil {
+		pageSize = domain.DefaultPageSize
+	}
+	return domain.NewCursorParams(after, pageSize, c.QueryBool("include_total")), nil
+}
+
 func parsePaginationParams(c *fiber.Ctx) domain.PaginationParams {
 	page, err := strconv.Atoi(c.Query("page", "1"))
 	if err != nil {
@@ -289,3 +658,86 @@ func parsePaginationParams(c *fiber.Ctx) domain.PaginationParams {
 	}
 	return domain.NewPaginationParams(page, pageSize)
 }
+
+// parseLeaveRequestQuery อ่านเงื่อนไขค้นหาใบลาจาก query string — คืน ErrInvalidLeaveQuery ถ้ารูปแบบไม่ถูกต้อง
+// (ค่าที่ไม่อยู่ในรายการที่รองรับ service ตรวจอีกครั้งด้วย Validate)
+func parseLeaveRequestQuery(c *fiber.Ctx) (domain.LeaveRequestQuery, error) {
+	query := domain.LeaveRequestQuery{Search: strings.TrimSpace(c.Query("q"))}
+	for _, s := range splitQueryList(c.Query("status")) {
+		query.Statuses = append(query.Statuses, domain.LeaveStatus(s))
+	}
+	for _, t := range splitQueryList(c.Query("leave_type")) {
+		query.LeaveTypes = append(query.LeaveTypes, domain.LeaveType(t))
+	}
+
+	var err error
+	if query.From, err = parseQueryDate(c, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseQueryDate(c, "to"); err != nil {
+		return query, err
+	}
+	if query.ReviewerID, err = parseQueryID(c, "reviewer_id"); err != nil {
+		return query, err
+	}
+	if query.MinDays, err = parseQueryFloat(c, "min_days"); err != nil {
+		return query, err
+	}
+	if query.MaxDays, err = parseQueryFloat(c, "max_days"); err != nil {
+		return query, err
+	}
+	if query.Sort, err = domain.ParseLeaveSort(c.Query("sort")); err != nil {
+		return query, err
+	}
+	return query, nil
+}
+
+// splitQueryList แยกค่าที่คั่นด้วย , และตัดค่าว่างทิ้ง
+func splitQueryList(raw string) []string {
+	var values []string
+	for _, v := range strings.Split(raw, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseQueryDate อ่านวันที่แบบ YYYY-MM-DD จาก query string — คืน nil ถ้าไม่ได้ระบุ
+func parseQueryDate(c *fiber.Ctx, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(dateFormat, raw)
+	if err != nil {
+		return nil, domain.ErrInvalidLeaveQuery
+	}
+	return &t, nil
+}
+
+// parseQueryID อ่านรหัส (UUID) จาก query string — คืน nil ถ้าไม่ได้ระบุ
+func parseQueryID(c *fiber.Ctx, key string) (*domain.ID, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	id, err := domain.ParseID(raw)
+	if err != nil {
+		return nil, domain.ErrInvalidLeaveQuery
+	}
+	return &id, nil
+}
+
+// parseQueryFloat อ่านตัวเลขจาก query string — คืน nil ถ้าไม่ได้ระบุ
+func parseQueryFloat(c *fiber.Ctx, key string) (*float64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return nil, domain.ErrInvalidLeaveQuery
+	}
+	return &v, nil
+}
@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+type MFAHandler struct {
+	mfaService ports.MFAService
+	validate   *validator.Validator
+}
+
+func NewMFAHandler(mfaService ports.MFAService, validate *validator.Validator) *MFAHandler {
+	return &MFAHandler{
+		mfaService: mfaService,
+		validate:   validate,
+	}
+}
+
+// BeginEnrollment เริ่มลงทะเบียน 2FA
+//
+//	@Summary		เริ่มลงทะเบียน 2FA
+//	@Description	สร้าง TOTP secret ใหม่และ otpauth URI สำหรับสแกนในแอป authenticator ยังไม่เปิดใช้จนกว่าจะยืนยันรหัสที่ /auth/mfa/confirm (เรียกซ้ำได้ secret เดิมที่ยังไม่ยืนยันจะถูกแทนที่)
+//	@Tags			Two-Factor Authentication
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.APIResponse{data=dto.MFAEnrollmentResponse}
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/mfa/enroll [post]
+func (h *MFAHandler) BeginEnrollment(c *fiber.Ctx) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	enrollment, err := h.mfaService.BeginEnrollment(c.Context(), userID)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("สร้าง secret สำเร็จ กรุณายืนยันรหัสจากแอป authenticator", dto.MFAEnrollmentResponse{
+			Secret:     enrollment.Secret,
+			OTPAuthURI: enrollment.OTPAuthURI,
+		}),
+	)
+}
+
+// ConfirmEnrollment ยืนยันรหัสเพื่อเปิดใช้ 2FA
+//
+//	@Summary		ยืนยันการลงทะเบียน 2FA
+//	@Description	ยืนยันรหัส TOTP 6 หลักจากแอป authenticator เพื่อเปิดใช้ 2FA จะได้รับ recovery codes ซึ่งแสดงเพียงครั้งเดียว token ที่ใช้อยู่ยังไม่นับว่ายืนยัน 2FA ต้อง login ใหม่
+//	@Tags			Two-Factor Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.MFACodeRequest	true	"รหัส TOTP"
+//	@Success		200	{object}	dto.APIResponse{data=dto.RecoveryCodesResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/mfa/confirm [post]
+func (h *MFAHandler) ConfirmEnrollment(c *fiber.Ctx) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.MFACodeRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	codes, err := h.mfaService.ConfirmEnrollment(c.Context(), userID, req.Code)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("เปิดใช้ 2FA สำเร็จ", dto.RecoveryCodesResponse{RecoveryCodes: codes}),
+	)
+}
+
+// Disable ปิดใช้ 2FA
+//
+//	@Summary		ปิดใช้ 2FA
+//	@Description	ปิดใช้ 2FA โดยยืนยันด้วยรหัส TOTP หรือ recovery code — บทบาทที่นโยบายบังคับ 2FA จะเข้าถึง endpoint ของบทบาทนั้นไม่ได้จนกว่าจะลงทะเบียนใหม่
+//	@Tags			Two-Factor Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.MFACodeRequest	true	"รหัส TOTP หรือ recovery code"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/mfa/disable [post]
+func (h *MFAHandler) Disable(c *fiber.Ctx) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.MFACodeRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	if err = h.mfaService.Disable(c.Context(), userID, req.Code); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.NewSuccessResponse("ปิดใช้ 2FA สำเร็จ", nil))
+}
+
+// RegenerateRecoveryCodes สร้าง recovery codes ชุดใหม่
+//
+//	@Summary		สร้าง recovery codes ใหม่
+//	@Description	ยืนยันด้วยรหัส TOTP (ไม่รับ recovery code) แล้วสร้าง recovery codes ชุดใหม่แทนชุดเดิมทั้งหมด
+//	@Tags			Two-Factor Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.MFACodeRequest	true	"รหัส TOTP"
+//	@Success		200	{object}	dto.APIResponse{data=dto.RecoveryCodesResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	userID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.MFACodeRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	codes, err := h.mfaService.RegenerateRecoveryCodes(c.Context(), userID, req.Code)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("สร้าง recovery codes ใหม่สำเร็จ", dto.RecoveryCodesResponse{RecoveryCodes: codes}),
+	)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"crypto/subtle"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/api/v1/auth/oidc"
+	oidcStateMaxAge     = 600 // วินาที — เท่ากับอายุ state ฝั่ง server
+)
+
+type OIDCHandler struct {
+	oidcService ports.OIDCService
+}
+
+func NewOIDCHandler(oidcService ports.OIDCService) *OIDCHandler {
+	return &OIDCHandler{oidcService: oidcService}
+}
+
+// Login เริ่ม login ผ่าน identity provider
+//
+//	@Summary		เข้าสู่ระบบผ่าน SSO (OIDC)
+//	@Description	redirect ไปหน้า login ของ identity provider (authorization code flow + PKCE) และผูก state กับ browser ด้วย cookie
+//	@Tags			Authentication
+//	@Success		302
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/oidc/login [get]
+func (h *OIDCHandler) Login(c *fiber.Ctx) error {
+	state, authURL, err := h.oidcService.BeginLogin(c.Context())
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	c.Cookie(&fiber.Cookie{
+		Name:     oidcStateCookie,
+		Value:    state,
+		Path:     oidcStateCookiePath,
+		MaxAge:   oidcStateMaxAge,
+		HTTPOnly: true,
+		Secure:   c.Protocol() == "https",
+		SameSite: fiber.CookieSameSiteLaxMode, // ต้องส่ง cookie กลับมาตอน IdP redirect (top-level GET)
+	})
+
+	return c.Redirect(authURL, fiber.StatusFound)
+}
+
+// Callback รับผลการ login จาก identity provider
+//
+//	@Summary		callback จาก SSO (OIDC)
+//	@Description	IdP redirect กลับมาพร้อม code และ state — ตรวจ state กับ cookie แลก code เป็น ID token จับคู่หรือสร้างผู้ใช้ (just-in-time) กำหนดบทบาทจากกลุ่ม แล้วออก access token และ refresh token ของระบบ
+//	@Tags			Authentication
+//	@Produce		json
+//	@Param			code	query	string	true	"authorization code จาก IdP"
+//	@Param			state	query	string	true	"state ที่ส่งไปตอนเริ่ม login"
+//	@Success		200	{object}	dto.APIResponse{data=dto.AuthResponse}
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c *fiber.Ctx) error {
+	cookieState := c.Cookies(oidcStateCookie)
+	c.Cookie(&fiber.Cookie{Name: oidcStateCookie, Path: oidcStateCookiePath, MaxAge: -1, HTTPOnly: true})
+
+	if c.Query("error") != "" {
+		return handleDomainError(c, domain.ErrOIDCLoginFailed) // ผู้ใช้ยกเลิกหรือ IdP ปฏิเสธ
+	}
+
+	state, code := c.Query("state"), c.Query("code")
+	if state == "" || code == "" || subtle.ConstantTimeCompare([]byte(state), []byte(cookieState)) != 1 {
+		return handleDomainError(c, domain.ErrInvalidOIDCState)
+	}
+
+	tokens, user, err := h.oidcService.CompleteLogin(c.Context(), state, code)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("เข้าสู่ระบบสำเร็จ", dto.ToAuthResponse(tokens, user)),
+	)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+type PasswordHandler struct {
+	passwordService ports.PasswordService
+	validate        *validator.Validator
+}
+
+func NewPasswordHandler(passwordService ports.PasswordService, validate *validator.Validator) *PasswordHandler {
+	return &PasswordHandler{
+		passwordService: passwordService,
+		validate:        validate,
+	}
+}
+
+// ChangePassword เปลี่ยนรหัสผ่านของผู้ใช้ที่ login อยู่
+//
+//	@Summary		เปลี่ยนรหัสผ่าน
+//	@Description	เปลี่ยนรหัสผ่านโดยยืนยันรหัสผ่านปัจจุบัน รหัสผ่านใหม่ต้องผ่านนโยบายความแข็งแรง เมื่อสำเร็จ token ทุกตัวของผู้ใช้ (รวมถึง token ที่ใช้เรียก) จะถูกยกเลิก ต้อง login ใหม่
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.ChangePasswordRequest	true	"รหัสผ่านปัจจุบันและรหัสผ่านใหม่"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/change-password [post]
+func (h *PasswordHandler) ChangePassword(c *fiber.Ctx) error {
+	claims, err := getClaimsFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.ChangePasswordRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	if err = h.passwordService.ChangePassword(c.Context(), claims.UserID, req.CurrentPassword, req.NewPassword); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("เปลี่ยนรหัสผ่านสำเร็จ กรุณาเข้าสู่ระบบใหม่", nil),
+	)
+}
+
+// ForgotPassword ขอลิงก์ตั้งรหัสผ่านใหม่ทางอีเมล
+//
+//	@Summary		ขอลิงก์ตั้งรหัสผ่านใหม่
+//	@Description	ส่งลิงก์ตั้งรหัสผ่านใหม่ (ใช้ได้ครั้งเดียวและมีอายุจำกัด) ไปยังอีเมลที่ระบุ ตอบกลับเหมือนกันทุกกรณีเพื่อไม่ให้รู้ว่าอีเมลมีอยู่ในระบบหรือไม่
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	dto.ForgotPasswordRequest	true	"อีเมลของบัญชี"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/forgot-password [post]
+func (h *PasswordHandler) ForgotPassword(c *fiber.Ctx) error {
+	var req dto.ForgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	if err := h.passwordService.RequestPasswordReset(c.Context(), req.Email); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("หากอีเมลนี้มีอยู่ในระบบ เราได้ส่งลิงก์สำหรับตั้งรหัสผ่านใหม่ไปแล้ว", nil),
+	)
+}
+
+// ResetPassword ตั้งรหัสผ่านใหม่ด้วย reset token
+//
+//	@Summary		ตั้งรหัสผ่านใหม่
+//	@Description	ตั้งรหัสผ่านใหม่ด้วย token จากลิงก์ในอีเมล token ใช้ได้ครั้งเดียว เมื่อสำเร็จ session ทั้งหมดของผู้ใช้จะถูกยกเลิก
+//	@Tags			Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body	dto.ResetPasswordRequest	true	"reset token และรหัสผ่านใหม่"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/auth/reset-password [post]
+func (h *PasswordHandler) ResetPassword(c *fiber.Ctx) error {
+	var req dto.ResetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	if err := h.passwordService.ResetPassword(c.Context(), req.Token, req.NewPassword); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ตั้งรหัสผ่านใหม่สำเร็จ กรุณาเข้าสู่ระบบด้วยรหัสผ่านใหม่", nil),
+	)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type ReportHandler struct {
+	reportService ports.ReportService
+}
+
+func NewReportHandler(reportService ports.ReportService) *ReportHandler {
+	return &ReportHandler{reportService: reportService}
+}
+
+// LeaveUsage วันลาที่อนุมัติแล้วแยกตามประเภทและเดือน (สิทธิ์ report.view)
+//
+//	@Summary		รายงานวันลารายเดือน
+//	@Description	รวมวันลาที่อนุมัติแล้วแยกตามประเภทการลาและเดือน — ใบลานับเข้าเดือนของวันเริ่มลา
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			year		query	int		false	"ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)"
+//	@Param			department	query	string	false	"แผนก (ทีม) ของพนักงาน"
+//	@Param			leave_type	query	string	false	"ประเภทการลา (sick/annual/personal)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.MonthlyLeaveUsageResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/reports/leave-usage [get]
+func (h *ReportHandler) LeaveUsage(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	rows, err := h.reportService.LeaveUsageByMonth(c.Context(), filter)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงรายงานวันลารายเดือนสำเร็จ", dto.ToMonthlyLeaveUsageResponses(rows)),
+	)
+}
+
+// Absenteeism อัตราการขาดงานแยกตามแผนก (สิทธิ์ report.view)
+//
+//	@Summary		รายงานอัตราการขาดงานรายแผนก
+//	@Description	อัตราการขาดงาน = วันลาที่อนุมัติ ÷ (จำนวนพนักงานในแผนก × จำนวนวันในปี)
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			year		query	int		false	"ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)"
+//	@Param			department	query	string	false	"แผนก (ทีม) ของพนักงาน"
+//	@Param			leave_type	query	string	false	"ประเภทการลา (sick/annual/personal)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.TeamAbsenteeismResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/reports/absenteeism [get]
+func (h *ReportHandler) Absenteeism(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	rows, err := h.reportService.AbsenteeismByTeam(c.Context(), filter)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงรายงานอัตราการขาดงานสำเร็จ", dto.ToTeamAbsenteeismResponses(rows)),
+	)
+}
+
+// ApprovalTurnaround เวลาเฉลี่ยตั้งแต่ยื่นจนพิจารณาแยกตามประเภทการลา (สิทธิ์ report.view)
+//
+//	@Summary		รายงานเวลาพิจารณาใบลา
+//	@Description	เวลาเฉลี่ยและนานที่สุดตั้งแต่ยื่นใบลาจนอนุมัติหรือปฏิเสธ (ชั่วโมง) แยกตามประเภทการลา
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			year		query	int		false	"ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)"
+//	@Param			department	query	string	false	"แผนก (ทีม) ของผู้ยื่นใบลา"
+//	@Param			leave_type	query	string	false	"ประเภทการลา (sick/annual/personal)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.ApprovalTurnaroundResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/reports/approval-turnaround [get]
+func (h *ReportHandler) ApprovalTurnaround(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	rows, err := h.reportService.ApprovalTurnaround(c.Context(), filter)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงรายงานเวลาพิจารณาใบลาสำเร็จ", dto.ToApprovalTurnaroundResponses(rows)),
+	)
+}
+
+// RejectionRates อัตราการปฏิเสธแยกตามผู้อนุมัติ (สิทธิ์ report.view)
+//
+//	@Summary		รายงานอัตราการปฏิเสธรายผู้อนุมัติ
+//	@Description	จำนวนใบลาที่พิจารณา ที่ปฏิเสธ และอัตราการปฏิเสธของผู้อนุมัติแต่ละคน เรียงจากพิจารณามากสุด
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			year		query	int		false	"ปีของรายงาน (ค่าเริ่มต้นคือปีปัจจุบัน)"
+//	@Param			department	query	string	false	"แผนก (ทีม) ของผู้ยื่นใบลา"
+//	@Param			leave_type	query	string	false	"ประเภทการลา (sick/annual/personal)"
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.ReviewerRejectionRateResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/reports/rejection-rates [get]
+func (h *ReportHandler) RejectionRates(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	rows, err := h.reportService.RejectionRateByReviewer(c.Context(), filter)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงรายงานอัตราการปฏิเสธสำเร็จ", dto.ToReviewerRejectionRateResponses(rows)),
+	)
+}
+
+// CarryOverRisks พนักงานที่มีวันลาคงเหลือเกินจำนวนที่ยกไปปีถัดไปได้ (สิทธิ์ report.view)
+//
+//	@Summary		รายงานวันลาที่เสี่ยงหายเมื่อขึ้นปีใหม่
+//	@Description	พนักงานที่มีวันลาคงเหลือ (หักที่ใช้และรออนุมัติ) เกินจำนวนที่ยกไปปีถัดไปได้ เรียงจากคงเหลือมากสุด — ไม่ระบุประเภทการลาคือลาพักร้อน
+//	@Tags			Reports
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			year		query	int		false	"ปีของยอดวันลา (ค่าเริ่มต้นคือปีปัจจุบัน)"
+//	@Param			department	query	string	false	"แผนก (ทีม) ของพนักงาน"
+//	@Param			leave_type	query	string	false	"ประเภทการลา (ค่าเริ่มต้น annual)"
+//	@Param			limit		query	int		false	"จำนวนพนักงาน (สูงสุด 100)"	default(10)
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.CarryOverRiskResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/reports/carry-over-risks [get]
+func (h *ReportHandler) CarryOverRisks(c *fiber.Ctx) error {
+	filter, err := parseReportFilter(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(domain.DefaultCarryOverLimit)))
+	if err != nil {
+		return handleDomainError(c, domain.ErrInvalidReportFilter)
+	}
+
+	rows, err := h.reportService.CarryOverRisks(c.Context(), filter, limit)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงรายงานวันลาที่เสี่ยงหายสำเร็จ", dto.ToCarryOverRiskResponses(rows)),
+	)
+}
+
+// parseReportFilter อ่านเงื่อนไขของรายงานจาก query string — ไม่ระบุปีคือปีปัจจุบัน
+func parseReportFilter(c *fiber.Ctx) (domain.ReportFilter, error) {
+	filter := domain.ReportFilter{
+		Department: c.Query("department"),
+		LeaveType:  domain.LeaveType(c.Query("leave_type")),
+		Year:       time.Now().Year(),
+	}
+	if raw := c.Query("year"); raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil {
+			return filter, domain.ErrInvalidReportFilter
+		}
+		filter.Year = year
+	}
+	return filter, nil
+}
@@ -0,0 +1,170 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+type RoleHandler struct {
+	roleService ports.RoleService
+	validate    *validator.Validator
+}
+
+func NewRoleHandler(roleService ports.RoleService, validate *validator.Validator) *RoleHandler {
+	return &RoleHandler{
+		roleService: roleService,
+		validate:    validate,
+	}
+}
+
+// List ดูบทบาททั้งหมดพร้อมสิทธิ์ (สิทธิ์ user.manage)
+//
+//	@Summary		ดูบทบาททั้งหมด
+//	@Description	ดึงรายการบทบาททั้งหมดในระบบพร้อมสิทธิ์ของแต่ละบทบาท
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.RoleResponse}
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/roles [get]
+func (h *RoleHandler) List(c *fiber.Ctx) error {
+	roles, err := h.roleService.ListRoles(c.Context())
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงข้อมูลบทบาทสำเร็จ", dto.ToRoleResponses(roles)),
+	)
+}
+
+// Save สร้างบทบาทใหม่หรือแก้สิทธิ์ของบทบาทเดิม (สิทธิ์ user.manage)
+//
+//	@Summary		สร้างหรือแก้ไขบทบาท
+//	@Description	กำหนดสิทธิ์ทั้งหมดของบทบาท (แทนที่สิทธิ์เดิม) ชื่อบทบาทใหม่ต้องเป็นตัวพิมพ์เล็ก ตัวเลข หรือ _ ยาว 2–32 ตัวอักษร บทบาท admin ต้องมีสิทธิ์ user.manage เสมอ
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			name	path	string				true	"ชื่อบทบาท"
+//	@Param			request	body	dto.SaveRoleRequest	true	"คำอธิบายและสิทธิ์ของบทบาท"
+//	@Success		200	{object}	dto.APIResponse{data=dto.RoleResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/roles/{name} [put]
+func (h *RoleHandler) Save(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.SaveRoleRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	permissions := make([]domain.Permission, 0, len(req.Permissions))
+	for _, p := range req.Permissions {
+		permissions = append(permissions, domain.Permission(p))
+	}
+
+	role, err := h.roleService.SaveRole(c.Context(), actorID, domain.Role(c.Params("name")), req.Description, permissions)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("บันทึกบทบาทสำเร็จ", dto.ToRoleResponse(role)),
+	)
+}
+
+// Delete ลบบทบาทที่สร้างเอง (สิทธิ์ user.manage)
+//
+//	@Summary		ลบบทบาท
+//	@Description	ลบบทบาทที่สร้างเอง บทบาทเริ่มต้นของระบบและบทบาทที่ยังมีผู้ใช้อยู่ลบไม่ได้
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			name	path	string	true	"ชื่อบทบาท"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/roles/{name} [delete]
+func (h *RoleHandler) Delete(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	if err = h.roleService.DeleteRole(c.Context(), actorID, domain.Role(c.Params("name"))); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ลบบทบาทสำเร็จ", nil),
+	)
+}
+
+// AssignUserRole เปลี่ยนบทบาทของผู้ใช้ (สิทธิ์ user.manage)
+//
+//	@Summary		เปลี่ยนบทบาทของผู้ใช้
+//	@Description	กำหนดบทบาทใหม่ให้ผู้ใช้ บทบาทต้องมีอยู่ในระบบ สิทธิ์ใหม่มีผลกับการตรวจใน service ทันที และกับ token ของผู้ใช้หลัง login ใหม่หรือ refresh
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string					true	"รหัสผู้ใช้ (UUID)"
+//	@Param			request	body	dto.AssignRoleRequest	true	"บทบาทใหม่"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/users/{id}/role [put]
+func (h *RoleHandler) AssignUserRole(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	userID, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัสผู้ใช้ไม่ถูกต้อง"),
+		)
+	}
+
+	var req dto.AssignRoleRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	if err = h.roleService.AssignRole(c.Context(), actorID, userID, domain.Role(req.Role)); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("เปลี่ยนบทบาทผู้ใช้สำเร็จ", nil),
+	)
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+type ServiceAccountHandler struct {
+	apiKeyService ports.APIKeyService
+	validate      *validator.Validator
+}
+
+func NewServiceAccountHandler(apiKeyService ports.APIKeyService, validate *validator.Validator) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		apiKeyService: apiKeyService,
+		validate:      validate,
+	}
+}
+
+// Create สร้าง service account พร้อม API key (สิทธิ์ user.manage)
+//
+//	@Summary		สร้าง service account
+//	@Description	สร้าง API key สำหรับระบบภายนอก (เช่น HR, payroll) พร้อม scope ที่อนุญาต API key จะแสดงเพียงครั้งเดียว ระบบเก็บเฉพาะ hash
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.CreateServiceAccountRequest	true	"ชื่อและ scope ของ service account"
+//	@Success		201	{object}	dto.APIResponse{data=dto.CreateServiceAccountResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/service-accounts [post]
+func (h *ServiceAccountHandler) Create(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.CreateServiceAccountRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	scopes := make([]domain.APIScope, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		scopes = append(scopes, domain.APIScope(scope))
+	}
+
+	account, key, err := h.apiKeyService.CreateServiceAccount(c.Context(), actorID, req.Name, scopes)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(
+		dto.NewSuccessResponse("สร้าง service account สำเร็จ — กรุณาเก็บ API key ไว้ในที่ปลอดภัย", dto.CreateServiceAccountResponse{
+			ServiceAccount: dto.ToServiceAccountResponse(account),
+			APIKey:         key,
+		}),
+	)
+}
+
+// List ดู service account ทั้งหมด (สิทธิ์ user.manage)
+//
+//	@Summary		ดู service account ทั้งหมด
+//	@Description	ดึงรายการ service account ทั้งหมด รวมที่ถูกยกเลิกแล้ว พร้อมเวลาที่ใช้ API key ล่าสุด
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.ServiceAccountResponse}
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/service-accounts [get]
+func (h *ServiceAccountHandler) List(c *fiber.Ctx) error {
+	accounts, err := h.apiKeyService.ListServiceAccounts(c.Context())
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงข้อมูล service account สำเร็จ", dto.ToServiceAccountResponses(accounts)),
+	)
+}
+
+// Revoke ยกเลิก API key ของ service account (สิทธิ์ user.manage)
+//
+//	@Summary		ยกเลิก service account
+//	@Description	ยกเลิก API key ของ service account ระบบภายนอกที่ใช้ key นี้จะเรียก API ไม่ได้ทันที
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัส service account (UUID)"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/service-accounts/{id}/revoke [post]
+func (h *ServiceAccountHandler) Revoke(c *fiber.Ctx) error {
+	id, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(
+			dto.NewErrorResponse("รหัส service account ไม่ถูกต้อง"),
+		)
+	}
+
+	if err = h.apiKeyService.RevokeServiceAccount(c.Context(), id); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ยกเลิก service account สำเร็จ", nil),
+	)
+}
@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/spreadsheet"
+)
+
+type UserImportHandler struct {
+	importService ports.UserImportService
+}
+
+func NewUserImportHandler(importService ports.UserImportService) *UserImportHandler {
+	return &UserImportHandler{importService: importService}
+}
+
+// Import นำเข้าผู้ใช้และยอดวันลายกมาจากไฟล์ CSV (สิทธิ์ user.manage และ balance.adjust)
+//
+//	@Summary		นำเข้าผู้ใช้และยอดวันลายกมาจาก CSV
+//	@Description	หนึ่งแถวต่อผู้ใช้หนึ่งคน คอลัมน์ email, first_name, last_name (บังคับ), department, role, year และยอดวันลา <leave_type> กับ <leave_type>_used เช่น annual_leave, annual_leave_used
+//	@Description	ตรวจทีละแถว แถวที่ไม่ผ่านถูกรายงานใน rows และไม่หยุดแถวอื่น — dry_run=true ตรวจอย่างเดียว, upsert=true แก้ผู้ใช้ที่มีอีเมลอยู่แล้ว
+//	@Description	ผู้ใช้ใหม่ไม่มีรหัสผ่าน ให้ตั้งรหัสผ่านผ่านลืมรหัสผ่าน หรือ login ผ่าน SSO/LDAP
+//	@Tags			Admin
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			file	formData	file	true	"ไฟล์ CSV (UTF-8, ไม่เกิน 5,000 แถว)"
+//	@Param			dry_run	query		bool	false	"ตรวจอย่างเดียว ไม่บันทึก"
+//	@Param			upsert	query		bool	false	"แก้ชื่อ แผนก บทบาท และยอดวันลาของผู้ใช้ที่มีอีเมลอยู่แล้ว"
+//	@Param			year	query		int		false	"ปีของยอดวันลาสำหรับแถวที่ไม่ระบุ year (ค่าเริ่มต้นคือปีปัจจุบัน)"
+//	@Success		200	{object}	dto.APIResponse{data=dto.UserImportResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/users/import [post]
+func (h *UserImportHandler) Import(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	opts := domain.UserImportOptions{DryRun: c.QueryBool("dry_run"), Upsert: c.QueryBool("upsert")}
+	if raw := c.Query("year"); raw != "" {
+		if opts.Year, err = strconv.Atoi(raw); err != nil {
+			return invalidImportFile(c, errors.New("ปีไม่ถูกต้อง"))
+		}
+	}
+
+	file, err := readUserImportFile(c)
+	if err != nil {
+		return invalidImportFile(c, err)
+	}
+
+	report, err := h.importService.ImportUsers(c.Context(), actorID, file, opts)
+	if errors.Is(err, domain.ErrInvalidImportFile) {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewErrorResponse(err.Error()))
+	}
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	message := "นำเข้าผู้ใช้สำเร็จ"
+	if opts.DryRun {
+		message = "ตรวจไฟล์นำเข้าสำเร็จ (ยังไม่บันทึก)"
+	}
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse(message, dto.ToUserImportResponse(report)),
+	)
+}
+
+// readUserImportFile อ่าน CSV จากฟิลด์ file ของ multipart form
+func readUserImportFile(c *fiber.Ctx) (domain.UserImportFile, error) {
+	fh, err := c.FormFile("file")
+	if err != nil {
+		return domain.UserImportFile{}, errors.New("ต้องแนบไฟล์ CSV ในฟิลด์ file")
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return domain.UserImportFile{}, err
+	}
+	defer f.Close()
+
+	header, rows, err := spreadsheet.ReadCSV(f, domain.MaxImportRows)
+	if err != nil {
+		return domain.UserImportFile{}, err
+	}
+	return toUserImportFile(header, rows), nil
+}
+
+func toUserImportFile(header []string, rows []spreadsheet.Row) domain.UserImportFile {
+	file := domain.UserImportFile{Header: header, Records: make([]domain.UserImportRecord, 0, len(rows))}
+	for _, r := range rows {
+		file.Records = append(file.Records, domain.UserImportRecord{Line: r.Line, Values: r.Values})
+	}
+	return file
+}
+
+func invalidImportFile(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusBadRequest).JSON(
+		dto.NewErrorResponse(domain.ErrInvalidImportFile.Error() + ": " + err.Error()),
+	)
+}
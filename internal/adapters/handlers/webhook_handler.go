@@ -0,0 +1,250 @@
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+type WebhookHandler struct {
+	webhookService ports.WebhookService
+	validate       *validator.Validator
+}
+
+func NewWebhookHandler(webhookService ports.WebhookService, validate *validator.Validator) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		validate:       validate,
+	}
+}
+
+// Create ลงทะเบียน webhook (สิทธิ์ user.manage)
+//
+//	@Summary		สร้าง webhook
+//	@Description	ลงทะเบียนปลายทางที่รับเหตุการณ์ของใบลา (leave.submitted, leave.approved, leave.rejected, balance.adjusted) ทาง HTTP POST ทุก request มี X-Webhook-Signature = sha256=HMAC-SHA256(secret, X-Webhook-Timestamp + "." + body)
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body	dto.CreateWebhookRequest	true	"ปลายทาง secret และเหตุการณ์"
+//	@Success		201	{object}	dto.APIResponse{data=dto.WebhookResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/webhooks [post]
+func (h *WebhookHandler) Create(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	var req dto.CreateWebhookRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	sub, err := h.webhookService.CreateSubscription(c.Context(), actorID, req.URL, req.Secret, dto.ToLeaveEventTypes(req.Events))
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(
+		dto.NewSuccessResponse("สร้าง webhook สำเร็จ", dto.ToWebhookResponse(sub)),
+	)
+}
+
+// List ดู webhook ทั้งหมด (สิทธิ์ user.manage)
+//
+//	@Summary		ดู webhook ทั้งหมด
+//	@Description	ดึงรายการ webhook ทั้งหมด (ไม่แสดง secret)
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	dto.APIResponse{data=[]dto.WebhookResponse}
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/webhooks [get]
+func (h *WebhookHandler) List(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	subs, err := h.webhookService.ListSubscriptions(c.Context(), actorID)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("ดึงข้อมูล webhook สำเร็จ", dto.ToWebhookResponses(subs)),
+	)
+}
+
+// Update แก้ webhook (สิทธิ์ user.manage)
+//
+//	@Summary		แก้ webhook
+//	@Description	แก้ปลายทาง เหตุการณ์ และสถานะเปิด/ปิด ไม่ส่ง secret = ใช้ secret เดิม ปิดใช้งานแล้วรายการที่ค้างอยู่จะถูกย้ายเป็น dead
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string						true	"รหัส webhook (UUID)"
+//	@Param			request	body	dto.UpdateWebhookRequest	true	"ค่าใหม่ของ webhook"
+//	@Success		200	{object}	dto.APIResponse{data=dto.WebhookResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/webhooks/{id} [put]
+func (h *WebhookHandler) Update(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	id, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewErrorResponse("รหัส webhook ไม่ถูกต้อง"))
+	}
+
+	var req dto.UpdateWebhookRequest
+	if err = c.BodyParser(&req); err != nil {
+		return handleBodyParseError(c)
+	}
+	if errs := h.validate.Validate(req); errs != nil {
+		return handleValidationError(c, errs)
+	}
+
+	sub, err := h.webhookService.UpdateSubscription(
+		c.Context(), actorID, id, req.URL, req.Secret, dto.ToLeaveEventTypes(req.Events), req.Active,
+	)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("แก้ไข webhook สำเร็จ", dto.ToWebhookResponse(sub)),
+	)
+}
+
+// Delete ลบ webhook (สิทธิ์ user.manage)
+//
+//	@Summary		ลบ webhook
+//	@Description	ลบ webhook รายการที่ยังไม่ได้ส่งจะถูกย้ายเป็น dead และส่งใหม่ไม่ได้
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัส webhook (UUID)"
+//	@Success		200	{object}	dto.APIResponse
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	id, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewErrorResponse("รหัส webhook ไม่ถูกต้อง"))
+	}
+
+	if err = h.webhookService.DeleteSubscription(c.Context(), actorID, id); err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(dto.NewSuccessResponse("ลบ webhook สำเร็จ", nil))
+}
+
+// ListDeliveries ค้นหารายการส่ง webhook (สิทธิ์ user.manage)
+//
+//	@Summary		ดูรายการส่ง webhook
+//	@Description	ดึงรายการส่ง webhook เรียงจากใหม่ไปเก่า พร้อมจำนวนครั้งที่ส่งและสาเหตุที่ส่งไม่สำเร็จ ใช้หารายการ dead เพื่อสั่งส่งใหม่ รองรับ pagination
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			subscription_id	query	string	false	"รหัส webhook (UUID)"
+//	@Param			status			query	string	false	"สถานะ (pending/delivered/dead)"
+//	@Param			page			query	int		false	"หน้าที่ต้องการ (เริ่มจาก 1)"	default(1)
+//	@Param			page_size		query	int		false	"จำนวนรายการต่อหน้า (สูงสุด 100)"	default(10)
+//	@Success		200	{object}	dto.PaginatedAPIResponse{data=[]dto.WebhookDeliveryResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/webhook-deliveries [get]
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	filter := domain.WebhookDeliveryFilter{Status: domain.WebhookDeliveryStatus(c.Query("status"))}
+	if raw := c.Query("subscription_id"); raw != "" {
+		id, err := domain.ParseID(raw)
+		if err != nil {
+			return handleDomainError(c, domain.ErrInvalidWebhookFilter)
+		}
+		filter.SubscriptionID = &id
+	}
+
+	result, err := h.webhookService.ListDeliveries(c.Context(), actorID, filter, parsePaginationParams(c))
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewPaginatedResponse(
+			"ดึงข้อมูลรายการส่ง webhook สำเร็จ",
+			dto.ToWebhookDeliveryResponses(result.Items),
+			result.Page, result.PageSize, result.Total, result.TotalPages,
+		),
+	)
+}
+
+// Redeliver สั่งส่ง webhook ใหม่ (สิทธิ์ user.manage)
+//
+//	@Summary		ส่ง webhook ใหม่
+//	@Description	นำรายการที่ dead หรือส่งสำเร็จแล้วกลับเข้าคิวส่ง ใช้ payload และ X-Webhook-Event-Id เดิม ปลายทางจึงตัดรายการซ้ำได้
+//	@Tags			Admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"รหัสรายการส่ง (UUID)"
+//	@Success		200	{object}	dto.APIResponse{data=dto.WebhookDeliveryResponse}
+//	@Failure		400	{object}	dto.ErrorResponse
+//	@Failure		401	{object}	dto.ErrorResponse
+//	@Failure		403	{object}	dto.ErrorResponse
+//	@Failure		404	{object}	dto.ErrorResponse
+//	@Failure		409	{object}	dto.ErrorResponse
+//	@Failure		500	{object}	dto.ErrorResponse
+//	@Router			/api/v1/admin/webhook-deliveries/{id}/redeliver [post]
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	actorID, err := getUserIDFromContext(c)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+	id, err := domain.ParseID(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(dto.NewErrorResponse("รหัสรายการส่ง webhook ไม่ถูกต้อง"))
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Context(), actorID, id)
+	if err != nil {
+		return handleDomainError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		dto.NewSuccessResponse("นำรายการส่ง webhook กลับเข้าคิวแล้ว", dto.ToWebhookDeliveryResponse(delivery)),
+	)
+}
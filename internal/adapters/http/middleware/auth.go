@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -10,7 +11,10 @@ import (
 	"github/be2bag/leave-management-system/internal/core/ports"
 )
 
-const bearerPrefix = "Bearer "
+const (
+	bearerPrefix = "Bearer "
+	apiKeyHeader = "X-API-Key"
+)
 
 func AuthMiddleware(tokenService ports.TokenService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -28,36 +32,122 @@ func AuthMiddleware(tokenService ports.TokenService) fiber.Handler {
 			return unauthorizedResponse(c, "ไม่พบ token")
 		}
 
-		claims, err := tokenService.ValidateToken(tokenString)
+		claims, err := tokenService.ValidateToken(c.Context(), tokenString)
 		if err != nil {
-			return unauthorizedResponse(c, "token ไม่ถูกต้องหรือหมดอายุ")
+			if errors.Is(err, domain.ErrUnauthorized) {
+				return unauthorizedResponse(c, "token ไม่ถูกต้อง หมดอายุ หรือถูกยกเลิกแล้ว")
+			}
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				dto.NewErrorResponse("เกิดข้อผิดพลาดภายในระบบ"),
+			)
 		}
 
+		c.Locals("claims", claims)
 		c.Locals("userID", claims.UserID.String())
 		c.Locals("email", claims.Email)
 		c.Locals("role", string(claims.Role))
+		setRequestActor(c, claims.UserID, domain.AuditActorUser)
 
 		return c.Next()
 	}
 }
 
-func RoleMiddleware(allowedRoles ...domain.Role) fiber.Handler {
+// APIKeyMiddleware ยืนยันตัวตนระบบภายนอกด้วย API key ใน header X-API-Key — ใช้กับ route ของ integration แทน AuthMiddleware
+func APIKeyMiddleware(apiKeyService ports.APIKeyService) fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		roleStr, ok := c.Locals("role").(string)
-		if !ok {
-			return unauthorizedResponse(c, "ไม่พบข้อมูลบทบาทผู้ใช้")
+		key := c.Get(apiKeyHeader)
+		if key == "" {
+			return unauthorizedResponse(c, "กรุณาระบุ X-API-Key header")
 		}
 
-		userRole := domain.Role(roleStr)
-		for _, role := range allowedRoles {
-			if userRole == role {
-				return c.Next()
+		account, err := apiKeyService.Authenticate(c.Context(), key)
+		if err != nil {
+			if errors.Is(err, domain.ErrInvalidAPIKey) {
+				return unauthorizedResponse(c, domain.ErrInvalidAPIKey.Error())
 			}
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				dto.NewErrorResponse("เกิดข้อผิดพลาดภายในระบบ"),
+			)
+		}
+
+		c.Locals("serviceAccount", account)
+		setRequestActor(c, account.ID, domain.AuditActorServiceAccount)
+
+		return c.Next()
+	}
+}
+
+// RequireScope ปฏิเสธ API key ที่ไม่ได้รับ scope ที่ระบุ — ต้องใช้หลัง APIKeyMiddleware
+func RequireScope(scope domain.APIScope) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		account, ok := c.Locals("serviceAccount").(*domain.ServiceAccount)
+		if !ok || account == nil {
+			return unauthorizedResponse(c, "ไม่พบข้อมูล service account")
+		}
+
+		if !account.HasScope(scope) {
+			return c.Status(fiber.StatusForbidden).JSON(
+				dto.NewErrorResponse("API key ไม่มี scope " + string(scope)),
+			)
+		}
+
+		return c.Next()
+	}
+}
+
+// RequirePermission ปฏิเสธผู้ใช้ที่ไม่มีสิทธิ์ที่ระบุ — ต้องใช้หลัง AuthMiddleware
+// ตรวจจากบทบาทปัจจุบันในฐานข้อมูลเหมือน service ไม่ใช่บทบาทใน token ที่อาจล้าสมัยหลังเปลี่ยนบทบาท
+func RequirePermission(authorizer ports.Authorizer, permission domain.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*domain.TokenClaims)
+		if !ok || claims == nil {
+			return unauthorizedResponse(c, "ไม่พบข้อมูลผู้ใช้")
+		}
+
+		err := authorizer.Authorize(c.Context(), claims.UserID, permission)
+		switch {
+		case err == nil:
+			return c.Next()
+		case errors.Is(err, domain.ErrPermissionDenied):
+			return c.Status(fiber.StatusForbidden).JSON(
+				dto.NewErrorResponse("ไม่มีสิทธิ์ " + string(permission)),
+			)
+		case errors.Is(err, domain.ErrUserNotFound):
+			return unauthorizedResponse(c, "ไม่พบผู้ใช้ในระบบ")
+		default:
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				dto.NewErrorResponse("เกิดข้อผิดพลาดภายในระบบ"),
+			)
+		}
+	}
+}
+
+// RequireMFA ปฏิเสธ token ที่ยังไม่ผ่าน 2FA เมื่อบทบาทของผู้ใช้มีสิทธิ์ที่นโยบายบังคับ — ต้องใช้หลัง AuthMiddleware
+// ตรวจจากบทบาทปัจจุบันในฐานข้อมูลเหมือน RequirePermission — ผู้ที่เพิ่งถูกเลื่อนบทบาทต้องยืนยัน 2FA ก่อนใช้สิทธิ์ใหม่
+func RequireMFA(authorizer ports.Authorizer, policy domain.MFAPolicy) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		claims, ok := c.Locals("claims").(*domain.TokenClaims)
+		if !ok || claims == nil {
+			return unauthorizedResponse(c, "ไม่พบข้อมูลผู้ใช้")
+		}
+
+		role, err := authorizer.UserRole(c.Context(), claims.UserID)
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			return unauthorizedResponse(c, "ไม่พบผู้ใช้ในระบบ")
+		case err != nil:
+			return c.Status(fiber.StatusInternalServerError).JSON(
+				dto.NewErrorResponse("เกิดข้อผิดพลาดภายในระบบ"),
+			)
+		}
+
+		if policy.Requires(role) && !claims.MFA {
+			return c.Status(fiber.StatusForbidden).JSON(
+				dto.NewErrorResponse("บทบาทที่มีสิทธิ์นี้ต้องเปิดใช้และยืนยันตัวตนด้วย 2FA ก่อนเข้าถึง endpoint นี้"),
+			)
 		}
 
-		return c.Status(fiber.StatusForbidden).JSON(
-			dto.NewErrorResponse("ไม่มีสิทธิ์เข้าถึง endpoint นี้"),
-		)
+		return c.Next()
 	}
 }
 
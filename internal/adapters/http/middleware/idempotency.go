@@ -0,0 +1,85 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"slices"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/adapters/dto"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	idempotencyKeyHeader     = "Idempotency-Key"
+	idempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// Idempotency ตอบ request ที่ส่งซ้ำด้วย Idempotency-Key เดิมด้วย response เดิมแทนการทำซ้ำ — ต้องใช้หลัง AuthMiddleware
+// request ที่ไม่มี header ทำงานตามปกติ, key เดิมที่ method, path หรือ body ต่างไปได้ 422
+func Idempotency(service ports.IdempotencyService) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := c.Get(idempotencyKeyHeader)
+		if key == "" {
+			return c.Next()
+		}
+		claims, ok := c.Locals("claims").(*domain.TokenClaims)
+		if !ok || claims == nil {
+			return unauthorizedResponse(c, "ไม่พบข้อมูลผู้ใช้")
+		}
+
+		record, replay, err := service.Begin(c.Context(), claims.UserID, key, requestFingerprint(c))
+		if err != nil {
+			return idempotencyErrorResponse(c, err)
+		}
+		if replay != nil {
+			c.Set(idempotentReplayedHeader, "true")
+			c.Set(fiber.HeaderContentType, replay.ContentType)
+			return c.Status(replay.StatusCode).Send(replay.Body)
+		}
+
+		if err = c.Next(); err != nil || c.Response().StatusCode() >= fiber.StatusInternalServerError {
+			// error ภายในระบบ — ยกเลิกการจองให้ client ลองใหม่ด้วย key เดิมได้ (ถ้ายกเลิกไม่สำเร็จ การจองหมดเวลาเองใน 1 นาที)
+			service.Release(c.Context(), record) //nolint:errcheck // การจองหมดเวลาเอง
+			return err
+		}
+
+		resp := domain.IdempotentResponse{
+			StatusCode:  c.Response().StatusCode(),
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        slices.Clone(c.Response().Body()),
+		}
+		// บันทึกไม่สำเร็จไม่ทำให้ request ที่สำเร็จแล้วล้มเหลว — แต่ request ซ้ำหลังการจองหมดเวลาจะถูกทำใหม่
+		service.Complete(c.Context(), record, resp) //nolint:errcheck // ดูด้านบน
+		return nil
+	}
+}
+
+// requestFingerprint hash ของ method, path และ body — request ซ้ำต้องได้ค่าเดียวกัน
+func requestFingerprint(c *fiber.Ctx) string {
+	h := sha256.New()
+	h.Write([]byte(c.Method()))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(c.OriginalURL()))
+	h.Write([]byte{'\n'})
+	h.Write(c.Body())
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func idempotencyErrorResponse(c *fiber.Ctx, err error) error {
+	status := fiber.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrInvalidIdempotencyKey):
+		status = fiber.StatusBadRequest
+	case errors.Is(err, domain.ErrIdempotencyKeyInProgress):
+		status = fiber.StatusConflict
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		status = fiber.StatusUnprocessableEntity
+	default:
+		return c.Status(status).JSON(dto.NewErrorResponse("เกิดข้อผิดพลาดภายในระบบ"))
+	}
+	return c.Status(status).JSON(dto.NewErrorResponse(err.Error()))
+}
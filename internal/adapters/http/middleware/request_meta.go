@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// RequestMeta เก็บ IP และ User-Agent ของ request ไว้ให้ service ใช้บันทึก audit log
+// service อ่านได้จาก c.Context() เพราะ fasthttp คืน c.Locals ผ่าน context.Value
+// AuthMiddleware และ APIKeyMiddleware เติมผู้เรียกลงใน struct เดียวกันหลังยืนยันตัวตน
+func RequestMeta() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Locals(domain.RequestMetaKey, &domain.RequestMeta{
+			IP:        c.IP(),
+			UserAgent: c.Get(fiber.HeaderUserAgent),
+		})
+		return c.Next()
+	}
+}
+
+// setRequestActor ระบุผู้เรียกใน RequestMeta ของ request ปัจจุบัน (ถ้ามี)
+func setRequestActor(c *fiber.Ctx, actorID domain.ID, actorType domain.AuditActorType) {
+	if meta, ok := c.Locals(domain.RequestMetaKey).(*domain.RequestMeta); ok {
+		meta.ActorID, meta.ActorType = &actorID, actorType
+	}
+}
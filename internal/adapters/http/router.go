@@ -13,28 +13,69 @@ import (
 	"github/be2bag/leave-management-system/internal/core/ports"
 )
 
+// Handlers รวม HTTP handlers ทั้งหมดที่ router ใช้
+type Handlers struct {
+	Auth     *handlers.AuthHandler
+	Password *handlers.PasswordHandler
+	MFA      *handlers.MFAHandler
+	OIDC     *handlers.OIDCHandler // nil เมื่อไม่ได้ตั้งค่า SSO
+	Leave    *handlers.LeaveHandler
+	Comment  *handlers.LeaveCommentHandler
+	Admin    *handlers.AdminHandler
+	Role     *handlers.RoleHandler
+	Audit    *handlers.AuditHandler
+	Report   *handlers.ReportHandler
+	Export   *handlers.ExportHandler
+	Import   *handlers.UserImportHandler
+	JWKS     *handlers.JWKSHandler
+
+	ServiceAccount *handlers.ServiceAccountHandler
+	Integration    *handlers.IntegrationHandler
+	Webhook        *handlers.WebhookHandler
+	EventStream    *handlers.EventStreamHandler
+}
+
 func SetupRouter(
 	app *fiber.App,
-	authHandler *handlers.AuthHandler,
-	leaveHandler *handlers.LeaveHandler,
+	h Handlers,
 	tokenService ports.TokenService,
+	apiKeyService ports.APIKeyService,
+	authorizer ports.Authorizer,
+	idempotency ports.IdempotencyService,
+	mfaPolicy domain.MFAPolicy,
 ) {
 	app.Use(middleware.SecurityHeaders())
+	app.Use(middleware.RequestMeta())
 
 	app.Get("/health", healthCheck)
+	app.Get("/.well-known/jwks.json", h.JWKS.GetJWKS) // public keys สำหรับตรวจสอบ JWT
 
 	api := app.Group("/api/v1")
+	authMiddleware := middleware.AuthMiddleware(tokenService)
 
-	setupAuthRoutes(api, authHandler)
+	setupAuthRoutes(api, h, authMiddleware)
+	setupMFARoutes(api, h.MFA, authMiddleware)
 
-	protected := api.Group("", middleware.AuthMiddleware(tokenService))
-	setupLeaveRoutes(protected, leaveHandler)
-	setupManagerRoutes(protected, leaveHandler)
+	// ระบบภายนอกใช้ API key ของ service account — ไม่รับ JWT ของผู้ใช้
+	// ต้องลงทะเบียนก่อนกลุ่ม protected เพราะ group ที่ไม่มี prefix ทำงานกับทุก path ใต้ /api/v1
+	integrations := api.Group("/integrations", middleware.APIKeyMiddleware(apiKeyService))
+	setupIntegrationRoutes(integrations, h.Integration)
+
+	protected := api.Group("", authMiddleware)
+	requireMFA := middleware.RequireMFA(authorizer, mfaPolicy)
+	idempotent := middleware.Idempotency(idempotency)
+	setupLeaveRoutes(protected, h.Leave, h.Comment, idempotent)
+	protected.Get("/events/stream", requireMFA, h.EventStream.Stream) // รับเหตุการณ์ของใบลาแบบ real-time (SSE)
+	setupManagerRoutes(protected, h.Leave, authorizer, requireMFA, idempotent)
+	setupAdminRoutes(protected, h, authorizer, requireMFA)
+	setupReportRoutes(protected, h.Report, authorizer, requireMFA)
 }
 
 const authRateLimitMax = 10
 
-func setupAuthRoutes(router fiber.Router, h *handlers.AuthHandler) {
+func setupAuthRoutes(router fiber.Router, hs Handlers, authMiddleware fiber.Handler) {
+	h, ph := hs.Auth, hs.Password
+
 	authLimiter := limiter.New(limiter.Config{
 		Max:        authRateLimitMax,
 		Expiration: 1 * time.Minute,
@@ -49,21 +90,115 @@ func setupAuthRoutes(router fiber.Router, h *handlers.AuthHandler) {
 	})
 
 	auth := router.Group("/auth", authLimiter)
-	auth.Post("/login", h.Login) // เข้าสู่ระบบ
+	auth.Post("/login", h.Login)                   // เข้าสู่ระบบ
+	auth.Post("/refresh", h.Refresh)               // ขอ access token ใหม่ด้วย refresh token
+	auth.Post("/logout", authMiddleware, h.Logout) // ออกจากระบบ (ยกเลิก token)
+
+	auth.Post("/change-password", authMiddleware, ph.ChangePassword) // เปลี่ยนรหัสผ่าน (ยกเลิกทุก session)
+	auth.Post("/forgot-password", ph.ForgotPassword)                 // ขอลิงก์ตั้งรหัสผ่านใหม่ทางอีเมล
+	auth.Post("/reset-password", ph.ResetPassword)                   // ตั้งรหัสผ่านใหม่ด้วย reset token
+	auth.Post("/mfa/verify", h.VerifyMFA)                            // login ขั้นที่สอง: ยืนยันรหัส 2FA
+
+	if hs.OIDC != nil {
+		auth.Get("/oidc/login", hs.OIDC.Login)       // redirect ไป identity provider
+		auth.Get("/oidc/callback", hs.OIDC.Callback) // รับ code จาก identity provider แล้วออก token
+	}
+}
+
+// setupMFARoutes ใส่ authMiddleware ราย route — prefix /auth/mfa ใช้ร่วมกับ /auth/mfa/verify ที่ยังไม่มี access token
+func setupMFARoutes(router fiber.Router, h *handlers.MFAHandler, authMiddleware fiber.Handler) {
+	mfa := router.Group("/auth/mfa")
+	mfa.Post("/enroll", authMiddleware, h.BeginEnrollment)                 // เริ่มลงทะเบียน TOTP
+	mfa.Post("/confirm", authMiddleware, h.ConfirmEnrollment)              // ยืนยันรหัสและเปิดใช้ 2FA
+	mfa.Post("/disable", authMiddleware, h.Disable)                        // ปิดใช้ 2FA
+	mfa.Post("/recovery-codes", authMiddleware, h.RegenerateRecoveryCodes) // สร้าง recovery codes ชุดใหม่
 }
 
-func setupLeaveRoutes(router fiber.Router, h *handlers.LeaveHandler) {
+func setupLeaveRoutes(router fiber.Router, h *handlers.LeaveHandler, ch *handlers.LeaveCommentHandler, idempotent fiber.Handler) {
 	leaves := router.Group("/leaves")
-	leaves.Post("/", h.Submit)                  // ยื่นใบลา
-	leaves.Get("/my-requests", h.GetMyRequests) // ดูประวัติใบลา
+	leaves.Post("/", idempotent, h.Submit)      // ยื่นใบลา (รองรับ Idempotency-Key)
+	leaves.Get("/my-requests", h.GetMyRequests) // ดูประวัติใบลา (กรอง + เรียง + full-text)
 	leaves.Get("/my-balance", h.GetMyBalance)   // ดูยอดวันลาคงเหลือ
+	leaves.Get("/:id", h.GetRequest)            // ดูรายละเอียดใบลา (เจ้าของ ผู้จัดการ หรือผู้ดูแลระบบ)
+	leaves.Put("/:id", h.Edit)                  // แก้ไขใบลาที่รออนุมัติ (เจ้าของเท่านั้น)
+	leaves.Post("/:id/cancel", h.Cancel)        // ยกเลิกใบลาที่รออนุมัติ (เจ้าของเท่านั้น)
+	leaves.Get("/:id/history", h.GetHistory)    // ดูประวัติของใบลา (เจ้าของหรือผู้อนุมัติ)
+	leaves.Post("/:id/comments", ch.Add)        // แสดงความคิดเห็น (เจ้าของหรือผู้อนุมัติ)
+	leaves.Get("/:id/comments", ch.List)        // ดูความคิดเห็น (บันทึกภายในเห็นเฉพาะผู้อนุมัติ)
+}
+
+// setupManagerRoutes route สำหรับผู้อนุมัติใบลา — ตรวจสิทธิ์ราย route เพราะแต่ละ route ใช้สิทธิ์ต่างกัน
+func setupManagerRoutes(
+	router fiber.Router,
+	h *handlers.LeaveHandler,
+	authz ports.Authorizer,
+	requireMFA, idempotent fiber.Handler,
+) {
+	viewTeam := middleware.RequirePermission(authz, domain.PermissionLeaveViewTeam)
+	approve := middleware.RequirePermission(authz, domain.PermissionLeaveApprove)
+
+	manager := router.Group("/manager", requireMFA)
+	manager.Get("/pending-requests", viewTeam, h.GetPendingRequests)      // ดูใบลารอการอนุมัติ
+	manager.Get("/requests", viewTeam, h.SearchRequests)                  // ค้นหาใบลาของทุกคน (กรอง + เรียง + full-text)
+	manager.Post("/requests/:id/approve", approve, idempotent, h.Approve) // อนุมัติใบลา (รองรับ Idempotency-Key)
+	manager.Post("/requests/:id/reject", approve, idempotent, h.Reject)   // ปฏิเสธใบลา (รองรับ Idempotency-Key)
+}
+
+// setupAdminRoutes route สำหรับผู้ดูแลระบบ — ตรวจสิทธิ์ราย route เพราะการกำหนดวันลาใช้สิทธิ์ balance.adjust แยกจาก user.manage
+func setupAdminRoutes(router fiber.Router, hs Handlers, authz ports.Authorizer, requireMFA fiber.Handler) {
+	h, sa, rh := hs.Admin, hs.ServiceAccount, hs.Role
+	manage := middleware.RequirePermission(authz, domain.PermissionUserManage)
+	adjust := middleware.RequirePermission(authz, domain.PermissionBalanceAdjust)
+
+	admin := router.Group("/admin", requireMFA)
+	admin.Post("/users/:id/revoke-sessions", manage, h.RevokeUserSessions) // ยกเลิกทุก session ของผู้ใช้
+	admin.Post("/users/:id/unlock", manage, h.UnlockUser)                  // ปลดล็อกบัญชีที่ login ผิดเกินกำหนด
+	admin.Put("/users/:id/role", manage, rh.AssignUserRole)                // เปลี่ยนบทบาทของผู้ใช้
+	admin.Put("/users/:id/balances", adjust, hs.Leave.AdjustEntitlement)   // กำหนดจำนวนวันลาที่ได้รับ
+	admin.Post("/users/import", manage, adjust, hs.Import.Import)          // นำเข้าผู้ใช้และยอดวันลายกมาจาก CSV
+
+	admin.Get("/roles", manage, rh.List)            // ดูบทบาททั้งหมด
+	admin.Put("/roles/:name", manage, rh.Save)      // สร้างหรือแก้สิทธิ์ของบทบาท
+	admin.Delete("/roles/:name", manage, rh.Delete) // ลบบทบาทที่สร้างเอง
+
+	admin.Get("/audit-events", manage, hs.Audit.Search)        // ค้นหา audit log
+	admin.Get("/audit-events/verify", manage, hs.Audit.Verify) // ตรวจ hash chain ของ audit log
+
+	export := middleware.RequirePermission(authz, domain.PermissionReportView)
+	admin.Get("/exports/requests.:format", export, hs.Export.ExportRequests) // ส่งออกใบลา (csv/xlsx)
+	admin.Get("/exports/balances.:format", export, hs.Export.ExportBalances) // ส่งออกยอดวันลา (csv/xlsx)
+
+	admin.Post("/service-accounts", manage, sa.Create)            // สร้าง service account พร้อม API key
+	admin.Get("/service-accounts", manage, sa.List)               // ดู service account ทั้งหมด
+	admin.Post("/service-accounts/:id/revoke", manage, sa.Revoke) // ยกเลิก API key
+
+	wh := hs.Webhook
+	admin.Post("/webhooks", manage, wh.Create)                            // ลงทะเบียน webhook
+	admin.Get("/webhooks", manage, wh.List)                               // ดู webhook ทั้งหมด
+	admin.Put("/webhooks/:id", manage, wh.Update)                         // แก้ webhook
+	admin.Delete("/webhooks/:id", manage, wh.Delete)                      // ลบ webhook
+	admin.Get("/webhook-deliveries", manage, wh.ListDeliveries)           // ดูรายการส่ง webhook
+	admin.Post("/webhook-deliveries/:id/redeliver", manage, wh.Redeliver) // สั่งส่ง webhook ใหม่
 }
 
-func setupManagerRoutes(router fiber.Router, h *handlers.LeaveHandler) {
-	manager := router.Group("/manager", middleware.RoleMiddleware(domain.RoleManager))
-	manager.Get("/pending-requests", h.GetPendingRequests) // ดูใบลารอการอนุมัติ
-	manager.Post("/requests/:id/approve", h.Approve)       // อนุมัติใบลา
-	manager.Post("/requests/:id/reject", h.Reject)         // ปฏิเสธใบลา
+// setupReportRoutes route รายงานสำหรับ HR — ทุก route ใช้สิทธิ์ report.view
+func setupReportRoutes(router fiber.Router, h *handlers.ReportHandler, authz ports.Authorizer, requireMFA fiber.Handler) {
+	reports := router.Group("/reports", requireMFA, middleware.RequirePermission(authz, domain.PermissionReportView))
+	reports.Get("/leave-usage", h.LeaveUsage)                 // วันลาที่อนุมัติแยกตามประเภทและเดือน
+	reports.Get("/absenteeism", h.Absenteeism)                // อัตราการขาดงานรายแผนก
+	reports.Get("/approval-turnaround", h.ApprovalTurnaround) // เวลาเฉลี่ยตั้งแต่ยื่นจนพิจารณา
+	reports.Get("/rejection-rates", h.RejectionRates)         // อัตราการปฏิเสธรายผู้อนุมัติ
+	reports.Get("/carry-over-risks", h.CarryOverRisks)        // วันลาคงเหลือที่เสี่ยงหายเมื่อขึ้นปีใหม่
+}
+
+// setupIntegrationRoutes route สำหรับระบบภายนอก — แต่ละกลุ่มต้องใช้ API key ที่มี scope ตรงกัน
+func setupIntegrationRoutes(router fiber.Router, h *handlers.IntegrationHandler) {
+	leaves := router.Group("/leaves", middleware.RequireScope(domain.ScopeLeavesRead))
+	leaves.Get("/", h.ListLeaves) // ดูใบลาตามสถานะ
+
+	balances := router.Group("/users/:id/balances")
+	balances.Get("/", middleware.RequireScope(domain.ScopeBalancesRead), h.GetUserBalances) // ดูยอดวันลาของพนักงาน
+	balances.Put("/", middleware.RequireScope(domain.ScopeBalancesWrite), h.SetEntitlement) // กำหนดจำนวนวันลาที่ได้รับ
 }
 
 func healthCheck(c *fiber.Ctx) error {
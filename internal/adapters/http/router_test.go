@@ -0,0 +1,179 @@
+package http
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/adapters/handlers"
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/pkg/validator"
+)
+
+const (
+	testAPIKey    = "lms_test_key"
+	demotedToken  = "demoted-manager-token"
+	promotedToken = "promoted-employee-token"
+)
+
+var (
+	// demotedUserID ผู้ใช้ที่ token ยังเป็น manager (ผ่าน 2FA) แต่ถูกเปลี่ยนบทบาทเป็น employee ในฐานข้อมูลแล้ว
+	demotedUserID = domain.NewID()
+	// promotedUserID ผู้ใช้ที่ token ยังเป็น employee (ไม่ผ่าน 2FA) แต่ถูกเลื่อนเป็น manager ในฐานข้อมูลแล้ว
+	promotedUserID = domain.NewID()
+)
+
+// testTokens token ที่ stubTokenService ยอมรับ — request ที่ส่งแค่ API key ต้องไม่ผ่าน AuthMiddleware
+var testTokens = map[string]*domain.TokenClaims{
+	demotedToken:  {UserID: demotedUserID, Role: domain.RoleManager, MFA: true},
+	promotedToken: {UserID: promotedUserID, Role: domain.RoleEmployee},
+}
+
+// testDBRoles บทบาทปัจจุบันในฐานข้อมูล
+var testDBRoles = map[domain.ID]domain.Role{
+	demotedUserID:  domain.RoleEmployee,
+	promotedUserID: domain.RoleManager,
+}
+
+type stubTokenService struct{ ports.TokenService }
+
+func (stubTokenService) ValidateToken(_ context.Context, token string) (*domain.TokenClaims, error) {
+	claims, ok := testTokens[token]
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+	return claims, nil
+}
+
+// stubAuthorizer ตรวจสิทธิ์จากบทบาทในฐานข้อมูล (สิทธิ์ของบทบาทเริ่มต้น)
+type stubAuthorizer struct{ ports.Authorizer }
+
+func (a stubAuthorizer) Authorize(ctx context.Context, userID domain.ID, permission domain.Permission) error {
+	role, err := a.UserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Has(permission) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (stubAuthorizer) UserRole(_ context.Context, userID domain.ID) (*domain.RoleDefinition, error) {
+	name, ok := testDBRoles[userID]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	for _, def := range domain.DefaultRoles() {
+		if def.Name == name {
+			return &def, nil
+		}
+	}
+	return &domain.RoleDefinition{Name: name}, nil
+}
+
+type stubAPIKeyService struct{ ports.APIKeyService }
+
+func (stubAPIKeyService) Authenticate(_ context.Context, key string) (*domain.ServiceAccount, error) {
+	if key != testAPIKey {
+		return nil, domain.ErrInvalidAPIKey
+	}
+	return &domain.ServiceAccount{ID: domain.NewID(), Name: "payroll", Scopes: []domain.APIScope{domain.ScopeLeavesRead}}, nil
+}
+
+type stubLeaveService struct{ ports.LeaveService }
+
+func (stubLeaveService) GetRequestsByStatus(
+	_ context.Context, _ domain.LeaveStatus, params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+	return domain.NewPaginatedResult([]domain.LeaveRequest{}, 0, params), nil
+}
+
+func newTestApp() *fiber.App {
+	app := fiber.New()
+	hs := Handlers{
+		Auth:        handlers.NewAuthHandler(nil, nil, validator.New()),
+		Integration: handlers.NewIntegrationHandler(stubLeaveService{}, validator.New()),
+	}
+	SetupRouter(app, hs, stubTokenService{}, stubAPIKeyService{}, stubAuthorizer{}, nil,
+		domain.MFAPolicy{RequiredPermissions: domain.DefaultMFARequiredPermissions()})
+	return app
+}
+
+func TestSetupRouter_IntegrationsUseAPIKeyOnly(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/integrations/leaves", nil)
+	req.Header.Set("X-API-Key", testAPIKey)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode, "route ของ integration ต้องไม่ผ่าน AuthMiddleware ของ JWT")
+}
+
+func TestSetupRouter_IntegrationsRejectMissingAPIKey(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/integrations/leaves", nil)
+	req.Header.Set("Authorization", "Bearer user-token")
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, "JWT ของผู้ใช้ใช้กับ integration ไม่ได้")
+}
+
+func TestSetupRouter_ProtectedRoutesStillRequireJWT(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/leaves/my-balance", nil)
+	req.Header.Set("X-API-Key", testAPIKey)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, "API key ใช้กับ route ของผู้ใช้ไม่ได้")
+}
+
+func TestSetupRouter_PermissionsUseCurrentRoleNotToken(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/manager/pending-requests", nil)
+	req.Header.Set("Authorization", "Bearer "+demotedToken)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode, "ผู้ที่ถูกถอดบทบาทต้องถูกปฏิเสธทันทีแม้ token ยังเป็น manager")
+}
+
+func TestSetupRouter_MFAUsesCurrentRoleNotToken(t *testing.T) {
+	app := newTestApp()
+
+	req := httptest.NewRequest(fiber.MethodGet, "/api/v1/manager/pending-requests", nil)
+	req.Header.Set("Authorization", "Bearer "+promotedToken)
+	resp, err := app.Test(req)
+
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusForbidden, resp.StatusCode,
+		"ผู้ที่เพิ่งถูกเลื่อนเป็น manager ต้องยืนยัน 2FA ก่อนอนุมัติ แม้ token เดิมเป็น employee")
+}
+
+func TestSetupRouter_MFAVerifyDoesNotRequireAccessToken(t *testing.T) {
+	app := newTestApp()
+
+	// body ว่างต้องถูกปฏิเสธที่ handler (400) ไม่ใช่ที่ AuthMiddleware (401)
+	req := httptest.NewRequest(fiber.MethodPost, "/api/v1/auth/mfa/verify", nil)
+	req.Header.Set("Content-Type", fiber.MIMEApplicationJSON)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode, "login ขั้นที่สองมีแค่ challenge token ยังไม่มี access token")
+
+	for _, path := range []string{"/enroll", "/confirm", "/disable", "/recovery-codes"} {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/api/v1/auth/mfa"+path, nil))
+		require.NoError(t, err)
+		assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode, "route ลงทะเบียน 2FA ต้องใช้ access token: %s", path)
+	}
+}
@@ -0,0 +1,193 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	dialTimeout    = 5 * time.Second
+	requestTimeout = 10 * time.Second
+	searchLimit    = 2 // พอสำหรับตรวจว่าพบผู้ใช้มากกว่าหนึ่งรายการหรือไม่
+)
+
+// Config ค่าตั้งค่าสำหรับเชื่อมต่อ LDAP/Active Directory
+type Config struct {
+	URL          string // ldap://host:389 หรือ ldaps://host:636
+	BindDN       string // service account สำหรับค้นหาผู้ใช้ (ว่าง = anonymous)
+	BindPassword string // รหัสผ่านของ service account
+	BaseDN       string // จุดเริ่มค้นหาผู้ใช้
+	UserFilter   string // filter ค้นหาผู้ใช้ — {email} ถูกแทนด้วยอีเมลที่ escape แล้ว
+	IDAttribute  string // attribute ที่ใช้เป็นรหัสผู้ใช้ถาวร (เช่น uid, sAMAccountName)
+	GroupBaseDN  string // จุดเริ่มค้นหากลุ่ม (ใช้คู่กับ GroupFilter)
+	GroupFilter  string // filter ค้นหากลุ่มของผู้ใช้ — {dn} ถูกแทนด้วย DN ของผู้ใช้ (ว่าง = อ่านจาก memberOf)
+	StartTLS     bool   // เข้ารหัสการเชื่อมต่อ ldap:// ด้วย StartTLS ก่อนส่งรหัสผ่าน
+}
+
+type authenticator struct {
+	cfg Config
+}
+
+// NewAuthenticator สร้าง Authenticator ที่ตรวจรหัสผ่านโดย bind กับ directory
+// ค้นหาผู้ใช้ด้วย service account แล้ว bind ด้วย DN ของผู้ใช้และรหัสผ่านที่กรอก
+func NewAuthenticator(cfg Config) ports.Authenticator {
+	return &authenticator{cfg: cfg}
+}
+
+// Authenticate ตรวจรหัสผ่านกับ directory แล้วคืนข้อมูลผู้ใช้พร้อมกลุ่มที่เป็นสมาชิก
+func (a *authenticator) Authenticate(_ context.Context, email, password string, _ *domain.User) (*domain.ExternalIdentity, error) {
+	// LDAP ถือว่า bind ด้วยรหัสผ่านว่างเป็น unauthenticated bind ซึ่งสำเร็จเสมอ
+	if password == "" {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	conn, err := a.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close() //nolint:errcheck // ปิดการเชื่อมต่อหลังใช้งาน ไม่มีผลต่อผลลัพธ์
+
+	entry, err := a.findUser(conn, email)
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := a.findGroups(conn, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = conn.Bind(entry.DN, password); err != nil {
+		if goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+			return nil, domain.ErrInvalidCredentials
+		}
+		return nil, fmt.Errorf("bind ผู้ใช้กับ LDAP ล้มเหลว: %w", err)
+	}
+
+	return a.identityFromEntry(entry, email, groups), nil
+}
+
+// connect เปิดการเชื่อมต่อและ bind ด้วย service account
+func (a *authenticator) connect() (*goldap.Conn, error) {
+	conn, err := goldap.DialURL(a.cfg.URL, goldap.DialWithDialer(&net.Dialer{Timeout: dialTimeout}))
+	if err != nil {
+		return nil, fmt.Errorf("เชื่อมต่อ LDAP ล้มเหลว: %w", err)
+	}
+	conn.SetTimeout(requestTimeout)
+
+	if a.cfg.StartTLS {
+		if err = conn.StartTLS(&tls.Config{ServerName: serverName(a.cfg.URL), MinVersion: tls.VersionTLS12}); err != nil {
+			conn.Close() //nolint:errcheck,gosec // การเชื่อมต่อใช้ไม่ได้แล้ว
+			return nil, fmt.Errorf("StartTLS กับ LDAP ล้มเหลว: %w", err)
+		}
+	}
+
+	if a.cfg.BindDN != "" {
+		if err = conn.Bind(a.cfg.BindDN, a.cfg.BindPassword); err != nil {
+			conn.Close() //nolint:errcheck,gosec // การเชื่อมต่อใช้ไม่ได้แล้ว
+			return nil, fmt.Errorf("bind service account กับ LDAP ล้มเหลว: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+// findUser ค้นหา entry ของผู้ใช้จากอีเมล — ไม่พบหรือพบหลายรายการถือว่ายืนยันตัวตนไม่สำเร็จ
+func (a *authenticator) findUser(conn *goldap.Conn, email string) (*goldap.Entry, error) {
+	filter := strings.ReplaceAll(a.cfg.UserFilter, "{email}", goldap.EscapeFilter(email))
+	request := goldap.NewSearchRequest(
+		a.cfg.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, searchLimit, int(requestTimeout.Seconds()), false,
+		filter, []string{"mail", "givenName", "sn", "cn", "memberOf", a.cfg.IDAttribute}, nil,
+	)
+
+	result, err := conn.Search(request)
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, fmt.Errorf("ค้นหาผู้ใช้ใน LDAP ล้มเหลว: %w", err)
+	}
+	if result == nil || len(result.Entries) != 1 {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return result.Entries[0], nil
+}
+
+// findGroups คืนชื่อกลุ่มของผู้ใช้ — จาก memberOf หรือค้นหาด้วย GroupFilter (เช่น groupOfNames ของ OpenLDAP)
+func (a *authenticator) findGroups(conn *goldap.Conn, entry *goldap.Entry) ([]string, error) {
+	if a.cfg.GroupFilter == "" {
+		return groupNames(entry.GetAttributeValues("memberOf")), nil
+	}
+
+	filter := strings.ReplaceAll(a.cfg.GroupFilter, "{dn}", goldap.EscapeFilter(entry.DN))
+	request := goldap.NewSearchRequest(
+		a.cfg.GroupBaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, int(requestTimeout.Seconds()), false,
+		filter, []string{"cn"}, nil,
+	)
+
+	result, err := conn.Search(request)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหากลุ่มใน LDAP ล้มเหลว: %w", err)
+	}
+
+	dns := make([]string, 0, len(result.Entries))
+	for _, group := range result.Entries {
+		dns = append(dns, group.DN)
+	}
+	return groupNames(dns), nil
+}
+
+// identityFromEntry แปลง entry ของผู้ใช้เป็น ExternalIdentity
+func (a *authenticator) identityFromEntry(entry *goldap.Entry, email string, groups []string) *domain.ExternalIdentity {
+	subject := entry.GetAttributeValue(a.cfg.IDAttribute)
+	if subject == "" {
+		subject = strings.ToLower(entry.DN)
+	}
+
+	mail := entry.GetAttributeValue("mail")
+	if mail == "" {
+		mail = email
+	}
+
+	identity := &domain.ExternalIdentity{
+		Provider:      domain.AuthProviderLDAP,
+		Subject:       subject,
+		Email:         mail,
+		FirstName:     entry.GetAttributeValue("givenName"),
+		LastName:      entry.GetAttributeValue("sn"),
+		Groups:        groups,
+		EmailVerified: true, // ค้นหาผู้ใช้จาก mail ใน directory ขององค์กรเอง
+	}
+	if identity.FirstName == "" && identity.LastName == "" {
+		identity.FirstName, identity.LastName, _ = strings.Cut(entry.GetAttributeValue("cn"), " ")
+	}
+	return identity
+}
+
+// serverName ชื่อ host จาก URL ของ directory — ใช้ตรวจใบรับรองตอน StartTLS
+func serverName(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// groupNames ดึงชื่อกลุ่ม (ค่าของ RDN แรก เช่น CN=HR-Managers,OU=Groups → HR-Managers) จาก DN ของกลุ่ม
+func groupNames(dns []string) []string {
+	names := make([]string, 0, len(dns))
+	for _, raw := range dns {
+		dn, err := goldap.ParseDN(raw)
+		if err != nil || len(dn.RDNs) == 0 || len(dn.RDNs[0].Attributes) == 0 {
+			continue
+		}
+		names = append(names, dn.RDNs[0].Attributes[0].Value)
+	}
+	return names
+}
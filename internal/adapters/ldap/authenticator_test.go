@@ -0,0 +1,49 @@
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestGroupNames(t *testing.T) {
+	names := groupNames([]string{
+		"CN=HR-Managers,OU=Groups,DC=corp,DC=com",
+		"cn=it-admins,ou=groups,dc=corp,dc=com",
+		"CN=Smith\\, John,OU=Groups,DC=corp,DC=com",
+		"not a dn",
+	})
+
+	assert.Equal(t, []string{"HR-Managers", "it-admins", "Smith, John"}, names)
+}
+
+func TestIdentityFromEntry(t *testing.T) {
+	a := &authenticator{cfg: Config{IDAttribute: "uid"}}
+	entry := goldap.NewEntry("uid=somchai,ou=people,dc=corp,dc=com", map[string][]string{
+		"uid":  {"somchai"},
+		"mail": {"Somchai@corp.com"},
+		"cn":   {"สมชาย ใจดี"},
+	})
+
+	identity := a.identityFromEntry(entry, "somchai@corp.com", []string{"hr-managers"})
+
+	assert.Equal(t, domain.AuthProviderLDAP, identity.Provider)
+	assert.Equal(t, "somchai", identity.Subject)
+	assert.Equal(t, "Somchai@corp.com", identity.Email)
+	assert.Equal(t, "สมชาย", identity.FirstName, "ไม่มี givenName/sn ต้องแยกจาก cn")
+	assert.Equal(t, "ใจดี", identity.LastName)
+	assert.Equal(t, []string{"hr-managers"}, identity.Groups)
+}
+
+func TestAuthenticate_EmptyPasswordRejected(t *testing.T) {
+	// ไม่ต้องเชื่อมต่อ directory — รหัสผ่านว่างคือ unauthenticated bind ที่ต้องปฏิเสธก่อน
+	a := NewAuthenticator(Config{URL: "ldap://127.0.0.1:1"})
+
+	_, err := a.Authenticate(context.Background(), "somchai@corp.com", "", nil)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+}
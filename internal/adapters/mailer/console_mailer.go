@@ -0,0 +1,24 @@
+package mailer
+
+import (
+	"context"
+	"log"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type consoleMailer struct {
+	from string
+}
+
+// NewConsoleMailer สร้าง Mailer ที่พิมพ์อีเมลออก log แทนการส่งจริง — ใช้สำหรับ development
+func NewConsoleMailer(from string) ports.Mailer {
+	return &consoleMailer{from: from}
+}
+
+// Send พิมพ์อีเมลออก log
+func (m *consoleMailer) Send(_ context.Context, msg domain.EmailMessage) error {
+	log.Printf("📧 [mailer] From: %s | To: %s | Subject: %s\n%s", m.from, msg.To, msg.Subject, msg.Body)
+	return nil
+}
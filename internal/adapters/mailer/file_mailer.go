@@ -0,0 +1,57 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type fileMailer struct {
+	dir  string
+	from string
+}
+
+// NewFileMailer สร้าง Mailer ที่เขียนอีเมลแต่ละฉบับเป็นไฟล์ .eml ในโฟลเดอร์ที่กำหนด — ใช้สำหรับ development/ทดสอบ
+func NewFileMailer(dir, from string) (ports.Mailer, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("สร้างโฟลเดอร์เก็บอีเมล %s ล้มเหลว: %w", dir, err)
+	}
+	return &fileMailer{dir: dir, from: from}, nil
+}
+
+// Send เขียนอีเมลลงไฟล์ — ชื่อไฟล์ขึ้นต้นด้วยเวลาส่ง เรียงตามลำดับได้
+func (m *fileMailer) Send(_ context.Context, msg domain.EmailMessage) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s-%s.eml", now.Format("20060102T150405.000000000"), sanitizeFileName(msg.To))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(msg.Body)
+
+	if err := os.WriteFile(filepath.Join(m.dir, name), []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("เขียนไฟล์อีเมลล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// sanitizeFileName แทนที่ตัวอักษรที่ไม่ปลอดภัยสำหรับชื่อไฟล์
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '@', r == '.', r == '-', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
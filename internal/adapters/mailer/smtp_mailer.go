@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// SMTPConfig ค่าตั้งค่าการเชื่อมต่อ SMTP server
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string // ว่าง = ไม่ยืนยันตัวตน
+	Password string
+	From     string
+}
+
+type smtpMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer สร้าง Mailer ที่ส่งอีเมลผ่าน SMTP — ใช้ STARTTLS เมื่อ server รองรับ
+func NewSMTPMailer(cfg SMTPConfig) ports.Mailer {
+	return &smtpMailer{cfg: cfg}
+}
+
+// Send เชื่อมต่อ SMTP server และส่งอีเมลหนึ่งฉบับ — ctx กำหนดเวลาสูงสุดของการส่งทั้งหมด
+func (m *smtpMailer) Send(ctx context.Context, msg domain.EmailMessage) error {
+	addr := net.JoinHostPort(m.cfg.Host, m.cfg.Port)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("เชื่อมต่อ SMTP server %s ล้มเหลว: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, m.cfg.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("เริ่มต้น SMTP session ล้มเหลว: %w", err)
+	}
+	defer client.Close()
+
+	if err = m.send(client, msg); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// send ขั้นตอน STARTTLS → AUTH → MAIL → RCPT → DATA
+func (m *smtpMailer) send(client *smtp.Client, msg domain.EmailMessage) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: m.cfg.Host, MinVersion: tls.VersionTLS12}); err != nil {
+			return fmt.Errorf("STARTTLS ล้มเหลว: %w", err)
+		}
+	}
+	if m.cfg.Username != "" {
+		auth := smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("ยืนยันตัวตนกับ SMTP server ล้มเหลว: %w", err)
+		}
+	}
+
+	if err := client.Mail(m.cfg.From); err != nil {
+		return fmt.Errorf("กำหนดผู้ส่งล้มเหลว: %w", err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return fmt.Errorf("กำหนดผู้รับล้มเหลว: %w", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("เริ่มส่งเนื้อหาอีเมลล้มเหลว: %w", err)
+	}
+	if _, err = w.Write(m.buildMessage(msg)); err != nil {
+		_ = w.Close()
+		return fmt.Errorf("ส่งเนื้อหาอีเมลล้มเหลว: %w", err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("SMTP server ไม่รับอีเมล: %w", err)
+	}
+	return nil
+}
+
+// buildMessage สร้างอีเมลแบบ MIME — หัวเรื่องเข้ารหัส RFC 2047 และเนื้อหาเป็น base64 เพื่อรองรับภาษาไทย
+func (m *smtpMailer) buildMessage(msg domain.EmailMessage) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(msg.Body))
+	const lineLength = 76 // RFC 2045
+	for len(encoded) > lineLength {
+		b.WriteString(encoded[:lineLength] + "\r\n")
+		encoded = encoded[lineLength:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,329 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	httpTimeout     = 10 * time.Second
+	maxResponseSize = 1 << 20 // 1MB — ป้องกัน IdP ตอบกลับข้อมูลขนาดใหญ่ผิดปกติ
+)
+
+// Config ค่าตั้งค่าสำหรับเชื่อมต่อ OpenID Connect provider
+type Config struct {
+	IssuerURL    string   // issuer ของ IdP — ใช้หา /.well-known/openid-configuration
+	ClientID     string   // client ที่ลงทะเบียนไว้กับ IdP
+	ClientSecret string   // client secret (client_secret_basic)
+	RedirectURL  string   // URL ของ /auth/oidc/callback ที่ลงทะเบียนไว้กับ IdP
+	GroupsClaim  string   // ชื่อ claim ที่เก็บรายชื่อกลุ่ม (เช่น groups, roles)
+	Scopes       []string // scopes ที่ขอ (ต้องมี openid)
+}
+
+// discovery ข้อมูลจาก /.well-known/openid-configuration ที่ใช้
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type provider struct {
+	client *http.Client
+	meta   *discovery
+	keys   map[string]any // kid → public key
+	cfg    Config
+	mu     sync.Mutex
+}
+
+// NewProvider สร้าง IdentityProvider สำหรับ OIDC authorization code flow
+// อ่าน discovery document ตอนใช้งานครั้งแรก — server เริ่มทำงานได้แม้ IdP ยังไม่พร้อม
+func NewProvider(cfg Config) ports.IdentityProvider {
+	return &provider{
+		cfg:    cfg,
+		client: &http.Client{Timeout: httpTimeout},
+		keys:   make(map[string]any),
+	}
+}
+
+// AuthCodeURL สร้าง URL หน้า login ของ IdP พร้อม state, nonce และ PKCE challenge
+func (p *provider) AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", p.cfg.ClientID)
+	params.Set("redirect_uri", p.cfg.RedirectURL)
+	params.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return meta.AuthorizationEndpoint + separator + params.Encode(), nil
+}
+
+// Exchange แลก authorization code เป็น ID token แล้วตรวจสอบก่อนแปลงเป็น ExternalIdentity
+func (p *provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error) {
+	meta, err := p.metadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("สร้างคำขอ token ล้มเหลว: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	if err = p.doJSON(req, &tokenResp); err != nil {
+		return nil, err
+	}
+	if tokenResp.IDToken == "" {
+		return nil, fmt.Errorf("%w: ไม่มี id_token ในคำตอบ", domain.ErrOIDCLoginFailed)
+	}
+
+	claims, err := p.verifyIDToken(ctx, meta, tokenResp.IDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+	return p.identityFromClaims(meta.Issuer, claims)
+}
+
+// verifyIDToken ตรวจลายเซ็น, issuer, audience, เวลาหมดอายุ และ nonce ของ ID token
+func (p *provider) verifyIDToken(ctx context.Context, meta *discovery, rawToken, nonce string) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawToken, claims,
+		func(token *jwt.Token) (any, error) {
+			kid, _ := token.Header["kid"].(string) // IdP ที่มีคีย์เดียวอาจไม่ส่ง kid
+			return p.publicKey(ctx, meta, kid)
+		},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "EdDSA"}),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Minute),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ID token ไม่ถูกต้อง: %w", domain.ErrOIDCLoginFailed, err)
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce == "" || tokenNonce != nonce {
+		return nil, fmt.Errorf("%w: nonce ไม่ตรงกัน", domain.ErrOIDCLoginFailed)
+	}
+	return claims, nil
+}
+
+// identityFromClaims แปลง claims มาตรฐานของ OIDC เป็น ExternalIdentity
+func (p *provider) identityFromClaims(issuer string, claims jwt.MapClaims) (*domain.ExternalIdentity, error) {
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, fmt.Errorf("%w: ไม่มี sub ใน ID token", domain.ErrOIDCLoginFailed)
+	}
+
+	identity := &domain.ExternalIdentity{
+		Provider:      issuer,
+		Subject:       subject,
+		Email:         stringClaim(claims, "email"),
+		FirstName:     stringClaim(claims, "given_name"),
+		LastName:      stringClaim(claims, "family_name"),
+		Groups:        stringListClaim(claims, p.cfg.GroupsClaim),
+		EmailVerified: boolClaim(claims, "email_verified"),
+	}
+
+	// IdP บางตัวส่งแค่ name — แยกชื่อจริงกับนามสกุลจากช่องว่างแรก
+	if identity.FirstName == "" && identity.LastName == "" {
+		first, last, _ := strings.Cut(stringClaim(claims, "name"), " ")
+		identity.FirstName, identity.LastName = first, last
+	}
+
+	// amr (RFC 8176) — ถือว่าผ่าน MFA เมื่อ IdP ระบุวิธียืนยันตัวตนหลายปัจจัย
+	for _, method := range stringListClaim(claims, "amr") {
+		if method == "mfa" || method == "otp" || method == "hwk" {
+			identity.MFA = true
+		}
+	}
+
+	return identity, nil
+}
+
+// metadata อ่าน discovery document ของ IdP (cache หลังอ่านสำเร็จครั้งแรก)
+func (p *provider) metadata(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	wellKnown := strings.TrimSuffix(p.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("สร้างคำขอ discovery ล้มเหลว: %w", err)
+	}
+
+	var meta discovery
+	if err = p.doJSON(req, &meta); err != nil {
+		return nil, fmt.Errorf("อ่าน OIDC discovery ล้มเหลว: %w", err)
+	}
+	if meta.Issuer != strings.TrimSuffix(p.cfg.IssuerURL, "/") && meta.Issuer != p.cfg.IssuerURL {
+		return nil, fmt.Errorf("issuer ใน discovery (%s) ไม่ตรงกับ OIDC_ISSUER_URL", meta.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document ของ %s ไม่ครบ", meta.Issuer)
+	}
+
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// publicKey คืน public key ตาม kid — โหลด JWKS ใหม่เมื่อไม่พบ (IdP rotate คีย์)
+func (p *provider) publicKey(ctx context.Context, meta *discovery, kid string) (any, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	keys, err := p.fetchJWKS(ctx, meta.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+	p.keys = keys
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("ไม่พบคีย์ kid %q ใน JWKS ของ IdP", kid)
+}
+
+// lookupKey หาคีย์จาก kid — token ที่ไม่มี kid ใช้ได้เมื่อ IdP มีคีย์เดียว (ต้องถือ mu)
+func (p *provider) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+// fetchJWKS โหลด JSON Web Key Set ของ IdP
+func (p *provider) fetchJWKS(ctx context.Context, jwksURI string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("สร้างคำขอ JWKS ล้มเหลว: %w", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err = p.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("โหลด JWKS ของ IdP ล้มเหลว: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue // ข้ามคีย์ชนิดที่ไม่รองรับ
+		}
+		keys[jwk.Kid] = key
+	}
+	return keys, nil
+}
+
+// doJSON ส่งคำขอและอ่านผลลัพธ์ JSON — IdP ตอบ 4xx ถือว่า login ไม่สำเร็จ (เช่น code หมดอายุ)
+func (p *provider) doJSON(req *http.Request, out any) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("เชื่อมต่อ IdP ล้มเหลว: %w", err)
+	}
+	defer resp.Body.Close() //nolint:errcheck // ปิด body หลังอ่านแล้ว ไม่มีผลต่อผลลัพธ์
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("อ่านคำตอบจาก IdP ล้มเหลว: %w", err)
+	}
+
+	switch {
+	case resp.StatusCode >= http.StatusBadRequest && resp.StatusCode < http.StatusInternalServerError:
+		return fmt.Errorf("%w: IdP ตอบ %d", domain.ErrOIDCLoginFailed, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("IdP ตอบ %d", resp.StatusCode)
+	}
+
+	if err = json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("แปลงคำตอบจาก IdP ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	value, _ := claims[name].(string)
+	return value
+}
+
+func boolClaim(claims jwt.MapClaims, name string) bool {
+	switch value := claims[name].(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true" // IdP บางตัวส่ง email_verified เป็นข้อความ
+	default:
+		return false
+	}
+}
+
+// stringListClaim อ่าน claim ที่เป็นรายการข้อความ (หรือข้อความเดียว)
+func stringListClaim(claims jwt.MapClaims, name string) []string {
+	switch value := claims[name].(type) {
+	case string:
+		return []string{value}
+	case []any:
+		list := make([]string, 0, len(value))
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				list = append(list, s)
+			}
+		}
+		return list
+	default:
+		return nil
+	}
+}
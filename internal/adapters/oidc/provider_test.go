@@ -0,0 +1,205 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+const (
+	testClientID     = "leave-system"
+	testClientSecret = "s3cret"
+	testKid          = "stub-key-1"
+)
+
+// stubIdP identity provider จำลองบน httptest — ออก ID token ที่ sign ด้วย RSA
+type stubIdP struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey // คีย์ที่เผยแพร่ใน JWKS
+	signer   *rsa.PrivateKey // คีย์ที่ใช้ sign ID token (ปกติคือ key)
+	claims   jwt.MapClaims   // claims ที่จะใส่ใน ID token ถัดไป
+	verifier string          // code_verifier ที่ได้รับล่าสุด
+}
+
+func newStubIdP(t *testing.T) *stubIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{key: key, signer: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", idp.jwks)
+	mux.HandleFunc("/token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (s *stubIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, discovery{
+		Issuer:                s.server.URL,
+		AuthorizationEndpoint: s.server.URL + "/authorize",
+		TokenEndpoint:         s.server.URL + "/token",
+		JWKSURI:               s.server.URL + "/jwks",
+	})
+}
+
+func (s *stubIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{"keys": []jsonWebKey{{
+		Kty: "RSA",
+		Kid: testKid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+	}}})
+}
+
+func (s *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != testClientID || secret != testClientSecret || r.FormValue("code") != "valid-code" {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+	s.verifier = r.FormValue("code_verifier")
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, s.claims)
+	token.Header["kid"] = testKid
+	signed, err := token.SignedString(s.signer)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, map[string]string{"id_token": signed, "token_type": "Bearer"})
+}
+
+// validClaims claims ของ ID token ที่ถูกต้องสำหรับ nonce ที่ระบุ
+func (s *stubIdP) validClaims(nonce string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            s.server.URL,
+		"aud":            testClientID,
+		"sub":            "user-42",
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "somchai@company.com",
+		"email_verified": true,
+		"name":           "สมชาย ใจดี",
+		"groups":         []string{"hr-managers"},
+		"amr":            []string{"pwd", "otp"},
+	}
+}
+
+func (s *stubIdP) provider() *provider {
+	p, _ := NewProvider(Config{
+		IssuerURL:    s.server.URL,
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  "http://localhost:8080/api/v1/auth/oidc/callback",
+		GroupsClaim:  "groups",
+		Scopes:       []string{"openid", "email", "profile"},
+	}).(*provider)
+	return p
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	idp := newStubIdP(t)
+
+	raw, err := idp.provider().AuthCodeURL(context.Background(), "state-1", "nonce-1", "challenge-1")
+	require.NoError(t, err)
+
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+	q := u.Query()
+	assert.Equal(t, idp.server.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, testClientID, q.Get("client_id"))
+	assert.Equal(t, "state-1", q.Get("state"))
+	assert.Equal(t, "nonce-1", q.Get("nonce"))
+	assert.Equal(t, "challenge-1", q.Get("code_challenge"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	idp := newStubIdP(t)
+	idp.claims = idp.validClaims("nonce-1")
+
+	identity, err := idp.provider().Exchange(context.Background(), "valid-code", "verifier-1", "nonce-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "verifier-1", idp.verifier, "ต้องส่ง PKCE verifier ไปตอนแลก code")
+	assert.Equal(t, idp.server.URL, identity.Provider)
+	assert.Equal(t, "user-42", identity.Subject)
+	assert.Equal(t, "somchai@company.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+	assert.Equal(t, "สมชาย", identity.FirstName, "ต้องแยกชื่อจาก name เมื่อไม่มี given_name")
+	assert.Equal(t, "ใจดี", identity.LastName)
+	assert.Equal(t, []string{"hr-managers"}, identity.Groups)
+	assert.True(t, identity.MFA, "amr มี otp ต้องถือว่าผ่าน MFA")
+}
+
+func TestProvider_Exchange_RejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(claims jwt.MapClaims)
+	}{
+		{"nonce ไม่ตรง", func(c jwt.MapClaims) { c["nonce"] = "other-nonce" }},
+		{"audience ไม่ตรง", func(c jwt.MapClaims) { c["aud"] = "another-client" }},
+		{"issuer ไม่ตรง", func(c jwt.MapClaims) { c["iss"] = "https://evil.example.com" }},
+		{"หมดอายุ", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }},
+		{"ไม่มี sub", func(c jwt.MapClaims) { delete(c, "sub") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idp := newStubIdP(t)
+			idp.claims = idp.validClaims("nonce-1")
+			tt.mutate(idp.claims)
+
+			_, err := idp.provider().Exchange(context.Background(), "valid-code", "verifier-1", "nonce-1")
+
+			assert.ErrorIs(t, err, domain.ErrOIDCLoginFailed)
+		})
+	}
+}
+
+func TestProvider_Exchange_RejectsForeignSignature(t *testing.T) {
+	idp := newStubIdP(t)
+	idp.claims = idp.validClaims("nonce-1")
+	// sign ด้วยคีย์ที่ไม่อยู่ใน JWKS ของ IdP แต่ใช้ kid เดียวกัน
+	foreign, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	idp.signer = foreign
+
+	_, err = idp.provider().Exchange(context.Background(), "valid-code", "verifier-1", "nonce-1")
+
+	assert.ErrorIs(t, err, domain.ErrOIDCLoginFailed)
+}
+
+func TestProvider_Exchange_InvalidCode(t *testing.T) {
+	idp := newStubIdP(t)
+
+	_, err := idp.provider().Exchange(context.Background(), "expired-code", "verifier-1", "nonce-1")
+
+	assert.ErrorIs(t, err, domain.ErrOIDCLoginFailed, "IdP ตอบ 4xx ต้องถือว่า login ไม่สำเร็จ")
+}
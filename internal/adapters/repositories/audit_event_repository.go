@@ -0,0 +1,147 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type auditEventRepository struct {
+	collection *mongo.Collection
+}
+
+// NewAuditEventRepository สร้าง repository ของ audit log — มีแต่การเพิ่มและอ่าน ไม่มีการแก้ไขหรือลบ
+func NewAuditEventRepository(db *database.MongoDB) ports.AuditRepository {
+	col := db.Database.Collection("audit_events")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "sequence", Value: 1}}, Options: options.Index().SetUnique(true)},                      // ลำดับใน hash chain (กัน sequence ซ้ำเมื่อบันทึกพร้อมกัน)
+		{Keys: bson.D{{Key: "actor_id", Value: 1}, {Key: "sequence", Value: -1}}},                                  // ค้นหาตามผู้กระทำ
+		{Keys: bson.D{{Key: "target_type", Value: 1}, {Key: "target_id", Value: 1}, {Key: "sequence", Value: -1}}}, // ค้นหาตามสิ่งที่ถูกกระทำ
+		{Keys: bson.D{{Key: "action", Value: 1}, {Key: "sequence", Value: -1}}},                                    // ค้นหาตามการกระทำ
+		{Keys: bson.D{{Key: "occurred_at", Value: -1}}},                                                            // ค้นหาตามช่วงเวลา
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index audit_events ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &auditEventRepository{collection: col}
+}
+
+// Last ดึง event ที่ sequence มากที่สุด
+func (r *auditEventRepository) Last(ctx context.Context) (*domain.AuditEvent, error) {
+	var event domain.AuditEvent
+	opts := options.FindOne().SetSort(bson.D{{Key: "sequence", Value: -1}})
+
+	err := r.collection.FindOne(ctx, bson.M{}, opts).Decode(&event)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ค้นหา audit event ล่าสุดล้มเหลว: %w", err)
+	}
+
+	return &event, nil
+}
+
+// Append บันทึก event ใหม่ — unique index ของ sequence ทำให้ instance ที่บันทึกพร้อมกันได้ sequence ไม่ซ้ำกัน
+func (r *auditEventRepository) Append(ctx context.Context, event *domain.AuditEvent) error {
+	if _, err := r.collection.InsertOne(ctx, event); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrAuditSequenceConflict
+		}
+		return fmt.Errorf("บันทึก audit event ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// Search ค้นหา event ตามเงื่อนไข (เรียงจากใหม่สุด, รองรับ pagination)
+func (r *auditEventRepository) Search(
+	ctx context.Context,
+	filter domain.AuditFilter,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.AuditEvent], error) {
+	query := auditFilterQuery(filter)
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("นับจำนวน audit event ล้มเหลว: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "sequence", Value: -1}}).
+		SetSkip(params.Offset()).
+		SetLimit(params.Limit())
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหา audit event ล้มเหลว: %w", err)
+	}
+
+	var events []domain.AuditEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูล audit event ล้มเหลว: %w", err)
+	}
+
+	return domain.NewPaginatedResult(events, total, params), nil
+}
+
+// FindAfter ดึง event ถัดจาก sequence ที่ระบุ เรียงจากเก่าไปใหม่ — ใช้ตรวจ hash chain ทีละชุด
+func (r *auditEventRepository) FindAfter(ctx context.Context, afterSequence int64, limit int64) ([]domain.AuditEvent, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "sequence", Value: 1}}).
+		SetLimit(limit)
+
+	cursor, err := r.collection.Find(ctx, bson.M{"sequence": bson.M{"$gt": afterSequence}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหา audit event ล้มเหลว: %w", err)
+	}
+
+	var events []domain.AuditEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูล audit event ล้มเหลว: %w", err)
+	}
+
+	return events, nil
+}
+
+// auditFilterQuery แปลงเงื่อนไขค้นหาเป็น MongoDB filter — field ที่ว่างไม่ถูกใส่
+func auditFilterQuery(filter domain.AuditFilter) bson.M {
+	query := bson.M{}
+	if filter.ActorID != nil {
+		query["actor_id"] = *filter.ActorID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.TargetType != "" {
+		query["target_type"] = filter.TargetType
+	}
+	if filter.TargetID != "" {
+		query["target_id"] = filter.TargetID
+	}
+
+	occurredAt := bson.M{}
+	if filter.From != nil {
+		occurredAt["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		occurredAt["$lt"] = *filter.To
+	}
+	if len(occurredAt) > 0 {
+		query["occurred_at"] = occurredAt
+	}
+
+	return query
+}
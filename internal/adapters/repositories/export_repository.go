@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+// exportBatchSize จำนวน document ที่ดึงจาก server ต่อรอบ — หน่วยความจำที่ใช้ขึ้นกับค่านี้ ไม่ใช่จำนวนทั้งหมด
+const exportBatchSize = 500
+
+type exportRepository struct {
+	requests *mongo.Collection
+	balances *mongo.Collection
+}
+
+func NewExportRepository(db *database.MongoDB) ports.ExportRepository {
+	return &exportRepository{
+		requests: db.Database.Collection("leave_requests"),
+		balances: db.Database.Collection("leave_balances"),
+	}
+}
+
+// EachRequest ใช้ filter และลำดับเดียวกับ Search แล้ว join ผู้ยื่นและผู้อนุมัติทีละ document
+func (r *exportRepository) EachRequest(
+	ctx context.Context,
+	query domain.LeaveRequestQuery,
+	fn func(*domain.LeaveRequestView) error,
+) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: leaveQueryFilter(query)}},
+		{{Key: "$sort", Value: leaveQuerySort(query.Sort)}},
+	}
+	pipeline = append(pipeline, userLookup("user_id", "requester")...)
+	pipeline = append(pipeline, userLookup("reviewer_id", "reviewer")...)
+
+	return eachDocument(ctx, r.requests, pipeline, fn)
+}
+
+// EachBalance เรียงตามปี พนักงาน และประเภทการลา แล้ว join ข้อมูลย่อของพนักงาน
+func (r *exportRepository) EachBalance(
+	ctx context.Context,
+	query domain.LeaveBalanceQuery,
+	fn func(*domain.LeaveBalanceView) error,
+) error {
+	filter := bson.M{}
+	if query.UserID != nil {
+		filter["user_id"] = *query.UserID
+	}
+	if query.Year != nil {
+		filter["year"] = *query.Year
+	}
+	if len(query.LeaveTypes) > 0 {
+		filter["leave_type"] = bson.M{"$in": query.LeaveTypes}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: bson.D{{Key: "year", Value: 1}, {Key: "user_id", Value: 1}, {Key: "leave_type", Value: 1}}}},
+	}
+	pipeline = append(pipeline, userLookup("user_id", "user")...)
+
+	return eachDocument(ctx, r.balances, pipeline, fn)
+}
+
+// eachDocument อ่านผลของ aggregation ทีละ document — allowDiskUse เพราะการเรียงตาม field ที่ไม่มี index
+// ของข้อมูลทั้ง collection อาจเกินหน่วยความจำที่ $sort ใช้ได้
+func eachDocument[T any](ctx context.Context, col *mongo.Collection, pipeline mongo.Pipeline, fn func(*T) error) error {
+	opts := options.Aggregate().SetBatchSize(exportBatchSize).SetAllowDiskUse(true)
+	cursor, err := col.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return fmt.Errorf("อ่านข้อมูลส่งออกล้มเหลว: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc T
+		if err := cursor.Decode(&doc); err != nil {
+			return fmt.Errorf("อ่านข้อมูลส่งออกล้มเหลว: %w", err)
+		}
+		if err := fn(&doc); err != nil {
+			return err
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return fmt.Errorf("อ่านข้อมูลส่งออกล้มเหลว: %w", err)
+	}
+	return nil
+}
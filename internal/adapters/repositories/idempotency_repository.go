@@ -0,0 +1,97 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type idempotencyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewIdempotencyRepository(db *database.MongoDB) ports.IdempotencyRepository {
+	col := db.Database.Collection("idempotency_keys")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)}, // key ของผู้ใช้หนึ่งคนใช้ได้ครั้งเดียว
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},             // TTL — MongoDB ลบ key ที่หมดอายุให้อัตโนมัติ
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index idempotency_keys ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &idempotencyRepository{collection: col}
+}
+
+// Reserve บันทึก key ใหม่ — unique index ตัดสินเมื่อหลาย request ใช้ key เดียวกันพร้อมกัน
+// key เดิมที่หมดเวลาแล้วแต่ TTL monitor ยังไม่ลบ (ทำงานทุก 60 วินาที) ถูกลบแล้วจองใหม่
+func (r *idempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord, now time.Time) (bool, error) {
+	reserved, err := r.insert(ctx, record)
+	if reserved || err != nil {
+		return reserved, err
+	}
+
+	expired := bson.M{"user_id": record.UserID, "key": record.Key, "expires_at": bson.M{"$lte": now}}
+	result, err := r.collection.DeleteOne(ctx, expired)
+	if err != nil {
+		return false, fmt.Errorf("ลบ Idempotency-Key ที่หมดเวลาล้มเหลว: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return false, nil
+	}
+	return r.insert(ctx, record)
+}
+
+// insert บันทึก key — คืน false เมื่อมี key นี้อยู่แล้ว
+func (r *idempotencyRepository) insert(ctx context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	if _, err := r.collection.InsertOne(ctx, record); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("บันทึก Idempotency-Key ล้มเหลว: %w", err)
+	}
+	return true, nil
+}
+
+// Find ค้นหา key ของผู้ใช้
+func (r *idempotencyRepository) Find(ctx context.Context, userID domain.ID, key string) (*domain.IdempotencyRecord, error) {
+	var record domain.IdempotencyRecord
+	err := r.collection.FindOne(ctx, bson.M{"user_id": userID, "key": key}).Decode(&record)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ค้นหา Idempotency-Key ล้มเหลว: %w", err)
+	}
+	return &record, nil
+}
+
+// Update บันทึก response และวันหมดอายุ
+func (r *idempotencyRepository) Update(ctx context.Context, record *domain.IdempotencyRecord) error {
+	update := bson.M{"$set": bson.M{"response": record.Response, "expires_at": record.ExpiresAt}}
+	if _, err := r.collection.UpdateByID(ctx, record.ID, update); err != nil {
+		return fmt.Errorf("บันทึก response ของ Idempotency-Key ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// Delete ลบ key
+func (r *idempotencyRepository) Delete(ctx context.Context, id domain.ID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": id}); err != nil {
+		return fmt.Errorf("ลบ Idempotency-Key ล้มเหลว: %w", err)
+	}
+	return nil
+}
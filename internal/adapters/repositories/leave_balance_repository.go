@@ -126,7 +126,7 @@ func (r *leaveBalanceRepository) ConfirmPending(
 	return nil
 }
 
-// ReleasePending ปล่อยวันลาที่จองไว้แบบ atomic ตอนปฏิเสธใบลา
+// ReleasePending ปล่อยวันลาที่จองไว้แบบ atomic ตอนปฏิเสธ แก้ไข หรือยกเลิกใบลา
 func (r *leaveBalanceRepository) ReleasePending(
 	ctx context.Context,
 	userID domain.ID,
@@ -155,3 +155,91 @@ func (r *leaveBalanceRepository) ReleasePending(
 
 	return nil
 }
+
+// SetTotalDays กำหนดจำนวนวันลาทั้งหมดแบบ atomic — สร้างยอดวันลาใหม่ถ้ายังไม่มี
+func (r *leaveBalanceRepository) SetTotalDays(
+	ctx context.Context,
+	userID domain.ID,
+	leaveType domain.LeaveType,
+	year int,
+	totalDays float64,
+) (*domain.LeaveBalance, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"leave_type": leaveType,
+		"year":       year,
+		// Atomic condition: used + pending <= total ใหม่
+		"$expr": bson.M{
+			"$lte": bson.A{
+				bson.M{"$add": bson.A{"$used_days", "$pending_days"}},
+				totalDays,
+			},
+		},
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"total_days": totalDays, "updated_at": now},
+		"$setOnInsert": bson.M{
+			"_id":          domain.NewID(),
+			"used_days":    0.0,
+			"pending_days": 0.0,
+			"created_at":   now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var balance domain.LeaveBalance
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&balance); err != nil {
+		// ยอดวันลามีอยู่แล้วแต่ไม่ผ่านเงื่อนไข — upsert พยายามสร้างเอกสารใหม่และชน unique index
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrEntitlementBelowUsage
+		}
+		return nil, fmt.Errorf("กำหนดจำนวนวันลาล้มเหลว: %w", err)
+	}
+
+	return &balance, nil
+}
+
+// SetOpeningBalance กำหนดยอดยกมาแบบ atomic — วันที่จองไว้ (pending) ยังคงอยู่และต้องรวมกับ used ได้ไม่เกิน total ใหม่
+func (r *leaveBalanceRepository) SetOpeningBalance(
+	ctx context.Context,
+	userID domain.ID,
+	year int,
+	opening domain.OpeningBalance,
+) (*domain.LeaveBalance, error) {
+	filter := bson.M{
+		"user_id":    userID,
+		"leave_type": opening.LeaveType,
+		"year":       year,
+		// Atomic condition: used ใหม่ + pending <= total ใหม่
+		"$expr": bson.M{
+			"$lte": bson.A{
+				bson.M{"$add": bson.A{opening.UsedDays, "$pending_days"}},
+				opening.TotalDays,
+			},
+		},
+	}
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{"total_days": opening.TotalDays, "used_days": opening.UsedDays, "updated_at": now},
+		"$setOnInsert": bson.M{
+			"_id":          domain.NewID(),
+			"pending_days": 0.0,
+			"created_at":   now,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var balance domain.LeaveBalance
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&balance); err != nil {
+		// ยอดวันลามีอยู่แล้วแต่ไม่ผ่านเงื่อนไข — upsert พยายามสร้างเอกสารใหม่และชน unique index
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrEntitlementBelowUsage
+		}
+		return nil, fmt.Errorf("กำหนดยอดวันลายกมาล้มเหลว: %w", err)
+	}
+
+	return &balance, nil
+}
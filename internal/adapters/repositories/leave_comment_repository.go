@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type leaveCommentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLeaveCommentRepository(db *database.MongoDB) ports.LeaveCommentRepository {
+	col := db.Database.Collection("leave_comments")
+
+	idx := mongo.IndexModel{Keys: bson.D{{Key: "request_id", Value: 1}, {Key: "created_at", Value: 1}}} // ความคิดเห็นของใบลาเรียงตามเวลา
+	if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+		log.Printf("คำเตือน: สร้าง index leave_comments ไม่สำเร็จ: %v", err)
+	}
+
+	return &leaveCommentRepository{collection: col}
+}
+
+// Create บันทึกความคิดเห็นใหม่
+func (r *leaveCommentRepository) Create(ctx context.Context, comment *domain.LeaveComment) error {
+	if _, err := r.collection.InsertOne(ctx, comment); err != nil {
+		return fmt.Errorf("บันทึกความคิดเห็นล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByRequestID ค้นหาความคิดเห็นของใบลา เรียงจากเก่าไปใหม่
+func (r *leaveCommentRepository) FindByRequestID(
+	ctx context.Context,
+	requestID domain.ID,
+	includeInternal bool,
+) ([]domain.LeaveComment, error) {
+	filter := bson.M{"request_id": requestID}
+	if !includeInternal {
+		filter["internal"] = false
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาความคิดเห็นล้มเหลว: %w", err)
+	}
+
+	comments := []domain.LeaveComment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลความคิดเห็นล้มเหลว: %w", err)
+	}
+	return comments, nil
+}
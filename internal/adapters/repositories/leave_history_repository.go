@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type leaveHistoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLeaveHistoryRepository(db *database.MongoDB) ports.LeaveHistoryRepository {
+	col := db.Database.Collection("leave_request_history")
+
+	idx := mongo.IndexModel{Keys: bson.D{{Key: "request_id", Value: 1}, {Key: "occurred_at", Value: 1}}} // ประวัติของใบลาเรียงตามเวลา
+	if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+		log.Printf("คำเตือน: สร้าง index leave_request_history ไม่สำเร็จ: %v", err)
+	}
+
+	return &leaveHistoryRepository{collection: col}
+}
+
+// Append เพิ่มเหตุการณ์ในประวัติของใบลา
+func (r *leaveHistoryRepository) Append(ctx context.Context, entry *domain.LeaveHistoryEntry) error {
+	if _, err := r.collection.InsertOne(ctx, entry); err != nil {
+		return fmt.Errorf("บันทึกประวัติใบลาล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByRequestID ค้นหาประวัติทั้งหมดของใบลา เรียงจากเก่าไปใหม่
+func (r *leaveHistoryRepository) FindByRequestID(ctx context.Context, requestID domain.ID) ([]domain.LeaveHistoryEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{"request_id": requestID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาประวัติใบลาล้มเหลว: %w", err)
+	}
+
+	entries := []domain.LeaveHistoryEntry{}
+	if err := cursor.All(ctx, &entries); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลประวัติใบลาล้มเหลว: %w", err)
+	}
+	return entries, nil
+}
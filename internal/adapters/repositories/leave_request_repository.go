@@ -29,9 +29,12 @@ func NewLeaveRequestRepository(db *database.MongoDB) ports.LeaveRequestRepositor
 // createLeaveRequestIndexes สร้าง indexes สำหรับ collection leave_requests
 func createLeaveRequestIndexes(col *mongo.Collection) {
 	indexes := []mongo.IndexModel{
-		{Keys: bson.D{{Key: "user_id", Value: 1}}},                                                             // ค้นหาตาม user
-		{Keys: bson.D{{Key: "status", Value: 1}}},                                                              // ค้นหาตามสถานะ
-		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "start_date", Value: 1}, {Key: "end_date", Value: 1}}}, // overlap check
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}},     // ประวัติใบลาของตนเอง (ใหม่สุดก่อน + cursor)
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "start_date", Value: 1}, {Key: "end_date", Value: 1}}},  // overlap check + ค้นหาตามช่วงวันของตนเอง
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},        // รายการตามสถานะ (รออนุมัติ, ระบบภายนอก + cursor ทั้งสองทิศ)
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "leave_type", Value: 1}, {Key: "start_date", Value: 1}}}, // ค้นหาของผู้จัดการตามสถานะ ประเภท และช่วงวัน
+		{Keys: bson.D{{Key: "reviewer_id", Value: 1}, {Key: "reviewed_at", Value: -1}}},                         // ใบลาที่ผู้อนุมัติคนหนึ่งพิจารณา
+		{Keys: bson.D{{Key: "reason", Value: "text"}}, Options: options.Index().SetDefaultLanguage("none")},     // ค้นหาคำใน reason (ไม่ตัดคำตามภาษา)
 	}
 
 	for _, idx := range indexes {
@@ -66,66 +69,231 @@ func (r *leaveRequestRepository) FindByID(ctx context.Context, id domain.ID) (*d
 	return &request, nil
 }
 
-// FindByUserID ค้นหาคำขอลาทั้งหมดของผู้ใช้ (เรียงจากใหม่สุด, รองรับ pagination)
-func (r *leaveRequestRepository) FindByUserID(
+// Search ค้นหาคำขอลาตามเงื่อนไข เรียงตาม query.Sort แล้วตาม _id เพื่อให้ลำดับคงที่ระหว่างหน้า
+// แบ่งหน้าด้วยเลขหน้า (skip + นับทั้งหมด) หรือด้วย cursor (keyset บน created_at, _id) ตาม params
+func (r *leaveRequestRepository) Search(
 	ctx context.Context,
-	userID domain.ID,
+	query domain.LeaveRequestQuery,
 	params domain.PaginationParams,
 ) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-	filter := bson.M{"user_id": userID}
+	return searchPage(ctx, r.collection, query, params, r.find, func(req *domain.LeaveRequest) *domain.LeaveRequest {
+		return req
+	})
+}
 
-	total, err := r.collection.CountDocuments(ctx, filter)
+// SearchWithUsers เหมือน Search แต่ join ผู้ยื่นและผู้อนุมัติด้วย $lookup หลังตัดหน้าแล้ว
+// จึง join เฉพาะรายการในหน้านั้น และไม่ต้องค้นผู้ใช้ทีละคน
+func (r *leaveRequestRepository) SearchWithUsers(
+	ctx context.Context,
+	query domain.LeaveRequestQuery,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.LeaveRequestView], error) {
+	return searchPage(ctx, r.collection, query, params, r.findWithUsers, func(view *domain.LeaveRequestView) *domain.LeaveRequest {
+		return &view.LeaveRequest
+	})
+}
+
+// pageFetcher อ่านรายการหนึ่งหน้าตาม filter และลำดับ (skip = 0 คือไม่ข้าม)
+type pageFetcher[T any] func(ctx context.Context, filter bson.M, sort bson.D, skip, limit int64) ([]T, error)
+
+// searchPage แบ่งหน้าแบบเลขหน้าหรือ keyset ตาม params — ใช้ร่วมกันระหว่างรายการใบลาธรรมดาและแบบมีผู้ใช้
+func searchPage[T any](
+	ctx context.Context,
+	col *mongo.Collection,
+	query domain.LeaveRequestQuery,
+	params domain.PaginationParams,
+	fetch pageFetcher[T],
+	requestOf func(*T) *domain.LeaveRequest,
+) (*domain.PaginatedResult[T], error) {
+	if params.Keyset {
+		return searchAfter(ctx, col, query, params, fetch, requestOf)
+	}
+	filter := leaveQueryFilter(query)
+
+	total, err := col.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("นับจำนวนคำขอลาล้มเหลว: %w", err)
 	}
 
-	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: -1}}).
-		SetSkip(params.Offset()).
-		SetLimit(params.Limit())
-
-	cursor, err := r.collection.Find(ctx, filter, opts)
+	items, err := fetch(ctx, filter, leaveQuerySort(query.Sort), params.Offset(), params.Limit())
 	if err != nil {
-		return nil, fmt.Errorf("ค้นหาคำขอลาตาม user ล้มเหลว: %w", err)
+		return nil, err
 	}
 
-	var requests []domain.LeaveRequest
-	if err := cursor.All(ctx, &requests); err != nil {
-		return nil, fmt.Errorf("อ่านข้อมูลคำขอลาล้มเหลว: %w", err)
+	result := domain.NewPaginatedResult(items, total, params)
+	if result.HasMore() && len(items) > 0 {
+		result.NextCursor = query.CursorOf(requestOf(&items[len(items)-1])) // ให้ client เปลี่ยนไปใช้ cursor ต่อได้
 	}
-
-	return domain.NewPaginatedResult(requests, total, params), nil
+	return result, nil
 }
 
-// FindByStatus ค้นหาคำขอลาตามสถานะ (เรียงจากเก่าสุดก่อน สำหรับ FIFO processing, รองรับ pagination)
-func (r *leaveRequestRepository) FindByStatus(
+// searchAfter แบ่งหน้าแบบ keyset — อ่านเกิน 1 รายการเพื่อรู้ว่ามีหน้าถัดไปหรือไม่ และนับทั้งหมดเฉพาะเมื่อขอ
+func searchAfter[T any](
 	ctx context.Context,
-	status domain.LeaveStatus,
+	col *mongo.Collection,
+	query domain.LeaveRequestQuery,
 	params domain.PaginationParams,
-) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-	filter := bson.M{"status": status}
+	fetch pageFetcher[T],
+	requestOf func(*T) *domain.LeaveRequest,
+) (*domain.PaginatedResult[T], error) {
+	filter := leaveQueryFilter(query)
+
+	var total *int64
+	if params.CountTotal {
+		count, err := col.CountDocuments(ctx, filter)
+		if err != nil {
+			return nil, fmt.Errorf("นับจำนวนคำขอลาล้มเหลว: %w", err)
+		}
+		total = &count
+	}
 
-	total, err := r.collection.CountDocuments(ctx, filter)
+	if params.After != nil {
+		filter["$or"] = afterCursorFilter(*params.After)
+	}
+	items, err := fetch(ctx, filter, leaveQuerySort(query.Sort), 0, params.Limit()+1)
 	if err != nil {
-		return nil, fmt.Errorf("นับจำนวนคำขอลาล้มเหลว: %w", err)
+		return nil, err
 	}
 
-	opts := options.Find().
-		SetSort(bson.D{{Key: "created_at", Value: 1}}).
-		SetSkip(params.Offset()).
-		SetLimit(params.Limit())
+	var next *domain.Cursor
+	if len(items) > params.PageSize {
+		items = items[:params.PageSize]
+		next = query.CursorOf(requestOf(&items[len(items)-1]))
+	}
+	return domain.NewCursorResult(items, next, total, params), nil
+}
 
+func (r *leaveRequestRepository) find(ctx context.Context, filter bson.M, sort bson.D, skip, limit int64) ([]domain.LeaveRequest, error) {
+	opts := options.Find().SetSort(sort).SetSkip(skip).SetLimit(limit)
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
-		return nil, fmt.Errorf("ค้นหาคำขอลาตามสถานะล้มเหลว: %w", err)
+		return nil, fmt.Errorf("ค้นหาคำขอลาล้มเหลว: %w", err)
 	}
 
 	var requests []domain.LeaveRequest
 	if err := cursor.All(ctx, &requests); err != nil {
 		return nil, fmt.Errorf("อ่านข้อมูลคำขอลาล้มเหลว: %w", err)
 	}
+	return requests, nil
+}
+
+// findWithUsers อ่านหนึ่งหน้าด้วย aggregation — $match/$sort/$skip/$limit ใช้ index เดียวกับ find แล้วจึง $lookup
+func (r *leaveRequestRepository) findWithUsers(
+	ctx context.Context,
+	filter bson.M,
+	sort bson.D,
+	skip, limit int64,
+) ([]domain.LeaveRequestView, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$sort", Value: sort}},
+	}
+	if skip > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$skip", Value: skip}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$limit", Value: limit}})
+	pipeline = append(pipeline, userLookup("user_id", "requester")...)
+	pipeline = append(pipeline, userLookup("reviewer_id", "reviewer")...)
 
-	return domain.NewPaginatedResult(requests, total, params), nil
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาคำขอลาล้มเหลว: %w", err)
+	}
+
+	var views []domain.LeaveRequestView
+	if err := cursor.All(ctx, &views); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลคำขอลาล้มเหลว: %w", err)
+	}
+	return views, nil
+}
+
+// userLookup join ผู้ใช้จาก field ที่ระบุเป็น document เดียวใน as — เลือกเฉพาะ field ของ UserSummary
+// เพื่อไม่ให้ password hash หรือข้อมูล IdP ติดมากับรายการใบลา (ไม่พบผู้ใช้ = ไม่มี field as)
+func userLookup(localField, as string) mongo.Pipeline {
+	return mongo.Pipeline{
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "users",
+			"localField":   localField,
+			"foreignField": "_id",
+			"pipeline":     bson.A{bson.M{"$project": bson.M{"full_name": 1, "email": 1, "department": 1}}},
+			"as":           as,
+		}}},
+		{{Key: "$unwind", Value: bson.M{"path": "$" + as, "preserveNullAndEmptyArrays": true}}},
+	}
+}
+
+// afterCursorFilter เงื่อนไขของรายการที่อยู่หลัง cursor ตามลำดับ (created_at, _id) — ทั้งสอง field เรียงทิศเดียวกัน
+func afterCursorFilter(after domain.Cursor) bson.A {
+	op := "$gt"
+	if after.Descending {
+		op = "$lt"
+	}
+	return bson.A{
+		bson.M{"created_at": bson.M{op: after.CreatedAt}},
+		bson.M{"created_at": after.CreatedAt, "_id": bson.M{op: after.ID}},
+	}
+}
+
+// leaveQueryFilter แปลงเงื่อนไขค้นหาเป็น MongoDB filter — field ที่ว่างไม่ถูกใส่
+func leaveQueryFilter(query domain.LeaveRequestQuery) bson.M {
+	filter := bson.M{}
+	if query.UserID != nil {
+		filter["user_id"] = *query.UserID
+	}
+	if query.ReviewerID != nil {
+		filter["reviewer_id"] = *query.ReviewerID
+	}
+	if len(query.Statuses) > 0 {
+		filter["status"] = bson.M{"$in": query.Statuses}
+	}
+	if len(query.LeaveTypes) > 0 {
+		filter["leave_type"] = bson.M{"$in": query.LeaveTypes}
+	}
+
+	// ช่วงวันลาคาบเกี่ยวกับช่วงที่ค้นหา — เงื่อนไขเดียวกับ overlap rule
+	if query.To != nil {
+		filter["start_date"] = bson.M{"$lte": *query.To}
+	}
+	if query.From != nil {
+		filter["end_date"] = bson.M{"$gte": *query.From}
+	}
+
+	totalDays := bson.M{}
+	if query.MinDays != nil {
+		totalDays["$gte"] = *query.MinDays
+	}
+	if query.MaxDays != nil {
+		totalDays["$lte"] = *query.MaxDays
+	}
+	if len(totalDays) > 0 {
+		filter["total_days"] = totalDays
+	}
+
+	if query.Search != "" {
+		filter["$text"] = bson.M{"$search": query.Search}
+	}
+	return filter
+}
+
+// leaveQuerySort แปลงลำดับการเรียงเป็น MongoDB sort — ปิดท้ายด้วย _id เพราะ field ที่เรียงอาจมีค่าซ้ำกัน
+// _id เรียงทิศเดียวกับ field แรก index { created_at: 1, _id: 1 } จึงใช้ได้ทั้งสองทิศ
+func leaveQuerySort(sorts []domain.LeaveSort) bson.D {
+	sort := make(bson.D, 0, len(sorts)+1)
+	for _, s := range sorts {
+		sort = append(sort, bson.E{Key: string(s.Field), Value: sortDirection(s.Descending)})
+	}
+	idDirection := 1
+	if len(sorts) > 0 {
+		idDirection = sortDirection(sorts[0].Descending)
+	}
+	return append(sort, bson.E{Key: "_id", Value: idDirection})
+}
+
+func sortDirection(descending bool) int {
+	if descending {
+		return -1
+	}
+	return 1
 }
 
 // Update อัปเดตคำขอลา (ใช้ ReplaceOne เพื่อแทนที่ทั้ง document)
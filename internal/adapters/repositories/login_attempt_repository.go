@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+// loginAttemptRetention ระยะเวลาเก็บสถิติหลัง login ผิดครั้งล่าสุด — ต้องนานกว่าระยะเวลาล็อก
+const loginAttemptRetention = 24 * time.Hour
+
+type loginAttemptRepository struct {
+	collection *mongo.Collection
+}
+
+func NewLoginAttemptRepository(db *database.MongoDB) ports.LoginAttemptRepository {
+	col := db.Database.Collection("login_attempts")
+
+	// TTL — ลบสถิติของอีเมลที่ไม่ได้ login ผิดมานานให้อัตโนมัติ
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "last_failed_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(int32(loginAttemptRetention.Seconds())),
+	}
+	if _, err := col.Indexes().CreateOne(context.Background(), indexModel); err != nil {
+		log.Printf("คำเตือน: สร้าง index login_attempts ไม่สำเร็จ: %v", err)
+	}
+
+	return &loginAttemptRepository{collection: col}
+}
+
+// Find ค้นหาสถิติการ login ผิดของอีเมล — คืน LoginAttempt ว่างถ้ายังไม่เคยผิด
+func (r *loginAttemptRepository) Find(ctx context.Context, email string) (*domain.LoginAttempt, error) {
+	var attempt domain.LoginAttempt
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": email}).Decode(&attempt)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return &domain.LoginAttempt{Email: email}, nil
+		}
+		return nil, fmt.Errorf("ค้นหาสถิติการเข้าสู่ระบบล้มเหลว: %w", err)
+	}
+
+	return &attempt, nil
+}
+
+// RecordAttempt บันทึกสถิติด้วย findOneAndUpdate ที่มีเงื่อนไขบน version เดิม (upsert) — คืนสถิติหลังอัปเดต
+// ถ้าคำขออื่นบันทึกไปก่อน filter จะไม่ตรงและ upsert ชน _id เดิม จึงคืน ErrTooManyLoginAttempts
+func (r *loginAttemptRepository) RecordAttempt(ctx context.Context, attempt *domain.LoginAttempt) (*domain.LoginAttempt, error) {
+	filter := bson.M{"_id": attempt.Email, "version": attempt.Version - 1}
+	if attempt.Version == 1 {
+		filter["version"] = bson.M{"$in": bson.A{0, nil}} // ยังไม่มีสถิติ หรือสถิติเดิมที่ยังไม่มี version
+	}
+
+	set := bson.M{
+		"failed_count":   attempt.FailedCount,
+		"lock_count":     attempt.LockCount,
+		"last_failed_at": attempt.LastFailedAt,
+		"version":        attempt.Version,
+	}
+	if attempt.LockedUntil != nil {
+		set["locked_until"] = *attempt.LockedUntil
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var updated domain.LoginAttempt
+	err := r.collection.FindOneAndUpdate(ctx, filter, bson.M{"$set": set}, opts).Decode(&updated)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, domain.ErrTooManyLoginAttempts
+		}
+		return nil, fmt.Errorf("บันทึกการเข้าสู่ระบบล้มเหลว: %w", err)
+	}
+
+	return &updated, nil
+}
+
+// Reset ล้างสถิติทั้งหมดของอีเมล
+func (r *loginAttemptRepository) Reset(ctx context.Context, email string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": email}); err != nil {
+		return fmt.Errorf("ล้างสถิติการเข้าสู่ระบบล้มเหลว: %w", err)
+	}
+	return nil
+}
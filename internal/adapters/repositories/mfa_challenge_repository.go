@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type mfaChallengeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMFAChallengeRepository(db *database.MongoDB) ports.MFAChallengeRepository {
+	col := db.Database.Collection("mfa_challenges")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},          // ค้นหาจาก hash ตอนยืนยันรหัส
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // TTL — MongoDB ลบ challenge ที่หมดอายุให้อัตโนมัติ
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index mfa_challenges ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &mfaChallengeRepository{collection: col}
+}
+
+// Create บันทึก challenge ใหม่
+func (r *mfaChallengeRepository) Create(ctx context.Context, challenge *domain.MFAChallenge) error {
+	if _, err := r.collection.InsertOne(ctx, challenge); err != nil {
+		return fmt.Errorf("บันทึก challenge ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByHash ค้นหา challenge จาก hash
+func (r *mfaChallengeRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	var challenge domain.MFAChallenge
+
+	err := r.collection.FindOne(ctx, bson.M{"token_hash": tokenHash}).Decode(&challenge)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, fmt.Errorf("ค้นหา challenge ล้มเหลว: %w", err)
+	}
+
+	return &challenge, nil
+}
+
+// Consume ลบ challenge แบบ atomic — สำเร็จได้เพียงครั้งเดียวต่อ challenge
+func (r *mfaChallengeRepository) Consume(ctx context.Context, id domain.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("ลบ challenge ล้มเหลว: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrInvalidMFAChallenge
+	}
+	return nil
+}
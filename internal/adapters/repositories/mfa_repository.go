@@ -0,0 +1,135 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type mfaRepository struct {
+	collection *mongo.Collection
+}
+
+func NewMFARepository(db *database.MongoDB) ports.MFARepository {
+	return &mfaRepository{
+		collection: db.Database.Collection("user_mfa"),
+	}
+}
+
+// Find ค้นหาสถานะ 2FA ของผู้ใช้ — คืน UserMFA ว่างถ้ายังไม่เคยลงทะเบียน
+func (r *mfaRepository) Find(ctx context.Context, userID domain.ID) (*domain.UserMFA, error) {
+	var state domain.UserMFA
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": userID}).Decode(&state)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return &domain.UserMFA{UserID: userID}, nil
+		}
+		return nil, fmt.Errorf("ค้นหาสถานะ 2FA ล้มเหลว: %w", err)
+	}
+
+	return &state, nil
+}
+
+// SavePendingSecret บันทึก secret ที่รอยืนยัน (upsert)
+func (r *mfaRepository) SavePendingSecret(ctx context.Context, userID domain.ID, secret string) error {
+	update := bson.M{"$set": bson.M{"pending_secret": secret}}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": userID}, update, options.UpdateOne().SetUpsert(true)); err != nil {
+		return fmt.Errorf("บันทึก TOTP secret ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// Enable เปิดใช้ 2FA — สำเร็จเฉพาะเมื่อ pending secret ยังตรงกับที่ยืนยัน (กันการลงทะเบียนซ้อนกัน)
+func (r *mfaRepository) Enable(ctx context.Context, userID domain.ID, secret string, recoveryCodeHashes []string) error {
+	filter := bson.M{
+		"_id":            userID,
+		"pending_secret": secret,
+		"enabled_at":     nil,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"secret":               secret,
+			"recovery_code_hashes": recoveryCodeHashes,
+			"last_used_step":       0,
+			"enabled_at":           time.Now(),
+		},
+		"$unset": bson.M{"pending_secret": ""},
+	}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("เปิดใช้ 2FA ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrMFANotEnrolled
+	}
+
+	return nil
+}
+
+// Disable ลบข้อมูล 2FA ทั้งหมดของผู้ใช้
+func (r *mfaRepository) Disable(ctx context.Context, userID domain.ID) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"_id": userID}); err != nil {
+		return fmt.Errorf("ปิดใช้ 2FA ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// ReplaceRecoveryCodes แทนที่ recovery codes ทั้งชุด
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID domain.ID, recoveryCodeHashes []string) error {
+	update := bson.M{"$set": bson.M{"recovery_code_hashes": recoveryCodeHashes}}
+
+	if _, err := r.collection.UpdateOne(ctx, bson.M{"_id": userID}, update); err != nil {
+		return fmt.Errorf("บันทึก recovery codes ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// ConsumeRecoveryCode ลบ recovery code ออกจากชุดแบบ atomic — สำเร็จได้เพียงครั้งเดียวต่อ code
+func (r *mfaRepository) ConsumeRecoveryCode(ctx context.Context, userID domain.ID, codeHash string) error {
+	filter := bson.M{
+		"_id":                  userID,
+		"recovery_code_hashes": codeHash,
+	}
+	update := bson.M{"$pull": bson.M{"recovery_code_hashes": codeHash}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("ใช้ recovery code ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
+
+// MarkStepUsed บันทึก time step ที่ใช้แล้ว — อัปเดตเฉพาะเมื่อ step ใหม่กว่าค่าเดิม (ป้องกัน replay)
+func (r *mfaRepository) MarkStepUsed(ctx context.Context, userID domain.ID, step int64) error {
+	filter := bson.M{
+		"_id":            userID,
+		"last_used_step": bson.M{"$lt": step},
+	}
+	update := bson.M{"$set": bson.M{"last_used_step": step}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("บันทึก TOTP step ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrInvalidMFACode
+	}
+
+	return nil
+}
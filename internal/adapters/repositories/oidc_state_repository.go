@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type oidcStateRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOIDCStateRepository(db *database.MongoDB) ports.OIDCStateRepository {
+	col := db.Database.Collection("oidc_states")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "state_hash", Value: 1}}, Options: options.Index().SetUnique(true)},          // ค้นหาจาก hash ตอน callback
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // TTL — ลบ state ที่ผู้ใช้ไม่ login ต่อให้อัตโนมัติ
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index oidc_states ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &oidcStateRepository{collection: col}
+}
+
+// Create บันทึก state ใหม่
+func (r *oidcStateRepository) Create(ctx context.Context, state *domain.OIDCLoginState) error {
+	if _, err := r.collection.InsertOne(ctx, state); err != nil {
+		return fmt.Errorf("บันทึก OIDC state ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// Consume ดึงและลบ state แบบ atomic — callback เดียวกันใช้ซ้ำไม่ได้
+func (r *oidcStateRepository) Consume(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	var state domain.OIDCLoginState
+
+	err := r.collection.FindOneAndDelete(ctx, bson.M{"state_hash": stateHash}).Decode(&state)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrInvalidOIDCState
+		}
+		return nil, fmt.Errorf("ดึง OIDC state ล้มเหลว: %w", err)
+	}
+
+	return &state, nil
+}
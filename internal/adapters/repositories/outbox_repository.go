@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+// outboxRetention เวลาที่เก็บเหตุการณ์ที่ส่งต่อครบแล้วก่อน MongoDB ลบให้อัตโนมัติ
+const outboxRetention = 7 * 24 * time.Hour
+
+type outboxRepository struct {
+	collection *mongo.Collection
+}
+
+func NewOutboxRepository(db *database.MongoDB) ports.OutboxRepository {
+	col := db.Database.Collection("outbox")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}}, // dispatcher หาเหตุการณ์ที่ถึงเวลา
+		{Keys: bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},         // event stream อ่านเหตุการณ์ใหม่ตามลำดับ
+		{
+			Keys:    bson.D{{Key: "processed_at", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(int32(outboxRetention.Seconds())),
+		}, // TTL — ลบเหตุการณ์ที่ส่งต่อครบแล้ว (pending/failed ไม่มี processed_at จึงไม่ถูกลบ)
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index outbox ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &outboxRepository{collection: col}
+}
+
+// Append บันทึกเหตุการณ์ลง outbox
+func (r *outboxRepository) Append(ctx context.Context, msg *domain.OutboxMessage) error {
+	if _, err := r.collection.InsertOne(ctx, msg); err != nil {
+		return fmt.Errorf("บันทึกเหตุการณ์ลง outbox ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue จองเหตุการณ์ที่ถึงเวลาเก่าที่สุดแบบ atomic — เลื่อน next_attempt_at ออกไปเท่ากับ lease
+// ถ้า process ล่มระหว่างส่งต่อ เหตุการณ์จะถูกหยิบขึ้นมาใหม่หลังหมด lease
+func (r *outboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domain.OutboxMessage, error) {
+	filter := bson.M{
+		"status":          domain.OutboxPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var msg domain.OutboxMessage
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&msg); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("จองเหตุการณ์ใน outbox ล้มเหลว: %w", err)
+	}
+	return &msg, nil
+}
+
+// Update บันทึกผลการส่งต่อ
+func (r *outboxRepository) Update(ctx context.Context, msg *domain.OutboxMessage) error {
+	if _, err := r.collection.ReplaceOne(ctx, bson.M{"_id": msg.ID}, msg); err != nil {
+		return fmt.Errorf("บันทึกผลการส่งต่อเหตุการณ์ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByID ค้นหาเหตุการณ์จากรหัส — คืน nil ถ้าไม่พบ
+func (r *outboxRepository) FindByID(ctx context.Context, id domain.ID) (*domain.OutboxMessage, error) {
+	var msg domain.OutboxMessage
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&msg); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("ค้นหาเหตุการณ์ใน outbox ล้มเหลว: %w", err)
+	}
+	return &msg, nil
+}
+
+// ListCreatedSince อ่านเหตุการณ์ทุกสถานะที่สร้างตั้งแต่ since เรียงตาม created_at แล้ว _id
+func (r *outboxRepository) ListCreatedSince(ctx context.Context, since time.Time, limit int) ([]domain.OutboxMessage, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"created_at": bson.M{"$gte": since}}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("อ่านเหตุการณ์ใน outbox ล้มเหลว: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var msgs []domain.OutboxMessage
+	if err := cursor.All(ctx, &msgs); err != nil {
+		return nil, fmt.Errorf("อ่านเหตุการณ์ใน outbox ล้มเหลว: %w", err)
+	}
+	return msgs, nil
+}
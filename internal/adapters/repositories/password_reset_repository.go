@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type passwordResetRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPasswordResetRepository(db *database.MongoDB) ports.PasswordResetRepository {
+	col := db.Database.Collection("password_reset_tokens")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},          // ค้นหาจาก hash ตอนตั้งรหัสผ่านใหม่
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},                                                       // ยกเลิก token ที่ค้างอยู่ของผู้ใช้
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // TTL — MongoDB ลบ token ที่หมดอายุให้อัตโนมัติ
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index password_reset_tokens ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &passwordResetRepository{collection: col}
+}
+
+// Create บันทึก reset token ใหม่
+func (r *passwordResetRepository) Create(ctx context.Context, token *domain.PasswordResetToken) error {
+	if _, err := r.collection.InsertOne(ctx, token); err != nil {
+		return fmt.Errorf("บันทึก reset token ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByHash ค้นหา reset token จาก hash
+func (r *passwordResetRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	filter := bson.M{"token_hash": tokenHash}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrInvalidResetToken
+		}
+		return nil, fmt.Errorf("ค้นหา reset token ล้มเหลว: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Consume ทำเครื่องหมายว่า token ถูกใช้แล้วแบบ atomic — สำเร็จได้เพียงครั้งเดียวต่อ token
+func (r *passwordResetRepository) Consume(ctx context.Context, id domain.ID) error {
+	filter := bson.M{
+		"_id":     id,
+		"used_at": nil,
+	}
+	update := bson.M{"$set": bson.M{"used_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("อัปเดต reset token ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrInvalidResetToken
+	}
+
+	return nil
+}
+
+// InvalidateByUserID ยกเลิก reset token ที่ยังไม่ถูกใช้ทั้งหมดของผู้ใช้
+func (r *passwordResetRepository) InvalidateByUserID(ctx context.Context, userID domain.ID) error {
+	filter := bson.M{
+		"user_id": userID,
+		"used_at": nil,
+	}
+	update := bson.M{"$set": bson.M{"used_at": time.Now()}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("ยกเลิก reset token ของผู้ใช้ล้มเหลว: %w", err)
+	}
+
+	return nil
+}
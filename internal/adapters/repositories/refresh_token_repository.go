@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type refreshTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRefreshTokenRepository(db *database.MongoDB) ports.RefreshTokenRepository {
+	col := db.Database.Collection("refresh_tokens")
+	createRefreshTokenIndexes(col)
+	return &refreshTokenRepository{collection: col}
+}
+
+// createRefreshTokenIndexes สร้าง indexes สำหรับ collection refresh_tokens
+func createRefreshTokenIndexes(col *mongo.Collection) {
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "token_hash", Value: 1}}, Options: options.Index().SetUnique(true)},          // ค้นหาจาก hash ตอน refresh
+		{Keys: bson.D{{Key: "family_id", Value: 1}}},                                                     // ยกเลิกทั้ง family
+		{Keys: bson.D{{Key: "user_id", Value: 1}}},                                                       // ยกเลิกทุก session ของผู้ใช้
+		{Keys: bson.D{{Key: "expires_at", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)}, // TTL — MongoDB ลบ token ที่หมดอายุให้อัตโนมัติ
+	}
+
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index refresh_tokens ไม่สำเร็จ: %v", err)
+		}
+	}
+}
+
+// Create บันทึก refresh token ใหม่
+func (r *refreshTokenRepository) Create(ctx context.Context, token *domain.RefreshToken) error {
+	if _, err := r.collection.InsertOne(ctx, token); err != nil {
+		return fmt.Errorf("บันทึก refresh token ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByHash ค้นหา refresh token จาก hash
+func (r *refreshTokenRepository) FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	filter := bson.M{"token_hash": tokenHash}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, fmt.Errorf("ค้นหา refresh token ล้มเหลว: %w", err)
+	}
+
+	return &token, nil
+}
+
+// Consume ทำเครื่องหมายว่า token ถูกใช้แล้วแบบ atomic — สำเร็จได้เพียงครั้งเดียวต่อ token
+func (r *refreshTokenRepository) Consume(ctx context.Context, id domain.ID) error {
+	filter := bson.M{
+		"_id":        id,
+		"revoked_at": nil, // ตรงกับ document ที่ยังไม่มี revoked_at
+	}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("อัปเดต refresh token ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrRefreshTokenReused
+	}
+
+	return nil
+}
+
+// RevokeFamily ยกเลิก refresh token ทุกตัวที่ยังใช้ได้ใน family เดียวกัน
+func (r *refreshTokenRepository) RevokeFamily(ctx context.Context, familyID domain.ID) error {
+	filter := bson.M{
+		"family_id":  familyID,
+		"revoked_at": nil,
+	}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("ยกเลิก refresh token family ล้มเหลว: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeByUserID ยกเลิก refresh token ทุกตัวที่ยังใช้ได้ของผู้ใช้
+func (r *refreshTokenRepository) RevokeByUserID(ctx context.Context, userID domain.ID) error {
+	filter := bson.M{
+		"user_id":    userID,
+		"revoked_at": nil,
+	}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	if _, err := r.collection.UpdateMany(ctx, filter, update); err != nil {
+		return fmt.Errorf("ยกเลิก refresh token ของผู้ใช้ล้มเหลว: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+// reviewedStatuses สถานะของใบลาที่พิจารณาแล้ว
+var reviewedStatuses = []domain.LeaveStatus{domain.LeaveStatusApproved, domain.LeaveStatusRejected}
+
+type reportRepository struct {
+	requests *mongo.Collection
+	balances *mongo.Collection
+	users    *mongo.Collection
+}
+
+func NewReportRepository(db *database.MongoDB) ports.ReportRepository {
+	balances := db.Database.Collection("leave_balances")
+
+	// ยอดวันลาของทุกคนในปีและประเภทหนึ่ง — unique index { user_id, leave_type, year } ใช้ไม่ได้เพราะไม่ได้ระบุ user_id
+	idx := mongo.IndexModel{Keys: bson.D{{Key: "year", Value: 1}, {Key: "leave_type", Value: 1}}}
+	if _, err := balances.Indexes().CreateOne(context.Background(), idx); err != nil {
+		log.Printf("คำเตือน: สร้าง index leave_balances ไม่สำเร็จ: %v", err)
+	}
+
+	return &reportRepository{
+		requests: db.Database.Collection("leave_requests"),
+		balances: balances,
+		users:    db.Database.Collection("users"),
+	}
+}
+
+// LeaveUsageByMonth รวมวันลาที่อนุมัติตามประเภทและเดือนของ start_date
+func (r *reportRepository) LeaveUsageByMonth(ctx context.Context, filter domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error) {
+	pipeline := requestReportPipeline(filter, domain.LeaveStatusApproved)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":      bson.M{"leave_type": "$leave_type", "month": bson.M{"$month": "$start_date"}},
+			"days":     bson.M{"$sum": "$total_days"},
+			"requests": bson.M{"$sum": 1},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "_id.month", Value: 1}, {Key: "_id.leave_type", Value: 1}}}},
+	)
+
+	var rows []struct {
+		ID struct {
+			LeaveType domain.LeaveType `bson:"leave_type"`
+			Month     int              `bson:"month"`
+		} `bson:"_id"`
+		Days     float64 `bson:"days"`
+		Requests int64   `bson:"requests"`
+	}
+	if err := aggregateAll(ctx, r.requests, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	usage := make([]domain.MonthlyLeaveUsage, 0, len(rows))
+	for _, row := range rows {
+		usage = append(usage, domain.MonthlyLeaveUsage{
+			LeaveType: row.ID.LeaveType, Month: row.ID.Month, Days: row.Days, Requests: row.Requests,
+		})
+	}
+	return usage, nil
+}
+
+// AbsenteeismByTeam นับพนักงานต่อแผนกจาก users และรวมวันลาที่อนุมัติต่อแผนกของผู้ยื่นจาก leave_requests
+// แผนกที่มีวันลาแต่ไม่มีพนักงานแล้ว (ผู้ใช้ถูกลบ) ยังแสดงโดยมีจำนวนพนักงานเป็น 0
+func (r *reportRepository) AbsenteeismByTeam(ctx context.Context, filter domain.ReportFilter) ([]domain.TeamAbsenteeism, error) {
+	department := bson.M{"$ifNull": bson.A{"$department", ""}}
+	headcountPipeline := mongo.Pipeline{}
+	if filter.Department != "" {
+		headcountPipeline = append(headcountPipeline, bson.D{{Key: "$match", Value: bson.M{"department": filter.Department}}})
+	}
+	headcountPipeline = append(headcountPipeline, bson.D{{Key: "$group", Value: bson.M{"_id": department, "count": bson.M{"$sum": 1}}}})
+
+	var headcounts []struct {
+		Department string `bson:"_id"`
+		Count      int64  `bson:"count"`
+	}
+	if err := aggregateAll(ctx, r.users, headcountPipeline, &headcounts); err != nil {
+		return nil, err
+	}
+
+	daysPipeline := requestReportPipeline(filter, domain.LeaveStatusApproved)
+	if filter.Department == "" {
+		daysPipeline = append(daysPipeline, userLookup("user_id", "requester")...)
+	}
+	daysPipeline = append(daysPipeline, bson.D{{Key: "$group", Value: bson.M{
+		"_id":  bson.M{"$ifNull": bson.A{"$requester.department", ""}},
+		"days": bson.M{"$sum": "$total_days"},
+	}}})
+
+	var days []struct {
+		Department string  `bson:"_id"`
+		Days       float64 `bson:"days"`
+	}
+	if err := aggregateAll(ctx, r.requests, daysPipeline, &days); err != nil {
+		return nil, err
+	}
+
+	teams := make(map[string]*domain.TeamAbsenteeism, len(headcounts))
+	for _, h := range headcounts {
+		teams[h.Department] = &domain.TeamAbsenteeism{Department: h.Department, Headcount: h.Count}
+	}
+	for _, d := range days {
+		if teams[d.Department] == nil {
+			teams[d.Department] = &domain.TeamAbsenteeism{Department: d.Department}
+		}
+		teams[d.Department].DaysTaken = d.Days
+	}
+
+	result := make([]domain.TeamAbsenteeism, 0, len(teams))
+	for _, t := range teams {
+		result = append(result, *t)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Department < result[j].Department })
+	return result, nil
+}
+
+// ApprovalTurnaround เวลาเฉลี่ยและนานที่สุด (ReviewedAt - CreatedAt) แยกตามประเภทการลา
+func (r *reportRepository) ApprovalTurnaround(ctx context.Context, filter domain.ReportFilter) ([]domain.ApprovalTurnaround, error) {
+	pipeline := requestReportPipeline(filter, reviewedStatuses...)
+	hours := bson.M{"$divide": bson.A{bson.M{"$subtract": bson.A{"$reviewed_at", "$created_at"}}, 3600000}}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: bson.M{"reviewed_at": bson.M{"$exists": true}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":       "$leave_type",
+			"reviewed":  bson.M{"$sum": 1},
+			"avg_hours": bson.M{"$avg": hours},
+			"max_hours": bson.M{"$max": hours},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	)
+
+	var rows []struct {
+		LeaveType domain.LeaveType `bson:"_id"`
+		Reviewed  int64            `bson:"reviewed"`
+		AvgHours  float64          `bson:"avg_hours"`
+		MaxHours  float64          `bson:"max_hours"`
+	}
+	if err := aggregateAll(ctx, r.requests, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	turnaround := make([]domain.ApprovalTurnaround, 0, len(rows))
+	for _, row := range rows {
+		turnaround = append(turnaround, domain.ApprovalTurnaround{
+			LeaveType: row.LeaveType, Reviewed: row.Reviewed, AverageHours: row.AvgHours, MaxHours: row.MaxHours,
+		})
+	}
+	return turnaround, nil
+}
+
+// RejectionCountsByReviewer จำนวนใบลาที่พิจารณาและที่ปฏิเสธของผู้อนุมัติแต่ละคน เรียงจากพิจารณามากสุด
+func (r *reportRepository) RejectionCountsByReviewer(
+	ctx context.Context,
+	filter domain.ReportFilter,
+) ([]domain.ReviewerRejectionRate, error) {
+	pipeline := requestReportPipeline(filter, reviewedStatuses...)
+	pipeline = append(pipeline,
+		bson.D{{Key: "$match", Value: bson.M{"reviewer_id": bson.M{"$exists": true}}}},
+		bson.D{{Key: "$group", Value: bson.M{
+			"_id":      "$reviewer_id",
+			"reviewed": bson.M{"$sum": 1},
+			"rejected": bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{"$status", domain.LeaveStatusRejected}}, 1, 0}}},
+		}}},
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "reviewed", Value: -1}, {Key: "_id", Value: 1}}}},
+	)
+	pipeline = append(pipeline, userLookup("_id", "reviewer")...)
+
+	var rows []struct {
+		Reviewer   *domain.UserSummary `bson:"reviewer,omitempty"`
+		ReviewerID domain.ID           `bson:"_id"`
+		Reviewed   int64               `bson:"reviewed"`
+		Rejected   int64               `bson:"rejected"`
+	}
+	if err := aggregateAll(ctx, r.requests, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	rates := make([]domain.ReviewerRejectionRate, 0, len(rows))
+	for _, row := range rows {
+		rates = append(rates, domain.ReviewerRejectionRate{
+			Reviewer: row.Reviewer, ReviewerID: row.ReviewerID, Reviewed: row.Reviewed, Rejected: row.Rejected,
+		})
+	}
+	return rates, nil
+}
+
+// CarryOverRisks ยอดวันลาที่คงเหลือ (total - used - pending) เกิน carryOverDays เรียงจากคงเหลือมากสุด
+// join ผู้ใช้หลังตัดจำนวนแล้ว เว้นแต่ต้องกรองตามแผนก
+func (r *reportRepository) CarryOverRisks(
+	ctx context.Context,
+	filter domain.ReportFilter,
+	carryOverDays float64,
+	limit int,
+) ([]domain.CarryOverRisk, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"year": filter.Year, "leave_type": filter.LeaveType}}},
+		{{Key: "$set", Value: bson.M{
+			"remaining_days": bson.M{"$subtract": bson.A{"$total_days", bson.M{"$add": bson.A{"$used_days", "$pending_days"}}}},
+		}}},
+		{{Key: "$match", Value: bson.M{"remaining_days": bson.M{"$gt": carryOverDays}}}},
+	}
+	if filter.Department != "" {
+		pipeline = append(pipeline, departmentMatch("user_id", "user", filter.Department)...)
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$sort", Value: bson.D{{Key: "remaining_days", Value: -1}, {Key: "user_id", Value: 1}}}},
+		bson.D{{Key: "$limit", Value: limit}},
+	)
+	if filter.Department == "" {
+		pipeline = append(pipeline, userLookup("user_id", "user")...)
+	}
+
+	var rows []struct {
+		User          *domain.UserSummary `bson:"user,omitempty"`
+		LeaveType     domain.LeaveType    `bson:"leave_type"`
+		UserID        domain.ID           `bson:"user_id"`
+		TotalDays     float64             `bson:"total_days"`
+		RemainingDays float64             `bson:"remaining_days"`
+	}
+	if err := aggregateAll(ctx, r.balances, pipeline, &rows); err != nil {
+		return nil, err
+	}
+
+	risks := make([]domain.CarryOverRisk, 0, len(rows))
+	for _, row := range rows {
+		risks = append(risks, domain.CarryOverRisk{
+			User: row.User, LeaveType: row.LeaveType, UserID: row.UserID, TotalDays: row.TotalDays, RemainingDays: row.RemainingDays,
+		})
+	}
+	return risks, nil
+}
+
+// requestReportPipeline เลือกใบลาในปีของรายงานตามสถานะและประเภทการลา — ถ้ากรองแผนกจะ join ผู้ยื่นเป็น requester
+func requestReportPipeline(filter domain.ReportFilter, statuses ...domain.LeaveStatus) mongo.Pipeline {
+	start, end := filter.Period()
+	match := bson.M{
+		"status":     bson.M{"$in": statuses},
+		"start_date": bson.M{"$gte": start, "$lt": end},
+	}
+	if filter.LeaveType != "" {
+		match["leave_type"] = filter.LeaveType
+	}
+
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: match}}}
+	if filter.Department != "" {
+		pipeline = append(pipeline, departmentMatch("user_id", "requester", filter.Department)...)
+	}
+	return pipeline
+}
+
+// departmentMatch join ผู้ใช้จาก localField เป็น as แล้วเหลือเฉพาะ document ของแผนกที่ระบุ
+func departmentMatch(localField, as, department string) mongo.Pipeline {
+	return append(userLookup(localField, as), bson.D{{Key: "$match", Value: bson.M{as + ".department": department}}})
+}
+
+func aggregateAll(ctx context.Context, col *mongo.Collection, pipeline mongo.Pipeline, results any) error {
+	cursor, err := col.Aggregate(ctx, pipeline)
+	if err != nil {
+		return fmt.Errorf("สร้างรายงานล้มเหลว: %w", err)
+	}
+	if err := cursor.All(ctx, results); err != nil {
+		return fmt.Errorf("อ่านผลรายงานล้มเหลว: %w", err)
+	}
+	return nil
+}
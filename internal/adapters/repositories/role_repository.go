@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type roleRepository struct {
+	collection *mongo.Collection
+}
+
+// NewRoleRepository ใช้ชื่อบทบาทเป็น _id — ไม่ต้องสร้าง index เพิ่ม
+func NewRoleRepository(db *database.MongoDB) ports.RoleRepository {
+	return &roleRepository{collection: db.Database.Collection("roles")}
+}
+
+// FindAll ดึงบทบาททั้งหมด เรียงตามชื่อ
+func (r *roleRepository) FindAll(ctx context.Context) ([]domain.RoleDefinition, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: 1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาบทบาทล้มเหลว: %w", err)
+	}
+
+	roles := make([]domain.RoleDefinition, 0)
+	if err = cursor.All(ctx, &roles); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลบทบาทล้มเหลว: %w", err)
+	}
+
+	return roles, nil
+}
+
+// FindByName ค้นหาบทบาทจากชื่อ
+func (r *roleRepository) FindByName(ctx context.Context, name domain.Role) (*domain.RoleDefinition, error) {
+	var role domain.RoleDefinition
+
+	err := r.collection.FindOne(ctx, bson.M{"_id": name}).Decode(&role)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("ค้นหาบทบาทล้มเหลว: %w", err)
+	}
+
+	return &role, nil
+}
+
+// Save สร้างหรือแก้ไขบทบาทแบบ atomic (upsert) — created_at และ built_in กำหนดครั้งเดียวตอนสร้าง
+func (r *roleRepository) Save(ctx context.Context, role *domain.RoleDefinition) (*domain.RoleDefinition, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"description": role.Description,
+			"permissions": role.Permissions,
+			"updated_at":  role.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{
+			"built_in":   role.BuiltIn,
+			"created_at": role.CreatedAt,
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var saved domain.RoleDefinition
+	if err := r.collection.FindOneAndUpdate(ctx, bson.M{"_id": role.Name}, update, opts).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("บันทึกบทบาทล้มเหลว: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// InsertIfMissing สร้างบทบาทเฉพาะเมื่อยังไม่มี — ทุก field อยู่ใน $setOnInsert จึงไม่ทับค่าที่แก้ไว้
+func (r *roleRepository) InsertIfMissing(ctx context.Context, role *domain.RoleDefinition) error {
+	update := bson.M{"$setOnInsert": bson.M{
+		"description": role.Description,
+		"permissions": role.Permissions,
+		"built_in":    role.BuiltIn,
+		"created_at":  role.CreatedAt,
+		"updated_at":  role.UpdatedAt,
+	}}
+
+	_, err := r.collection.UpdateOne(ctx, bson.M{"_id": role.Name}, update, options.UpdateOne().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("สร้างบทบาทเริ่มต้นล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// Delete ลบบทบาทที่สร้างเอง — บทบาทเริ่มต้นไม่ตรงกับ filter จึงไม่ถูกลบ
+func (r *roleRepository) Delete(ctx context.Context, name domain.Role) error {
+	filter := bson.M{
+		"_id":      name,
+		"built_in": false,
+	}
+
+	result, err := r.collection.DeleteOne(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("ลบบทบาทล้มเหลว: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrRoleNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type securityEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSecurityEventRepository(db *database.MongoDB) ports.SecurityEventRepository {
+	col := db.Database.Collection("security_events")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "user_id", Value: 1}, {Key: "created_at", Value: -1}}}, // ดูเหตุการณ์ของผู้ใช้ เรียงจากล่าสุด
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},                             // ดูเหตุการณ์ทั้งหมด เรียงจากล่าสุด
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index security_events ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &securityEventRepository{collection: col}
+}
+
+// Record บันทึกเหตุการณ์ด้านความปลอดภัย
+func (r *securityEventRepository) Record(ctx context.Context, event *domain.SecurityEvent) error {
+	if _, err := r.collection.InsertOne(ctx, event); err != nil {
+		return fmt.Errorf("บันทึกเหตุการณ์ด้านความปลอดภัยล้มเหลว: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type serviceAccountRepository struct {
+	collection *mongo.Collection
+}
+
+func NewServiceAccountRepository(db *database.MongoDB) ports.ServiceAccountRepository {
+	col := db.Database.Collection("service_accounts")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "key_hash", Value: 1}}, Options: options.Index().SetUnique(true)}, // ค้นหาจาก hash ทุก request ของ integration
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},                                        // ดูรายการทั้งหมด เรียงจากใหม่ไปเก่า
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index service_accounts ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &serviceAccountRepository{collection: col}
+}
+
+// Create บันทึก service account ใหม่
+func (r *serviceAccountRepository) Create(ctx context.Context, account *domain.ServiceAccount) error {
+	if _, err := r.collection.InsertOne(ctx, account); err != nil {
+		return fmt.Errorf("บันทึก service account ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByKeyHash ค้นหา service account จาก hash ของ API key
+func (r *serviceAccountRepository) FindByKeyHash(ctx context.Context, keyHash string) (*domain.ServiceAccount, error) {
+	var account domain.ServiceAccount
+	filter := bson.M{"key_hash": keyHash}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&account)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("ค้นหา service account ล้มเหลว: %w", err)
+	}
+
+	return &account, nil
+}
+
+// FindAll ดึง service account ทั้งหมด เรียงจากใหม่ไปเก่า
+func (r *serviceAccountRepository) FindAll(ctx context.Context) ([]domain.ServiceAccount, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	cursor, err := r.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหา service account ล้มเหลว: %w", err)
+	}
+
+	accounts := make([]domain.ServiceAccount, 0)
+	if err = cursor.All(ctx, &accounts); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูล service account ล้มเหลว: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// Revoke ยกเลิก service account ที่ยังใช้งานอยู่
+func (r *serviceAccountRepository) Revoke(ctx context.Context, id domain.ID) error {
+	filter := bson.M{
+		"_id":        id,
+		"revoked_at": nil,
+	}
+	update := bson.M{"$set": bson.M{"revoked_at": time.Now()}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("ยกเลิก service account ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrServiceAccountNotFound
+	}
+
+	return nil
+}
+
+// TouchLastUsed บันทึกเวลาที่ใช้ API key ล่าสุด
+func (r *serviceAccountRepository) TouchLastUsed(ctx context.Context, id domain.ID, usedAt time.Time) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{"last_used_at": usedAt}}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("บันทึกเวลาใช้ API key ล้มเหลว: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// userRevocation การยกเลิกทุก token ของผู้ใช้
+type userRevocation struct {
+	revokedAt time.Time // token ที่ออกก่อนเวลานี้ (ละเอียดถึงมิลลิวินาที) ใช้ไม่ได้
+	expiresAt time.Time // เวลาที่ลบรายการนี้ได้
+}
+
+type memoryTokenRevocationStore struct {
+	tokens    map[string]time.Time // jti → เวลาหมดอายุของ token
+	users     map[domain.ID]userRevocation
+	accessTTL time.Duration
+	mu        sync.RWMutex
+}
+
+// NewMemoryTokenRevocationStore สร้าง denylist ใน memory — เหมาะกับ instance เดียวหรือการพัฒนา local
+// (ข้อมูลหายเมื่อ restart และไม่แชร์ระหว่างหลาย instance)
+func NewMemoryTokenRevocationStore(accessTTL time.Duration) ports.TokenRevocationStore {
+	return &memoryTokenRevocationStore{
+		tokens:    make(map[string]time.Time),
+		users:     make(map[domain.ID]userRevocation),
+		accessTTL: accessTTL,
+	}
+}
+
+// RevokeToken เพิ่ม jti ลงใน denylist จนถึงเวลาที่ token หมดอายุ
+func (s *memoryTokenRevocationStore) RevokeToken(_ context.Context, tokenID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneExpired(time.Now())
+	s.tokens[tokenID] = expiresAt
+	return nil
+}
+
+// RevokeUserTokens ยกเลิก token ทุกตัวของผู้ใช้ที่ออกก่อน revokedAt
+func (s *memoryTokenRevocationStore) RevokeUserTokens(_ context.Context, userID domain.ID, revokedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneExpired(time.Now())
+	s.users[userID] = userRevocation{
+		revokedAt: revokedAt.Truncate(time.Millisecond), // เท่ากับความละเอียดของ iat_ms และ MongoDB
+		expiresAt: revokedAt.Add(s.accessTTL),
+	}
+	return nil
+}
+
+// IsRevoked ตรวจสอบว่า token ถูกยกเลิกราย token หรือรายผู้ใช้หรือไม่
+func (s *memoryTokenRevocationStore) IsRevoked(_ context.Context, claims *domain.TokenClaims) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.tokens[claims.TokenID]; ok {
+		return true, nil
+	}
+
+	if revocation, ok := s.users[claims.UserID]; ok && claims.IssuedAt.Before(revocation.revokedAt) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// pruneExpired ลบรายการที่หมดอายุแล้ว — เรียกตอนเขียนเพื่อไม่ให้ map โตไม่สิ้นสุด (ต้องถือ lock อยู่แล้ว)
+func (s *memoryTokenRevocationStore) pruneExpired(now time.Time) {
+	for tokenID, expiresAt := range s.tokens {
+		if !now.Before(expiresAt) {
+			delete(s.tokens, tokenID)
+		}
+	}
+	for userID, revocation := range s.users {
+		if !now.Before(revocation.expiresAt) {
+			delete(s.users, userID)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestMemoryTokenRevocationStore_RevokeUserTokensWithinSameSecond(t *testing.T) {
+	store := NewMemoryTokenRevocationStore(15 * time.Minute)
+	userID := domain.NewID()
+	second := time.Now().Truncate(time.Second)
+
+	// token เดิมออกต้นวินาที → ตั้งรหัสผ่านใหม่ (ยกเลิกทุก session) → login ใหม่ในวินาทีเดียวกัน
+	require.NoError(t, store.RevokeUserTokens(context.Background(), userID, second.Add(500*time.Millisecond)))
+
+	oldSession := &domain.TokenClaims{UserID: userID, TokenID: "old", IssuedAt: second.Add(200 * time.Millisecond)}
+	revoked, err := store.IsRevoked(context.Background(), oldSession)
+	require.NoError(t, err)
+	assert.True(t, revoked, "token ที่ออกก่อนการยกเลิกต้องใช้ไม่ได้แม้อยู่ในวินาทีเดียวกัน")
+
+	newLogin := &domain.TokenClaims{UserID: userID, TokenID: "new", IssuedAt: second.Add(700 * time.Millisecond)}
+	revoked, err = store.IsRevoked(context.Background(), newLogin)
+	require.NoError(t, err)
+	assert.False(t, revoked, "token ที่ login ใหม่หลังการยกเลิกต้องใช้ได้")
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+const (
+	revokedTokenKeyPrefix = "jti:"  // key ของการยกเลิกราย token
+	revokedUserKeyPrefix  = "user:" // key ของการยกเลิกทุก token ของผู้ใช้
+)
+
+// revocationDocument รายการใน denylist — ใช้ collection เดียวทั้งราย token และรายผู้ใช้
+type revocationDocument struct {
+	RevokedAt time.Time `bson:"revoked_at"` // เวลาที่ยกเลิก
+	ExpiresAt time.Time `bson:"expires_at"` // เวลาที่ลบรายการนี้ได้ (token ที่เกี่ยวข้องหมดอายุหมดแล้ว)
+	ID        string    `bson:"_id"`        // jti:<token id> หรือ user:<user id>
+}
+
+type tokenRevocationRepository struct {
+	collection *mongo.Collection
+	accessTTL  time.Duration
+}
+
+// NewTokenRevocationRepository สร้าง denylist บน MongoDB — accessTTL ใช้กำหนดว่าต้องเก็บการยกเลิกรายผู้ใช้ไว้นานเท่าไร
+func NewTokenRevocationRepository(db *database.MongoDB, accessTTL time.Duration) ports.TokenRevocationStore {
+	col := db.Database.Collection("token_revocations")
+
+	// TTL index — MongoDB ลบรายการที่ token หมดอายุแล้วให้อัตโนมัติ denylist จึงไม่โตไม่สิ้นสุด
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().SetExpireAfterSeconds(0),
+	}
+	if _, err := col.Indexes().CreateOne(context.Background(), indexModel); err != nil {
+		log.Printf("คำเตือน: สร้าง index token_revocations ไม่สำเร็จ: %v", err)
+	}
+
+	return &tokenRevocationRepository{collection: col, accessTTL: accessTTL}
+}
+
+// RevokeToken เพิ่ม jti ลงใน denylist จนถึงเวลาที่ token หมดอายุ
+func (r *tokenRevocationRepository) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	doc := revocationDocument{
+		ID:        revokedTokenKeyPrefix + tokenID,
+		RevokedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+	return r.upsert(ctx, &doc)
+}
+
+// RevokeUserTokens ยกเลิก token ทุกตัวของผู้ใช้ที่ออกก่อน revokedAt — เก็บไว้นานเท่าอายุ access token
+// เทียบกับ iat_ms ที่ละเอียดถึงมิลลิวินาทีเท่ากับ date ของ MongoDB — token ที่ออกก่อนการยกเลิกในวินาทีเดียวกันใช้ไม่ได้
+// ส่วน token ที่ login ใหม่หลังการยกเลิก (เช่นหลังตั้งรหัสผ่านใหม่) ใช้ได้
+func (r *tokenRevocationRepository) RevokeUserTokens(ctx context.Context, userID domain.ID, revokedAt time.Time) error {
+	revokedAt = revokedAt.Truncate(time.Millisecond)
+	doc := revocationDocument{
+		ID:        revokedUserKeyPrefix + userID.String(),
+		RevokedAt: revokedAt,
+		ExpiresAt: revokedAt.Add(r.accessTTL),
+	}
+	return r.upsert(ctx, &doc)
+}
+
+// IsRevoked ตรวจสอบทั้ง jti และการยกเลิกรายผู้ใช้ในคำสั่งเดียว
+func (r *tokenRevocationRepository) IsRevoked(ctx context.Context, claims *domain.TokenClaims) (bool, error) {
+	filter := bson.M{"_id": bson.M{"$in": bson.A{
+		revokedTokenKeyPrefix + claims.TokenID,
+		revokedUserKeyPrefix + claims.UserID.String(),
+	}}}
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return false, fmt.Errorf("ค้นหา token denylist ล้มเหลว: %w", err)
+	}
+
+	var docs []revocationDocument
+	if err := cursor.All(ctx, &docs); err != nil {
+		return false, fmt.Errorf("อ่านข้อมูล token denylist ล้มเหลว: %w", err)
+	}
+
+	for _, doc := range docs {
+		if doc.ID == revokedTokenKeyPrefix+claims.TokenID {
+			return true, nil
+		}
+		if claims.IssuedAt.Before(doc.RevokedAt) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// upsert บันทึกรายการ denylist (แทนที่ของเดิมถ้ามี)
+func (r *tokenRevocationRepository) upsert(ctx context.Context, doc *revocationDocument) error {
+	filter := bson.M{"_id": doc.ID}
+	opts := options.Replace().SetUpsert(true)
+
+	if _, err := r.collection.ReplaceOne(ctx, filter, doc, opts); err != nil {
+		return fmt.Errorf("บันทึก token denylist ล้มเหลว: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+const helloTimeout = 5 * time.Second
+
+type transactionManager struct {
+	client    *mongo.Client
+	supported bool
+}
+
+// NewTransactionManager สร้าง TransactionManager บน MongoDB — transaction ใช้ได้เฉพาะ Replica Set หรือ sharded cluster
+// บน standalone จะเรียก fn ตรงๆ (แต่ละคำสั่ง atomic เฉพาะตัว และ service ยังคืนสถานะเองเมื่อขั้นตอนถัดไปล้มเหลว)
+func NewTransactionManager(db *database.MongoDB) ports.TransactionManager {
+	supported, err := supportsTransactions(db)
+	if err != nil {
+		log.Printf("คำเตือน: ตรวจสอบการรองรับ transaction ไม่สำเร็จ: %v", err)
+	}
+	if !supported {
+		log.Println("⚠️  MongoDB ไม่ใช่ Replica Set — ใบลา, audit log และ outbox ถูกบันทึกแยกคำสั่ง (ไม่อยู่ใน transaction เดียวกัน)")
+	}
+	return &transactionManager{client: db.Client, supported: supported}
+}
+
+// supportsTransactions ตรวจจากคำสั่ง hello ว่าเชื่อมต่อกับ Replica Set หรือ mongos หรือไม่
+func supportsTransactions(db *database.MongoDB) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), helloTimeout)
+	defer cancel()
+	if err := db.Database.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, err
+	}
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
+}
+
+// WithinTransaction เรียก fn ใน transaction — ctx ที่อยู่ใน transaction อยู่แล้วจะใช้ transaction เดิม
+func (m *transactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if !m.supported || mongo.SessionFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	session, err := m.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("เริ่ม session ของ MongoDB ล้มเหลว: %w", err)
+	}
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(ctx context.Context) (any, error) {
+		return nil, fn(ctx)
+	})
+	return err
+}
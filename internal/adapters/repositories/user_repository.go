@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"time"
 
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
@@ -22,18 +23,32 @@ type userRepository struct {
 func NewUserRepository(db *database.MongoDB) ports.UserRepository {
 	col := db.Database.Collection("users")
 
-	// สร้าง unique index สำหรับ email — ป้องกัน duplicate email ในระดับ database
-	indexModel := mongo.IndexModel{
-		Keys:    bson.D{{Key: "email", Value: 1}},
-		Options: options.Index().SetUnique(true),
+	indexes := []mongo.IndexModel{
+		// unique index สำหรับ email — ป้องกัน duplicate email ในระดับ database
+		{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true)},
+		// บัญชีใน IdP หนึ่งบัญชีผูกกับผู้ใช้ได้คนเดียว — partial index เฉพาะผู้ใช้ที่ผูกกับ IdP
+		{
+			Keys: bson.D{{Key: "auth_provider", Value: 1}, {Key: "external_id", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"external_id": bson.M{"$exists": true}}),
+		},
+		// นับผู้ใช้ตามบทบาทก่อนลบบทบาท
+		{Keys: bson.D{{Key: "role", Value: 1}}},
 	}
-	if _, err := col.Indexes().CreateOne(context.Background(), indexModel); err != nil {
-		log.Printf("คำเตือน: สร้าง index email ไม่สำเร็จ: %v", err)
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index users ไม่สำเร็จ: %v", err)
+		}
 	}
 
 	return &userRepository{collection: col}
 }
 
+// NewUserProfileRepository ใช้ collection เดียวกับ NewUserRepository (index ถูกสร้างที่นั่น)
+func NewUserProfileRepository(db *database.MongoDB) ports.UserProfileRepository {
+	return &userRepository{collection: db.Database.Collection("users")}
+}
+
 // FindByEmail ค้นหาผู้ใช้จากอีเมล
 func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
 	var user domain.User
@@ -49,3 +64,179 @@ func (r *userRepository) FindByEmail(ctx context.Context, email string) (*domain
 
 	return &user, nil
 }
+
+// FindByID ค้นหาผู้ใช้จากรหัส
+func (r *userRepository) FindByID(ctx context.Context, id domain.ID) (*domain.User, error) {
+	var user domain.User
+	filter := bson.M{"_id": id}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("ค้นหาผู้ใช้จากรหัสล้มเหลว: %w", err)
+	}
+
+	return &user, nil
+}
+
+// FindByIDs ค้นหาผู้ใช้หลายคนใน query เดียว — ใช้แนบข้อมูลผู้ใช้กับรายการโดยไม่ค้นทีละคน
+func (r *userRepository) FindByIDs(ctx context.Context, ids []domain.ID) ([]domain.User, error) {
+	users := []domain.User{}
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	cursor, err := r.collection.Find(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาผู้ใช้จากรหัสล้มเหลว: %w", err)
+	}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลผู้ใช้ล้มเหลว: %w", err)
+	}
+	return users, nil
+}
+
+// FindByExternalID ค้นหาผู้ใช้จากบัญชีใน IdP
+func (r *userRepository) FindByExternalID(ctx context.Context, provider, subject string) (*domain.User, error) {
+	var user domain.User
+	filter := bson.M{
+		"auth_provider": provider,
+		"external_id":   subject,
+	}
+
+	err := r.collection.FindOne(ctx, filter).Decode(&user)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("ค้นหาผู้ใช้จากบัญชี IdP ล้มเหลว: %w", err)
+	}
+
+	return &user, nil
+}
+
+// Create สร้างผู้ใช้ใหม่
+func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
+	if _, err := r.collection.InsertOne(ctx, user); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return domain.ErrEmailAlreadyExists
+		}
+		return fmt.Errorf("สร้างผู้ใช้ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// UpdatePassword เปลี่ยน password hash ของผู้ใช้
+func (r *userRepository) UpdatePassword(ctx context.Context, id domain.ID, passwordHash string) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{
+		"password_hash": passwordHash,
+		"updated_at":    time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("เปลี่ยนรหัสผ่านล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateExternalIdentity บันทึกการผูกบัญชีกับ IdP พร้อมชื่อและบทบาทล่าสุด
+func (r *userRepository) UpdateExternalIdentity(ctx context.Context, user *domain.User) error {
+	filter := bson.M{"_id": user.ID}
+	update := bson.M{"$set": bson.M{
+		"password_hash": user.PasswordHash,
+		"auth_provider": user.AuthProvider,
+		"external_id":   user.ExternalID,
+		"first_name":    user.FirstName,
+		"last_name":     user.LastName,
+		"full_name":     user.FullName,
+		"role":          user.Role,
+		"updated_at":    user.UpdatedAt,
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("อัปเดตข้อมูลผู้ใช้จาก IdP ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateRole เปลี่ยนบทบาทของผู้ใช้
+func (r *userRepository) UpdateRole(ctx context.Context, id domain.ID, role domain.Role) error {
+	filter := bson.M{"_id": id}
+	update := bson.M{"$set": bson.M{
+		"role":       role,
+		"updated_at": time.Now(),
+	}}
+
+	result, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return fmt.Errorf("เปลี่ยนบทบาทผู้ใช้ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// UpdateProfile บันทึกชื่อ แผนก และบทบาท — แผนกที่ว่างถูกลบออกจาก document ให้ตรงกับ omitempty
+func (r *userRepository) UpdateProfile(ctx context.Context, user *domain.User) error {
+	set := bson.M{
+		"first_name": user.FirstName,
+		"last_name":  user.LastName,
+		"full_name":  user.FullName,
+		"role":       user.Role,
+		"updated_at": user.UpdatedAt,
+	}
+	update := bson.M{"$set": set}
+	if user.Department != "" {
+		set["department"] = user.Department
+	} else {
+		update["$unset"] = bson.M{"department": ""}
+	}
+
+	result, err := r.collection.UpdateOne(ctx, bson.M{"_id": user.ID}, update)
+	if err != nil {
+		return fmt.Errorf("แก้ข้อมูลผู้ใช้ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// CountByRole นับจำนวนผู้ใช้ในบทบาท
+func (r *userRepository) CountByRole(ctx context.Context, role domain.Role) (int64, error) {
+	count, err := r.collection.CountDocuments(ctx, bson.M{"role": role})
+	if err != nil {
+		return 0, fmt.Errorf("นับผู้ใช้ตามบทบาทล้มเหลว: %w", err)
+	}
+	return count, nil
+}
+
+// FindByRoles ค้นหาผู้ใช้ทั้งหมดในบทบาทที่ระบุ
+func (r *userRepository) FindByRoles(ctx context.Context, roles []domain.Role) ([]domain.User, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{"role": bson.M{"$in": roles}})
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาผู้ใช้ตามบทบาทล้มเหลว: %w", err)
+	}
+
+	users := []domain.User{}
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลผู้ใช้ล้มเหลว: %w", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type webhookDeliveryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookDeliveryRepository(db *database.MongoDB) ports.WebhookDeliveryRepository {
+	col := db.Database.Collection("webhook_deliveries")
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}}},      // dispatcher หารายการที่ถึงเวลาส่ง
+		{Keys: bson.D{{Key: "subscription_id", Value: 1}, {Key: "created_at", Value: -1}}}, // ดูรายการส่งของ webhook
+		{Keys: bson.D{{Key: "created_at", Value: -1}}},                                     // ดูรายการส่งทั้งหมด เรียงจากใหม่ไปเก่า
+		{
+			Keys:    bson.D{{Key: "event_id", Value: 1}, {Key: "subscription_id", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}, // idempotency — เหตุการณ์เดียวกันจาก outbox สร้างรายการส่งต่อ webhook ได้ครั้งเดียว
+	}
+	for _, idx := range indexes {
+		if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+			log.Printf("คำเตือน: สร้าง index webhook_deliveries ไม่สำเร็จ: %v", err)
+		}
+	}
+
+	return &webhookDeliveryRepository{collection: col}
+}
+
+// CreateMany บันทึกรายการส่งของเหตุการณ์หนึ่ง — insert แบบ unordered เพื่อให้รายการที่ซ้ำ
+// (เหตุการณ์ที่ถูกส่งต่อซ้ำ) ไม่ขวางรายการอื่น และไม่นับเป็น error
+func (r *webhookDeliveryRepository) CreateMany(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	_, err := r.collection.InsertMany(ctx, deliveries, options.InsertMany().SetOrdered(false))
+	if err != nil && !isOnlyDuplicateKeyError(err) {
+		return fmt.Errorf("บันทึกรายการส่ง webhook ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// isOnlyDuplicateKeyError error ของ InsertMany เกิดจาก key ซ้ำทั้งหมดหรือไม่
+func isOnlyDuplicateKeyError(err error) bool {
+	var bulkErr mongo.BulkWriteException
+	if !errors.As(err, &bulkErr) || bulkErr.WriteConcernError != nil || len(bulkErr.WriteErrors) == 0 {
+		return false
+	}
+	for _, we := range bulkErr.WriteErrors {
+		if !mongo.IsDuplicateKeyError(we) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindByID ค้นหารายการส่งจากรหัส
+func (r *webhookDeliveryRepository) FindByID(ctx context.Context, id domain.ID) (*domain.WebhookDelivery, error) {
+	var delivery domain.WebhookDelivery
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&delivery); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("ค้นหารายการส่ง webhook ล้มเหลว: %w", err)
+	}
+	return &delivery, nil
+}
+
+// Search ค้นหารายการส่งตามเงื่อนไข เรียงจากใหม่ไปเก่า
+func (r *webhookDeliveryRepository) Search(
+	ctx context.Context,
+	filter domain.WebhookDeliveryFilter,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.WebhookDelivery], error) {
+	query := bson.M{}
+	if filter.SubscriptionID != nil {
+		query["subscription_id"] = *filter.SubscriptionID
+	}
+	if filter.Status != "" {
+		query["status"] = filter.Status
+	}
+
+	total, err := r.collection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("นับจำนวนรายการส่ง webhook ล้มเหลว: %w", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetSkip(params.Offset()).
+		SetLimit(params.Limit())
+
+	cursor, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหารายการส่ง webhook ล้มเหลว: %w", err)
+	}
+
+	var deliveries []domain.WebhookDelivery
+	if err = cursor.All(ctx, &deliveries); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูลรายการส่ง webhook ล้มเหลว: %w", err)
+	}
+
+	return domain.NewPaginatedResult(deliveries, total, params), nil
+}
+
+// ClaimDue จองรายการที่ถึงเวลาส่งเก่าที่สุดแบบ atomic — เลื่อน next_attempt_at ออกไปเท่ากับ lease
+// ถ้า process ล่มระหว่างส่ง รายการจะถูกหยิบขึ้นมาส่งใหม่หลังหมด lease
+func (r *webhookDeliveryRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+) (*domain.WebhookDelivery, error) {
+	filter := bson.M{
+		"status":          domain.WebhookDeliveryPending,
+		"next_attempt_at": bson.M{"$lte": now},
+	}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_attempt_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var delivery domain.WebhookDelivery
+	if err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&delivery); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("จองรายการส่ง webhook ล้มเหลว: %w", err)
+	}
+	return &delivery, nil
+}
+
+// Update บันทึกผลการส่ง
+func (r *webhookDeliveryRepository) Update(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": delivery.ID}, delivery)
+	if err != nil {
+		return fmt.Errorf("บันทึกรายการส่ง webhook ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrWebhookDeliveryNotFound
+	}
+	return nil
+}
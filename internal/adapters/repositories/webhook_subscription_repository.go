@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+	"github/be2bag/leave-management-system/internal/infrastructure/database"
+)
+
+type webhookSubscriptionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewWebhookSubscriptionRepository(db *database.MongoDB) ports.WebhookSubscriptionRepository {
+	col := db.Database.Collection("webhook_subscriptions")
+
+	// หา webhook ที่รับเหตุการณ์ทุกครั้งที่มีใบลาเปลี่ยนแปลง
+	idx := mongo.IndexModel{Keys: bson.D{{Key: "events", Value: 1}, {Key: "active", Value: 1}}}
+	if _, err := col.Indexes().CreateOne(context.Background(), idx); err != nil {
+		log.Printf("คำเตือน: สร้าง index webhook_subscriptions ไม่สำเร็จ: %v", err)
+	}
+
+	return &webhookSubscriptionRepository{collection: col}
+}
+
+// Create บันทึก webhook ใหม่
+func (r *webhookSubscriptionRepository) Create(ctx context.Context, sub *domain.WebhookSubscription) error {
+	if _, err := r.collection.InsertOne(ctx, sub); err != nil {
+		return fmt.Errorf("บันทึก webhook ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// FindByID ค้นหา webhook จากรหัส
+func (r *webhookSubscriptionRepository) FindByID(ctx context.Context, id domain.ID) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&sub); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, fmt.Errorf("ค้นหา webhook ล้มเหลว: %w", err)
+	}
+	return &sub, nil
+}
+
+// FindAll ดึง webhook ทั้งหมด เรียงจากใหม่ไปเก่า
+func (r *webhookSubscriptionRepository) FindAll(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	return r.find(ctx, bson.M{}, opts)
+}
+
+// FindActiveByEvent ดึง webhook ที่เปิดอยู่และรับเหตุการณ์นี้
+func (r *webhookSubscriptionRepository) FindActiveByEvent(
+	ctx context.Context,
+	eventType domain.LeaveEventType,
+) ([]domain.WebhookSubscription, error) {
+	return r.find(ctx, bson.M{"events": eventType, "active": true}, options.Find())
+}
+
+func (r *webhookSubscriptionRepository) find(
+	ctx context.Context,
+	filter bson.M,
+	opts *options.FindOptionsBuilder,
+) ([]domain.WebhookSubscription, error) {
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหา webhook ล้มเหลว: %w", err)
+	}
+
+	subs := make([]domain.WebhookSubscription, 0)
+	if err = cursor.All(ctx, &subs); err != nil {
+		return nil, fmt.Errorf("อ่านข้อมูล webhook ล้มเหลว: %w", err)
+	}
+	return subs, nil
+}
+
+// Update บันทึกการแก้ไข webhook
+func (r *webhookSubscriptionRepository) Update(ctx context.Context, sub *domain.WebhookSubscription) error {
+	result, err := r.collection.ReplaceOne(ctx, bson.M{"_id": sub.ID}, sub)
+	if err != nil {
+		return fmt.Errorf("แก้ไข webhook ล้มเหลว: %w", err)
+	}
+	if result.MatchedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
+
+// Delete ลบ webhook
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id domain.ID) error {
+	result, err := r.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return fmt.Errorf("ลบ webhook ล้มเหลว: %w", err)
+	}
+	if result.DeletedCount == 0 {
+		return domain.ErrWebhookNotFound
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// maxResponseBody ขนาด response ที่อ่านทิ้งสูงสุด — ให้ connection กลับไปใช้ซ้ำได้โดยไม่อ่านข้อมูลไม่จำกัด
+const maxResponseBody = 64 * 1024
+
+type httpSender struct {
+	client    *http.Client
+	userAgent string
+}
+
+// NewHTTPSender สร้าง WebhookSender ที่ส่ง HTTP POST — ไม่ตาม redirect เพื่อไม่ส่ง payload ไปยังปลายทางที่ไม่ได้ลงทะเบียน
+func NewHTTPSender(userAgent string) ports.WebhookSender {
+	return &httpSender{
+		client: &http.Client{
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		userAgent: userAgent,
+	}
+}
+
+// Send ส่ง body ไปยัง url — เวลารอสูงสุดกำหนดด้วย ctx
+func (s *httpSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("สร้าง request ของ webhook ล้มเหลว: %w", err)
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("User-Agent", s.userAgent)
+
+	resp, err := s.client.Do(req) //nolint:gosec // URL มาจาก webhook ที่ผู้ดูแลระบบลงทะเบียน
+	if err != nil {
+		return 0, fmt.Errorf("ส่ง webhook ล้มเหลว: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	return resp.StatusCode, nil
+}
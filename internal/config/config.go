@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -13,8 +14,62 @@ type Config struct {
 	MongoURI    string // MongoDB connection string
 	MongoDBName string // ชื่อฐานข้อมูล
 
-	JWTSecret      string // คีย์ลับสำหรับ sign JWT token (ต้องเปลี่ยนใน production!)
-	JWTExpireHours string // จำนวนชั่วโมงก่อน token หมดอายุ
+	JWTSecret              string // คีย์ลับสำหรับ sign JWT แบบ HS256 — ถ้ามี JWT_SIGNING_KEY_FILE จะใช้ตรวจสอบ token เก่าอย่างเดียว
+	JWTSigningKeyFile      string // path ของ private key (PEM, RSA หรือ Ed25519) ที่ใช้ sign token ใหม่
+	JWTAcceptLegacyHS256   string // "true" = ยังยอมรับ token HS256 จาก JWT_SECRET หลังเปลี่ยนไปใช้ JWT_SIGNING_KEY_FILE (ชั่วคราวระหว่างย้ายระบบ)
+	JWTVerificationKeys    string // path ของคีย์เก่าที่ยังต้องตรวจสอบได้ระหว่าง rotate (คั่นด้วย comma)
+	JWTAccessExpireMinutes string // จำนวนนาทีก่อน access token หมดอายุ
+	JWTRefreshExpireHours  string // จำนวนชั่วโมงก่อน refresh token หมดอายุ
+
+	TokenRevocationStore string // ที่เก็บ token denylist: mongo (default) หรือ memory (instance เดียวเท่านั้น)
+
+	LoginMaxFailedAttempts string // จำนวนครั้งที่ login ผิดติดต่อกันก่อนล็อกบัญชี
+	LoginLockoutMinutes    string // จำนวนนาทีที่ล็อกบัญชีครั้งแรก (ล็อกซ้ำนานขึ้นเป็นเท่าตัว)
+
+	MFARequiredPermissions string // สิทธิ์ที่ต้องยืนยัน 2FA — บทบาทที่มีสิทธิ์เหล่านี้ต้องผ่าน 2FA (คั่นด้วย comma หรือ none = ไม่บังคับ)
+	MFARequiredRoles       string // ค่าเดิมที่ยกเลิกแล้ว — ถ้ากำหนดไว้ระบบจะไม่เริ่มทำงาน
+	MFAIssuer              string // ชื่อระบบที่แสดงในแอป authenticator
+
+	OIDCIssuerURL    string // issuer ของ identity provider (ว่าง = ปิด SSO)
+	OIDCClientID     string // client ID ที่ลงทะเบียนไว้กับ IdP
+	OIDCClientSecret string // client secret
+	OIDCRedirectURL  string // URL ของ /api/v1/auth/oidc/callback ที่ลงทะเบียนไว้กับ IdP
+	OIDCScopes       string // scopes ที่ขอ (คั่นด้วยช่องว่าง)
+	OIDCGroupsClaim  string // ชื่อ claim ที่เก็บรายชื่อกลุ่ม
+	OIDCRoleMapping  string // กลุ่ม=บทบาท คั่นด้วย comma เช่น hr-managers=manager,it-admins=admin
+	OIDCDefaultRole  string // บทบาทของผู้ใช้ที่ไม่อยู่ในกลุ่มใดที่กำหนด
+
+	LDAPURL          string // ldap:// หรือ ldaps:// ของ directory (ว่าง = ปิด)
+	LDAPStartTLS     string // "true" = ใช้ StartTLS กับ ldap://
+	LDAPBindDN       string // service account สำหรับค้นหาผู้ใช้
+	LDAPBindPassword string // รหัสผ่านของ service account
+	LDAPBaseDN       string // จุดเริ่มค้นหาผู้ใช้
+	LDAPUserFilter   string // filter ค้นหาผู้ใช้ ({email} = อีเมลที่กรอก)
+	LDAPIDAttribute  string // attribute ที่ใช้เป็นรหัสผู้ใช้ถาวร
+	LDAPGroupBaseDN  string // จุดเริ่มค้นหากลุ่ม (ใช้คู่กับ LDAPGroupFilter)
+	LDAPGroupFilter  string // filter ค้นหากลุ่ม ({dn} = DN ของผู้ใช้) — ว่าง = อ่านจาก memberOf
+	LDAPEmailDomains string // โดเมนอีเมลที่ยืนยันตัวตนกับ directory คั่นด้วย comma
+	LDAPRoleMapping  string // กลุ่ม=บทบาท คั่นด้วย comma
+	LDAPDefaultRole  string // บทบาทของผู้ใช้ที่ไม่อยู่ในกลุ่มใดที่กำหนด
+
+	PasswordMinLength          string // ความยาวขั้นต่ำของรหัสผ่านใหม่
+	PasswordRequire            string // ชนิดตัวอักษรที่รหัสผ่านใหม่ต้องมี (คั่นด้วย comma: upper, lower, digit, symbol)
+	PasswordResetExpireMinutes string // จำนวนนาทีก่อนลิงก์ตั้งรหัสผ่านใหม่หมดอายุ
+	PasswordResetURL           string // URL หน้าเว็บตั้งรหัสผ่านใหม่ — token จะถูกต่อท้าย
+
+	Mailer        string // วิธีส่งอีเมล: console (default), file หรือ smtp
+	MailerFileDir string // โฟลเดอร์เก็บไฟล์อีเมลเมื่อใช้ MAILER=file
+	MailFrom      string // อีเมลผู้ส่ง
+	SMTPHost      string // SMTP server เมื่อใช้ MAILER=smtp
+	SMTPPort      string // พอร์ต SMTP (587 = STARTTLS)
+	SMTPUsername  string // ชื่อผู้ใช้ SMTP (ว่าง = ไม่ยืนยันตัวตน)
+	SMTPPassword  string // รหัสผ่าน SMTP
+
+	NotificationLocale string // ภาษาเริ่มต้นของอีเมลแจ้งเตือน: th หรือ en
+
+	IdempotencyKeyTTLHours string // จำนวนชั่วโมงที่เก็บ response ของ Idempotency-Key ไว้ตอบ request ที่ส่งซ้ำ
+
+	LeaveCarryOverMaxDays string // จำนวนวันลาพักร้อนคงเหลือที่ยกไปปีถัดไปได้ (ใช้ในรายงานวันลาที่เสี่ยงหาย)
 
 	CORSOrigins string // อนุญาต origins (default: * สำหรับ development เท่านั้น)
 }
@@ -23,24 +78,134 @@ func Load() (*Config, error) {
 	godotenv.Load() //nolint:errcheck // .env file is optional
 
 	cfg := &Config{
-		ServerPort:     getEnv("SERVER_PORT", "8080"),
-		MongoURI:       getEnv("MONGO_URI", "mongodb://localhost:27017"),
-		MongoDBName:    getEnv("MONGO_DB_NAME", "leave_management"),
-		JWTSecret:      getEnv("JWT_SECRET", ""),
-		JWTExpireHours: getEnv("JWT_EXPIRE_HOURS", "24"),
-		CORSOrigins:    getEnv("CORS_ORIGINS", "*"),
+		ServerPort:             getEnv("SERVER_PORT", "8080"),
+		MongoURI:               getEnv("MONGO_URI", "mongodb://localhost:27017"),
+		MongoDBName:            getEnv("MONGO_DB_NAME", "leave_management"),
+		JWTSecret:              getEnv("JWT_SECRET", ""),
+		JWTSigningKeyFile:      getEnv("JWT_SIGNING_KEY_FILE", ""),
+		JWTAcceptLegacyHS256:   getEnv("JWT_ACCEPT_LEGACY_HS256", "false"),
+		JWTVerificationKeys:    getEnv("JWT_VERIFICATION_KEY_FILES", ""),
+		JWTAccessExpireMinutes: getEnv("JWT_ACCESS_EXPIRE_MINUTES", "15"),
+		JWTRefreshExpireHours:  getEnv("JWT_REFRESH_EXPIRE_HOURS", "168"),
+		TokenRevocationStore:   getEnv("TOKEN_REVOCATION_STORE", "mongo"),
+		CORSOrigins:            getEnv("CORS_ORIGINS", "*"),
+
+		LoginMaxFailedAttempts: getEnv("LOGIN_MAX_FAILED_ATTEMPTS", "5"),
+		LoginLockoutMinutes:    getEnv("LOGIN_LOCKOUT_MINUTES", "15"),
+
+		MFARequiredPermissions: getEnv("MFA_REQUIRED_PERMISSIONS", "leave.approve,user.manage,balance.adjust"),
+		MFARequiredRoles:       getEnv("MFA_REQUIRED_ROLES", ""),
+		MFAIssuer:              getEnv("MFA_ISSUER", "Leave Management System"),
+
+		OIDCIssuerURL:    getEnv("OIDC_ISSUER_URL", ""),
+		OIDCClientID:     getEnv("OIDC_CLIENT_ID", ""),
+		OIDCClientSecret: getEnv("OIDC_CLIENT_SECRET", ""),
+		OIDCRedirectURL:  getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/v1/auth/oidc/callback"),
+		OIDCScopes:       getEnv("OIDC_SCOPES", "openid profile email groups"),
+		OIDCGroupsClaim:  getEnv("OIDC_GROUPS_CLAIM", "groups"),
+		OIDCRoleMapping:  getEnv("OIDC_ROLE_MAPPING", ""),
+		OIDCDefaultRole:  getEnv("OIDC_DEFAULT_ROLE", "employee"),
+
+		LDAPURL:          getEnv("LDAP_URL", ""),
+		LDAPStartTLS:     getEnv("LDAP_START_TLS", "false"),
+		LDAPBindDN:       getEnv("LDAP_BIND_DN", ""),
+		LDAPBindPassword: getEnv("LDAP_BIND_PASSWORD", ""),
+		LDAPBaseDN:       getEnv("LDAP_BASE_DN", ""),
+		LDAPUserFilter:   getEnv("LDAP_USER_FILTER", "(&(objectClass=person)(mail={email}))"),
+		LDAPIDAttribute:  getEnv("LDAP_ID_ATTRIBUTE", "uid"),
+		LDAPGroupBaseDN:  getEnv("LDAP_GROUP_BASE_DN", ""),
+		LDAPGroupFilter:  getEnv("LDAP_GROUP_FILTER", ""),
+		LDAPEmailDomains: getEnv("LDAP_EMAIL_DOMAINS", ""),
+		LDAPRoleMapping:  getEnv("LDAP_ROLE_MAPPING", ""),
+		LDAPDefaultRole:  getEnv("LDAP_DEFAULT_ROLE", "employee"),
+
+		PasswordMinLength:          getEnv("PASSWORD_MIN_LENGTH", "10"),
+		PasswordRequire:            getEnv("PASSWORD_REQUIRE", "upper,lower,digit"),
+		PasswordResetExpireMinutes: getEnv("PASSWORD_RESET_EXPIRE_MINUTES", "30"),
+		PasswordResetURL:           getEnv("PASSWORD_RESET_URL", "http://localhost:3000/reset-password?token="),
+
+		Mailer:        getEnv("MAILER", "console"),
+		MailerFileDir: getEnv("MAILER_FILE_DIR", "./tmp/mail"),
+		MailFrom:      getEnv("MAIL_FROM", "no-reply@company.com"),
+		SMTPHost:      getEnv("SMTP_HOST", ""),
+		SMTPPort:      getEnv("SMTP_PORT", "587"),
+		SMTPUsername:  getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:  getEnv("SMTP_PASSWORD", ""),
+
+		NotificationLocale: getEnv("NOTIFICATION_LOCALE", "th"),
+
+		IdempotencyKeyTTLHours: getEnv("IDEMPOTENCY_KEY_TTL_HOURS", "24"),
+
+		LeaveCarryOverMaxDays: getEnv("LEAVE_CARRY_OVER_MAX_DAYS", "5"),
 	}
 
-	if cfg.JWTSecret == "" {
-		return nil, fmt.Errorf("JWT_SECRET ต้องถูกกำหนดค่า (environment variable หรือ .env)")
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// validate ตรวจค่าที่จำเป็นและค่าที่ขัดแย้งกัน
+func (cfg *Config) validate() error {
+	if cfg.JWTSecret == "" && cfg.JWTSigningKeyFile == "" {
+		return fmt.Errorf("JWT_SECRET หรือ JWT_SIGNING_KEY_FILE ต้องถูกกำหนดค่า (environment variable หรือ .env)")
 	}
 
 	const minSecretLength = 32
-	if len(cfg.JWTSecret) < minSecretLength {
-		return nil, fmt.Errorf("JWT_SECRET ต้องมีความยาวอย่างน้อย %d ตัวอักษร (ปัจจุบัน: %d)", minSecretLength, len(cfg.JWTSecret))
+	if cfg.JWTSecret != "" && len(cfg.JWTSecret) < minSecretLength {
+		return fmt.Errorf("JWT_SECRET ต้องมีความยาวอย่างน้อย %d ตัวอักษร (ปัจจุบัน: %d)", minSecretLength, len(cfg.JWTSecret))
+	}
+	// JWT_SECRET ที่ค้างไว้หลังย้ายไปใช้ private key จะเป็น verifier ของ HS256 ตลอดไปโดยไม่มีใครตั้งใจ
+	if cfg.JWTSecret != "" && cfg.JWTSigningKeyFile != "" && cfg.JWTAcceptLegacyHS256 != "true" {
+		return fmt.Errorf("กำหนด JWT_SECRET และ JWT_SIGNING_KEY_FILE พร้อมกันไม่ได้ — ลบ JWT_SECRET " +
+			"หรือตั้ง JWT_ACCEPT_LEGACY_HS256=true ระหว่างรอ token HS256 เดิมหมดอายุ")
 	}
 
-	return cfg, nil
+	if cfg.TokenRevocationStore != "mongo" && cfg.TokenRevocationStore != "memory" {
+		return fmt.Errorf("TOKEN_REVOCATION_STORE ต้องเป็น mongo หรือ memory (ปัจจุบัน: %s)", cfg.TokenRevocationStore)
+	}
+
+	if err := cfg.validateMailer(); err != nil {
+		return err
+	}
+
+	if cfg.OIDCIssuerURL != "" && cfg.OIDCClientID == "" {
+		return fmt.Errorf("OIDC_CLIENT_ID ต้องถูกกำหนดค่าเมื่อเปิดใช้ OIDC_ISSUER_URL")
+	}
+
+	return cfg.validateLDAP()
+}
+
+// validateMailer ตรวจวิธีส่งอีเมลและภาษาของการแจ้งเตือน
+func (cfg *Config) validateMailer() error {
+	switch cfg.Mailer {
+	case "console", "file":
+	case "smtp":
+		if cfg.SMTPHost == "" {
+			return fmt.Errorf("SMTP_HOST ต้องถูกกำหนดค่าเมื่อใช้ MAILER=smtp")
+		}
+	default:
+		return fmt.Errorf("MAILER ต้องเป็น console, file หรือ smtp (ปัจจุบัน: %s)", cfg.Mailer)
+	}
+
+	if cfg.NotificationLocale != "th" && cfg.NotificationLocale != "en" {
+		return fmt.Errorf("NOTIFICATION_LOCALE ต้องเป็น th หรือ en (ปัจจุบัน: %s)", cfg.NotificationLocale)
+	}
+	return nil
+}
+
+// validateLDAP ตรวจค่าตั้งค่า LDAP เมื่อเปิดใช้
+func (cfg *Config) validateLDAP() error {
+	if cfg.LDAPURL == "" {
+		return nil
+	}
+	if cfg.LDAPBaseDN == "" || !strings.Contains(cfg.LDAPUserFilter, "{email}") {
+		return fmt.Errorf("LDAP_BASE_DN ต้องถูกกำหนดค่า และ LDAP_USER_FILTER ต้องมี {email} เมื่อเปิดใช้ LDAP_URL")
+	}
+	if cfg.LDAPGroupFilter != "" && (cfg.LDAPGroupBaseDN == "" || !strings.Contains(cfg.LDAPGroupFilter, "{dn}")) {
+		return fmt.Errorf("LDAP_GROUP_FILTER ต้องมี {dn} และต้องกำหนด LDAP_GROUP_BASE_DN")
+	}
+	return nil
 }
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,252 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// AuditAction การกระทำที่ถูกบันทึกใน audit log — รูปแบบ <resource>.<action>
+type AuditAction string
+
+const (
+	AuditLoginSucceeded        AuditAction = "auth.login_succeeded"    // เข้าสู่ระบบสำเร็จ (รหัสผ่าน, 2FA หรือ SSO)
+	AuditLoginFailed           AuditAction = "auth.login_failed"       // รหัสผ่านหรือรหัส 2FA ไม่ถูกต้อง
+	AuditLeaveSubmitted        AuditAction = "leave.submitted"         // ยื่นใบลา
+	AuditLeaveApproved         AuditAction = "leave.approved"          // อนุมัติใบลา
+	AuditLeaveRejected         AuditAction = "leave.rejected"          // ปฏิเสธใบลา
+	AuditLeaveEdited           AuditAction = "leave.edited"            // เจ้าของแก้ไขใบลาที่รออนุมัติ
+	AuditLeaveCancelled        AuditAction = "leave.cancelled"         // เจ้าของยกเลิกใบลาที่รออนุมัติ
+	AuditBalanceAdjusted       AuditAction = "balance.adjusted"        // กำหนดจำนวนวันลาที่ได้รับ
+	AuditUserRoleAssigned      AuditAction = "user.role_assigned"      // เปลี่ยนบทบาทของผู้ใช้
+	AuditUserUnlocked          AuditAction = "user.unlocked"           // ปลดล็อกบัญชี
+	AuditUserSessionsRevoked   AuditAction = "user.sessions_revoked"   // ยกเลิกทุก session ของผู้ใช้
+	AuditUserImported          AuditAction = "user.imported"           // สร้างหรือแก้ผู้ใช้จากไฟล์นำเข้า
+	AuditRoleSaved             AuditAction = "role.saved"              // สร้างหรือแก้สิทธิ์ของบทบาท
+	AuditRoleDeleted           AuditAction = "role.deleted"            // ลบบทบาท
+	AuditServiceAccountCreated AuditAction = "service_account.created" // สร้าง service account
+	AuditServiceAccountRevoked AuditAction = "service_account.revoked" // ยกเลิก API key
+	AuditWebhookSaved          AuditAction = "webhook.saved"           // สร้างหรือแก้ webhook
+	AuditWebhookDeleted        AuditAction = "webhook.deleted"         // ลบ webhook
+	AuditWebhookRedelivered    AuditAction = "webhook.redelivered"     // สั่งส่ง webhook ใหม่
+)
+
+// AuditActorType ประเภทของผู้กระทำ
+type AuditActorType string
+
+const (
+	AuditActorUser           AuditActorType = "user"            // ผู้ใช้ที่ login ด้วย JWT
+	AuditActorServiceAccount AuditActorType = "service_account" // ระบบภายนอกที่ใช้ API key
+	AuditActorAnonymous      AuditActorType = "anonymous"       // ยังไม่ยืนยันตัวตน (เช่น login ไม่สำเร็จด้วยอีเมลที่ไม่มีในระบบ)
+	AuditActorSystem         AuditActorType = "system"          // งานที่รันจาก command line (เช่น cmd/import)
+)
+
+// AuditTargetType ประเภทของสิ่งที่ถูกกระทำ
+type AuditTargetType string
+
+const (
+	AuditTargetUser            AuditTargetType = "user"
+	AuditTargetLeaveRequest    AuditTargetType = "leave_request"
+	AuditTargetLeaveBalance    AuditTargetType = "leave_balance"
+	AuditTargetRole            AuditTargetType = "role"
+	AuditTargetServiceAccount  AuditTargetType = "service_account"
+	AuditTargetWebhook         AuditTargetType = "webhook"
+	AuditTargetWebhookDelivery AuditTargetType = "webhook_delivery"
+)
+
+// AuditChange ค่าของ field ก่อนและหลังการกระทำ (แปลงเป็นข้อความ เพื่อให้ hash คำนวณซ้ำได้ตรงกันเสมอ)
+type AuditChange struct {
+	Field  string `json:"field"  bson:"field"`  // ชื่อ field
+	Before string `json:"before" bson:"before"` // ค่าก่อน (ว่าง = ไม่มีค่า)
+	After  string `json:"after"  bson:"after"`  // ค่าหลัง (ว่าง = ถูกลบ)
+}
+
+// AuditEvent บันทึกว่าใครทำอะไรกับอะไร — เพิ่มได้อย่างเดียว และเชื่อมกันเป็น hash chain ตาม sequence
+type AuditEvent struct {
+	OccurredAt time.Time       `json:"occurred_at"          bson:"occurred_at"`          // เวลาที่เกิด (ละเอียดระดับ millisecond ตาม MongoDB)
+	ActorID    *ID             `json:"actor_id,omitempty"   bson:"actor_id,omitempty"`   // ผู้กระทำ (nil = anonymous)
+	Changes    []AuditChange   `json:"changes"              bson:"changes"`              // ค่าที่เปลี่ยน
+	ActorType  AuditActorType  `json:"actor_type"           bson:"actor_type"`           // ประเภทผู้กระทำ
+	Action     AuditAction     `json:"action"               bson:"action"`               // การกระทำ
+	TargetType AuditTargetType `json:"target_type"          bson:"target_type"`          // ประเภทสิ่งที่ถูกกระทำ
+	TargetID   string          `json:"target_id"            bson:"target_id"`            // รหัสสิ่งที่ถูกกระทำ (UUID หรืออีเมลสำหรับ login)
+	IP         string          `json:"ip,omitempty"         bson:"ip,omitempty"`         // IP ของผู้เรียก
+	UserAgent  string          `json:"user_agent,omitempty" bson:"user_agent,omitempty"` // User-Agent ของผู้เรียก
+	PrevHash   string          `json:"prev_hash"            bson:"prev_hash"`            // hash ของ event ก่อนหน้า (ว่าง = event แรก)
+	Hash       string          `json:"hash"                 bson:"hash"`                 // SHA-256 ของ event นี้รวม prev_hash
+	Sequence   int64           `json:"sequence"             bson:"sequence"`             // ลำดับใน chain (เริ่มที่ 1, unique)
+	ID         ID              `json:"id"                   bson:"_id"`                  // รหัส event (UUID)
+}
+
+func NewAuditEvent(action AuditAction, targetType AuditTargetType, targetID string, changes []AuditChange) *AuditEvent {
+	if changes == nil {
+		changes = []AuditChange{}
+	}
+	return &AuditEvent{
+		ID:         NewID(),
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Changes:    changes,
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
+
+// By ระบุผู้ใช้ที่เป็นผู้กระทำ — ใช้เมื่อ service รู้ตัวผู้กระทำแน่ชัด (เช่น ผู้ที่เพิ่ง login)
+func (e *AuditEvent) By(userID ID) *AuditEvent {
+	e.ActorID = &userID
+	e.ActorType = AuditActorUser
+	return e
+}
+
+// Seal ต่อ event เข้ากับ chain หลัง prev (nil = event แรก) แล้วคำนวณ hash
+func (e *AuditEvent) Seal(prev *AuditEvent) {
+	e.Sequence, e.PrevHash = 1, ""
+	if prev != nil {
+		e.Sequence, e.PrevHash = prev.Sequence+1, prev.Hash
+	}
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash คำนวณ SHA-256 จากทุก field ยกเว้น hash เอง — แต่ละค่านำหน้าด้วยความยาวเพื่อไม่ให้ต่อกันแล้วกำกวม
+func (e *AuditEvent) ComputeHash() string {
+	h := sha256.New()
+	actorID := ""
+	if e.ActorID != nil {
+		actorID = e.ActorID.String()
+	}
+
+	writeHashField(h, strconv.FormatInt(e.Sequence, 10))
+	writeHashField(h, e.ID.String())
+	writeHashField(h, strconv.FormatInt(e.OccurredAt.UnixMilli(), 10))
+	writeHashField(h, string(e.ActorType))
+	writeHashField(h, actorID)
+	writeHashField(h, string(e.Action))
+	writeHashField(h, string(e.TargetType))
+	writeHashField(h, e.TargetID)
+	for _, c := range e.Changes {
+		writeHashField(h, c.Field)
+		writeHashField(h, c.Before)
+		writeHashField(h, c.After)
+	}
+	writeHashField(h, e.IP)
+	writeHashField(h, e.UserAgent)
+	writeHashField(h, e.PrevHash)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyAfter ตรวจว่า event นี้ต่อจาก prev ถูกต้อง (nil = event แรก) — คืนสาเหตุเมื่อไม่ถูกต้อง หรือข้อความว่างเมื่อถูกต้อง
+func (e *AuditEvent) VerifyAfter(prev *AuditEvent) string {
+	wantSeq, wantPrev := int64(1), ""
+	if prev != nil {
+		wantSeq, wantPrev = prev.Sequence+1, prev.Hash
+	}
+
+	switch {
+	case e.Sequence != wantSeq:
+		return fmt.Sprintf("sequence ขาดช่วง: คาดว่า %d แต่พบ %d", wantSeq, e.Sequence)
+	case e.PrevHash != wantPrev:
+		return "prev_hash ไม่ตรงกับ hash ของ event ก่อนหน้า"
+	case e.Hash != e.ComputeHash():
+		return "hash ไม่ตรงกับเนื้อหา — event ถูกแก้ไข"
+	}
+	return ""
+}
+
+func writeHashField(h hash.Hash, value string) {
+	fmt.Fprintf(h, "%d:%s;", len(value), value)
+}
+
+// DiffChanges เทียบค่าก่อนและหลังแล้วคืนเฉพาะ field ที่เปลี่ยน เรียงตามชื่อ field
+func DiffChanges(before, after map[string]any) []AuditChange {
+	fields := make(map[string]bool, len(before)+len(after))
+	for k := range before {
+		fields[k] = true
+	}
+	for k := range after {
+		fields[k] = true
+	}
+
+	changes := make([]AuditChange, 0, len(fields))
+	for field := range fields {
+		b, a := formatAuditValue(before[field]), formatAuditValue(after[field])
+		if b != a {
+			changes = append(changes, AuditChange{Field: field, Before: b, After: a})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func formatAuditValue(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return val.UTC().Format(time.RFC3339)
+	case *ID:
+		if val == nil {
+			return ""
+		}
+		return val.String()
+	default:
+		return fmt.Sprint(val)
+	}
+}
+
+// AuditFilter เงื่อนไขค้นหา audit log — field ที่ว่างคือไม่กรอง
+type AuditFilter struct {
+	From       *time.Time      // ตั้งแต่เวลา (รวม)
+	To         *time.Time      // ถึงเวลา (ไม่รวม)
+	ActorID    *ID             // ผู้กระทำ
+	Action     AuditAction     // การกระทำ
+	TargetType AuditTargetType // ประเภทสิ่งที่ถูกกระทำ
+	TargetID   string          // รหัสสิ่งที่ถูกกระทำ
+}
+
+// Validate ตรวจว่าช่วงเวลาถูกต้อง (From ต้องก่อน To)
+func (f AuditFilter) Validate() error {
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidAuditFilter
+	}
+	return nil
+}
+
+// AuditChainReport ผลการตรวจความถูกต้องของ hash chain
+type AuditChainReport struct {
+	BrokenAt *int64 `json:"broken_at,omitempty"` // sequence แรกที่ไม่ตรง (nil = ไม่พบปัญหา)
+	Reason   string `json:"reason,omitempty"`    // สาเหตุที่ไม่ตรง
+	Checked  int64  `json:"checked"`             // จำนวน event ที่ตรวจแล้ว
+	Valid    bool   `json:"valid"`               // chain ถูกต้องทั้งหมด
+}
+
+// RequestMeta ข้อมูลของ request ปัจจุบันที่ audit log ต้องใช้ — ตั้งโดย HTTP middleware
+type RequestMeta struct {
+	ActorID   *ID            // ผู้เรียก (ผู้ใช้หรือ service account)
+	ActorType AuditActorType // ประเภทผู้เรียก (ว่าง = ยังไม่ยืนยันตัวตน)
+	IP        string         // IP ของผู้เรียก
+	UserAgent string         // User-Agent ของผู้เรียก
+}
+
+type requestMetaKey struct{}
+
+// RequestMetaKey key ของ RequestMeta ใน context — fasthttp.RequestCtx.Value อ่านจาก user value
+// ที่ Fiber ตั้งด้วย c.Locals จึงใช้ key เดียวกันได้ทั้งใน middleware และ service
+var RequestMetaKey any = requestMetaKey{}
+
+// ContextWithRequestMeta แนบ RequestMeta ไปกับ context (ใช้นอก HTTP เช่น job หรือการทดสอบ)
+func ContextWithRequestMeta(ctx context.Context, meta *RequestMeta) context.Context {
+	return context.WithValue(ctx, RequestMetaKey, meta)
+}
+
+// RequestMetaFromContext อ่าน RequestMeta จาก context — คืน nil ถ้าไม่มี
+func RequestMetaFromContext(ctx context.Context) *RequestMeta {
+	meta, _ := ctx.Value(RequestMetaKey).(*RequestMeta)
+	return meta
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// AuthTokens ชุด token ที่ออกให้หลังยืนยันตัวตนสำเร็จ
+type AuthTokens struct {
+	AccessExpiresAt time.Time // เวลาหมดอายุของ access token
+	AccessToken     string    // JWT access token (อายุสั้น)
+	RefreshToken    string    // refresh token สำหรับขอ access token ใหม่ (อายุยาว, rotate ทุกครั้งที่ใช้)
+}
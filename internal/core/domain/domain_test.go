@@ -1,6 +1,7 @@
 package domain_test
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -96,6 +97,35 @@ func TestLeaveRequest_Reject_NotPending(t *testing.T) {
 	assert.ErrorIs(t, err, domain.ErrRequestNotPending, "ต้อง error เมื่อปฏิเสธซ้ำ")
 }
 
+func TestLeaveRequest_Edit(t *testing.T) {
+	request := domain.NewLeaveRequest(domain.NewID(), domain.LeaveTypeSick,
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), "ป่วย")
+
+	err := request.Edit(domain.LeaveTypeAnnual,
+		time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC), "พักผ่อน")
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.LeaveTypeAnnual, request.LeaveType)
+	assert.Equal(t, 3.0, request.TotalDays, "จำนวนวันต้องคำนวณใหม่จากช่วงวันที่แก้")
+	assert.Equal(t, domain.LeaveStatusPending, request.Status)
+
+	_ = request.Approve(domain.NewID(), "อนุมัติ")
+	err = request.Edit(domain.LeaveTypeSick, request.StartDate, request.EndDate, "แก้หลังอนุมัติ")
+	assert.ErrorIs(t, err, domain.ErrRequestNotPending, "ใบลาที่พิจารณาแล้วต้องแก้ไม่ได้")
+}
+
+func TestLeaveRequest_Cancel(t *testing.T) {
+	request := domain.NewLeaveRequest(domain.NewID(), domain.LeaveTypeSick,
+		time.Now(), time.Now(), "ป่วย")
+
+	assert.NoError(t, request.Cancel())
+	assert.Equal(t, domain.LeaveStatusCancelled, request.Status)
+	assert.ErrorIs(t, request.Cancel(), domain.ErrRequestNotPending, "ต้อง error เมื่อยกเลิกซ้ำ")
+
+	impact := domain.NewLeaveBalanceImpact(request, nil)
+	assert.Equal(t, domain.LeaveBalanceEffectNone, impact.Effect, "ใบลาที่ยกเลิกแล้วต้องไม่จองวันลา")
+}
+
 // ─── CalculateLeaveDays Tests ───────────────────────────────────────────
 // ทดสอบการคำนวณจำนวนวันลา
 // ─────────────────────────────────────────────────────────────────────────
@@ -140,7 +170,34 @@ func TestCalculateLeaveDays(t *testing.T) {
 func TestRole_IsValid(t *testing.T) {
 	assert.True(t, domain.RoleEmployee.IsValid(), "employee ต้อง valid")
 	assert.True(t, domain.RoleManager.IsValid(), "manager ต้อง valid")
-	assert.False(t, domain.Role("admin").IsValid(), "admin ต้อง invalid")
+	assert.True(t, domain.RoleAdmin.IsValid(), "admin ต้อง valid")
+	assert.False(t, domain.Role("superuser").IsValid(), "superuser ต้อง invalid")
+}
+
+func TestRole_IsValidName(t *testing.T) {
+	assert.True(t, domain.RoleManager.IsValidName())
+	assert.True(t, domain.Role("hr_officer").IsValidName(), "บทบาทที่สร้างเองต้องใช้ได้")
+	assert.False(t, domain.Role("HR Officer").IsValidName())
+	assert.False(t, domain.Role("x").IsValidName())
+	assert.False(t, domain.Role("1admin").IsValidName())
+}
+
+func TestDefaultRoles_Permissions(t *testing.T) {
+	roles := make(map[domain.Role]domain.RoleDefinition)
+	for _, r := range domain.DefaultRoles() {
+		assert.True(t, r.BuiltIn, "บทบาทเริ่มต้นต้องเป็น built-in")
+		roles[r.Name] = r
+	}
+
+	employee, manager, admin := roles[domain.RoleEmployee], roles[domain.RoleManager], roles[domain.RoleAdmin]
+	assert.Empty(t, employee.Permissions)
+	assert.True(t, manager.Has(domain.PermissionLeaveApprove))
+	assert.True(t, manager.Has(domain.PermissionLeaveViewTeam))
+	assert.False(t, manager.Has(domain.PermissionUserManage))
+	assert.True(t, admin.Has(domain.PermissionUserManage))
+	assert.True(t, admin.Has(domain.PermissionBalanceAdjust))
+	assert.False(t, admin.Has(domain.PermissionLeaveApprove))
+	assert.False(t, domain.Permission("leave.delete").IsValid())
 }
 
 func TestLeaveType_IsValid(t *testing.T) {
@@ -154,5 +211,562 @@ func TestLeaveStatus_IsValid(t *testing.T) {
 	assert.True(t, domain.LeaveStatusPending.IsValid())
 	assert.True(t, domain.LeaveStatusApproved.IsValid())
 	assert.True(t, domain.LeaveStatusRejected.IsValid())
-	assert.False(t, domain.LeaveStatus("cancelled").IsValid())
+	assert.True(t, domain.LeaveStatusCancelled.IsValid())
+	assert.False(t, domain.LeaveStatus("withdrawn").IsValid())
+}
+
+// ─── Lockout Policy Tests ───────────────────────────────────────────────
+
+func TestLockoutPolicy_NextAllowedAt(t *testing.T) {
+	policy := domain.DefaultLockoutPolicy()
+	failedAt := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		count    int
+		expected time.Time
+	}{
+		{"ยังไม่เคยผิด", 0, time.Time{}},
+		{"ผิดครั้งแรกไม่หน่วง", 1, time.Time{}},
+		{"ผิด 2 ครั้งหน่วง 1 วินาที", 2, failedAt.Add(1 * time.Second)},
+		{"ผิด 4 ครั้งหน่วง 4 วินาที", 4, failedAt.Add(4 * time.Second)},
+		{"หน่วงไม่เกิน MaxBackoff", 20, failedAt.Add(policy.MaxBackoff)},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			attempt := &domain.LoginAttempt{FailedCount: tc.count, LastFailedAt: failedAt}
+			assert.Equal(t, tc.expected, policy.NextAllowedAt(attempt))
+		})
+	}
+}
+
+func TestLockoutPolicy_NextAllowedAt_Locked(t *testing.T) {
+	policy := domain.DefaultLockoutPolicy()
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	attempt := &domain.LoginAttempt{LockedUntil: &lockedUntil}
+
+	assert.Equal(t, lockedUntil, policy.NextAllowedAt(attempt))
+	assert.False(t, policy.ShouldLock(attempt))
+	assert.True(t, policy.ShouldLock(&domain.LoginAttempt{FailedCount: policy.MaxFailedAttempts}))
+}
+
+func TestLockoutPolicy_RecordAttemptEscalatesLocks(t *testing.T) {
+	policy := domain.DefaultLockoutPolicy()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	attempt := &domain.LoginAttempt{}
+
+	var locks []time.Duration
+	for range 6 * policy.MaxFailedAttempts {
+		if policy.RecordAttempt(attempt, now) {
+			locks = append(locks, attempt.LockedUntil.Sub(now))
+			assert.Zero(t, attempt.FailedCount, "ถูกล็อกแล้วเริ่มนับครั้งที่ผิดใหม่")
+		}
+	}
+
+	assert.Equal(t, []time.Duration{
+		15 * time.Minute, 30 * time.Minute, time.Hour, 2 * time.Hour, 4 * time.Hour, policy.MaxLockoutDuration,
+	}, locks)
+	assert.Equal(t, 6*policy.MaxFailedAttempts, attempt.Version)
+}
+
+// ─── MFA Policy Tests ───────────────────────────────────────────────────
+
+func TestMFAPolicy_Requires(t *testing.T) {
+	policy := domain.MFAPolicy{RequiredPermissions: domain.DefaultMFARequiredPermissions()}
+	roles := make(map[domain.Role]*domain.RoleDefinition)
+	for _, def := range domain.DefaultRoles() {
+		roles[def.Name] = &def
+	}
+
+	assert.True(t, policy.Requires(roles[domain.RoleManager]))
+	assert.True(t, policy.Requires(roles[domain.RoleAdmin]))
+	assert.False(t, policy.Requires(roles[domain.RoleEmployee]))
+	assert.False(t, policy.Requires(nil))
+	assert.False(t, domain.MFAPolicy{}.Requires(roles[domain.RoleManager]), "นโยบายว่างต้องไม่บังคับบทบาทใด")
+}
+
+func TestMFAPolicy_RequiresCustomRoleWithSensitivePermission(t *testing.T) {
+	policy := domain.MFAPolicy{RequiredPermissions: domain.DefaultMFARequiredPermissions()}
+
+	hr := domain.NewRoleDefinition("hr", "ฝ่ายบุคคล", []domain.Permission{domain.PermissionBalanceAdjust})
+	auditor := domain.NewRoleDefinition("auditor", "ผู้ตรวจสอบ", []domain.Permission{domain.PermissionReportView})
+
+	assert.True(t, policy.Requires(hr), "บทบาทที่สร้างเองซึ่งปรับยอดวันลาได้ต้องใช้ 2FA แม้ไม่ได้ระบุชื่อบทบาท")
+	assert.False(t, policy.Requires(auditor))
+}
+
+// ─── External Identity Tests ────────────────────────────────────────────
+
+func TestGroupRoleMapping_Resolve(t *testing.T) {
+	mapping := domain.GroupRoleMapping{
+		Groups: map[string]domain.Role{
+			"hr-managers": domain.RoleManager,
+			"it-admins":   domain.RoleAdmin,
+		},
+		DefaultRole: domain.RoleEmployee,
+	}
+
+	assert.Equal(t, domain.RoleEmployee, mapping.Resolve(nil), "ไม่อยู่ในกลุ่มใดต้องได้บทบาทเริ่มต้น")
+	assert.Equal(t, domain.RoleEmployee, mapping.Resolve([]string{"sales"}))
+	assert.Equal(t, domain.RoleManager, mapping.Resolve([]string{"sales", "HR-Managers"}), "ชื่อกลุ่มต้องไม่สนตัวพิมพ์")
+	assert.Equal(t, domain.RoleAdmin, mapping.Resolve([]string{"it-admins", "hr-managers"}), "ต้องเลือกบทบาทสูงสุด")
+}
+
+func TestGroupRoleMapping_Sync(t *testing.T) {
+	mapping := domain.GroupRoleMapping{
+		Groups:      map[string]domain.Role{"hr-managers": domain.RoleManager},
+		DefaultRole: domain.RoleEmployee,
+	}
+
+	assert.Equal(t, domain.RoleManager, mapping.Sync(domain.RoleEmployee, []string{"HR-Managers"}))
+	assert.Equal(t, domain.RoleEmployee, mapping.Sync(domain.RoleManager, []string{"sales"}),
+		"บทบาทที่ได้จากกลุ่มต้องถูกถอนเมื่อออกจากกลุ่ม")
+	assert.Equal(t, domain.RoleAdmin, mapping.Sync(domain.RoleAdmin, []string{"sales"}),
+		"บทบาทที่กำหนดในระบบต้องคงเดิมเมื่อ mapping ไม่ได้กำหนดให้")
+	assert.Equal(t, domain.Role("hr"), mapping.Sync("hr", nil))
+}
+
+func TestNewExternalUser(t *testing.T) {
+	identity := &domain.ExternalIdentity{
+		Provider: "https://idp.example.com", Subject: "sub-1", Email: "Somchai@Company.com", FirstName: "สมชาย", LastName: "ใจดี",
+	}
+
+	user := domain.NewExternalUser(identity, domain.RoleManager)
+
+	assert.True(t, user.IsExternal())
+	assert.Equal(t, "somchai@company.com", user.Email)
+	assert.Equal(t, "sub-1", user.ExternalID)
+	assert.Empty(t, user.PasswordHash)
+	assert.False(t, domain.NewUser("a", "b", "c@d.com", "hash", domain.RoleEmployee).IsExternal())
+}
+
+func TestServiceAccount_HasScope(t *testing.T) {
+	account := domain.NewServiceAccount("payroll-sync", "lms_abcdefghijklmnop", "hash", []domain.APIScope{domain.ScopeLeavesRead}, domain.NewID())
+
+	assert.Equal(t, "lms_abcdefgh", account.KeyPrefix)
+	assert.True(t, account.HasScope(domain.ScopeLeavesRead))
+	assert.False(t, account.HasScope(domain.ScopeBalancesWrite))
+	assert.False(t, account.IsRevoked())
+	assert.False(t, domain.APIScope("leaves:delete").IsValid())
+}
+
+// ─── Audit Log Tests ────────────────────────────────────────────────────
+
+func TestDiffChanges(t *testing.T) {
+	reviewerID := domain.NewID()
+	before := map[string]any{"status": domain.LeaveStatusPending, "total_days": 3.0, "reviewer_id": (*domain.ID)(nil)}
+	after := map[string]any{"status": domain.LeaveStatusApproved, "total_days": 3.0, "reviewer_id": &reviewerID, "note": "ok"}
+
+	changes := domain.DiffChanges(before, after)
+
+	assert.Equal(t, []domain.AuditChange{
+		{Field: "note", Before: "", After: "ok"},
+		{Field: "reviewer_id", Before: "", After: reviewerID.String()},
+		{Field: "status", Before: "pending", After: "approved"},
+	}, changes, "ต้องมีเฉพาะ field ที่เปลี่ยน เรียงตามชื่อ")
+	assert.Empty(t, domain.DiffChanges(nil, nil))
+}
+
+func TestAuditEvent_SealAndVerify(t *testing.T) {
+	first := domain.NewAuditEvent(domain.AuditLeaveSubmitted, domain.AuditTargetLeaveRequest, "r1", nil).By(domain.NewID())
+	first.Seal(nil)
+	second := domain.NewAuditEvent(domain.AuditLeaveApproved, domain.AuditTargetLeaveRequest, "r1",
+		[]domain.AuditChange{{Field: "status", Before: "pending", After: "approved"}})
+	second.Seal(first)
+
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Empty(t, first.PrevHash)
+	assert.Equal(t, int64(2), second.Sequence)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.Empty(t, first.VerifyAfter(nil))
+	assert.Empty(t, second.VerifyAfter(first))
+
+	second.Changes[0].After = "rejected"
+	assert.NotEmpty(t, second.VerifyAfter(first), "แก้เนื้อหาแล้ว hash ต้องไม่ตรง")
+
+	second.Changes[0].After = "approved"
+	second.Hash = second.ComputeHash()
+	first.IP = "10.0.0.9"
+	first.Hash = first.ComputeHash()
+	assert.NotEmpty(t, second.VerifyAfter(first), "แก้ event ก่อนหน้าแล้วคำนวณ hash ใหม่ ต้องทำให้ event ถัดไปไม่ตรง")
+}
+
+func TestAuditFilter_Validate(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+
+	assert.NoError(t, domain.AuditFilter{}.Validate())
+	assert.NoError(t, domain.AuditFilter{From: &now, To: &later}.Validate())
+	assert.ErrorIs(t, domain.AuditFilter{From: &later, To: &now}.Validate(), domain.ErrInvalidAuditFilter)
+}
+
+// ─── Webhook Tests ──────────────────────────────────────────────────────
+
+func TestNewWebhookSubscription_Validation(t *testing.T) {
+	secret := "0123456789abcdef"
+	events := []domain.LeaveEventType{domain.LeaveEventApproved, domain.LeaveEventApproved, domain.LeaveEventBalanceAdjusted}
+
+	sub, err := domain.NewWebhookSubscription("https://hr.example.com/hooks", secret, events, domain.NewID())
+	assert.NoError(t, err)
+	assert.True(t, sub.Active)
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventApproved, domain.LeaveEventBalanceAdjusted}, sub.Events, "ต้องตัดเหตุการณ์ซ้ำ")
+	assert.False(t, sub.Subscribes(domain.LeaveEventSubmitted))
+
+	_, err = domain.NewWebhookSubscription("ftp://hr.example.com", secret, events, domain.NewID())
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookURL)
+	_, err = domain.NewWebhookSubscription("https://hr.example.com", "short", events, domain.NewID())
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookSecret)
+	_, err = domain.NewWebhookSubscription("https://hr.example.com", secret, []domain.LeaveEventType{"leave.deleted"}, domain.NewID())
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookEvent)
+
+	assert.NoError(t, sub.Update("https://hr.example.com/v2", "", events, false), "secret ว่างต้องใช้ secret เดิม")
+	assert.Equal(t, secret, sub.Secret)
+	assert.False(t, sub.Subscribes(domain.LeaveEventApproved), "webhook ที่ปิดอยู่ต้องไม่รับเหตุการณ์")
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, domain.WebhookRetryDelay(1))
+	assert.Equal(t, time.Minute, domain.WebhookRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, domain.WebhookRetryDelay(4))
+	assert.Equal(t, time.Hour, domain.WebhookRetryDelay(9), "ต้องไม่เกิน 1 ชั่วโมง")
+}
+
+func TestWebhookDelivery_Lifecycle(t *testing.T) {
+	now := time.Now()
+	event := domain.NewLeaveEvent(domain.LeaveEventSubmitted, &domain.LeaveRequest{ID: domain.NewID()}, domain.NewID())
+	delivery := domain.NewWebhookDelivery(domain.NewID(), &event, `{}`)
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, event.ID, delivery.EventID)
+	assert.ErrorIs(t, delivery.Redeliver(now), domain.ErrWebhookDeliveryPending)
+
+	delivery.RecordFailure(503, "ปลายทางตอบ HTTP 503", now)
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, now.Add(30*time.Second), delivery.NextAttemptAt)
+
+	for delivery.Attempts < domain.MaxWebhookAttempts {
+		delivery.RecordFailure(0, "connection refused", now)
+	}
+	assert.Equal(t, domain.WebhookDeliveryDead, delivery.Status, "ส่งไม่สำเร็จครบจำนวนครั้งต้องเป็น dead")
+
+	assert.NoError(t, delivery.Redeliver(now))
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	assert.Zero(t, delivery.Attempts)
+
+	delivery.RecordSuccess(204, now)
+	assert.Equal(t, domain.WebhookDeliveryDelivered, delivery.Status)
+	assert.Empty(t, delivery.LastError)
+}
+
+func TestOutboxMessage_Lifecycle(t *testing.T) {
+	now := time.Now()
+	event := domain.NewLeaveEvent(domain.LeaveEventApproved, &domain.LeaveRequest{ID: domain.NewID()}, domain.NewID())
+	msg := domain.NewOutboxMessage(event)
+	assert.Equal(t, event.ID, msg.ID, "รหัสข้อความต้องเท่ากับรหัสเหตุการณ์")
+	assert.Equal(t, domain.OutboxPending, msg.Status)
+
+	msg.MarkCompleted("email")
+	msg.MarkCompleted("email")
+	assert.Equal(t, []string{"email"}, msg.Completed)
+	assert.True(t, msg.IsCompleted("email"))
+	assert.False(t, msg.IsCompleted("webhook"))
+
+	msg.RecordFailure("webhook: timeout", now)
+	assert.Equal(t, domain.OutboxPending, msg.Status)
+	assert.Equal(t, now.Add(5*time.Second), msg.NextAttemptAt)
+	msg.RecordFailure("webhook: timeout", now)
+	assert.Equal(t, now.Add(10*time.Second), msg.NextAttemptAt)
+
+	msg.RecordSuccess(now)
+	assert.Equal(t, domain.OutboxProcessed, msg.Status)
+	assert.Empty(t, msg.LastError)
+	assert.NotNil(t, msg.ProcessedAt)
+
+	failing := domain.NewOutboxMessage(event)
+	for failing.Attempts < domain.MaxOutboxAttempts {
+		failing.RecordFailure("email: queue full", now)
+	}
+	assert.Equal(t, domain.OutboxFailed, failing.Status, "ลองครบจำนวนครั้งแล้วต้องเป็น failed")
+}
+
+func TestEventStreamViewer_CanSee(t *testing.T) {
+	ownerID := domain.NewID()
+	request := &domain.LeaveRequest{ID: domain.NewID(), UserID: ownerID}
+	submitted := domain.NewLeaveEvent(domain.LeaveEventSubmitted, request, ownerID)
+	adjusted := domain.NewBalanceAdjustedEvent(&domain.LeaveBalance{ID: domain.NewID(), UserID: ownerID}, domain.NewID())
+
+	owner := domain.EventStreamViewer{UserID: ownerID}
+	other := domain.EventStreamViewer{UserID: domain.NewID()}
+	manager := domain.EventStreamViewer{UserID: domain.NewID(), CanViewTeam: true}
+
+	assert.True(t, owner.CanSee(&submitted))
+	assert.True(t, owner.CanSee(&adjusted))
+	assert.False(t, other.CanSee(&submitted))
+	assert.True(t, manager.CanSee(&submitted))
+	assert.False(t, manager.CanSee(&adjusted), "การปรับยอดวันลาเห็นเฉพาะเจ้าของ")
+
+	comment := domain.NewLeaveComment(request.ID, domain.NewID(), "บันทึกภายใน", true, []domain.ID{ownerID})
+	mentioned := domain.NewCommentMentionedEvent(request, comment)
+	assert.False(t, owner.CanSee(&mentioned), "ความคิดเห็นแจ้งทางอีเมลเท่านั้น")
+	assert.False(t, manager.CanSee(&mentioned))
+	assert.False(t, domain.LeaveEventCommentMentioned.IsValid(), "webhook สมัครรับความคิดเห็นไม่ได้")
+}
+
+func TestIdempotencyRecord_Lifecycle(t *testing.T) {
+	now := time.Now()
+	for _, key := range []string{"", "has space", "ไทย", string(make([]byte, domain.IdempotencyKeyMaxLength+1))} {
+		_, err := domain.NewIdempotencyRecord(domain.NewID(), key, "fp", now)
+		assert.ErrorIs(t, err, domain.ErrInvalidIdempotencyKey, "key %q ต้องไม่ผ่าน", key)
+	}
+
+	record, err := domain.NewIdempotencyRecord(domain.NewID(), "3f1c-retry-01", "fp", now)
+	assert.NoError(t, err)
+	assert.Equal(t, now.Add(domain.IdempotencyLockTimeout), record.ExpiresAt, "จอง key ไว้ชั่วคราวระหว่างทำ request")
+
+	_, err = record.Replay("fp")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyInProgress)
+
+	record.Complete(domain.IdempotentResponse{StatusCode: 201, Body: []byte("{}")}, now, time.Hour)
+	assert.Equal(t, now.Add(time.Hour), record.ExpiresAt)
+
+	resp, err := record.Replay("fp")
+	assert.NoError(t, err)
+	assert.Equal(t, 201, resp.StatusCode)
+
+	_, err = record.Replay("other")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+}
+
+func TestLeaveRequestQuery_Validate(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	one, five := 1.0, 5.0
+
+	valid := domain.LeaveRequestQuery{
+		From: &from, To: &to, MinDays: &one, MaxDays: &five,
+		Statuses:   []domain.LeaveStatus{domain.LeaveStatusPending, domain.LeaveStatusApproved},
+		LeaveTypes: []domain.LeaveType{domain.LeaveTypeSick},
+		Sort:       []domain.LeaveSort{{Field: domain.LeaveSortStartDate, Descending: true}, {Field: domain.LeaveSortCreatedAt}},
+	}
+	assert.NoError(t, valid.Validate())
+
+	invalid := map[string]domain.LeaveRequestQuery{
+		"สถานะไม่รู้จัก":       {Statuses: []domain.LeaveStatus{"archived"}},
+		"ประเภทไม่รู้จัก":      {LeaveTypes: []domain.LeaveType{"maternity"}},
+		"from หลัง to":         {From: &to, To: &from},
+		"min มากกว่า max":      {MinDays: &five, MaxDays: &one},
+		"เรียงด้วย field ซ้ำ":  {Sort: []domain.LeaveSort{{Field: domain.LeaveSortCreatedAt}, {Field: domain.LeaveSortCreatedAt, Descending: true}}},
+		"เรียงด้วย field อื่น": {Sort: []domain.LeaveSort{{Field: "reason"}}},
+	}
+	for name, q := range invalid {
+		assert.ErrorIs(t, q.Validate(), domain.ErrInvalidLeaveQuery, name)
+	}
+}
+
+func TestParseLeaveSort(t *testing.T) {
+	sorts, err := domain.ParseLeaveSort("-start_date, total_days")
+	assert.NoError(t, err)
+	assert.Equal(t, []domain.LeaveSort{
+		{Field: domain.LeaveSortStartDate, Descending: true},
+		{Field: domain.LeaveSortTotalDays},
+	}, sorts)
+
+	_, err = domain.ParseLeaveSort("-password")
+	assert.ErrorIs(t, err, domain.ErrInvalidLeaveQuery)
+
+	sorts, err = domain.ParseLeaveSort("")
+	assert.NoError(t, err)
+	assert.Empty(t, sorts)
+}
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	cursor := domain.Cursor{CreatedAt: time.UnixMilli(1767225600123).UTC(), ID: domain.NewID(), Descending: true}
+
+	decoded, err := domain.DecodeCursor(cursor.Encode())
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	for _, raw := range []string{"not-base64!", "MTIz", cursor.Encode() + "x"} {
+		_, err = domain.DecodeCursor(raw)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor, raw)
+	}
+}
+
+func TestLeaveRequestQuery_ValidatePagination(t *testing.T) {
+	newest := domain.LeaveRequestQuery{Sort: []domain.LeaveSort{{Field: domain.LeaveSortCreatedAt, Descending: true}}}
+	byStart := domain.LeaveRequestQuery{Sort: []domain.LeaveSort{{Field: domain.LeaveSortStartDate}}}
+	ascCursor := &domain.Cursor{CreatedAt: time.Now(), ID: domain.NewID()}
+
+	assert.NoError(t, byStart.ValidatePagination(domain.NewPaginationParams(2, 10)), "แบบเลขหน้าเรียงได้ทุก field")
+	assert.NoError(t, newest.ValidatePagination(domain.NewCursorParams(nil, 10, false)))
+	assert.ErrorIs(t, byStart.ValidatePagination(domain.NewCursorParams(nil, 10, false)), domain.ErrInvalidCursor)
+	assert.ErrorIs(t, newest.ValidatePagination(domain.NewCursorParams(ascCursor, 10, false)), domain.ErrInvalidCursor,
+		"cursor ของรายการเรียงจากเก่าไปใหม่ใช้กับรายการที่เรียงกลับด้านไม่ได้")
+}
+
+func TestNewCursorResult(t *testing.T) {
+	params := domain.NewCursorParams(nil, 500, false)
+	assert.Equal(t, domain.MaxPageSize, params.PageSize)
+
+	last := domain.NewCursorResult([]int{1, 2}, nil, nil, params)
+	assert.False(t, last.HasMore())
+	assert.False(t, last.TotalCounted)
+
+	total := int64(250)
+	next := domain.NewCursorResult([]int{1}, &domain.Cursor{ID: domain.NewID()}, &total, params)
+	assert.True(t, next.HasMore())
+	assert.True(t, next.TotalCounted)
+	assert.Equal(t, total, next.Total)
+}
+
+func TestNewLeaveBalanceImpact(t *testing.T) {
+	request := domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeSick,
+		time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), "ไม่สบาย",
+	)
+
+	impact := domain.NewLeaveBalanceImpact(request, nil)
+	assert.Equal(t, domain.LeaveBalanceEffectReserved, impact.Effect)
+	assert.InDelta(t, request.TotalDays, impact.Days, 0.001)
+	assert.Equal(t, 2026, impact.Year)
+
+	assert.NoError(t, request.Reject(domain.NewID(), "ไม่อนุมัติ"))
+	impact = domain.NewLeaveBalanceImpact(request, nil)
+	assert.Equal(t, domain.LeaveBalanceEffectNone, impact.Effect)
+	assert.Zero(t, impact.Days)
+}
+
+func TestNewLeaveRequestView(t *testing.T) {
+	request := domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeSick,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), "ไม่สบาย",
+	)
+	requester := domain.User{ID: request.UserID, FullName: "สมชาย ใจดี", Email: "somchai@company.com", PasswordHash: "hash"}
+	users := map[domain.ID]domain.UserSummary{request.UserID: requester.Summary()}
+
+	view := domain.NewLeaveRequestView(request, users)
+	assert.Equal(t, "somchai@company.com", view.Requester.Email)
+	assert.Nil(t, view.Reviewer, "ใบลาที่ยังไม่ได้พิจารณาไม่มีผู้อนุมัติ")
+
+	reviewerID := domain.NewID()
+	assert.NoError(t, request.Approve(reviewerID, ""))
+	view = domain.NewLeaveRequestView(request, users)
+	assert.Nil(t, view.Reviewer, "ผู้อนุมัติที่ไม่พบไม่ถูกแนบ")
+}
+
+// ─── Report Tests ───────────────────────────────────────────────────────
+
+func TestReportFilter(t *testing.T) {
+	assert.NoError(t, domain.ReportFilter{Year: 2026}.Validate())
+	assert.NoError(t, domain.ReportFilter{Year: 2026, LeaveType: domain.LeaveTypeSick, Department: "วิศวกรรม"}.Validate())
+	assert.ErrorIs(t, domain.ReportFilter{Year: 0}.Validate(), domain.ErrInvalidReportFilter)
+	assert.ErrorIs(t, domain.ReportFilter{Year: 2026, LeaveType: "x"}.Validate(), domain.ErrInvalidReportFilter)
+	assert.ErrorIs(t,
+		domain.ReportFilter{Year: 2026, Department: strings.Repeat("ก", domain.MaxReportDepartmentLen+1)}.Validate(),
+		domain.ErrInvalidReportFilter,
+	)
+
+	start, end := domain.ReportFilter{Year: 2026}.Period()
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), end)
+	assert.InDelta(t, 365.0, domain.ReportFilter{Year: 2026}.DaysInPeriod(), 0.001)
+	assert.InDelta(t, 366.0, domain.ReportFilter{Year: 2024}.DaysInPeriod(), 0.001)
+}
+
+func TestCarryOverRisk_ApplyCarryOver(t *testing.T) {
+	risk := domain.CarryOverRisk{RemainingDays: 8}
+	risk.ApplyCarryOver(5)
+	assert.InDelta(t, 3.0, risk.DaysAtRisk, 0.001)
+
+	risk.ApplyCarryOver(10)
+	assert.Zero(t, risk.DaysAtRisk, "คงเหลือไม่เกินที่ยกไปได้ต้องไม่เสี่ยง")
+}
+
+// ─── Export Tests ───────────────────────────────────────────────────────
+
+func TestLeaveBalanceQuery_Validate(t *testing.T) {
+	year := 2026
+	assert.NoError(t, domain.LeaveBalanceQuery{}.Validate())
+	assert.NoError(t, domain.LeaveBalanceQuery{
+		Year:       &year,
+		LeaveTypes: []domain.LeaveType{domain.LeaveTypeSick, domain.LeaveTypeAnnual},
+	}.Validate())
+
+	tooEarly := domain.MinReportYear - 1
+	assert.ErrorIs(t, domain.LeaveBalanceQuery{Year: &tooEarly}.Validate(), domain.ErrInvalidLeaveQuery)
+	assert.ErrorIs(t,
+		domain.LeaveBalanceQuery{LeaveTypes: []domain.LeaveType{"vacation"}}.Validate(),
+		domain.ErrInvalidLeaveQuery,
+	)
+}
+
+// ─── User Import Tests ──────────────────────────────────────────────────
+
+func TestUserImportFile_Validate(t *testing.T) {
+	valid := domain.UserImportFile{Header: []string{"email", "first_name", "last_name", "year", "sick_leave", "sick_leave_used"}}
+	assert.NoError(t, valid.Validate())
+
+	invalid := map[string][]string{
+		"ไม่มีคอลัมน์ที่บังคับ": {"email", "first_name"},
+		"คอลัมน์ไม่รู้จัก":      {"email", "first_name", "last_name", "vacation"},
+		"คอลัมน์ซ้ำ":            {"email", "first_name", "last_name", "email"},
+		"used ไม่มีคู่":         {"email", "first_name", "last_name", "sick_leave_used"},
+	}
+	for name, header := range invalid {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, domain.UserImportFile{Header: header}.Validate(), domain.ErrInvalidImportFile)
+		})
+	}
+
+	assert.ErrorIs(t, domain.UserImportOptions{Year: 1999}.Validate(), domain.ErrInvalidImportFile)
+	assert.NoError(t, domain.UserImportOptions{}.Validate(), "ไม่ระบุปีคือปีปัจจุบัน")
+
+	tooMany := domain.UserImportFile{Header: valid.Header, Records: make([]domain.UserImportRecord, domain.MaxImportRows+1)}
+	assert.ErrorIs(t, tooMany.Validate(), domain.ErrInvalidImportFile)
+}
+
+func TestUserImportFile_ParseRecord(t *testing.T) {
+	file := domain.UserImportFile{Header: []string{"email", "first_name", "last_name", "role", "year", "annual_leave", "annual_leave_used", "sick_leave"}}
+
+	row, err := file.ParseRecord(domain.UserImportRecord{
+		Line: 2, Values: []string{"Somchai@Company.com", "สมชาย", "ใจดี", "", "", "15", "2.5", ""},
+	}, 2026)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "somchai@company.com", row.Email)
+	assert.Equal(t, domain.RoleEmployee, row.Role, "ไม่ระบุบทบาทคือพนักงาน")
+	assert.Equal(t, 2026, row.Year)
+	assert.Equal(t, []domain.OpeningBalance{{LeaveType: domain.LeaveTypeAnnual, TotalDays: 15, UsedDays: 2.5}}, row.Balances,
+		"ช่องวันที่ได้รับที่ว่างคือไม่นำเข้า")
+
+	invalid := map[string][]string{
+		"จำนวนคอลัมน์ไม่ตรง":   {"a@company.com", "ก", "ข"},
+		"อีเมลไม่ถูกต้อง":      {"Somchai <a@company.com>", "ก", "ข", "", "", "", "", ""},
+		"ไม่มีนามสกุล":         {"a@company.com", "ก", "", "", "", "", "", ""},
+		"ชื่อบทบาทไม่ถูกต้อง":  {"a@company.com", "ก", "ข", "Admin!", "", "", "", ""},
+		"ปีไม่ถูกต้อง":         {"a@company.com", "ก", "ข", "", "1999", "", "", ""},
+		"วันลาไม่ใช่ตัวเลข":    {"a@company.com", "ก", "ข", "", "", "สิบ", "", ""},
+		"วันลาเกินหนึ่งปี":     {"a@company.com", "ก", "ข", "", "", "367", "", ""},
+		"ใช้มากกว่าที่ได้รับ":  {"a@company.com", "ก", "ข", "", "", "5", "6", ""},
+		"ระบุ used อย่างเดียว": {"a@company.com", "ก", "ข", "", "", "", "1", ""},
+	}
+	for name, values := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := file.ParseRecord(domain.UserImportRecord{Line: 3, Values: values}, 2026)
+			assert.ErrorIs(t, err, domain.ErrInvalidImportRow)
+		})
+	}
+}
+
+func TestUserImportReport_Add(t *testing.T) {
+	var report domain.UserImportReport
+	report.Add(domain.UserImportResult{Line: 2, Action: domain.UserImportCreated})
+	report.Add(domain.UserImportResult{Line: 3, Action: domain.UserImportUpdated})
+	report.Add(domain.UserImportResult{Line: 4, Action: domain.UserImportFailed, Error: "x"})
+
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 1, report.Updated)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.Rows, 3)
 }
@@ -0,0 +1,8 @@
+package domain
+
+// EmailMessage อีเมลที่ระบบส่งถึงผู้ใช้ (ข้อความล้วน)
+type EmailMessage struct {
+	To      string // อีเมลผู้รับ
+	Subject string // หัวเรื่อง
+	Body    string // เนื้อหา
+}
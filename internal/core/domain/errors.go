@@ -7,23 +7,93 @@ var (
 
 	ErrUserNotFound       = errors.New("ไม่พบผู้ใช้ในระบบ")
 	ErrInvalidCredentials = errors.New("อีเมลหรือรหัสผ่านไม่ถูกต้อง")
+	ErrEmailAlreadyExists = errors.New("อีเมลนี้ถูกใช้งานแล้ว")
 
 	// ─── Leave Errors ───────────────────────────────────────────────
 
-	ErrInvalidLeaveType     = errors.New("ประเภทการลาไม่ถูกต้อง")
-	ErrInsufficientBalance  = errors.New("วันลาคงเหลือไม่เพียงพอ")
-	ErrOverlappingLeave     = errors.New("วันลาซ้ำซ้อนกับใบลาที่มีอยู่แล้ว")
-	ErrInvalidDateRange     = errors.New("ช่วงวันที่ไม่ถูกต้อง: วันสิ้นสุดต้องไม่ก่อนวันเริ่มต้น")
-	ErrLeaveBalanceNotFound = errors.New("ไม่พบข้อมูลยอดวันลาสำหรับประเภทและปีที่ระบุ")
+	ErrInvalidLeaveType      = errors.New("ประเภทการลาไม่ถูกต้อง")
+	ErrInsufficientBalance   = errors.New("วันลาคงเหลือไม่เพียงพอ")
+	ErrOverlappingLeave      = errors.New("วันลาซ้ำซ้อนกับใบลาที่มีอยู่แล้ว")
+	ErrInvalidDateRange      = errors.New("ช่วงวันที่ไม่ถูกต้อง: วันสิ้นสุดต้องไม่ก่อนวันเริ่มต้น")
+	ErrLeaveBalanceNotFound  = errors.New("ไม่พบข้อมูลยอดวันลาสำหรับประเภทและปีที่ระบุ")
+	ErrEntitlementBelowUsage = errors.New("จำนวนวันลาทั้งหมดต้องไม่น้อยกว่าวันลาที่ใช้และจองไว้แล้ว")
 
 	// ─── Leave Request Errors ───────────────────────────────────────
 
+	ErrInvalidLeaveStatus      = errors.New("สถานะใบลาไม่ถูกต้อง")
 	ErrRequestNotFound         = errors.New("ไม่พบคำขอลา")
 	ErrRequestNotPending       = errors.New("ใบลาไม่อยู่ในสถานะรอดำเนินการ")
 	ErrRequestAlreadyProcessed = errors.New("ใบลาถูกดำเนินการไปแล้ว")
 	ErrSelfApproval            = errors.New("ไม่สามารถอนุมัติหรือปฏิเสธใบลาของตนเองได้")
+	ErrInvalidLeaveQuery       = errors.New("เงื่อนไขค้นหาใบลาไม่ถูกต้อง")
+	ErrInvalidCursor           = errors.New("cursor ไม่ถูกต้อง หรือใช้กับการเรียงนี้ไม่ได้ (cursor ใช้ได้เฉพาะเมื่อเรียงตาม created_at)")
+	ErrInvalidReportFilter     = errors.New("เงื่อนไขรายงานไม่ถูกต้อง (ปี 2000–2100, ประเภทการลา, แผนกไม่เกิน 100 ตัวอักษร)")
+
+	// ─── Leave Comment Errors ───────────────────────────────────────
+
+	ErrInternalCommentForbidden = errors.New("บันทึกภายในใช้ได้เฉพาะผู้อนุมัติใบลาของผู้อื่น")
+	ErrInvalidMention           = errors.New("ผู้ใช้ที่กล่าวถึงไม่มีอยู่หรือไม่มีสิทธิ์เห็นความคิดเห็นนี้")
 
 	// ─── Auth Errors ────────────────────────────────────────────────
 
-	ErrUnauthorized = errors.New("ไม่มีสิทธิ์เข้าถึง")
+	ErrUnauthorized         = errors.New("ไม่มีสิทธิ์เข้าถึง")
+	ErrInvalidRefreshToken  = errors.New("refresh token ไม่ถูกต้องหรือหมดอายุ")
+	ErrRefreshTokenReused   = errors.New("ตรวจพบการใช้ refresh token ซ้ำ — session ที่เกี่ยวข้องถูกยกเลิกทั้งหมดแล้ว")
+	ErrInvalidMFAChallenge  = errors.New("การยืนยัน 2FA หมดอายุหรือไม่ถูกต้อง กรุณาเข้าสู่ระบบใหม่")
+	ErrInvalidMFACode       = errors.New("รหัสยืนยัน 2FA ไม่ถูกต้อง")
+	ErrMFANotEnrolled       = errors.New("ยังไม่ได้เริ่มลงทะเบียน 2FA")
+	ErrMFAAlreadyEnabled    = errors.New("เปิดใช้ 2FA อยู่แล้ว")
+	ErrMFANotEnabled        = errors.New("ยังไม่ได้เปิดใช้ 2FA")
+	ErrInvalidOIDCState     = errors.New("การเข้าสู่ระบบผ่าน identity provider หมดอายุหรือไม่ถูกต้อง กรุณาเริ่มใหม่")
+	ErrOIDCLoginFailed      = errors.New("เข้าสู่ระบบผ่าน identity provider ไม่สำเร็จ")
+	ErrOIDCEmailNotVerified = errors.New("identity provider ยังไม่ยืนยันอีเมลของบัญชีนี้")
+	ErrOIDCAccountConflict  = errors.New("อีเมลนี้ผูกกับบัญชีของ identity provider อื่นอยู่แล้ว")
+	ErrTooManyLoginAttempts = errors.New("เข้าสู่ระบบไม่สำเร็จหลายครั้งเกินไป กรุณารอสักครู่แล้วลองใหม่")
+
+	// ─── Password Errors ────────────────────────────────────────────
+
+	ErrIncorrectPassword = errors.New("รหัสผ่านปัจจุบันไม่ถูกต้อง")
+	ErrPasswordReused    = errors.New("รหัสผ่านใหม่ต้องไม่ซ้ำกับรหัสผ่านเดิม")
+	ErrPasswordTooLong   = errors.New("รหัสผ่านยาวเกิน 72 bytes")
+	ErrInvalidResetToken = errors.New("ลิงก์ตั้งรหัสผ่านใหม่ไม่ถูกต้อง หมดอายุ หรือถูกใช้ไปแล้ว")
+
+	// ─── Role & Permission Errors ───────────────────────────────────
+
+	ErrPermissionDenied = errors.New("ไม่มีสิทธิ์ดำเนินการนี้")
+	ErrRoleNotFound     = errors.New("ไม่พบบทบาทในระบบ")
+	ErrInvalidRole      = errors.New("ชื่อบทบาทหรือสิทธิ์ไม่ถูกต้อง")
+	ErrBuiltInRole      = errors.New("ไม่สามารถลบบทบาทเริ่มต้นหรือถอดสิทธิ์ user.manage ออกจากบทบาท admin ได้")
+	ErrRoleInUse        = errors.New("บทบาทนี้ยังมีผู้ใช้อยู่ — ย้ายผู้ใช้ไปบทบาทอื่นก่อนลบ")
+
+	// ─── Service Account Errors ─────────────────────────────────────
+
+	ErrServiceAccountNotFound = errors.New("ไม่พบ service account หรือถูกยกเลิกไปแล้ว")
+	ErrInvalidAPIKey          = errors.New("API key ไม่ถูกต้องหรือถูกยกเลิกแล้ว")
+	ErrInvalidAPIScope        = errors.New("scope ไม่ถูกต้อง")
+
+	// ─── Audit Log Errors ───────────────────────────────────────────
+
+	ErrAuditSequenceConflict = errors.New("sequence ของ audit event ซ้ำกับที่บันทึกไว้แล้ว")
+	ErrInvalidAuditFilter    = errors.New("เงื่อนไขค้นหา audit log ไม่ถูกต้อง")
+
+	// ─── Webhook Errors ─────────────────────────────────────────────
+
+	ErrWebhookNotFound         = errors.New("ไม่พบ webhook")
+	ErrWebhookDeliveryNotFound = errors.New("ไม่พบรายการส่ง webhook")
+	ErrWebhookDeliveryPending  = errors.New("รายการส่ง webhook นี้อยู่ระหว่างรอส่งอยู่แล้ว")
+	ErrInvalidWebhookURL       = errors.New("URL ของ webhook ต้องเป็น http หรือ https")
+	ErrInvalidWebhookSecret    = errors.New("secret ของ webhook ต้องยาวอย่างน้อย 16 ตัวอักษร")
+	ErrInvalidWebhookEvent     = errors.New("ต้องเลือกเหตุการณ์ของ webhook อย่างน้อยหนึ่งรายการ และต้องเป็นเหตุการณ์ที่รองรับ")
+	ErrInvalidWebhookFilter    = errors.New("เงื่อนไขค้นหารายการส่ง webhook ไม่ถูกต้อง")
+
+	// ─── Idempotency Errors ─────────────────────────────────────────
+
+	ErrInvalidIdempotencyKey    = errors.New("รูปแบบ Idempotency-Key ไม่ถูกต้อง (ต้องยาว 1-255 ตัวอักษรและเป็น ASCII ที่พิมพ์ได้)")
+	ErrIdempotencyKeyReused     = errors.New("มีการใช้ Idempotency-Key นี้กับ request อื่นแล้ว")
+	ErrIdempotencyKeyInProgress = errors.New("request ที่ใช้ Idempotency-Key นี้ยังทำงานอยู่ กรุณาลองใหม่ภายหลัง")
+
+	// ─── Import Errors ──────────────────────────────────────────────
+
+	ErrInvalidImportFile = errors.New("ไฟล์นำเข้าไม่ถูกต้อง")
+	ErrInvalidImportRow  = errors.New("ข้อมูลในแถวไม่ถูกต้อง")
 )
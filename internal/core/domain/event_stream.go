@@ -0,0 +1,24 @@
+package domain
+
+// EventStreamViewer ผู้ที่เชื่อมต่อ event stream แบบ real-time — สิทธิ์ถูกตรวจตอนเชื่อมต่อและตรวจซ้ำเป็นระยะ
+type EventStreamViewer struct {
+	UserID      ID
+	CanViewTeam bool // มีสิทธิ์ leave.view_team
+}
+
+// CanSee ผู้เชื่อมต่อเห็นเหตุการณ์นี้หรือไม่
+// - เจ้าของใบลาหรือยอดวันลาเห็นทุกเหตุการณ์ของตัวเอง
+// - ผู้มีสิทธิ์ดูใบลาของทีมเห็นใบลาใหม่และการเปลี่ยนสถานะใบลาของทุกคน (ไม่รวมการปรับยอดวันลา)
+// - การกล่าวถึงในความคิดเห็นแจ้งทางอีเมลเท่านั้น — บันทึกภายในต้องไม่ถึงเจ้าของใบลา
+func (v EventStreamViewer) CanSee(event *LeaveEvent) bool {
+	switch {
+	case event.Comment != nil:
+		return false
+	case event.Balance != nil:
+		return event.Balance.UserID == v.UserID
+	case event.Request != nil:
+		return event.Request.UserID == v.UserID || v.CanViewTeam
+	default:
+		return false
+	}
+}
@@ -0,0 +1,29 @@
+package domain
+
+// LeaveBalanceQuery เงื่อนไขส่งออกยอดวันลา — field ที่ว่างคือไม่กรอง
+type LeaveBalanceQuery struct {
+	UserID     *ID         // เจ้าของยอดวันลา
+	Year       *int        // ปีของยอดวันลา
+	LeaveTypes []LeaveType // ประเภทการลาใดประเภทหนึ่ง
+}
+
+func (q LeaveBalanceQuery) Validate() error {
+	if len(q.LeaveTypes) > MaxLeaveQueryValueLen {
+		return ErrInvalidLeaveQuery
+	}
+	for _, t := range q.LeaveTypes {
+		if !t.IsValid() {
+			return ErrInvalidLeaveQuery
+		}
+	}
+	if q.Year != nil && (*q.Year < MinReportYear || *q.Year > MaxReportYear) {
+		return ErrInvalidLeaveQuery
+	}
+	return nil
+}
+
+// LeaveBalanceView ยอดวันลาพร้อมข้อมูลย่อของพนักงาน
+type LeaveBalanceView struct {
+	User         *UserSummary `bson:"user,omitempty"` // พนักงาน (nil = ไม่พบผู้ใช้)
+	LeaveBalance `bson:",inline"`
+}
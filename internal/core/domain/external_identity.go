@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// AuthProviderLDAP ค่า AuthProvider ของผู้ใช้ที่ยืนยันตัวตนกับ LDAP/Active Directory
+const AuthProviderLDAP = "ldap"
+
+// ExternalIdentity ข้อมูลผู้ใช้จาก identity provider ภายนอก (claims ใน OIDC ID token หรือ entry ใน LDAP ที่ตรวจสอบแล้ว)
+type ExternalIdentity struct {
+	Provider      string   // issuer ของ IdP หรือ AuthProviderLDAP
+	Subject       string   // sub — รหัสผู้ใช้ใน IdP (ไม่เปลี่ยนแม้เปลี่ยนอีเมล)
+	Email         string   // อีเมล
+	FirstName     string   // ชื่อจริง (given_name)
+	LastName      string   // นามสกุล (family_name)
+	Groups        []string // กลุ่มที่ผู้ใช้อยู่ — ใช้กำหนดบทบาท
+	EmailVerified bool     // IdP ยืนยันอีเมลแล้ว
+	MFA           bool     // IdP ยืนยันตัวตนหลายปัจจัยแล้ว (amr)
+}
+
+// OIDCLoginState ข้อมูลที่ต้องจำระหว่าง redirect ไป IdP จนกลับมาที่ callback (ใช้ได้ครั้งเดียว)
+type OIDCLoginState struct {
+	ExpiresAt    time.Time `bson:"expires_at"`    // เวลาหมดอายุ
+	StateHash    string    `bson:"state_hash"`    // SHA-256 hash ของ state ที่ส่งไป IdP
+	Nonce        string    `bson:"nonce"`         // ต้องตรงกับ nonce ใน ID token (ป้องกัน replay)
+	CodeVerifier string    `bson:"code_verifier"` // PKCE code verifier
+	ID           ID        `bson:"_id"`           // รหัส state
+}
+
+// NewOIDCLoginState สร้าง state ใหม่ที่หมดอายุหลัง ttl
+func NewOIDCLoginState(stateHash, nonce, codeVerifier string, ttl time.Duration) *OIDCLoginState {
+	return &OIDCLoginState{
+		ID:           NewID(),
+		StateHash:    stateHash,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(ttl),
+	}
+}
+
+// IsExpired ตรวจสอบว่า state หมดอายุ ณ เวลาที่ระบุหรือไม่
+func (s *OIDCLoginState) IsExpired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
+
+// GroupRoleMapping แปลงกลุ่มใน IdP เป็นบทบาทในระบบ
+type GroupRoleMapping struct {
+	Groups      map[string]Role // ชื่อกลุ่ม (ไม่สนตัวพิมพ์) → บทบาท
+	DefaultRole Role            // บทบาทเมื่อไม่อยู่ในกลุ่มใดที่กำหนด
+}
+
+// Resolve เลือกบทบาทที่มีสิทธิ์สูงสุดจากกลุ่มที่ผู้ใช้อยู่
+func (m GroupRoleMapping) Resolve(groups []string) Role {
+	resolved := m.DefaultRole
+	for _, group := range groups {
+		role, ok := m.Groups[strings.ToLower(group)]
+		if ok && role.rank() > resolved.rank() {
+			resolved = role
+		}
+	}
+	return resolved
+}
+
+// Sync บทบาทของผู้ใช้เดิมหลัง login — เปลี่ยนเฉพาะเมื่อ mapping กำหนดบทบาทให้
+// อยู่ในกลุ่มที่กำหนดได้บทบาทของกลุ่ม, ออกจากกลุ่มแล้วบทบาทที่ได้จากกลุ่มกลับเป็น DefaultRole
+// ส่วนบทบาทที่ผู้ดูแลระบบกำหนดเอง (ไม่มีกลุ่มใดให้) คงไว้ตามเดิม
+func (m GroupRoleMapping) Sync(current Role, groups []string) Role {
+	for _, group := range groups {
+		if _, ok := m.Groups[strings.ToLower(group)]; ok {
+			return m.Resolve(groups)
+		}
+	}
+	for _, granted := range m.Groups {
+		if granted == current {
+			return m.DefaultRole
+		}
+	}
+	return current
+}
@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+const (
+	IdempotencyKeyMaxLength = 255            // ความยาวสูงสุดของ Idempotency-Key
+	IdempotencyLockTimeout  = time.Minute    // เวลาที่จอง key ระหว่างทำ request — เกินแล้ว request ใหม่ใช้ key นี้ได้
+	DefaultIdempotencyTTL   = 24 * time.Hour // เวลาที่เก็บ response ไว้ตอบ request ที่ส่งซ้ำ
+)
+
+// IdempotentResponse response ที่บันทึกไว้ตอบ request ที่ส่งซ้ำด้วย Idempotency-Key เดิม
+type IdempotentResponse struct {
+	ContentType string `bson:"content_type"`
+	Body        []byte `bson:"body"`
+	StatusCode  int    `bson:"status_code"`
+}
+
+// IdempotencyRecord การใช้ Idempotency-Key หนึ่งครั้งของผู้ใช้ — unique ตามผู้ใช้และ key
+type IdempotencyRecord struct {
+	CreatedAt   time.Time           `bson:"created_at"`
+	ExpiresAt   time.Time           `bson:"expires_at"`         // หมดเวลาจอง (ยังไม่มี response) หรือหมดอายุ (TTL)
+	Response    *IdempotentResponse `bson:"response,omitempty"` // nil = request แรกยังทำงานอยู่
+	Key         string              `bson:"key"`
+	Fingerprint string              `bson:"fingerprint"` // hash ของ method, path และ body — ใช้ตรวจว่าเป็น request เดียวกัน
+	UserID      ID                  `bson:"user_id"`
+	ID          ID                  `bson:"_id"`
+}
+
+// NewIdempotencyRecord จอง key สำหรับ request แรก — ตรวจรูปแบบ key ก่อน
+func NewIdempotencyRecord(userID ID, key, fingerprint string, now time.Time) (*IdempotencyRecord, error) {
+	if !isValidIdempotencyKey(key) {
+		return nil, ErrInvalidIdempotencyKey
+	}
+	return &IdempotencyRecord{
+		ID:          NewID(),
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(IdempotencyLockTimeout),
+	}, nil
+}
+
+// isValidIdempotencyKey key ต้องยาว 1-255 ตัวอักษรและเป็น ASCII ที่พิมพ์ได้
+func isValidIdempotencyKey(key string) bool {
+	if key == "" || len(key) > IdempotencyKeyMaxLength {
+		return false
+	}
+	for i := 0; i < len(key); i++ {
+		if key[i] < 0x21 || key[i] > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Replay ตรวจ request ที่ส่งซ้ำด้วย key นี้ — คืน response เดิมเมื่อเป็น request เดียวกันที่ทำเสร็จแล้ว
+func (r *IdempotencyRecord) Replay(fingerprint string) (*IdempotentResponse, error) {
+	if r.Fingerprint != fingerprint {
+		return nil, ErrIdempotencyKeyReused
+	}
+	if r.Response == nil {
+		return nil, ErrIdempotencyKeyInProgress
+	}
+	return r.Response, nil
+}
+
+// Complete บันทึก response ของ request แรกและเก็บไว้จนถึง now + ttl
+func (r *IdempotencyRecord) Complete(resp IdempotentResponse, now time.Time, ttl time.Duration) {
+	r.Response = &resp
+	r.ExpiresAt = now.Add(ttl)
+}
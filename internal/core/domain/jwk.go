@@ -0,0 +1,18 @@
+package domain
+
+// JSONWebKey public key ในรูปแบบ JWK (RFC 7517) — ใช้เผยแพร่ให้ service อื่นตรวจสอบ JWT
+type JSONWebKey struct {
+	Kty string `json:"kty"`           // ประเภทคีย์ (RSA / OKP)
+	Kid string `json:"kid"`           // รหัสคีย์ — ตรงกับ header kid ของ JWT
+	Use string `json:"use"`           // การใช้งาน (sig)
+	Alg string `json:"alg"`           // algorithm (RS256 / EdDSA)
+	N   string `json:"n,omitempty"`   // RSA modulus (base64url)
+	E   string `json:"e,omitempty"`   // RSA exponent (base64url)
+	Crv string `json:"crv,omitempty"` // curve ของ OKP (Ed25519)
+	X   string `json:"x,omitempty"`   // public key ของ OKP (base64url)
+}
+
+// JSONWebKeySet ชุด public keys สำหรับ endpoint /.well-known/jwks.json
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
@@ -15,6 +15,19 @@ type LeaveBalance struct {
 	Year        int       `json:"year"         bson:"year"`         // ปีที่ยอดวันลานี้ใช้ได้
 }
 
+// AuditSnapshot ค่าของยอดวันลาที่ใช้เทียบใน audit log — nil = ยังไม่มียอดวันลา
+func (b *LeaveBalance) AuditSnapshot() map[string]any {
+	if b == nil {
+		return nil
+	}
+	return map[string]any{
+		"user_id":    b.UserID,
+		"leave_type": b.LeaveType,
+		"year":       b.Year,
+		"total_days": b.TotalDays,
+	}
+}
+
 // RemainingDays คำนวณจำนวนวันลาคงเหลือ (หักทั้งที่ใช้แล้วและที่จองไว้)
 func (b *LeaveBalance) RemainingDays() float64 {
 	return b.TotalDays - b.UsedDays - b.PendingDays
@@ -0,0 +1,29 @@
+package domain
+
+import "time"
+
+// MaxCommentMentions จำนวนผู้ใช้สูงสุดที่กล่าวถึงได้ในความคิดเห็นเดียว
+const MaxCommentMentions = 10
+
+// LeaveComment ความคิดเห็นในใบลา — internal คือบันทึกที่เห็นเฉพาะผู้อนุมัติ (เจ้าของใบลาไม่เห็น)
+type LeaveComment struct {
+	CreatedAt time.Time `json:"created_at"         bson:"created_at"`         // เวลาที่แสดงความคิดเห็น
+	Mentions  []ID      `json:"mentions,omitempty" bson:"mentions,omitempty"` // ผู้ใช้ที่ถูกกล่าวถึง (ได้รับอีเมลแจ้งเตือน)
+	Body      string    `json:"body"               bson:"body"`               // ข้อความ
+	Internal  bool      `json:"internal"           bson:"internal"`           // บันทึกภายในของผู้อนุมัติ
+	ID        ID        `json:"id"                 bson:"_id"`                // รหัสความคิดเห็น (UUID)
+	RequestID ID        `json:"request_id"         bson:"request_id"`         // รหัสใบลา
+	AuthorID  ID        `json:"author_id"          bson:"author_id"`          // ผู้แสดงความคิดเห็น
+}
+
+func NewLeaveComment(requestID, authorID ID, body string, internal bool, mentions []ID) *LeaveComment {
+	return &LeaveComment{
+		ID:        NewID(),
+		RequestID: requestID,
+		AuthorID:  authorID,
+		Body:      body,
+		Internal:  internal,
+		Mentions:  mentions,
+		CreatedAt: time.Now(),
+	}
+}
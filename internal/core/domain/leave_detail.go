@@ -0,0 +1,59 @@
+package domain
+
+// LeaveBalanceEffect ผลของใบลาต่อยอดวันลาตามสถานะปัจจุบัน
+type LeaveBalanceEffect string
+
+const (
+	LeaveBalanceEffectReserved LeaveBalanceEffect = "reserved" // รออนุมัติ — จองไว้ใน pending_days
+	LeaveBalanceEffectDeducted LeaveBalanceEffect = "deducted" // อนุมัติแล้ว — หักเป็น used_days
+	LeaveBalanceEffectNone     LeaveBalanceEffect = "none"     // ถูกปฏิเสธหรือยกเลิก — ไม่กระทบยอดวันลา
+)
+
+// LeaveBalanceImpact ผลของใบลาต่อยอดวันลาประเภทและปีเดียวกับใบลา
+type LeaveBalanceImpact struct {
+	Balance *LeaveBalance      // ยอดวันลาปัจจุบัน (nil = ยังไม่มียอดวันลาของประเภทและปีนี้)
+	Effect  LeaveBalanceEffect // ใบลานี้จองหรือหักยอดวันลาอยู่หรือไม่
+	Days    float64            // จำนวนวันที่ใบลานี้จองหรือหักไว้ (0 เมื่อไม่กระทบ)
+	Year    int                // ปีของยอดวันลาที่ใบลานี้ใช้
+}
+
+// NewLeaveBalanceImpact คำนวณผลของใบลาต่อยอดวันลา — ยอดวันลาของปีที่เริ่มลา
+func NewLeaveBalanceImpact(request *LeaveRequest, balance *LeaveBalance) LeaveBalanceImpact {
+	impact := LeaveBalanceImpact{Balance: balance, Effect: LeaveBalanceEffectNone, Year: request.StartDate.Year()}
+	switch request.Status {
+	case LeaveStatusPending:
+		impact.Effect, impact.Days = LeaveBalanceEffectReserved, request.TotalDays
+	case LeaveStatusApproved:
+		impact.Effect, impact.Days = LeaveBalanceEffectDeducted, request.TotalDays
+	case LeaveStatusRejected, LeaveStatusCancelled:
+		// ปล่อยวันที่จองไว้แล้ว — ไม่กระทบยอดวันลา
+	}
+	return impact
+}
+
+// LeaveRequestView ใบลาพร้อมข้อมูลย่อของผู้ยื่นและผู้อนุมัติ — ได้จาก $lookup หรือ FindByIDs ไม่ได้บันทึกลงใบลา
+type LeaveRequestView struct {
+	Requester    *UserSummary `bson:"requester,omitempty"` // ผู้ยื่นใบลา (nil = ไม่พบผู้ใช้)
+	Reviewer     *UserSummary `bson:"reviewer,omitempty"`  // ผู้อนุมัติ/ปฏิเสธ (nil = ยังไม่ได้พิจารณาหรือไม่พบผู้ใช้)
+	LeaveRequest `bson:",inline"`
+}
+
+// NewLeaveRequestView แนบข้อมูลย่อของผู้ยื่นและผู้อนุมัติจาก users ที่ค้นมาแล้ว
+func NewLeaveRequestView(request *LeaveRequest, users map[ID]UserSummary) LeaveRequestView {
+	view := LeaveRequestView{LeaveRequest: *request}
+	if u, ok := users[request.UserID]; ok {
+		view.Requester = &u
+	}
+	if request.ReviewerID != nil {
+		if u, ok := users[*request.ReviewerID]; ok {
+			view.Reviewer = &u
+		}
+	}
+	return view
+}
+
+// LeaveRequestDetail ใบลาพร้อมข้อมูลประกอบสำหรับหน้ารายละเอียด
+type LeaveRequestDetail struct {
+	BalanceImpact    LeaveBalanceImpact // ผลต่อยอดวันลา
+	LeaveRequestView                    // ใบลา + ผู้ยื่น + ผู้อนุมัติ
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// LeaveHistoryAction ประเภทเหตุการณ์ในประวัติของใบลา
+type LeaveHistoryAction string
+
+const (
+	LeaveHistoryCreated    LeaveHistoryAction = "created"     // ยื่นใบลา
+	LeaveHistoryEdited     LeaveHistoryAction = "edited"      // เจ้าของแก้ไขรายละเอียดใบลา
+	LeaveHistoryCommented  LeaveHistoryAction = "commented"   // แสดงความคิดเห็น
+	LeaveHistoryApproved   LeaveHistoryAction = "approved"    // อนุมัติ
+	LeaveHistoryRejected   LeaveHistoryAction = "rejected"    // ปฏิเสธ
+	LeaveHistoryRolledBack LeaveHistoryAction = "rolled_back" // ระบบคืนสถานะเป็น pending เพราะปรับยอดวันลาไม่สำเร็จ
+	LeaveHistoryCancelled  LeaveHistoryAction = "cancelled"   // เจ้าของยกเลิกใบลา
+)
+
+// LeaveHistoryEntry เหตุการณ์หนึ่งรายการในประวัติของใบลา — เพิ่มได้อย่างเดียว
+type LeaveHistoryEntry struct {
+	OccurredAt time.Time          `json:"occurred_at"           bson:"occurred_at"`           // เวลาที่เกิด
+	ActorID    *ID                `json:"actor_id,omitempty"    bson:"actor_id,omitempty"`    // ผู้กระทำ (nil = ระบบ)
+	Changes    []AuditChange      `json:"changes,omitempty"     bson:"changes,omitempty"`     // ค่าที่เปลี่ยน
+	Action     LeaveHistoryAction `json:"action"                bson:"action"`                // ประเภทเหตุการณ์
+	FromStatus LeaveStatus        `json:"from_status,omitempty" bson:"from_status,omitempty"` // สถานะก่อนเกิดเหตุการณ์ (ว่าง = ใบลาใหม่)
+	ToStatus   LeaveStatus        `json:"to_status"             bson:"to_status"`             // สถานะหลังเกิดเหตุการณ์
+	Note       string             `json:"note,omitempty"        bson:"note,omitempty"`        // หมายเหตุ เช่น หมายเหตุผู้อนุมัติหรือสาเหตุที่ rollback
+	ID         ID                 `json:"id"                    bson:"_id"`                   // รหัสเหตุการณ์ (UUID)
+	RequestID  ID                 `json:"request_id"            bson:"request_id"`            // รหัสใบลา
+}
+
+// LeaveHistoryView เหตุการณ์ของใบลาพร้อมข้อมูลย่อของผู้กระทำ
+type LeaveHistoryView struct {
+	Actor *UserSummary // ผู้กระทำ (nil = ระบบหรือไม่พบผู้ใช้)
+	LeaveHistoryEntry
+}
+
+// NewLeaveHistoryEntry สร้างเหตุการณ์ของใบลา — ค่าที่เปลี่ยนได้จากการเทียบ snapshot ก่อน/หลัง
+func NewLeaveHistoryEntry(
+	action LeaveHistoryAction,
+	actorID *ID,
+	fromStatus LeaveStatus,
+	request *LeaveRequest,
+	before map[string]any,
+	note string,
+) *LeaveHistoryEntry {
+	return &LeaveHistoryEntry{
+		ID:         NewID(),
+		RequestID:  request.ID,
+		Action:     action,
+		ActorID:    actorID,
+		FromStatus: fromStatus,
+		ToStatus:   request.Status,
+		Changes:    DiffChanges(before, request.AuditSnapshot()),
+		Note:       note,
+		OccurredAt: time.Now().UTC().Truncate(time.Millisecond),
+	}
+}
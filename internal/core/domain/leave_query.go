@@ -0,0 +1,157 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	MaxLeaveSortKeys      = 3   // จำนวน field ที่เรียงได้พร้อมกันสูงสุด
+	MaxLeaveSearchLength  = 100 // ความยาวสูงสุดของคำค้นใน reason
+	MaxLeaveQueryValueLen = 10  // จำนวนค่าสูงสุดของ filter แบบหลายค่า (status, leave_type)
+)
+
+// LeaveSortField field ที่ใช้เรียงรายการใบลาได้ — จำกัดไว้เฉพาะ field ที่มี index รองรับ
+type LeaveSortField string
+
+const (
+	LeaveSortCreatedAt  LeaveSortField = "created_at"
+	LeaveSortStartDate  LeaveSortField = "start_date"
+	LeaveSortEndDate    LeaveSortField = "end_date"
+	LeaveSortTotalDays  LeaveSortField = "total_days"
+	LeaveSortReviewedAt LeaveSortField = "reviewed_at"
+)
+
+func (f LeaveSortField) IsValid() bool {
+	switch f {
+	case LeaveSortCreatedAt, LeaveSortStartDate, LeaveSortEndDate, LeaveSortTotalDays, LeaveSortReviewedAt:
+		return true
+	}
+	return false
+}
+
+// LeaveSort การเรียงตาม field หนึ่ง
+type LeaveSort struct {
+	Field      LeaveSortField
+	Descending bool
+}
+
+// ParseLeaveSort แปลงค่าเช่น "-start_date,created_at" — ขึ้นต้นด้วย "-" คือเรียงจากมากไปน้อย
+func ParseLeaveSort(raw string) ([]LeaveSort, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var sorts []LeaveSort
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		sort := LeaveSort{Field: LeaveSortField(strings.TrimPrefix(part, "-")), Descending: strings.HasPrefix(part, "-")}
+		if !sort.Field.IsValid() {
+			return nil, ErrInvalidLeaveQuery
+		}
+		sorts = append(sorts, sort)
+	}
+	return sorts, nil
+}
+
+// LeaveRequestQuery เงื่อนไขค้นหาใบลา — field ที่ว่างคือไม่กรอง
+type LeaveRequestQuery struct {
+	From       *time.Time    // ใบลาที่ช่วงวันลาคาบเกี่ยวตั้งแต่วันนี้ (รวม)
+	To         *time.Time    // ใบลาที่ช่วงวันลาคาบเกี่ยวถึงวันนี้ (รวม)
+	UserID     *ID           // เจ้าของใบลา
+	ReviewerID *ID           // ผู้อนุมัติ/ปฏิเสธ
+	MinDays    *float64      // จำนวนวันลาขั้นต่ำ (รวม)
+	MaxDays    *float64      // จำนวนวันลาสูงสุด (รวม)
+	Search     string        // คำค้นใน reason (full-text)
+	Statuses   []LeaveStatus // สถานะใดสถานะหนึ่ง
+	LeaveTypes []LeaveType   // ประเภทการลาใดประเภทหนึ่ง
+	Sort       []LeaveSort   // ลำดับการเรียง (ว่าง = ตามค่าเริ่มต้นของแต่ละรายการ)
+}
+
+// Validate ตรวจค่าของ filter และการเรียง
+func (q LeaveRequestQuery) Validate() error {
+	if len(q.Statuses) > MaxLeaveQueryValueLen || len(q.LeaveTypes) > MaxLeaveQueryValueLen {
+		return ErrInvalidLeaveQuery
+	}
+	for _, s := range q.Statuses {
+		if !s.IsValid() {
+			return ErrInvalidLeaveQuery
+		}
+	}
+	for _, t := range q.LeaveTypes {
+		if !t.IsValid() {
+			return ErrInvalidLeaveQuery
+		}
+	}
+	if len([]rune(q.Search)) > MaxLeaveSearchLength {
+		return ErrInvalidLeaveQuery
+	}
+	if err := q.validateRanges(); err != nil {
+		return err
+	}
+	return q.validateSort()
+}
+
+// validateRanges ช่วงวันที่และจำนวนวันต้องไม่กลับด้าน และจำนวนวันต้องไม่ติดลบ
+func (q LeaveRequestQuery) validateRanges() error {
+	if q.From != nil && q.To != nil && q.From.After(*q.To) {
+		return ErrInvalidLeaveQuery
+	}
+	if q.MinDays != nil && *q.MinDays < 0 || q.MaxDays != nil && *q.MaxDays < 0 {
+		return ErrInvalidLeaveQuery
+	}
+	if q.MinDays != nil && q.MaxDays != nil && *q.MinDays > *q.MaxDays {
+		return ErrInvalidLeaveQuery
+	}
+	return nil
+}
+
+func (q LeaveRequestQuery) validateSort() error {
+	if len(q.Sort) > MaxLeaveSortKeys {
+		return ErrInvalidLeaveQuery
+	}
+	seen := make(map[LeaveSortField]bool, len(q.Sort))
+	for _, s := range q.Sort {
+		if !s.Field.IsValid() || seen[s.Field] {
+			return ErrInvalidLeaveQuery
+		}
+		seen[s.Field] = true
+	}
+	return nil
+}
+
+// WithDefaultSort ใช้การเรียงที่กำหนดเมื่อผู้เรียกไม่ได้ระบุ
+func (q LeaveRequestQuery) WithDefaultSort(sort ...LeaveSort) LeaveRequestQuery {
+	if len(q.Sort) == 0 {
+		q.Sort = sort
+	}
+	return q
+}
+
+// ValidatePagination ตรวจว่าแบ่งหน้าแบบ keyset ได้ — ต้องเรียงด้วย created_at อย่างเดียวและทิศทางตรงกับ cursor
+func (q LeaveRequestQuery) ValidatePagination(params PaginationParams) error {
+	if !params.Keyset {
+		return nil
+	}
+	descending, ok := q.CursorDirection()
+	if !ok || (params.After != nil && params.After.Descending != descending) {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+// CursorDirection ทิศทางของ created_at เมื่อเรียงด้วย created_at อย่างเดียว (ok = false คือใช้ cursor ไม่ได้)
+func (q LeaveRequestQuery) CursorDirection() (descending, ok bool) {
+	if len(q.Sort) != 1 || q.Sort[0].Field != LeaveSortCreatedAt {
+		return false, false
+	}
+	return q.Sort[0].Descending, true
+}
+
+// CursorOf cursor ที่ชี้ไปยังใบลาในรายการที่เรียงตาม query นี้
+func (q LeaveRequestQuery) CursorOf(r *LeaveRequest) *Cursor {
+	descending, ok := q.CursorDirection()
+	if !ok {
+		return nil
+	}
+	return &Cursor{CreatedAt: r.CreatedAt, ID: r.ID, Descending: descending}
+}
@@ -64,6 +64,46 @@ func (r *LeaveRequest) Reject(reviewerID ID, note string) error {
 	return nil
 }
 
+// Edit แก้ประเภท ช่วงวัน และเหตุผลของใบลา — ทำได้เฉพาะใบลาที่อยู่ในสถานะ Pending เท่านั้น
+func (r *LeaveRequest) Edit(leaveType LeaveType, startDate, endDate time.Time, reason string) error {
+	if r.Status != LeaveStatusPending {
+		return ErrRequestNotPending
+	}
+	r.LeaveType = leaveType
+	r.StartDate = startDate
+	r.EndDate = endDate
+	r.TotalDays = CalculateLeaveDays(startDate, endDate)
+	r.Reason = reason
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// Cancel เจ้าของยกเลิกใบลา — ทำได้เฉพาะใบลาที่อยู่ในสถานะ Pending เท่านั้น
+// (ใบลาที่อนุมัติแล้วต้องคืน used_days และแจ้งผู้อนุมัติ ยังไม่รองรับ)
+func (r *LeaveRequest) Cancel() error {
+	if r.Status != LeaveStatusPending {
+		return ErrRequestNotPending
+	}
+	r.Status = LeaveStatusCancelled
+	r.UpdatedAt = time.Now()
+	return nil
+}
+
+// AuditSnapshot ค่าของใบลาที่ใช้เทียบใน audit log
+func (r *LeaveRequest) AuditSnapshot() map[string]any {
+	return map[string]any{
+		"user_id":     r.UserID,
+		"leave_type":  r.LeaveType,
+		"start_date":  r.StartDate,
+		"end_date":    r.EndDate,
+		"total_days":  r.TotalDays,
+		"reason":      r.Reason,
+		"status":      r.Status,
+		"reviewer_id": r.ReviewerID,
+		"review_note": r.ReviewNote,
+	}
+}
+
 // CalculateLeaveDays คำนวณจำนวนวันลาจากวันเริ่มต้นถึงวันสิ้นสุด (นับรวมวันเริ่มต้น)
 func CalculateLeaveDays(startDate, endDate time.Time) float64 {
 	start := time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.UTC)
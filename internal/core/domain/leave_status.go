@@ -3,14 +3,15 @@ package domain
 type LeaveStatus string // สถานะของใบลา
 
 const (
-	LeaveStatusPending  LeaveStatus = "pending"  // ใบลารอการอนุมัติจากผู้จัดการ
-	LeaveStatusApproved LeaveStatus = "approved" // ใบลาได้รับการอนุมัติแล้ว — หักวันลาจากยอดคงเหลือ
-	LeaveStatusRejected LeaveStatus = "rejected" // ใบลาถูกปฏิเสธ — ยอดวันลาไม่เปลี่ยนแปลง
+	LeaveStatusPending   LeaveStatus = "pending"   // ใบลารอการอนุมัติจากผู้จัดการ
+	LeaveStatusApproved  LeaveStatus = "approved"  // ใบลาได้รับการอนุมัติแล้ว — หักวันลาจากยอดคงเหลือ
+	LeaveStatusRejected  LeaveStatus = "rejected"  // ใบลาถูกปฏิเสธ — ยอดวันลาไม่เปลี่ยนแปลง
+	LeaveStatusCancelled LeaveStatus = "cancelled" // เจ้าของยกเลิกก่อนได้รับการพิจารณา — ปล่อยวันลาที่จองไว้
 )
 
 func (s LeaveStatus) IsValid() bool {
 	switch s {
-	case LeaveStatusPending, LeaveStatusApproved, LeaveStatusRejected:
+	case LeaveStatusPending, LeaveStatusApproved, LeaveStatusRejected, LeaveStatusCancelled:
 		return true
 	default:
 		return false
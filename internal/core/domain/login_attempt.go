@@ -0,0 +1,81 @@
+package domain
+
+import "time"
+
+// LoginAttempt สถิติการ login ผิดของแต่ละอีเมล — นับทุกอีเมล (รวมที่ไม่มีในระบบ) เพื่อไม่ให้เดาได้ว่าอีเมลมีอยู่หรือไม่
+// แต่ละความพยายามถูกนับไว้ก่อนตรวจรหัสผ่าน และล้างเมื่อ login สำเร็จ
+type LoginAttempt struct {
+	LastFailedAt time.Time  `json:"last_failed_at"         bson:"last_failed_at"`         // เวลาที่นับความพยายามล่าสุด
+	LockedUntil  *time.Time `json:"locked_until,omitempty" bson:"locked_until,omitempty"` // ล็อกบัญชีถึงเวลานี้ (nil = ไม่ถูกล็อก)
+	Email        string     `json:"email"                  bson:"_id"`                    // อีเมลที่ใช้ login (lowercase)
+	FailedCount  int        `json:"failed_count"           bson:"failed_count"`           // จำนวนครั้งที่ผิดติดต่อกันนับจากสำเร็จหรือถูกล็อกครั้งล่าสุด
+	LockCount    int        `json:"lock_count"             bson:"lock_count"`             // จำนวนครั้งที่ถูกล็อกนับจาก login สำเร็จครั้งล่าสุด
+	Version      int        `json:"-"                      bson:"version"`                // เพิ่มทุกครั้งที่นับ — ใช้ตรวจว่ามีคำขออื่นนับไปก่อนหรือไม่
+}
+
+// LockoutPolicy นโยบายหน่วงเวลาและล็อกบัญชีเมื่อ login ผิดติดต่อกัน
+type LockoutPolicy struct {
+	MaxFailedAttempts  int           // ผิดครบจำนวนนี้จะถูกล็อก
+	LockoutDuration    time.Duration // ระยะเวลาล็อกครั้งแรก — เพิ่มเป็นเท่าตัวทุกครั้งที่ถูกล็อกซ้ำ
+	MaxLockoutDuration time.Duration // ระยะเวลาล็อกสูงสุด
+	BaseBackoff        time.Duration // เวลาหน่วงหลังผิดครั้งที่ 2 — เพิ่มเป็นเท่าตัวทุกครั้งที่ผิด
+	MaxBackoff         time.Duration // เวลาหน่วงสูงสุด
+}
+
+// DefaultLockoutPolicy ผิด 5 ครั้งล็อก 15, 30, 60 ... นาที (สูงสุด 4 ชั่วโมง) หน่วง 1, 2, 4 ... วินาที (สูงสุด 30 วินาที) ระหว่างนั้น
+func DefaultLockoutPolicy() LockoutPolicy {
+	return LockoutPolicy{
+		MaxFailedAttempts:  5,
+		LockoutDuration:    15 * time.Minute,
+		MaxLockoutDuration: 4 * time.Hour,
+		BaseBackoff:        time.Second,
+		MaxBackoff:         30 * time.Second,
+	}
+}
+
+// NextAllowedAt คืนเวลาที่เร็วที่สุดที่อนุญาตให้ login ครั้งถัดไป
+func (p LockoutPolicy) NextAllowedAt(a *LoginAttempt) time.Time {
+	var next time.Time
+	if a.LockedUntil != nil {
+		next = *a.LockedUntil
+	}
+
+	// ผิดครั้งแรกยังไม่หน่วง เผื่อพิมพ์ผิด
+	if a.FailedCount >= 2 {
+		backoff := p.BaseBackoff << (a.FailedCount - 2)
+		if backoff > p.MaxBackoff || backoff <= 0 {
+			backoff = p.MaxBackoff
+		}
+		if t := a.LastFailedAt.Add(backoff); t.After(next) {
+			next = t
+		}
+	}
+
+	return next
+}
+
+// ShouldLock ตรวจสอบว่าจำนวนครั้งที่ผิดถึงเกณฑ์ล็อกบัญชีแล้วหรือไม่
+func (p LockoutPolicy) ShouldLock(a *LoginAttempt) bool {
+	return a.FailedCount >= p.MaxFailedAttempts
+}
+
+// RecordAttempt นับความพยายามครั้งใหม่ — ถึงเกณฑ์แล้วล็อกนานกว่าครั้งก่อนและเริ่มนับใหม่ คืน true ถ้าครั้งนี้ทำให้ถูกล็อก
+func (p LockoutPolicy) RecordAttempt(a *LoginAttempt, now time.Time) bool {
+	a.FailedCount++
+	a.LastFailedAt = now
+	a.Version++
+	if !p.ShouldLock(a) {
+		return false
+	}
+
+	a.LockCount++
+	limit := max(p.MaxLockoutDuration, p.LockoutDuration) // ไม่สั้นกว่าระยะเวลาล็อกที่ตั้งไว้
+	duration := p.LockoutDuration << (a.LockCount - 1)
+	if duration > limit || duration <= 0 {
+		duration = limit
+	}
+	lockedUntil := now.Add(duration)
+	a.LockedUntil = &lockedUntil
+	a.FailedCount = 0
+	return true
+}
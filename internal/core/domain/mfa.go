@@ -0,0 +1,79 @@
+package domain
+
+import "time"
+
+// UserMFA สถานะ two-factor authentication (TOTP) ของผู้ใช้ — แยก collection จาก users
+type UserMFA struct {
+	EnabledAt          *time.Time `json:"enabled_at,omitempty" bson:"enabled_at,omitempty"`     // เวลาที่เปิดใช้ (nil = ยังไม่เปิด)
+	Secret             string     `json:"-"                    bson:"secret,omitempty"`         // TOTP secret (base32) ที่ยืนยันแล้ว
+	PendingSecret      string     `json:"-"                    bson:"pending_secret,omitempty"` // secret ที่สร้างแล้วแต่ยังไม่ยืนยันรหัส
+	RecoveryCodeHashes []string   `json:"-"                    bson:"recovery_code_hashes"`     // SHA-256 hash ของ recovery code ที่ยังไม่ถูกใช้
+	LastUsedStep       int64      `json:"-"                    bson:"last_used_step"`           // time step ล่าสุดที่ใช้แล้ว — ป้องกันการใช้รหัสเดิมซ้ำ
+	UserID             ID         `json:"user_id"              bson:"_id"`                      // รหัสผู้ใช้
+}
+
+// IsEnabled ตรวจสอบว่าผู้ใช้เปิดใช้ 2FA แล้วหรือไม่
+func (m *UserMFA) IsEnabled() bool {
+	return m.EnabledAt != nil && m.Secret != ""
+}
+
+// TOTPEnrollment ข้อมูลสำหรับตั้งค่าแอป authenticator (แสดงครั้งเดียวตอนเริ่มลงทะเบียน)
+type TOTPEnrollment struct {
+	Secret     string // secret แบบ base32 สำหรับกรอกเอง
+	OTPAuthURI string // otpauth:// URI สำหรับสร้าง QR code
+}
+
+// MFAChallenge ผลของการ login ขั้นแรกสำหรับผู้ใช้ที่เปิด 2FA — ต้องนำ token ไปยืนยันพร้อมรหัส
+type MFAChallenge struct {
+	ExpiresAt time.Time `json:"expires_at" bson:"expires_at"` // เวลาหมดอายุ (สั้น)
+	TokenHash string    `json:"-"          bson:"token_hash"` // SHA-256 hash ของ challenge token
+	Token     string    `json:"-"          bson:"-"`          // challenge token จริง (ไม่เก็บลงฐานข้อมูล)
+	ID        ID        `json:"id"         bson:"_id"`        // รหัส challenge
+	UserID    ID        `json:"user_id"    bson:"user_id"`    // ผู้ใช้ที่ผ่านรหัสผ่านแล้ว
+}
+
+func NewMFAChallenge(userID ID, token, tokenHash string, ttl time.Duration) *MFAChallenge {
+	return &MFAChallenge{
+		ID:        NewID(),
+		UserID:    userID,
+		Token:     token,
+		TokenHash: tokenHash,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+}
+
+// IsExpired ตรวจสอบว่า challenge หมดอายุ ณ เวลาที่ระบุหรือไม่
+func (c *MFAChallenge) IsExpired(now time.Time) bool {
+	return !now.Before(c.ExpiresAt)
+}
+
+// LoginResult ผลการ login ขั้นแรก — ได้ Tokens ทันที หรือได้ Challenge ถ้าผู้ใช้เปิด 2FA
+type LoginResult struct {
+	Tokens    *AuthTokens   // token ชุดเต็ม (nil ถ้าต้องยืนยัน 2FA)
+	User      *User         // ผู้ใช้ที่ login
+	Challenge *MFAChallenge // challenge สำหรับยืนยัน 2FA (nil ถ้าไม่ต้อง)
+}
+
+// MFAPolicy สิทธิ์ที่ต้องยืนยันตัวตนด้วย 2FA — บทบาทใดที่มีสิทธิ์เหล่านี้ (รวมบทบาทที่สร้างเอง) ต้องผ่าน 2FA
+// ก่อนใช้งาน endpoint ที่มีสิทธิ์สูง
+type MFAPolicy struct {
+	RequiredPermissions []Permission
+}
+
+// DefaultMFARequiredPermissions สิทธิ์ที่เปลี่ยนข้อมูลของผู้อื่นได้
+func DefaultMFARequiredPermissions() []Permission {
+	return []Permission{PermissionLeaveApprove, PermissionUserManage, PermissionBalanceAdjust}
+}
+
+// Requires ตรวจสอบว่าบทบาทนี้ต้องใช้ 2FA หรือไม่
+func (p MFAPolicy) Requires(role *RoleDefinition) bool {
+	if role == nil {
+		return false
+	}
+	for _, permission := range p.RequiredPermissions {
+		if role.Has(permission) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,90 @@
+package domain
+
+import "time"
+
+// LeaveEventType ประเภทเหตุการณ์ของใบลาที่ส่งต่อให้ handler ผ่าน outbox
+type LeaveEventType string
+
+const (
+	LeaveEventSubmitted       LeaveEventType = "leave.submitted"  // ยื่นใบลา — แจ้งผู้อนุมัติ
+	LeaveEventApproved        LeaveEventType = "leave.approved"   // อนุมัติ — แจ้งเจ้าของใบลา
+	LeaveEventRejected        LeaveEventType = "leave.rejected"   // ปฏิเสธ — แจ้งเจ้าของใบลา
+	LeaveEventBalanceAdjusted LeaveEventType = "balance.adjusted" // กำหนดจำนวนวันลาที่ได้รับ — ส่งเฉพาะ webhook
+
+	LeaveEventCommentMentioned LeaveEventType = "comment.mentioned" // กล่าวถึงผู้ใช้ในความคิดเห็น — ส่งเฉพาะอีเมลถึงผู้ที่ถูกกล่าวถึง
+)
+
+// IsValid ประเภทเหตุการณ์ที่ webhook รับได้ — ไม่รวม comment.mentioned เพราะความคิดเห็นอาจเป็นบันทึกภายใน
+func (t LeaveEventType) IsValid() bool {
+	switch t {
+	case LeaveEventSubmitted, LeaveEventApproved, LeaveEventRejected, LeaveEventBalanceAdjusted:
+		return true
+	default:
+		return false
+	}
+}
+
+// LeaveEvent เหตุการณ์ที่เกิดกับใบลาหรือยอดวันลา (domain event) — เก็บสำเนา ณ เวลานั้นและถูกบันทึกลง outbox
+// ใน transaction เดียวกับการเปลี่ยนแปลง
+type LeaveEvent struct {
+	OccurredAt time.Time      `bson:"occurred_at"`       // เวลาที่เกิด
+	Request    *LeaveRequest  `bson:"request,omitempty"` // สำเนาใบลาหลังเกิดเหตุการณ์ (nil สำหรับ balance.adjusted)
+	Balance    *LeaveBalance  `bson:"balance,omitempty"` // สำเนายอดวันลาหลังปรับ (เฉพาะ balance.adjusted)
+	Comment    *LeaveComment  `bson:"comment,omitempty"` // ความคิดเห็นที่กล่าวถึงผู้ใช้ (เฉพาะ comment.mentioned)
+	Type       LeaveEventType `bson:"type"`              // ประเภทเหตุการณ์
+	ID         ID             `bson:"id"`                // รหัสเหตุการณ์ — idempotency key ที่ handler ใช้ตัดเหตุการณ์ซ้ำ
+	ActorID    ID             `bson:"actor_id"`          // ผู้ยื่นหรือผู้พิจารณา (ว่าง = ระบบหรือไม่ทราบ)
+}
+
+func NewLeaveEvent(eventType LeaveEventType, request *LeaveRequest, actorID ID) LeaveEvent {
+	snapshot := *request
+	return LeaveEvent{
+		ID:         NewID(),
+		OccurredAt: time.Now(),
+		Type:       eventType,
+		Request:    &snapshot,
+		ActorID:    actorID,
+	}
+}
+
+func NewBalanceAdjustedEvent(balance *LeaveBalance, actorID ID) LeaveEvent {
+	snapshot := *balance
+	return LeaveEvent{
+		ID:         NewID(),
+		OccurredAt: time.Now(),
+		Type:       LeaveEventBalanceAdjusted,
+		Balance:    &snapshot,
+		ActorID:    actorID,
+	}
+}
+
+// NewCommentMentionedEvent เหตุการณ์กล่าวถึงผู้ใช้ในความคิดเห็น — ผู้กระทำคือผู้แสดงความคิดเห็น
+func NewCommentMentionedEvent(request *LeaveRequest, comment *LeaveComment) LeaveEvent {
+	event := NewLeaveEvent(LeaveEventCommentMentioned, request, comment.AuthorID)
+	snapshot := *comment
+	event.Comment = &snapshot
+	return event
+}
+
+// SubjectID รหัสใบลาหรือยอดวันลาที่เหตุการณ์นี้อ้างถึง
+func (e *LeaveEvent) SubjectID() ID {
+	if e.Balance != nil {
+		return e.Balance.ID
+	}
+	if e.Request != nil {
+		return e.Request.ID
+	}
+	return ID{}
+}
+
+// Locale ภาษาของอีเมลแจ้งเตือน
+type Locale string
+
+const (
+	LocaleThai    Locale = "th" // ภาษาไทย
+	LocaleEnglish Locale = "en" // ภาษาอังกฤษ
+)
+
+func (l Locale) IsValid() bool {
+	return l == LocaleThai || l == LocaleEnglish
+}
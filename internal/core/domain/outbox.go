@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+const (
+	MaxOutboxAttempts    = 10               // จำนวนครั้งที่ส่งต่อได้ก่อนย้ายเป็น failed
+	outboxBaseRetryDelay = 5 * time.Second  // เวลารอก่อนลองใหม่ครั้งแรก — เพิ่มเป็นสองเท่าทุกครั้ง
+	outboxMaxRetryDelay  = 10 * time.Minute // เวลารอสูงสุดระหว่างครั้ง
+	outboxErrorMaxLength = 500              // ความยาวสูงสุด (ตัวอักษร) ของข้อความ error ที่เก็บไว้
+)
+
+// OutboxStatus สถานะของเหตุการณ์ใน outbox
+type OutboxStatus string
+
+const (
+	OutboxPending   OutboxStatus = "pending"   // รอส่งต่อหรือรอลองใหม่
+	OutboxProcessed OutboxStatus = "processed" // handler ทุกตัวทำสำเร็จแล้ว
+	OutboxFailed    OutboxStatus = "failed"    // ลองครบจำนวนครั้งแล้วยังมี handler ที่ไม่สำเร็จ
+)
+
+// OutboxMessage เหตุการณ์ที่รอส่งต่อให้ handler ภายใน process — บันทึกพร้อมกับการเปลี่ยนแปลงที่ทำให้เกิดเหตุการณ์
+type OutboxMessage struct {
+	CreatedAt     time.Time    `bson:"created_at"`
+	NextAttemptAt time.Time    `bson:"next_attempt_at"`        // เวลาที่ส่งต่อครั้งถัดไป
+	ProcessedAt   *time.Time   `bson:"processed_at,omitempty"` // เวลาที่ handler ทุกตัวทำสำเร็จ (TTL)
+	Event         LeaveEvent   `bson:"event"`
+	Status        OutboxStatus `bson:"status"`
+	LastError     string       `bson:"last_error,omitempty"`
+	Completed     []string     `bson:"completed"` // ชื่อ handler ที่ทำสำเร็จแล้ว — ไม่ถูกเรียกซ้ำเมื่อลองใหม่
+	Attempts      int          `bson:"attempts"`
+	ID            ID           `bson:"_id"` // เท่ากับรหัสเหตุการณ์ — เหตุการณ์หนึ่งอยู่ใน outbox ได้ครั้งเดียว
+}
+
+// NewOutboxMessage สร้างข้อความ outbox ของเหตุการณ์ที่พร้อมส่งต่อทันที
+func NewOutboxMessage(event LeaveEvent) *OutboxMessage {
+	return &OutboxMessage{
+		ID:            event.ID,
+		Event:         event,
+		Status:        OutboxPending,
+		Completed:     []string{},
+		NextAttemptAt: event.OccurredAt,
+		CreatedAt:     event.OccurredAt,
+	}
+}
+
+// IsCompleted handler นี้ทำเหตุการณ์สำเร็จแล้วหรือไม่
+func (m *OutboxMessage) IsCompleted(handler string) bool {
+	return slices.Contains(m.Completed, handler)
+}
+
+// MarkCompleted บันทึกว่า handler ทำเหตุการณ์สำเร็จแล้ว
+func (m *OutboxMessage) MarkCompleted(handler string) {
+	if !m.IsCompleted(handler) {
+		m.Completed = append(m.Completed, handler)
+	}
+}
+
+// RecordSuccess บันทึกว่า handler ทุกตัวทำสำเร็จแล้ว
+func (m *OutboxMessage) RecordSuccess(now time.Time) {
+	m.Attempts++
+	m.Status = OutboxProcessed
+	m.LastError = ""
+	m.ProcessedAt = &now
+}
+
+// RecordFailure บันทึกรอบที่มี handler ไม่สำเร็จ — ครบ MaxOutboxAttempts แล้วย้ายเป็น failed
+// มิฉะนั้นเลื่อนไปลองใหม่แบบ exponential backoff (เฉพาะ handler ที่ยังไม่สำเร็จ)
+func (m *OutboxMessage) RecordFailure(reason string, now time.Time) {
+	m.Attempts++
+	if r := []rune(reason); len(r) > outboxErrorMaxLength {
+		reason = string(r[:outboxErrorMaxLength])
+	}
+	m.LastError = reason
+
+	if m.Attempts >= MaxOutboxAttempts {
+		m.Status = OutboxFailed
+		return
+	}
+	delay := outboxBaseRetryDelay
+	for i := 1; i < m.Attempts && delay < outboxMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	m.NextAttemptAt = now.Add(min(delay, outboxMaxRetryDelay))
+}
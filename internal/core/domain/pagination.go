@@ -1,15 +1,25 @@
 package domain
 
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
 const (
 	DefaultPage     = 1   // หน้าเริ่มต้น
 	DefaultPageSize = 10  // จำนวนรายการต่อหน้าเริ่มต้น
 	MaxPageSize     = 100 // จำนวนรายการต่อหน้าสูงสุด
 )
 
-// PaginationParams พารามิเตอร์สำหรับแบ่งหน้า
+// PaginationParams พารามิเตอร์สำหรับแบ่งหน้า — แบบเลขหน้า (skip) หรือแบบ cursor (keyset)
 type PaginationParams struct {
-	Page     int // หน้าที่ต้องการ (เริ่มจาก 1)
-	PageSize int // จำนวนรายการต่อหน้า
+	After      *Cursor // รายการสุดท้ายของหน้าก่อน (keyset, nil = หน้าแรก)
+	Page       int     // หน้าที่ต้องการ (เริ่มจาก 1, ไม่ใช้กับ keyset)
+	PageSize   int     // จำนวนรายการต่อหน้า
+	Keyset     bool    // แบ่งหน้าด้วย cursor บน (created_at, _id) แทนการ skip
+	CountTotal bool    // นับจำนวนรายการทั้งหมด — แบบเลขหน้านับเสมอ
 }
 
 // NewPaginationParams สร้าง PaginationParams พร้อม normalize ค่า
@@ -17,13 +27,19 @@ func NewPaginationParams(page, pageSize int) PaginationParams {
 	if page < 1 {
 		page = DefaultPage
 	}
+	return PaginationParams{Page: page, PageSize: normalizePageSize(pageSize), CountTotal: true}
+}
+
+// NewCursorParams สร้าง PaginationParams แบบ keyset — after = nil คือหน้าแรก, ไม่นับจำนวนทั้งหมดถ้าไม่ได้ขอ
+func NewCursorParams(after *Cursor, pageSize int, countTotal bool) PaginationParams {
+	return PaginationParams{After: after, Page: DefaultPage, PageSize: normalizePageSize(pageSize), Keyset: true, CountTotal: countTotal}
+}
+
+func normalizePageSize(pageSize int) int {
 	if pageSize < 1 {
-		pageSize = DefaultPageSize
-	}
-	if pageSize > MaxPageSize {
-		pageSize = MaxPageSize
+		return DefaultPageSize
 	}
-	return PaginationParams{Page: page, PageSize: pageSize}
+	return min(pageSize, MaxPageSize)
 }
 
 func (p PaginationParams) Offset() int64 {
@@ -34,11 +50,14 @@ func (p PaginationParams) Limit() int64 {
 }
 
 type PaginatedResult[T any] struct {
-	Items      []T   // รายการข้อมูล
-	Total      int64 // จำนวนรายการทั้งหมด
-	Page       int   // หน้าปัจจุบัน
-	PageSize   int   // จำนวนรายการต่อหน้า
-	TotalPages int   // จำนวนหน้าทั้งหมด
+	NextCursor   *Cursor // ตำแหน่งของหน้าถัดไป (nil = หน้าสุดท้าย หรือเรียงแบบที่ใช้ cursor ไม่ได้)
+	Items        []T     // รายการข้อมูล
+	Total        int64   // จำนวนรายการทั้งหมด (ใช้ได้เมื่อ TotalCounted)
+	Page         int     // หน้าปัจจุบัน
+	PageSize     int     // จำนวนรายการต่อหน้า
+	TotalPages   int     // จำนวนหน้าทั้งหมด
+	Keyset       bool    // แบ่งหน้าด้วย cursor — ไม่มีเลขหน้า
+	TotalCounted bool    // นับจำนวนทั้งหมดแล้ว
 }
 
 // NewPaginatedResult สร้าง PaginatedResult พร้อมคำนวณจำนวนหน้าทั้งหมด
@@ -51,10 +70,69 @@ func NewPaginatedResult[T any](items []T, total int64, params PaginationParams)
 		items = []T{}
 	}
 	return &PaginatedResult[T]{
-		Items:      items,
-		Total:      total,
-		Page:       params.Page,
-		PageSize:   params.PageSize,
-		TotalPages: totalPages,
+		Items:        items,
+		Total:        total,
+		Page:         params.Page,
+		PageSize:     params.PageSize,
+		TotalPages:   totalPages,
+		TotalCounted: true,
+	}
+}
+
+// NewCursorResult สร้าง PaginatedResult แบบ keyset — total = nil คือไม่ได้นับจำนวนทั้งหมด
+func NewCursorResult[T any](items []T, next *Cursor, total *int64, params PaginationParams) *PaginatedResult[T] {
+	if items == nil {
+		items = []T{}
+	}
+	result := &PaginatedResult[T]{Items: items, NextCursor: next, PageSize: params.PageSize, Keyset: true}
+	if total != nil {
+		result.Total, result.TotalCounted = *total, true
+	}
+	return result
+}
+
+// HasMore มีรายการหน้าถัดไปหรือไม่
+func (r *PaginatedResult[T]) HasMore() bool {
+	if r.Keyset {
+		return r.NextCursor != nil
+	}
+	return r.Page < r.TotalPages
+}
+
+// Cursor ตำแหน่งของรายการสุดท้ายในหน้าก่อน — keyset pagination บน (created_at, _id)
+type Cursor struct {
+	CreatedAt  time.Time
+	ID         ID
+	Descending bool // ทิศทางของรายการที่ออก cursor — ใช้กับรายการที่เรียงกลับด้านไม่ได้
+}
+
+// Encode แปลง cursor เป็นข้อความสำหรับส่งให้ client — client ไม่ควรแกะหรือสร้างเอง
+func (c Cursor) Encode() string {
+	direction := "a"
+	if c.Descending {
+		direction = "d"
+	}
+	raw := strconv.FormatInt(c.CreatedAt.UnixMilli(), 10) + "." + c.ID.String() + "." + direction
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor แปลงข้อความจาก Encode กลับเป็น cursor
+func DecodeCursor(encoded string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 3 || (parts[2] != "a" && parts[2] != "d") {
+		return nil, ErrInvalidCursor
+	}
+	millis, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	id, err := ParseID(parts[1])
+	if err != nil {
+		return nil, ErrInvalidCursor
 	}
+	return &Cursor{CreatedAt: time.UnixMilli(millis).UTC(), ID: id, Descending: parts[2] == "d"}, nil
 }
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// PasswordResetToken token สำหรับตั้งรหัสผ่านใหม่ — ใช้ได้ครั้งเดียว เก็บเฉพาะ hash
+type PasswordResetToken struct {
+	CreatedAt time.Time  `json:"created_at"        bson:"created_at"`        // วันที่ออก token
+	ExpiresAt time.Time  `json:"expires_at"        bson:"expires_at"`        // เวลาหมดอายุ
+	UsedAt    *time.Time `json:"used_at,omitempty" bson:"used_at,omitempty"` // เวลาที่ถูกใช้หรือถูกยกเลิก (nil = ยังใช้ได้)
+	TokenHash string     `json:"-"                 bson:"token_hash"`        // SHA-256 hash ของ token
+	ID        ID         `json:"id"                bson:"_id"`               // รหัส reset token (UUID)
+	UserID    ID         `json:"user_id"           bson:"user_id"`           // รหัสผู้ใช้เจ้าของ token
+}
+
+func NewPasswordResetToken(userID ID, tokenHash string, ttl time.Duration) *PasswordResetToken {
+	now := time.Now()
+	return &PasswordResetToken{
+		ID:        NewID(),
+		UserID:    userID,
+		TokenHash: tokenHash,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// IsUsed ตรวจสอบว่า token ถูกใช้หรือถูกยกเลิกไปแล้วหรือไม่
+func (t *PasswordResetToken) IsUsed() bool {
+	return t.UsedAt != nil
+}
+
+// IsExpired ตรวจสอบว่า token หมดอายุ ณ เวลาที่ระบุหรือไม่
+func (t *PasswordResetToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
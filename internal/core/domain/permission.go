@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Permission สิทธิ์ย่อยที่ประกอบกันเป็นบทบาท — รูปแบบ <resource>.<action>
+type Permission string
+
+const (
+	PermissionLeaveApprove  Permission = "leave.approve"   // อนุมัติหรือปฏิเสธใบลาของผู้อื่น
+	PermissionLeaveViewTeam Permission = "leave.view_team" // ดูใบลาที่รอการอนุมัติของทีม
+	PermissionBalanceAdjust Permission = "balance.adjust"  // กำหนดจำนวนวันลาที่พนักงานได้รับ
+	PermissionUserManage    Permission = "user.manage"     // จัดการผู้ใช้ บทบาท session และ service account
+	PermissionReportView    Permission = "report.view"     // ดูรายงานสถิติการลาของทั้งองค์กร (HR)
+)
+
+func (p Permission) IsValid() bool {
+	switch p {
+	case PermissionLeaveApprove, PermissionLeaveViewTeam, PermissionBalanceAdjust, PermissionUserManage,
+		PermissionReportView:
+		return true
+	default:
+		return false
+	}
+}
+
+// roleNamePattern ชื่อบทบาทที่สร้างเอง — ตัวพิมพ์เล็ก ตัวเลข และ _ ยาว 2–32 ตัวอักษร
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,31}$`)
+
+// RoleDefinition บทบาทที่เก็บในฐานข้อมูล — กำหนดว่าบทบาทนั้นมีสิทธิ์อะไรบ้าง
+type RoleDefinition struct {
+	CreatedAt   time.Time    `json:"created_at"  bson:"created_at"`  // วันที่สร้าง
+	UpdatedAt   time.Time    `json:"updated_at"  bson:"updated_at"`  // วันที่แก้ไขล่าสุด
+	Description string       `json:"description" bson:"description"` // คำอธิบายบทบาท
+	Name        Role         `json:"name"        bson:"_id"`         // ชื่อบทบาท — ใช้เป็น primary key และค่าใน users.role
+	Permissions []Permission `json:"permissions" bson:"permissions"` // สิทธิ์ทั้งหมดของบทบาท
+	BuiltIn     bool         `json:"built_in"    bson:"built_in"`    // บทบาทเริ่มต้นของระบบ — แก้สิทธิ์ได้แต่ลบไม่ได้
+}
+
+func NewRoleDefinition(name Role, description string, permissions []Permission) *RoleDefinition {
+	now := time.Now()
+	return &RoleDefinition{
+		Name:        name,
+		Description: description,
+		Permissions: permissions,
+		BuiltIn:     name.IsValid(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Has ตรวจสอบว่าบทบาทมีสิทธิ์ที่ระบุหรือไม่
+func (r *RoleDefinition) Has(permission Permission) bool {
+	for _, p := range r.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditSnapshot ค่าของบทบาทที่ใช้เทียบใน audit log — nil = ยังไม่มีบทบาทนี้
+func (r *RoleDefinition) AuditSnapshot() map[string]any {
+	if r == nil {
+		return nil
+	}
+	permissions := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		permissions = append(permissions, string(p))
+	}
+	return map[string]any{
+		"description": r.Description,
+		"permissions": strings.Join(permissions, ","),
+	}
+}
+
+// DefaultRoles บทบาทเริ่มต้นที่ระบบสร้างให้เมื่อยังไม่มีในฐานข้อมูล — สิทธิ์ตรงกับพฤติกรรมเดิมของแต่ละบทบาท
+func DefaultRoles() []RoleDefinition {
+	return []RoleDefinition{
+		*NewRoleDefinition(RoleEmployee, "พนักงานทั่วไป — ยื่นใบลาและดูข้อมูลของตนเอง", []Permission{}),
+		*NewRoleDefinition(RoleManager, "ผู้จัดการ — ดูและอนุมัติใบลาของทีม", []Permission{
+			PermissionLeaveViewTeam, PermissionLeaveApprove,
+		}),
+		*NewRoleDefinition(RoleAdmin, "ผู้ดูแลระบบ — จัดการผู้ใช้ ยอดวันลา และดูรายงาน", []Permission{
+			PermissionUserManage, PermissionBalanceAdjust, PermissionReportView,
+		}),
+	}
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// RefreshToken refresh token ที่ออกให้ผู้ใช้ — เก็บเฉพาะ hash ไม่เก็บ token จริง
+type RefreshToken struct {
+	CreatedAt time.Time  `json:"created_at"           bson:"created_at"`           // วันที่ออก token
+	ExpiresAt time.Time  `json:"expires_at"           bson:"expires_at"`           // เวลาหมดอายุ
+	RevokedAt *time.Time `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"` // เวลาที่ถูกใช้ rotate หรือถูกยกเลิก (nil = ยังใช้ได้)
+	TokenHash string     `json:"-"                    bson:"token_hash"`           // SHA-256 hash ของ token
+	ID        ID         `json:"id"                   bson:"_id"`                  // รหัส refresh token (UUID)
+	UserID    ID         `json:"user_id"              bson:"user_id"`              // รหัสผู้ใช้เจ้าของ token
+	FamilyID  ID         `json:"family_id"            bson:"family_id"`            // token ที่ rotate ต่อกันมาจากการ login ครั้งเดียวกันใช้ family เดียวกัน
+	MFA       bool       `json:"mfa"                  bson:"mfa"`                  // session นี้ยืนยัน 2FA แล้ว — access token ที่ออกใหม่จะได้ claim "mfa" ด้วย
+}
+
+func NewRefreshToken(userID, familyID ID, tokenHash string, mfa bool, ttl time.Duration) *RefreshToken {
+	now := time.Now()
+	return &RefreshToken{
+		ID:        NewID(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		MFA:       mfa,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}
+}
+
+// IsRevoked ตรวจสอบว่า token ถูกใช้ rotate หรือถูกยกเลิกไปแล้วหรือไม่
+func (t *RefreshToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// IsExpired ตรวจสอบว่า token หมดอายุ ณ เวลาที่ระบุหรือไม่
+func (t *RefreshToken) IsExpired(now time.Time) bool {
+	return !now.Before(t.ExpiresAt)
+}
@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+const (
+	MinReportYear          = 2000 // ปีแรกที่ออกรายงานได้
+	MaxReportYear          = 2100 // ปีสุดท้ายที่ออกรายงานได้
+	MaxReportDepartmentLen = 100  // ความยาวสูงสุดของชื่อแผนกที่ใช้กรอง
+	DefaultCarryOverLimit  = 10   // จำนวนผู้ที่เสี่ยงเสียวันลาที่แสดงเริ่มต้น
+	MaxCarryOverLimit      = 100  // จำนวนผู้ที่เสี่ยงเสียวันลาที่แสดงได้สูงสุด
+)
+
+// ReportFilter เงื่อนไขของรายงาน — ปีของใบลานับจาก start_date เหมือนยอดวันลา
+// แผนกและประเภทการลาที่ว่างคือทั้งหมด
+type ReportFilter struct {
+	Department string    // แผนกของพนักงาน (ทีม)
+	LeaveType  LeaveType // ประเภทการลา
+	Year       int       // ปีของรายงาน
+}
+
+func (f ReportFilter) Validate() error {
+	if f.Year < MinReportYear || f.Year > MaxReportYear {
+		return ErrInvalidReportFilter
+	}
+	if f.LeaveType != "" && !f.LeaveType.IsValid() {
+		return ErrInvalidReportFilter
+	}
+	if len([]rune(f.Department)) > MaxReportDepartmentLen {
+		return ErrInvalidReportFilter
+	}
+	return nil
+}
+
+// Period ช่วงเวลาของปีในรายงาน [1 ม.ค., 1 ม.ค. ปีถัดไป) ตาม UTC
+func (f ReportFilter) Period() (start, end time.Time) {
+	start = time.Date(f.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(1, 0, 0)
+}
+
+// DaysInPeriod จำนวนวันในปีของรายงาน (365 หรือ 366)
+func (f ReportFilter) DaysInPeriod() float64 {
+	start, end := f.Period()
+	return end.Sub(start).Hours() / 24
+}
+
+// MonthlyLeaveUsage วันลาที่อนุมัติแล้วของประเภทหนึ่งในเดือนหนึ่ง — นับเข้าเดือนของวันเริ่มลา
+type MonthlyLeaveUsage struct {
+	LeaveType LeaveType // ประเภทการลา
+	Days      float64   // จำนวนวันลารวม
+	Requests  int64     // จำนวนใบลา
+	Month     int       // เดือน (1–12)
+}
+
+// TeamAbsenteeism อัตราการขาดงานของแผนก = วันลาที่อนุมัติ ÷ (จำนวนพนักงาน × จำนวนวันในปี)
+type TeamAbsenteeism struct {
+	Department string  // แผนก (ว่าง = ไม่ระบุแผนก)
+	Headcount  int64   // จำนวนพนักงานในแผนก
+	DaysTaken  float64 // วันลาที่อนุมัติรวม
+	Rate       float64 // อัตราการขาดงาน (0–1)
+}
+
+// ApplyRate คำนวณอัตราการขาดงานจากจำนวนวันในช่วงของรายงาน
+func (t *TeamAbsenteeism) ApplyRate(daysInPeriod float64) {
+	t.Rate = ratio(t.DaysTaken, float64(t.Headcount)*daysInPeriod)
+}
+
+// ApprovalTurnaround เวลาเฉลี่ยตั้งแต่ยื่นจนอนุมัติ/ปฏิเสธ (ReviewedAt - CreatedAt) ของประเภทการลาหนึ่ง
+type ApprovalTurnaround struct {
+	LeaveType    LeaveType // ประเภทการลา
+	Reviewed     int64     // จำนวนใบลาที่พิจารณาแล้ว
+	AverageHours float64   // เวลาเฉลี่ย (ชั่วโมง)
+	MaxHours     float64   // เวลานานที่สุด (ชั่วโมง)
+}
+
+// ReviewerRejectionRate อัตราการปฏิเสธของผู้อนุมัติหนึ่งคน
+type ReviewerRejectionRate struct {
+	Reviewer   *UserSummary // ข้อมูลย่อของผู้อนุมัติ (nil = ไม่พบผู้ใช้)
+	ReviewerID ID           // รหัสผู้อนุมัติ
+	Reviewed   int64        // จำนวนใบลาที่พิจารณา
+	Rejected   int64        // จำนวนใบลาที่ปฏิเสธ
+	Rate       float64      // อัตราการปฏิเสธ (0–1)
+}
+
+// ApplyRate คำนวณอัตราการปฏิเสธ
+func (r *ReviewerRejectionRate) ApplyRate() {
+	r.Rate = ratio(float64(r.Rejected), float64(r.Reviewed))
+}
+
+// CarryOverRisk ยอดวันลาคงเหลือที่เกินจำนวนที่ยกไปปีถัดไปได้ — ส่วนที่เกินจะหายเมื่อขึ้นปีใหม่
+type CarryOverRisk struct {
+	User          *UserSummary // ข้อมูลย่อของพนักงาน (nil = ไม่พบผู้ใช้)
+	LeaveType     LeaveType    // ประเภทการลา
+	UserID        ID           // รหัสพนักงาน
+	TotalDays     float64      // วันลาทั้งหมดที่ได้รับ
+	RemainingDays float64      // วันลาคงเหลือ (หักที่ใช้และจองไว้)
+	DaysAtRisk    float64      // วันลาที่จะหายถ้าไม่ใช้ภายในปี
+}
+
+// ApplyCarryOver คำนวณวันลาที่จะหายจากจำนวนวันที่ยกไปปีถัดไปได้
+func (c *CarryOverRisk) ApplyCarryOver(carryOverDays float64) {
+	c.DaysAtRisk = max(c.RemainingDays-carryOverDays, 0)
+}
+
+// ratio หารโดยคืน 0 เมื่อตัวหารเป็น 0
+func ratio(part, whole float64) float64 {
+	if whole <= 0 {
+		return 0
+	}
+	return part / whole
+}
@@ -5,13 +5,34 @@ type Role string
 const (
 	RoleEmployee Role = "employee" // คือพนักงานทั่วไป — สามารถยื่นใบลาและดูประวัติการลาได้
 	RoleManager  Role = "manager"  // คือผู้จัดการ — สามารถอนุมัติหรือปฏิเสธใบลาได้
+	RoleAdmin    Role = "admin"    // คือผู้ดูแลระบบ — จัดการผู้ใช้และ session ได้
 )
 
+// IsValid ตรวจสอบว่าเป็นบทบาทเริ่มต้นของระบบ — บทบาทที่สร้างเองในฐานข้อมูลตรวจด้วย IsValidName
 func (r Role) IsValid() bool {
 	switch r {
-	case RoleEmployee, RoleManager:
+	case RoleEmployee, RoleManager, RoleAdmin:
 		return true
 	default:
 		return false
 	}
 }
+
+// IsValidName ตรวจสอบรูปแบบชื่อบทบาท (รวมบทบาทที่สร้างเอง)
+func (r Role) IsValidName() bool {
+	return roleNamePattern.MatchString(string(r))
+}
+
+// rank ลำดับสิทธิ์ของบทบาท — ใช้เลือกบทบาทสูงสุดเมื่อผู้ใช้อยู่หลายกลุ่ม
+func (r Role) rank() int {
+	switch r {
+	case RoleEmployee:
+		return 1
+	case RoleManager:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import "time"
+
+// SecurityEventType ประเภทเหตุการณ์ด้านความปลอดภัย
+type SecurityEventType string
+
+const (
+	SecurityEventAccountLocked   SecurityEventType = "account_locked"   // บัญชีถูกล็อกจากการ login ผิดติดต่อกัน
+	SecurityEventAccountUnlocked SecurityEventType = "account_unlocked" // ผู้ดูแลระบบปลดล็อกบัญชี
+)
+
+// SecurityEvent บันทึกเหตุการณ์ด้านความปลอดภัยที่ผู้ดูแลระบบควรตรวจสอบได้ภายหลัง
+type SecurityEvent struct {
+	CreatedAt time.Time         `json:"created_at"         bson:"created_at"`         // เวลาที่เกิดเหตุการณ์
+	UserID    *ID               `json:"user_id,omitempty"  bson:"user_id,omitempty"`  // ผู้ใช้ที่เกี่ยวข้อง (nil = อีเมลที่ไม่มีในระบบ)
+	ActorID   *ID               `json:"actor_id,omitempty" bson:"actor_id,omitempty"` // ผู้ดำเนินการ (nil = ระบบ)
+	Type      SecurityEventType `json:"type"               bson:"type"`               // ประเภทเหตุการณ์
+	Email     string            `json:"email"              bson:"email"`              // อีเมลที่เกี่ยวข้อง
+	Detail    string            `json:"detail"             bson:"detail"`             // รายละเอียดเพิ่มเติม
+	ID        ID                `json:"id"                 bson:"_id"`                // รหัสเหตุการณ์ (UUID)
+}
+
+func NewSecurityEvent(eventType SecurityEventType, email string, userID, actorID *ID, detail string) *SecurityEvent {
+	return &SecurityEvent{
+		ID:        NewID(),
+		Type:      eventType,
+		Email:     email,
+		UserID:    userID,
+		ActorID:   actorID,
+		Detail:    detail,
+		CreatedAt: time.Now(),
+	}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// APIScope สิทธิ์ของ API key — รูปแบบ <resource>:<action>
+type APIScope string
+
+const (
+	ScopeLeavesRead    APIScope = "leaves:read"    // อ่านใบลาทั้งหมด
+	ScopeBalancesRead  APIScope = "balances:read"  // อ่านยอดวันลาของพนักงาน
+	ScopeBalancesWrite APIScope = "balances:write" // กำหนดจำนวนวันลาที่พนักงานได้รับ
+)
+
+func (s APIScope) IsValid() bool {
+	switch s {
+	case ScopeLeavesRead, ScopeBalancesRead, ScopeBalancesWrite:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKeyPrefix ขึ้นต้นของ API key ทุกตัว — ช่วยให้ secret scanner และผู้ดูแลระบบแยกออกจาก token อื่นได้
+const APIKeyPrefix = "lms_"
+
+// apiKeyDisplayLength ความยาวของ key ที่เก็บไว้แสดงผล (APIKeyPrefix + 8 ตัวอักษรแรก)
+const apiKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// ServiceAccount บัญชีสำหรับระบบภายนอก (เช่น HR, payroll) ที่เรียก API ด้วย API key แทนการใช้บัญชีผู้ใช้จริง
+type ServiceAccount struct {
+	CreatedAt  time.Time  `json:"created_at"             bson:"created_at"`             // วันที่สร้าง
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"` // เวลาที่ใช้ key ล่าสุด (nil = ยังไม่เคยใช้)
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"   bson:"revoked_at,omitempty"`   // เวลาที่ถูกยกเลิก (nil = ยังใช้ได้)
+	Name       string     `json:"name"                   bson:"name"`                   // ชื่อระบบที่ใช้ key
+	KeyPrefix  string     `json:"key_prefix"             bson:"key_prefix"`             // ส่วนต้นของ key สำหรับระบุตัว key โดยไม่เปิดเผย secret
+	KeyHash    string     `json:"-"                      bson:"key_hash"`               // SHA-256 hash ของ key
+	Scopes     []APIScope `json:"scopes"                 bson:"scopes"`                 // สิทธิ์ที่ได้รับ
+	ID         ID         `json:"id"                     bson:"_id"`                    // รหัส service account (UUID)
+	CreatedBy  ID         `json:"created_by"             bson:"created_by"`             // ผู้ดูแลระบบที่สร้าง
+}
+
+func NewServiceAccount(name, key, keyHash string, scopes []APIScope, createdBy ID) *ServiceAccount {
+	prefix := key
+	if len(prefix) > apiKeyDisplayLength {
+		prefix = prefix[:apiKeyDisplayLength]
+	}
+	return &ServiceAccount{
+		ID:        NewID(),
+		Name:      name,
+		KeyPrefix: prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+}
+
+// IsRevoked ตรวจสอบว่า key ถูกยกเลิกไปแล้วหรือไม่
+func (a *ServiceAccount) IsRevoked() bool {
+	return a.RevokedAt != nil
+}
+
+// HasScope ตรวจสอบว่า key ได้รับสิทธิ์ที่ระบุหรือไม่
+func (a *ServiceAccount) HasScope(scope APIScope) bool {
+	for _, s := range a.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditSnapshot ค่าของ service account ที่ใช้เทียบใน audit log — ไม่รวม hash ของ key
+func (a *ServiceAccount) AuditSnapshot() map[string]any {
+	scopes := make([]string, 0, len(a.Scopes))
+	for _, scope := range a.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+	return map[string]any{
+		"name":       a.Name,
+		"key_prefix": a.KeyPrefix,
+		"scopes":     strings.Join(scopes, ","),
+	}
+}
@@ -1,7 +1,13 @@
 package domain
 
+import "time"
+
 type TokenClaims struct {
-	Email  string // อีเมลผู้ใช้
-	Role   Role   // บทบาทของผู้ใช้ (employee/manager)
-	UserID ID     // รหัสผู้ใช้ (UUID)
+	IssuedAt  time.Time // เวลาที่ออก token (iat_ms ละเอียดถึงมิลลิวินาที หรือ iat สำหรับ token เดิม)
+	ExpiresAt time.Time // เวลาหมดอายุของ token (exp)
+	TokenID   string    // รหัสเฉพาะของ token (jti) — ใช้สำหรับ revoke
+	Email     string    // อีเมลผู้ใช้
+	Role      Role      // บทบาทของผู้ใช้ (employee/manager/admin)
+	MFA       bool      // session นี้ยืนยัน 2FA แล้ว (claim "mfa")
+	UserID    ID        // รหัสผู้ใช้ (UUID)
 }
@@ -1,18 +1,25 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // User ข้อมูลผู้ใช้งานในระบบ
 type User struct {
-	CreatedAt    time.Time `json:"created_at" bson:"created_at"`    // วันที่สร้าง
-	UpdatedAt    time.Time `json:"updated_at" bson:"updated_at"`    // วันที่แก้ไขล่าสุด
-	FirstName    string    `json:"first_name" bson:"first_name"`    // ชื่อจริง
-	LastName     string    `json:"last_name"  bson:"last_name"`     // นามสกุล
-	FullName     string    `json:"full_name"  bson:"full_name"`     // ชื่อเต็ม (first + last)
-	Email        string    `json:"email"      bson:"email"`         // อีเมล (unique)
-	PasswordHash string    `json:"-"          bson:"password_hash"` // รหัสผ่านที่เข้ารหัสแล้ว (ไม่ส่งกลับใน JSON)
-	Role         Role      `json:"role"       bson:"role"`          // บทบาท (employee/manager)
-	ID           ID        `json:"user_id"    bson:"_id"`           // รหัสผู้ใช้ (UUID) — ใช้เป็น primary key
+	CreatedAt    time.Time `json:"created_at" bson:"created_at"`              // วันที่สร้าง
+	UpdatedAt    time.Time `json:"updated_at" bson:"updated_at"`              // วันที่แก้ไขล่าสุด
+	FirstName    string    `json:"first_name" bson:"first_name"`              // ชื่อจริง
+	LastName     string    `json:"last_name"  bson:"last_name"`               // นามสกุล
+	FullName     string    `json:"full_name"  bson:"full_name"`               // ชื่อเต็ม (first + last)
+	Email        string    `json:"email"      bson:"email"`                   // อีเมล (unique)
+	Department   string    `json:"department" bson:"department,omitempty"`    // แผนก (ว่าง = ไม่ระบุ)
+	PasswordHash string    `json:"-"          bson:"password_hash"`           // รหัสผ่านที่เข้ารหัสแล้ว (ไม่ส่งกลับใน JSON) — ว่างสำหรับผู้ใช้ที่ login ผ่าน IdP
+	AuthProvider string    `json:"-"          bson:"auth_provider,omitempty"` // issuer ของ IdP ที่ผูกบัญชีไว้ (ว่าง = รหัสผ่านในระบบ)
+	ExternalID   string    `json:"-"          bson:"external_id,omitempty"`   // รหัสผู้ใช้ใน IdP (sub)
+	Role         Role      `json:"role"       bson:"role"`                    // บทบาท (employee/manager)
+	Locale       Locale    `json:"locale"     bson:"locale,omitempty"`        // ภาษาของอีเมลแจ้งเตือน (ว่าง = ค่าเริ่มต้นของระบบ)
+	ID           ID        `json:"user_id"    bson:"_id"`                     // รหัสผู้ใช้ (UUID) — ใช้เป็น primary key
 }
 
 func NewUser(firstName, lastName, email, passwordHash string, role Role) *User {
@@ -29,3 +36,43 @@ func NewUser(firstName, lastName, email, passwordHash string, role Role) *User {
 		UpdatedAt:    now,
 	}
 }
+
+// NewExternalUser สร้างผู้ใช้ใหม่จากข้อมูลของ IdP (just-in-time provisioning) — ไม่มีรหัสผ่านในระบบ
+func NewExternalUser(identity *ExternalIdentity, role Role) *User {
+	user := NewUser(identity.FirstName, identity.LastName, strings.ToLower(identity.Email), "", role)
+	user.AuthProvider = identity.Provider
+	user.ExternalID = identity.Subject
+	return user
+}
+
+// ApplyExternalIdentity ผูกบัญชีกับ IdP และปรับชื่อ/บทบาทให้ตรงกับข้อมูลล่าสุดจาก IdP — รหัสผ่านเดิมในระบบใช้ไม่ได้อีก
+func (u *User) ApplyExternalIdentity(identity *ExternalIdentity, role Role) {
+	u.PasswordHash = ""
+	u.AuthProvider = identity.Provider
+	u.ExternalID = identity.Subject
+	if identity.FirstName != "" || identity.LastName != "" {
+		u.FirstName = identity.FirstName
+		u.LastName = identity.LastName
+		u.FullName = strings.TrimSpace(identity.FirstName + " " + identity.LastName)
+	}
+	u.Role = role
+	u.UpdatedAt = time.Now()
+}
+
+// Summary ข้อมูลย่อของผู้ใช้สำหรับแสดงคู่กับใบลา
+func (u *User) Summary() UserSummary {
+	return UserSummary{ID: u.ID, FullName: u.FullName, Email: u.Email, Department: u.Department}
+}
+
+// UserSummary ข้อมูลย่อของผู้ใช้ที่แนบไปกับใบลา — ไม่มีข้อมูลยืนยันตัวตน
+type UserSummary struct {
+	FullName   string `json:"full_name"  bson:"full_name"`            // ชื่อเต็ม
+	Email      string `json:"email"      bson:"email"`                // อีเมล
+	Department string `json:"department" bson:"department,omitempty"` // แผนก
+	ID         ID     `json:"user_id"    bson:"_id"`                  // รหัสผู้ใช้
+}
+
+// IsExternal ตรวจสอบว่าผู้ใช้ยืนยันตัวตนกับ IdP หรือ directory (ไม่มีรหัสผ่านในระบบ)
+func (u *User) IsExternal() bool {
+	return u.AuthProvider != ""
+}
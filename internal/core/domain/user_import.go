@@ -0,0 +1,292 @@
+package domain
+
+import (
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MaxImportRows      = 5000 // จำนวนแถวสูงสุดต่อไฟล์นำเข้า
+	MaxImportNameLen   = 100  // ความยาวสูงสุดของชื่อ นามสกุล และแผนก
+	MaxImportEmailLen  = 254  // ความยาวสูงสุดของอีเมล (RFC 5321)
+	MaxEntitlementDays = 366  // จำนวนวันลาที่ได้รับสูงสุดต่อปี
+)
+
+// คอลัมน์ของไฟล์นำเข้า — นอกจากนี้คือคอลัมน์ยอดวันลา <leave_type> (วันที่ได้รับ) และ <leave_type>_used (วันที่ใช้ไปแล้ว)
+const (
+	ImportColumnEmail      = "email"      // อีเมล (ใช้จับคู่กับผู้ใช้เดิม)
+	ImportColumnFirstName  = "first_name" // ชื่อจริง
+	ImportColumnLastName   = "last_name"  // นามสกุล
+	ImportColumnDepartment = "department" // แผนก (ไม่บังคับ)
+	ImportColumnRole       = "role"       // บทบาท (ว่าง = employee)
+	ImportColumnYear       = "year"       // ปีของยอดวันลา (ว่าง = ปีที่ระบุตอนนำเข้า)
+
+	importUsedSuffix = "_used"
+)
+
+// UserImportOptions วิธีนำเข้า
+type UserImportOptions struct {
+	DryRun bool // ตรวจทุกแถวและรายงานผลโดยไม่บันทึก
+	Upsert bool // แก้ข้อมูลผู้ใช้ที่มีอีเมลอยู่แล้ว (false = อีเมลที่มีอยู่แล้วเป็นข้อผิดพลาดของแถว)
+	Year   int  // ปีของยอดวันลาสำหรับแถวที่ไม่ระบุ year (0 = ปีปัจจุบัน)
+}
+
+func (o UserImportOptions) Validate() error {
+	if o.Year != 0 && (o.Year < MinReportYear || o.Year > MaxReportYear) {
+		return fmt.Errorf("%w: ปีต้องอยู่ระหว่าง %d–%d", ErrInvalidImportFile, MinReportYear, MaxReportYear)
+	}
+	return nil
+}
+
+// UserImportFile ไฟล์นำเข้าผู้ใช้และยอดวันลายกมา — หนึ่งแถวต่อผู้ใช้หนึ่งคน
+type UserImportFile struct {
+	Header  []string           // ชื่อคอลัมน์ตามลำดับในไฟล์
+	Records []UserImportRecord // แถวข้อมูล
+}
+
+// UserImportRecord ค่าของแถวหนึ่งตามลำดับคอลัมน์ใน Header
+type UserImportRecord struct {
+	Line   int      // บรรทัดในไฟล์ (หัวตารางคือบรรทัด 1)
+	Values []string // ค่าของแต่ละคอลัมน์
+}
+
+// OpeningBalance ยอดวันลายกมาจากระบบเดิม
+type OpeningBalance struct {
+	LeaveType LeaveType // ประเภทการลา
+	TotalDays float64   // จำนวนวันลาที่ได้รับทั้งปี
+	UsedDays  float64   // จำนวนวันลาที่ใช้ไปแล้ว
+}
+
+// UserImportRow แถวที่แปลงและตรวจแล้ว
+type UserImportRow struct {
+	Email      string           // อีเมล (lowercase)
+	FirstName  string           // ชื่อจริง
+	LastName   string           // นามสกุล
+	Department string           // แผนก
+	Role       Role             // บทบาท
+	Year       int              // ปีของยอดวันลา
+	Balances   []OpeningBalance // ยอดวันลาที่ระบุในแถว
+}
+
+// Validate ตรวจหัวตาราง — ต้องมีคอลัมน์ที่บังคับ ไม่มีคอลัมน์ที่ไม่รู้จักหรือซ้ำ และจำนวนแถวไม่เกิน MaxImportRows
+func (f UserImportFile) Validate() error {
+	if len(f.Records) > MaxImportRows {
+		return fmt.Errorf("%w: เกิน %d แถว", ErrInvalidImportFile, MaxImportRows)
+	}
+
+	seen := make(map[string]bool, len(f.Header))
+	for _, col := range f.Header {
+		if seen[col] {
+			return fmt.Errorf("%w: คอลัมน์ %q ซ้ำ", ErrInvalidImportFile, col)
+		}
+		seen[col] = true
+		if !isImportColumn(col) {
+			return fmt.Errorf("%w: ไม่รู้จักคอลัมน์ %q", ErrInvalidImportFile, col)
+		}
+	}
+
+	for _, col := range []string{ImportColumnEmail, ImportColumnFirstName, ImportColumnLastName} {
+		if !seen[col] {
+			return fmt.Errorf("%w: ไม่มีคอลัมน์ %q", ErrInvalidImportFile, col)
+		}
+	}
+	for col := range seen {
+		if total, ok := strings.CutSuffix(col, importUsedSuffix); ok && !seen[total] {
+			return fmt.Errorf("%w: คอลัมน์ %q ต้องมีคอลัมน์ %q คู่กัน", ErrInvalidImportFile, col, total)
+		}
+	}
+	return nil
+}
+
+func isImportColumn(col string) bool {
+	switch col {
+	case ImportColumnEmail, ImportColumnFirstName, ImportColumnLastName,
+		ImportColumnDepartment, ImportColumnRole, ImportColumnYear:
+		return true
+	}
+	leaveType, _ := strings.CutSuffix(col, importUsedSuffix)
+	return LeaveType(leaveType).IsValid()
+}
+
+// ParseRecord แปลงแถวตามหัวตารางที่ผ่าน Validate แล้ว — แถวที่ไม่ถูกต้องคืน ErrInvalidImportRow พร้อมสาเหตุ
+// การมีอยู่ของบทบาทและอีเมลในระบบตรวจที่ service
+func (f UserImportFile) ParseRecord(record UserImportRecord, defaultYear int) (*UserImportRow, error) {
+	if len(record.Values) != len(f.Header) {
+		return nil, invalidImportRow(fmt.Sprintf("มี %d คอลัมน์ แต่หัวตารางมี %d คอลัมน์", len(record.Values), len(f.Header)))
+	}
+	values := make(map[string]string, len(f.Header))
+	for i, col := range f.Header {
+		values[col] = record.Values[i]
+	}
+
+	row := &UserImportRow{
+		Email:      strings.ToLower(values[ImportColumnEmail]),
+		FirstName:  values[ImportColumnFirstName],
+		LastName:   values[ImportColumnLastName],
+		Department: values[ImportColumnDepartment],
+		Role:       Role(values[ImportColumnRole]),
+		Year:       defaultYear,
+	}
+	if row.Role == "" {
+		row.Role = RoleEmployee
+	}
+	if err := row.validateProfile(); err != nil {
+		return nil, err
+	}
+
+	if raw := values[ImportColumnYear]; raw != "" {
+		year, err := strconv.Atoi(raw)
+		if err != nil || year < MinReportYear || year > MaxReportYear {
+			return nil, invalidImportRow(fmt.Sprintf("ปี %q ต้องอยู่ระหว่าง %d–%d", raw, MinReportYear, MaxReportYear))
+		}
+		row.Year = year
+	}
+
+	balances, err := parseOpeningBalances(f.Header, values)
+	if err != nil {
+		return nil, err
+	}
+	row.Balances = balances
+	return row, nil
+}
+
+func (r *UserImportRow) validateProfile() error {
+	if len(r.Email) > MaxImportEmailLen {
+		return invalidImportRow("อีเมลยาวเกินไป")
+	}
+	if addr, err := mail.ParseAddress(r.Email); err != nil || addr.Address != r.Email {
+		return invalidImportRow(fmt.Sprintf("อีเมล %q ไม่ถูกต้อง", r.Email))
+	}
+	if r.FirstName == "" || r.LastName == "" {
+		return invalidImportRow("ต้องระบุชื่อและนามสกุล")
+	}
+	for _, v := range []string{r.FirstName, r.LastName, r.Department} {
+		if utf8.RuneCountInString(v) > MaxImportNameLen {
+			return invalidImportRow(fmt.Sprintf("ชื่อ นามสกุล และแผนกยาวได้ไม่เกิน %d ตัวอักษร", MaxImportNameLen))
+		}
+	}
+	if !r.Role.IsValidName() {
+		return invalidImportRow(fmt.Sprintf("บทบาท %q ไม่ถูกต้อง", r.Role))
+	}
+	return nil
+}
+
+// parseOpeningBalances อ่านยอดวันลาตามลำดับคอลัมน์ — ช่องวันที่ได้รับที่ว่างคือไม่นำเข้ายอดของประเภทนั้น
+func parseOpeningBalances(header []string, values map[string]string) ([]OpeningBalance, error) {
+	var balances []OpeningBalance
+	for _, col := range header {
+		leaveType := LeaveType(col)
+		if !leaveType.IsValid() {
+			continue
+		}
+		total, used := values[col], values[col+importUsedSuffix]
+		if total == "" {
+			if used != "" {
+				return nil, invalidImportRow(fmt.Sprintf("ระบุ %s%s แต่ไม่ระบุ %s", col, importUsedSuffix, col))
+			}
+			continue
+		}
+
+		balance := OpeningBalance{LeaveType: leaveType}
+		var err error
+		if balance.TotalDays, err = parseImportDays(col, total); err != nil {
+			return nil, err
+		}
+		if used != "" {
+			if balance.UsedDays, err = parseImportDays(col+importUsedSuffix, used); err != nil {
+				return nil, err
+			}
+		}
+		if balance.UsedDays > balance.TotalDays {
+			return nil, invalidImportRow(fmt.Sprintf("%s: วันที่ใช้ไปแล้วมากกว่าวันที่ได้รับ", col))
+		}
+		balances = append(balances, balance)
+	}
+	return balances, nil
+}
+
+func parseImportDays(col, raw string) (float64, error) {
+	days, err := strconv.ParseFloat(raw, 64)
+	if err != nil || days < 0 || days > MaxEntitlementDays {
+		return 0, invalidImportRow(fmt.Sprintf("%s: %q ต้องเป็นตัวเลข 0–%d", col, raw, MaxEntitlementDays))
+	}
+	return days, nil
+}
+
+func invalidImportRow(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidImportRow, reason)
+}
+
+// NewUser สร้างผู้ใช้ใหม่จากแถว — ไม่มีรหัสผ่าน ผู้ใช้ตั้งรหัสผ่านเองผ่านลืมรหัสผ่าน หรือ login ผ่าน SSO/LDAP
+func (r *UserImportRow) NewUser() *User {
+	user := NewUser(r.FirstName, r.LastName, r.Email, "", r.Role)
+	user.Department = r.Department
+	return user
+}
+
+// ApplyTo แก้ชื่อ แผนก และบทบาทของผู้ใช้เดิมตามแถว
+func (r *UserImportRow) ApplyTo(user *User) {
+	user.FirstName = r.FirstName
+	user.LastName = r.LastName
+	user.FullName = r.FirstName + " " + r.LastName
+	user.Department = r.Department
+	user.Role = r.Role
+	user.UpdatedAt = time.Now()
+}
+
+// ImportSnapshot ค่าของผู้ใช้ที่ใช้เทียบใน audit log ของการนำเข้า — nil = ยังไม่มีผู้ใช้
+func (u *User) ImportSnapshot() map[string]any {
+	if u == nil {
+		return nil
+	}
+	return map[string]any{
+		"email":      u.Email,
+		"full_name":  u.FullName,
+		"department": u.Department,
+		"role":       u.Role,
+	}
+}
+
+// UserImportAction ผลของแถว
+type UserImportAction string
+
+const (
+	UserImportCreated UserImportAction = "created" // สร้างผู้ใช้ใหม่ (dry run = จะสร้าง)
+	UserImportUpdated UserImportAction = "updated" // แก้ผู้ใช้เดิม (dry run = จะแก้)
+	UserImportFailed  UserImportAction = "failed"  // แถวไม่ถูกต้อง — ไม่มีการบันทึกจากแถวนี้
+)
+
+// UserImportResult ผลของแต่ละแถว
+type UserImportResult struct {
+	Line   int              // บรรทัดในไฟล์
+	Email  string           // อีเมลในแถว
+	Action UserImportAction // ผลของแถว
+	Error  string           // สาเหตุเมื่อ Action เป็น failed
+}
+
+// UserImportReport สรุปผลการนำเข้าทั้งไฟล์
+type UserImportReport struct {
+	DryRun  bool               // ไม่ได้บันทึกข้อมูล
+	Upsert  bool               // แก้ผู้ใช้เดิมได้
+	Created int                // จำนวนผู้ใช้ที่สร้าง
+	Updated int                // จำนวนผู้ใช้ที่แก้
+	Failed  int                // จำนวนแถวที่ไม่ผ่าน
+	Rows    []UserImportResult // ผลรายแถวตามลำดับในไฟล์
+}
+
+// Add บันทึกผลของแถวและนับตามผล
+func (r *UserImportReport) Add(result UserImportResult) {
+	switch result.Action {
+	case UserImportCreated:
+		r.Created++
+	case UserImportUpdated:
+		r.Updated++
+	case UserImportFailed:
+		r.Failed++
+	}
+	r.Rows = append(r.Rows, result)
+}
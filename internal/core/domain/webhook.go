@@ -0,0 +1,209 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	MinWebhookSecretLength = 16               // ความยาวขั้นต่ำของ secret ที่ใช้ sign payload
+	MaxWebhookAttempts     = 10               // จำนวนครั้งที่ส่งได้ก่อนย้ายเป็น dead
+	webhookBaseRetryDelay  = 30 * time.Second // เวลารอก่อนส่งใหม่ครั้งแรก — เพิ่มเป็นสองเท่าทุกครั้ง
+	webhookMaxRetryDelay   = time.Hour        // เวลารอสูงสุดระหว่างครั้ง
+	webhookErrorMaxLength  = 500              // ความยาวสูงสุด (ตัวอักษร) ของข้อความ error ที่เก็บไว้
+)
+
+// WebhookSubscription ปลายทางที่รับเหตุการณ์ของใบลาทาง HTTP POST (เช่น payroll, Slack bot)
+type WebhookSubscription struct {
+	CreatedAt time.Time        `json:"created_at" bson:"created_at"` // วันที่สร้าง
+	UpdatedAt time.Time        `json:"updated_at" bson:"updated_at"` // วันที่แก้ไขล่าสุด
+	URL       string           `json:"url"        bson:"url"`        // ปลายทาง (http/https)
+	Secret    string           `json:"-"          bson:"secret"`     // secret สำหรับ HMAC-SHA256 — ไม่ส่งกลับใน API
+	Events    []LeaveEventType `json:"events"     bson:"events"`     // เหตุการณ์ที่ต้องการรับ
+	Active    bool             `json:"active"     bson:"active"`     // ปิดไว้ = ไม่สร้างรายการส่งใหม่
+	ID        ID               `json:"id"         bson:"_id"`        // รหัส subscription (UUID)
+	CreatedBy ID               `json:"created_by" bson:"created_by"` // ผู้ดูแลระบบที่สร้าง
+}
+
+func NewWebhookSubscription(rawURL, secret string, events []LeaveEventType, createdBy ID) (*WebhookSubscription, error) {
+	now := time.Now()
+	sub := &WebhookSubscription{
+		ID:        NewID(),
+		Active:    true,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := sub.Update(rawURL, secret, events, true); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Update แก้ปลายทาง secret และเหตุการณ์ — secret ว่าง = ใช้ secret เดิม
+func (s *WebhookSubscription) Update(rawURL, secret string, events []LeaveEventType, active bool) error {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if secret == "" {
+		secret = s.Secret
+	}
+	if len(secret) < MinWebhookSecretLength {
+		return ErrInvalidWebhookSecret
+	}
+
+	seen := make(map[LeaveEventType]bool, len(events))
+	normalized := make([]LeaveEventType, 0, len(events))
+	for _, e := range events {
+		if !e.IsValid() {
+			return ErrInvalidWebhookEvent
+		}
+		if !seen[e] {
+			seen[e] = true
+			normalized = append(normalized, e)
+		}
+	}
+	if len(normalized) == 0 {
+		return ErrInvalidWebhookEvent
+	}
+
+	s.URL = u.String()
+	s.Secret = secret
+	s.Events = normalized
+	s.Active = active
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// Subscribes ตรวจว่า subscription ต้องการรับเหตุการณ์นี้หรือไม่
+func (s *WebhookSubscription) Subscribes(eventType LeaveEventType) bool {
+	if !s.Active {
+		return false
+	}
+	for _, e := range s.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditSnapshot ค่าของ subscription ที่ใช้เทียบใน audit log — ไม่รวม secret
+func (s *WebhookSubscription) AuditSnapshot() map[string]any {
+	if s == nil {
+		return nil
+	}
+	events := make([]string, 0, len(s.Events))
+	for _, e := range s.Events {
+		events = append(events, string(e))
+	}
+	return map[string]any{
+		"url":    s.URL,
+		"events": strings.Join(events, ","),
+		"active": s.Active,
+	}
+}
+
+// WebhookDeliveryStatus สถานะการส่ง webhook
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // รอส่งหรือรอส่งใหม่
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered" // ปลายทางตอบ 2xx แล้ว
+	WebhookDeliveryDead      WebhookDeliveryStatus = "dead"      // ส่งไม่สำเร็จครบจำนวนครั้ง — รอผู้ดูแลระบบสั่งส่งใหม่
+)
+
+func (s WebhookDeliveryStatus) IsValid() bool {
+	return s == WebhookDeliveryPending || s == WebhookDeliveryDelivered || s == WebhookDeliveryDead
+}
+
+// WebhookDelivery รายการส่ง webhook หนึ่งครั้งต่อ subscription ต่อเหตุการณ์ (outbox) — payload ถูกสร้างครั้งเดียว
+// ตอนเกิดเหตุการณ์ การส่งใหม่จึงได้ข้อมูล ณ เวลาที่เกิดเสมอ
+type WebhookDelivery struct {
+	CreatedAt      time.Time             `json:"created_at"                 bson:"created_at"`                 // เวลาที่สร้างรายการ
+	NextAttemptAt  time.Time             `json:"next_attempt_at"            bson:"next_attempt_at"`            // เวลาที่จะส่งครั้งถัดไป
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty"     bson:"delivered_at,omitempty"`     // เวลาที่ส่งสำเร็จ
+	EventType      LeaveEventType        `json:"event_type"                 bson:"event_type"`                 // ประเภทเหตุการณ์
+	Status         WebhookDeliveryStatus `json:"status"                     bson:"status"`                     // สถานะ
+	Payload        string                `json:"payload"                    bson:"payload"`                    // JSON ที่ส่ง (ใช้ sign ทั้งก้อน)
+	LastError      string                `json:"last_error,omitempty"       bson:"last_error,omitempty"`       // สาเหตุที่ส่งไม่สำเร็จครั้งล่าสุด
+	Attempts       int                   `json:"attempts"                   bson:"attempts"`                   // จำนวนครั้งที่ส่งแล้ว
+	LastStatusCode int                   `json:"last_status_code,omitempty" bson:"last_status_code,omitempty"` // HTTP status ครั้งล่าสุด (0 = เชื่อมต่อไม่ได้)
+	ID             ID                    `json:"id"                         bson:"_id"`                        // รหัสรายการส่ง (UUID)
+	SubscriptionID ID                    `json:"subscription_id"            bson:"subscription_id"`            // subscription ปลายทาง
+	EventID        ID                    `json:"event_id"                   bson:"event_id"`                   // รหัสเหตุการณ์ — เหมือนกันทุกครั้งที่ส่งใหม่
+}
+
+func NewWebhookDelivery(subscriptionID ID, event *LeaveEvent, payload string) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             NewID(),
+		SubscriptionID: subscriptionID,
+		EventID:        event.ID,
+		EventType:      event.Type,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+	}
+}
+
+// RecordSuccess บันทึกว่าปลายทางรับแล้ว
+func (d *WebhookDelivery) RecordSuccess(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = WebhookDeliveryDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+}
+
+// RecordFailure บันทึกการส่งไม่สำเร็จ — ครบ MaxWebhookAttempts แล้วย้ายเป็น dead มิฉะนั้นเลื่อนไปส่งใหม่แบบ exponential backoff
+func (d *WebhookDelivery) RecordFailure(statusCode int, reason string, now time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	if r := []rune(reason); len(r) > webhookErrorMaxLength {
+		reason = string(r[:webhookErrorMaxLength])
+	}
+	d.LastError = reason
+
+	if d.Attempts >= MaxWebhookAttempts {
+		d.Status = WebhookDeliveryDead
+		return
+	}
+	d.NextAttemptAt = now.Add(WebhookRetryDelay(d.Attempts))
+}
+
+// Abandon ย้ายเป็น dead โดยไม่ส่ง (เช่น webhook ถูกลบหรือปิดใช้งาน) — ผู้ดูแลระบบสั่งส่งใหม่ได้ภายหลัง
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryDead
+	d.LastError = reason
+}
+
+// Redeliver ส่งใหม่ตามคำสั่งผู้ดูแลระบบ — เริ่มนับจำนวนครั้งใหม่ ใช้ payload และรหัสเหตุการณ์เดิม
+func (d *WebhookDelivery) Redeliver(now time.Time) error {
+	if d.Status == WebhookDeliveryPending {
+		return ErrWebhookDeliveryPending
+	}
+	d.Status = WebhookDeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.DeliveredAt = nil
+	return nil
+}
+
+// WebhookRetryDelay เวลารอก่อนส่งใหม่หลังส่งไม่สำเร็จครั้งที่ attempts — 30s, 1m, 2m, ... สูงสุด 1 ชั่วโมง
+func WebhookRetryDelay(attempts int) time.Duration {
+	delay := webhookBaseRetryDelay
+	for i := 1; i < attempts && delay < webhookMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, webhookMaxRetryDelay)
+}
+
+// WebhookDeliveryFilter เงื่อนไขค้นหารายการส่ง webhook — ค่าว่าง = ไม่กรอง
+type WebhookDeliveryFilter struct {
+	SubscriptionID *ID
+	Status         WebhookDeliveryStatus
+}
@@ -0,0 +1,33 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// AuditLogger บันทึกการกระทำลง audit log — service ที่เรียกต้องคืน error ต่อเมื่อบันทึกไม่สำเร็จ เช่นเดียวกับ SecurityEventRepository
+type AuditLogger interface {
+	// Record เติมผู้กระทำ IP และ User-Agent จาก RequestMeta ใน context (ถ้ายังไม่ได้ระบุ) แล้วต่อท้าย hash chain
+	Record(ctx context.Context, event *domain.AuditEvent) error
+}
+
+type AuditService interface {
+	AuditLogger
+	// Search ค้นหา audit event ตามเงื่อนไข เรียงจากใหม่ไปเก่า (รองรับ pagination)
+	Search(ctx context.Context, filter domain.AuditFilter, params domain.PaginationParams) (*domain.PaginatedResult[domain.AuditEvent], error)
+	// VerifyChain ตรวจ hash chain ทั้งหมดตั้งแต่ event แรก — รายงาน sequence แรกที่ถูกแก้ไขหรือขาดหาย
+	VerifyChain(ctx context.Context) (*domain.AuditChainReport, error)
+}
+
+// AuditRepository เก็บ audit event แบบเพิ่มได้อย่างเดียว — ไม่มีเมธอดแก้ไขหรือลบ
+type AuditRepository interface {
+	// Last ดึง event ล่าสุดของ chain — คืน nil ถ้ายังไม่มี event
+	Last(ctx context.Context) (*domain.AuditEvent, error)
+	// Append บันทึก event ใหม่ — คืน ErrAuditSequenceConflict ถ้า sequence ถูกใช้ไปแล้ว (มีผู้บันทึกพร้อมกัน)
+	Append(ctx context.Context, event *domain.AuditEvent) error
+	// Search ค้นหา event ตามเงื่อนไข เรียงตาม sequence จากใหม่ไปเก่า (รองรับ pagination)
+	Search(ctx context.Context, filter domain.AuditFilter, params domain.PaginationParams) (*domain.PaginatedResult[domain.AuditEvent], error)
+	// FindAfter ดึง event ที่ sequence มากกว่าที่ระบุ เรียงจากเก่าไปใหม่ ไม่เกิน limit รายการ
+	FindAfter(ctx context.Context, afterSequence int64, limit int64) ([]domain.AuditEvent, error)
+}
@@ -2,18 +2,83 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github/be2bag/leave-management-system/internal/core/domain"
 )
 
 type AuthService interface {
-	// Login เข้าสู่ระบบ — คืน JWT token และข้อมูลผู้ใช้
-	Login(ctx context.Context, email, password string) (string, *domain.User, error)
+	// Login เข้าสู่ระบบขั้นแรก — คืน token ชุดเต็ม หรือ challenge ถ้าผู้ใช้เปิด 2FA
+	Login(ctx context.Context, email, password string) (*domain.LoginResult, error)
+	// VerifyMFA ยืนยัน challenge ด้วยรหัส TOTP หรือ recovery code — คืน token ชุดเต็ม
+	VerifyMFA(ctx context.Context, challengeToken, code string) (*domain.AuthTokens, *domain.User, error)
+	// Refresh แลก refresh token เป็น token ชุดใหม่ (rotation) — ใช้ซ้ำจะยกเลิกทั้ง family
+	Refresh(ctx context.Context, refreshToken string) (*domain.AuthTokens, *domain.User, error)
+}
+
+type Authenticator interface {
+	// Authenticate ตรวจสอบรหัสผ่านของอีเมล — user เป็น nil เมื่อไม่พบอีเมลในระบบ
+	// คืนข้อมูลผู้ใช้จาก directory (nil สำหรับรหัสผ่านในระบบ) หรือ ErrInvalidCredentials เมื่อรหัสผ่านไม่ถูกต้อง
+	Authenticate(ctx context.Context, email, password string, user *domain.User) (*domain.ExternalIdentity, error)
 }
 
 type TokenService interface {
-	// GenerateToken สร้าง JWT token จากข้อมูลผู้ใช้
-	GenerateToken(user *domain.User) (string, error)
-	// ValidateToken ตรวจสอบและถอดรหัส JWT token — คืนข้อมูลผู้ใช้จาก token
-	ValidateToken(tokenString string) (*domain.TokenClaims, error)
+	// GenerateToken สร้าง JWT access token จากข้อมูลผู้ใช้ — mfa ระบุว่า session ยืนยัน 2FA แล้ว คืน token และเวลาหมดอายุ
+	GenerateToken(user *domain.User, mfa bool) (string, time.Time, error)
+	// ValidateToken ตรวจสอบและถอดรหัส JWT token พร้อมตรวจ denylist — คืนข้อมูลผู้ใช้จาก token
+	ValidateToken(ctx context.Context, tokenString string) (*domain.TokenClaims, error)
+}
+
+type JWKSProvider interface {
+	// PublicJWKS คืน public keys ทั้งหมดที่ใช้ตรวจสอบ token (รวมคีย์เก่าที่ยังอยู่ระหว่าง rotate)
+	PublicJWKS() domain.JSONWebKeySet
+}
+
+type SessionService interface {
+	// Logout ออกจากระบบ — ยกเลิก access token ปัจจุบันและ refresh token family ที่ส่งมา (ถ้ามี)
+	Logout(ctx context.Context, claims *domain.TokenClaims, refreshToken string) error
+	// RevokeAllSessions ยกเลิกทุก session ของผู้ใช้ (access token และ refresh token ทั้งหมด)
+	RevokeAllSessions(ctx context.Context, userID domain.ID) error
+}
+
+type TokenRevocationStore interface {
+	// RevokeToken เพิ่ม jti ลงใน denylist — เก็บไว้จนถึงเวลาที่ token หมดอายุ
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// RevokeUserTokens ยกเลิก access token ทุกตัวของผู้ใช้ที่ออกก่อนเวลาที่ระบุ (ละเอียดถึงมิลลิวินาทีเหมือน iat_ms)
+	RevokeUserTokens(ctx context.Context, userID domain.ID, revokedAt time.Time) error
+	// IsRevoked ตรวจสอบว่า token ถูกยกเลิกแล้วหรือไม่ (ทั้งราย token และรายผู้ใช้)
+	IsRevoked(ctx context.Context, claims *domain.TokenClaims) (bool, error)
+}
+
+type RefreshTokenRepository interface {
+	// Create บันทึก refresh token ใหม่
+	Create(ctx context.Context, token *domain.RefreshToken) error
+	// FindByHash ค้นหา refresh token จาก hash
+	FindByHash(ctx context.Context, tokenHash string) (*domain.RefreshToken, error)
+	// Consume ทำเครื่องหมายว่า token ถูกใช้แล้วแบบ atomic — คืน ErrRefreshTokenReused ถ้าถูกใช้ไปก่อนแล้ว
+	Consume(ctx context.Context, id domain.ID) error
+	// RevokeFamily ยกเลิก refresh token ทุกตัวใน family เดียวกัน
+	RevokeFamily(ctx context.Context, familyID domain.ID) error
+	// RevokeByUserID ยกเลิก refresh token ทุกตัวของผู้ใช้
+	RevokeByUserID(ctx context.Context, userID domain.ID) error
+}
+
+type AccountLockService interface {
+	// UnlockAccount ปลดล็อกบัญชีที่ถูกล็อกจากการ login ผิดติดต่อกัน และล้างจำนวนครั้งที่ผิด (ต้องมีสิทธิ์ user.manage)
+	UnlockAccount(ctx context.Context, actorID, userID domain.ID) error
+}
+
+type LoginAttemptRepository interface {
+	// Find ค้นหาสถิติการ login ผิดของอีเมล — คืน LoginAttempt ว่างถ้ายังไม่เคยผิด
+	Find(ctx context.Context, email string) (*domain.LoginAttempt, error)
+	// RecordAttempt บันทึกสถิติที่นับความพยายามครั้งใหม่แล้ว (Version เพิ่มขึ้น 1) ในคำสั่ง atomic เดียว
+	// เฉพาะเมื่อ version ในฐานข้อมูลยังเป็นค่าที่อ่านมา — คืนสถิติหลังอัปเดต หรือ ErrTooManyLoginAttempts ถ้ามีคำขออื่นนับไปก่อน
+	RecordAttempt(ctx context.Context, attempt *domain.LoginAttempt) (*domain.LoginAttempt, error)
+	// Reset ล้างสถิติทั้งหมดของอีเมล (login สำเร็จหรือผู้ดูแลระบบปลดล็อก)
+	Reset(ctx context.Context, email string) error
+}
+
+type SecurityEventRepository interface {
+	// Record บันทึกเหตุการณ์ด้านความปลอดภัย
+	Record(ctx context.Context, event *domain.SecurityEvent) error
 }
@@ -0,0 +1,62 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// EventHandler รับเหตุการณ์จาก outbox ภายใน process — ถูกเรียกซ้ำได้ (at-least-once)
+// จึงต้องใช้ event.ID เป็น idempotency key เพื่อไม่ทำงานซ้ำ
+type EventHandler interface {
+	// HandlerName ชื่อคงที่ของ handler — outbox บันทึกชื่อนี้เมื่อทำสำเร็จเพื่อไม่เรียกซ้ำตอนลองใหม่
+	HandlerName() string
+	// Handle ทำงานตามเหตุการณ์ — คืน error เพื่อให้ dispatcher ลองใหม่ภายหลัง
+	Handle(ctx context.Context, event domain.LeaveEvent) error
+}
+
+// EventDispatcher ส่งต่อเหตุการณ์ใน outbox ให้ handler ทุกตัว
+type EventDispatcher interface {
+	// Run วนส่งต่อเหตุการณ์จนกว่า ctx จะถูกยกเลิก
+	Run(ctx context.Context)
+}
+
+type OutboxRepository interface {
+	// Append บันทึกเหตุการณ์ลง outbox — เรียกด้วย ctx ของ transaction เพื่อให้บันทึกพร้อมกับการเปลี่ยนแปลง
+	Append(ctx context.Context, msg *domain.OutboxMessage) error
+	// ClaimDue จองเหตุการณ์ pending ที่ถึงเวลาหนึ่งรายการแบบ atomic โดยเลื่อน next_attempt_at ออกไป lease
+	// — instance อื่นจึงไม่ส่งต่อซ้ำ คืน nil ถ้าไม่มีเหตุการณ์ที่ถึงเวลา
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domain.OutboxMessage, error)
+	// Update บันทึกผลการส่งต่อ
+	Update(ctx context.Context, msg *domain.OutboxMessage) error
+	// FindByID ค้นหาเหตุการณ์จากรหัส — คืน nil ถ้าไม่พบ (เช่น ถูกลบตามเวลาเก็บแล้ว)
+	FindByID(ctx context.Context, id domain.ID) (*domain.OutboxMessage, error)
+	// ListCreatedSince อ่านเหตุการณ์ทุกสถานะที่สร้างตั้งแต่ since เรียงตาม created_at ไม่เกิน limit รายการ
+	// — อ่านอย่างเดียวไม่จองเหตุการณ์ ทุก instance จึงได้เหตุการณ์ครบ
+	ListCreatedSince(ctx context.Context, since time.Time, limit int) ([]domain.OutboxMessage, error)
+}
+
+// TransactionManager รันหลายคำสั่งของ repository ใน transaction เดียว
+type TransactionManager interface {
+	// WithinTransaction เรียก fn ด้วย ctx ของ transaction — fn คืน error แล้วทุกคำสั่งใน fn ถูกยกเลิก
+	// fn อาจถูกเรียกซ้ำเมื่อ transaction ชนกับ transaction อื่น
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EventStream ส่งเหตุการณ์จาก outbox ให้ผู้ที่เชื่อมต่ออยู่แบบ real-time (Server-Sent Events)
+type EventStream interface {
+	// Run อ่านเหตุการณ์ใหม่จาก outbox ส่งให้ผู้เชื่อมต่อกับ instance นี้ และตรวจ token กับสิทธิ์ของผู้เชื่อมต่อซ้ำเป็นระยะ
+	// จนกว่า ctx จะถูกยกเลิก — ทุก instance ต้องรันเองเพราะไม่ได้แย่งเหตุการณ์กันเหมือน EventDispatcher
+	Run(ctx context.Context)
+	// Subscribe รับเหตุการณ์ที่ผู้ใช้มีสิทธิ์เห็นจนกว่า ctx ถูกยกเลิก — lastEventID ไม่ว่างคือส่งเหตุการณ์หลังจากนั้นที่พลาดไปก่อน
+	// resumed = false เมื่อหาเหตุการณ์ lastEventID ไม่พบหรือพลาดไปมากเกินไป (client ต้องโหลดข้อมูลใหม่)
+	// channel ถูกปิดเมื่อ ctx ถูกยกเลิก, client รับไม่ทัน, token หมดอายุหรือถูกยกเลิก หรือ Close
+	Subscribe(
+		ctx context.Context,
+		claims *domain.TokenClaims,
+		lastEventID string,
+	) (events <-chan domain.LeaveEvent, resumed bool, err error)
+	// Close ปิดการเชื่อมต่อทั้งหมด (ตอนปิด server)
+	Close()
+}
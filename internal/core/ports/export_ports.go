@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// ExportService ส่งออกใบลาและยอดวันลาทีละรายการ — fn ถูกเรียกตามลำดับ ถ้า fn คืน error จะหยุดอ่านทันที
+type ExportService interface {
+	// ExportRequests ใบลาตามเงื่อนไขเดียวกับการค้นหา พร้อมผู้ยื่นและผู้อนุมัติ (ไม่ระบุลำดับ = ตามวันเริ่มลา)
+	ExportRequests(ctx context.Context, query domain.LeaveRequestQuery, fn func(*domain.LeaveRequestView) error) error
+	// ExportBalances ยอดวันลาตามเงื่อนไข พร้อมข้อมูลย่อของพนักงาน เรียงตามปี พนักงาน และประเภทการลา
+	ExportBalances(ctx context.Context, query domain.LeaveBalanceQuery, fn func(*domain.LeaveBalanceView) error) error
+}
+
+// ExportRepository อ่านจาก MongoDB cursor ทีละ document — ไม่โหลดผลทั้งหมดเข้าหน่วยความจำ
+type ExportRepository interface {
+	// EachRequest เรียก fn กับใบลาทุกใบที่ตรงเงื่อนไขตามลำดับใน query
+	EachRequest(ctx context.Context, query domain.LeaveRequestQuery, fn func(*domain.LeaveRequestView) error) error
+	// EachBalance เรียก fn กับยอดวันลาทุกรายการที่ตรงเงื่อนไข
+	EachBalance(ctx context.Context, query domain.LeaveBalanceQuery, fn func(*domain.LeaveBalanceView) error) error
+}
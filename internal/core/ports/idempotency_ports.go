@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// IdempotencyService จัดการ Idempotency-Key ของ request ที่เปลี่ยนข้อมูล
+type IdempotencyService interface {
+	// Begin จอง key ของผู้ใช้ — คืน response เดิมเมื่อเป็น request ซ้ำที่ทำเสร็จแล้ว (nil = ทำ request ต่อแล้วเรียก Complete หรือ Release)
+	// คืน ErrIdempotencyKeyReused เมื่อ key ถูกใช้กับ request อื่น และ ErrIdempotencyKeyInProgress เมื่อ request แรกยังไม่เสร็จ
+	Begin(ctx context.Context, userID domain.ID, key, fingerprint string) (*domain.IdempotencyRecord, *domain.IdempotentResponse, error)
+	// Complete บันทึก response ของ request แรกไว้ตอบ request ที่ส่งซ้ำ
+	Complete(ctx context.Context, record *domain.IdempotencyRecord, resp domain.IdempotentResponse) error
+	// Release ยกเลิกการจอง key — client ลองใหม่ด้วย key เดิมได้ (เช่น เมื่อเกิด error ภายในระบบ)
+	Release(ctx context.Context, record *domain.IdempotencyRecord) error
+}
+
+type IdempotencyRepository interface {
+	// Reserve บันทึก key ใหม่ หรือแทนที่ key เดิมที่หมดเวลาแล้ว — คืน false ถ้ายังมี key นี้ที่ยังไม่หมดเวลา
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord, now time.Time) (bool, error)
+	// Find ค้นหา key ของผู้ใช้ — คืน nil ถ้าไม่พบ
+	Find(ctx context.Context, userID domain.ID, key string) (*domain.IdempotencyRecord, error)
+	// Update บันทึก response และวันหมดอายุ
+	Update(ctx context.Context, record *domain.IdempotencyRecord) error
+	// Delete ลบ key
+	Delete(ctx context.Context, id domain.ID) error
+}
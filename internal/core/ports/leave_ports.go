@@ -11,16 +11,42 @@ type LeaveService interface {
 	// Submit ยื่นใบลาใหม่ — ตรวจสอบ overlap และ balance ก่อนสร้าง
 	Submit(ctx context.Context, userID domain.ID, leaveType domain.LeaveType,
 		startDate, endDate time.Time, reason string) (*domain.LeaveRequest, error)
-	// GetMyRequests ดูประวัติใบลาทั้งหมดของตนเอง (รองรับ pagination)
-	GetMyRequests(ctx context.Context, userID domain.ID, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
+	// GetMyRequests ดูประวัติใบลาของตนเองตามเงื่อนไข พร้อมผู้อนุมัติ (รองรับ pagination)
+	GetMyRequests(ctx context.Context, userID domain.ID, query domain.LeaveRequestQuery, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequestView], error)
 	// GetMyBalance ดูยอดวันลาคงเหลือของตนเอง
 	GetMyBalance(ctx context.Context, userID domain.ID) ([]domain.LeaveBalance, error)
-	// GetPendingRequests ดูใบลาที่รอการอนุมัติ (สำหรับผู้จัดการ, รองรับ pagination)
-	GetPendingRequests(ctx context.Context, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
-	// Approve อนุมัติใบลา — หักยอดวันลาของพนักงาน
+	// GetPendingRequests ดูใบลาที่รอการอนุมัติพร้อมผู้ยื่น (ต้องมีสิทธิ์ leave.view_team, รองรับ pagination)
+	GetPendingRequests(ctx context.Context, viewerID domain.ID, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequestView], error)
+	// SearchRequests ค้นหาใบลาของทุกคนตามเงื่อนไข พร้อมผู้ยื่นและผู้อนุมัติ (ต้องมีสิทธิ์ leave.view_team, รองรับ pagination)
+	SearchRequests(ctx context.Context, viewerID domain.ID, query domain.LeaveRequestQuery, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequestView], error)
+	// GetRequestsByStatus ดูใบลาทั้งหมดตามสถานะ (สำหรับระบบภายนอก เช่น payroll, รองรับ pagination)
+	GetRequestsByStatus(ctx context.Context, status domain.LeaveStatus, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
+	// SetEntitlement กำหนดจำนวนวันลาทั้งหมดที่พนักงานได้รับในปีนั้น — สร้างยอดวันลาใหม่ถ้ายังไม่มี
+	SetEntitlement(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, totalDays float64) (*domain.LeaveBalance, error)
+	// AdjustEntitlement กำหนดจำนวนวันลาที่พนักงานได้รับโดยผู้ใช้ในระบบ (ต้องมีสิทธิ์ balance.adjust)
+	AdjustEntitlement(ctx context.Context, actorID, userID domain.ID, leaveType domain.LeaveType, year int, totalDays float64) (*domain.LeaveBalance, error)
+	// Approve อนุมัติใบลา — หักยอดวันลาของพนักงาน (ต้องมีสิทธิ์ leave.approve)
 	Approve(ctx context.Context, requestID, reviewerID domain.ID, note string) error
-	// Reject ปฏิเสธใบลา — ยอดวันลาไม่เปลี่ยนแปลง
+	// Reject ปฏิเสธใบลา — ยอดวันลาไม่เปลี่ยนแปลง (ต้องมีสิทธิ์ leave.approve)
 	Reject(ctx context.Context, requestID, reviewerID domain.ID, note string) error
+	// GetHistory ดูประวัติของใบลาเรียงตามเวลาพร้อมผู้กระทำ (เจ้าของใบลาหรือผู้มีสิทธิ์ leave.view_team/user.manage)
+	GetHistory(ctx context.Context, requestID, viewerID domain.ID) ([]domain.LeaveHistoryView, error)
+}
+
+// LeaveReadService อ่านใบลาพร้อมข้อมูลประกอบ — แยกจาก LeaveService เพื่อไม่ให้ interface ใหญ่เกินไป
+type LeaveReadService interface {
+	// GetRequest ดูใบลาพร้อมข้อมูลย่อของผู้ยื่น ผู้อนุมัติ และผลต่อยอดวันลา
+	// (เจ้าของใบลา ผู้มีสิทธิ์ leave.view_team หรือ user.manage — ผู้อื่นได้ ErrRequestNotFound)
+	GetRequest(ctx context.Context, requestID, viewerID domain.ID) (*domain.LeaveRequestDetail, error)
+}
+
+// LeaveAmendService เจ้าของแก้ไขหรือยกเลิกใบลาที่ยังรออนุมัติ — แยกจาก LeaveService เพื่อไม่ให้ interface ใหญ่เกินไป
+type LeaveAmendService interface {
+	// Edit แก้ประเภท ช่วงวัน และเหตุผล — ปล่อยวันลาที่จองไว้เดิมแล้วจองใหม่ตามใบลาที่แก้ (ผู้อื่นได้ ErrRequestNotFound)
+	Edit(ctx context.Context, requestID, userID domain.ID, leaveType domain.LeaveType,
+		startDate, endDate time.Time, reason string) (*domain.LeaveRequest, error)
+	// Cancel ยกเลิกใบลาและปล่อยวันลาที่จองไว้ — reason บันทึกในประวัติใบลา (ผู้อื่นได้ ErrRequestNotFound)
+	Cancel(ctx context.Context, requestID, userID domain.ID, reason string) (*domain.LeaveRequest, error)
 }
 
 type LeaveBalanceRepository interface {
@@ -30,8 +56,12 @@ type LeaveBalanceRepository interface {
 	ReservePending(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, days float64) error
 	// ConfirmPending ยืนยันวันลาแบบ atomic — ย้ายจาก pending_days ไป used_days (ใช้ตอนอนุมัติ)
 	ConfirmPending(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, days float64) error
-	// ReleasePending ปล่อยวันลาที่จองไว้แบบ atomic — ลด pending_days (ใช้ตอนปฏิเสธ)
+	// ReleasePending ปล่อยวันลาที่จองไว้แบบ atomic — ลด pending_days (ใช้ตอนปฏิเสธ แก้ไข หรือยกเลิก)
 	ReleasePending(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, days float64) error
+	// SetTotalDays กำหนด total_days แบบ atomic (upsert) — คืน ErrEntitlementBelowUsage ถ้าน้อยกว่า used + pending
+	SetTotalDays(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, totalDays float64) (*domain.LeaveBalance, error)
+	// SetOpeningBalance กำหนดยอดยกมา (total_days + used_days) แบบ atomic (upsert) — คืน ErrEntitlementBelowUsage ถ้า total น้อยกว่า used + pending
+	SetOpeningBalance(ctx context.Context, userID domain.ID, year int, balance domain.OpeningBalance) (*domain.LeaveBalance, error)
 }
 
 type LeaveRequestRepository interface {
@@ -39,14 +69,35 @@ type LeaveRequestRepository interface {
 	Create(ctx context.Context, request *domain.LeaveRequest) error
 	// FindByID ค้นหาคำขอลาจากรหัส
 	FindByID(ctx context.Context, id domain.ID) (*domain.LeaveRequest, error)
-	// FindByUserID ค้นหาคำขอลาทั้งหมดของผู้ใช้ (รองรับ pagination)
-	FindByUserID(ctx context.Context, userID domain.ID, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
-	// FindByStatus ค้นหาคำขอลาตามสถานะ (เช่น pending สำหรับผู้จัดการ, รองรับ pagination)
-	FindByStatus(ctx context.Context, status domain.LeaveStatus, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
-	// Update อัปเดตคำขอลา (เช่น เปลี่ยนสถานะเป็น approved/rejected)
+	// Search ค้นหาคำขอลาตามเงื่อนไขและลำดับใน query (รองรับ pagination)
+	Search(ctx context.Context, query domain.LeaveRequestQuery, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
+	// SearchWithUsers เหมือน Search แต่แนบข้อมูลย่อของผู้ยื่นและผู้อนุมัติมาใน query เดียว
+	SearchWithUsers(ctx context.Context, query domain.LeaveRequestQuery, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequestView], error)
+	// Update อัปเดตคำขอลา (เช่น เปลี่ยนสถานะเป็น approved/rejected/cancelled)
 	Update(ctx context.Context, request *domain.LeaveRequest) error
 	// UpdateWithStatusCheck อัปเดตคำขอลาแบบ atomic
 	UpdateWithStatusCheck(ctx context.Context, request *domain.LeaveRequest, expectedStatus domain.LeaveStatus) error
 	// HasOverlap ตรวจสอบว่ามีคำขอลาที่ซ้ำซ้อนกับช่วงวันที่ที่ระบุหรือไม่
 	HasOverlap(ctx context.Context, userID domain.ID, startDate, endDate time.Time, excludeID *domain.ID) (bool, error)
 }
+
+type LeaveHistoryRepository interface {
+	// Append เพิ่มเหตุการณ์ในประวัติของใบลา
+	Append(ctx context.Context, entry *domain.LeaveHistoryEntry) error
+	// FindByRequestID ค้นหาประวัติทั้งหมดของใบลา เรียงจากเก่าไปใหม่
+	FindByRequestID(ctx context.Context, requestID domain.ID) ([]domain.LeaveHistoryEntry, error)
+}
+
+type LeaveCommentService interface {
+	// AddComment แสดงความคิดเห็นในใบลา (เจ้าของใบลาหรือผู้มีสิทธิ์ leave.view_team/user.manage) — แจ้งเตือนผู้ที่ถูกกล่าวถึงทางอีเมล
+	AddComment(ctx context.Context, requestID, authorID domain.ID, body string, internal bool, mentions []domain.ID) (*domain.LeaveComment, error)
+	// ListComments ดูความคิดเห็นของใบลาเรียงตามเวลา — บันทึกภายในเห็นเฉพาะผู้มีสิทธิ์ leave.approve ที่ไม่ใช่เจ้าของใบลา
+	ListComments(ctx context.Context, requestID, viewerID domain.ID) ([]domain.LeaveComment, error)
+}
+
+type LeaveCommentRepository interface {
+	// Create บันทึกความคิดเห็นใหม่
+	Create(ctx context.Context, comment *domain.LeaveComment) error
+	// FindByRequestID ค้นหาความคิดเห็นของใบลา เรียงจากเก่าไปใหม่ — includeInternal = false จะไม่คืนบันทึกภายใน
+	FindByRequestID(ctx context.Context, requestID domain.ID, includeInternal bool) ([]domain.LeaveComment, error)
+}
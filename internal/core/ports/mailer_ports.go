@@ -0,0 +1,12 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type Mailer interface {
+	// Send ส่งอีเมลถึงผู้ใช้
+	Send(ctx context.Context, msg domain.EmailMessage) error
+}
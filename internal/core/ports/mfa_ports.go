@@ -0,0 +1,44 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type MFAService interface {
+	// BeginEnrollment สร้าง TOTP secret ใหม่ (ยังไม่เปิดใช้จนกว่าจะยืนยันรหัส)
+	BeginEnrollment(ctx context.Context, userID domain.ID) (*domain.TOTPEnrollment, error)
+	// ConfirmEnrollment ยืนยันรหัสจากแอป authenticator แล้วเปิดใช้ 2FA — คืน recovery codes (แสดงครั้งเดียว)
+	ConfirmEnrollment(ctx context.Context, userID domain.ID, code string) ([]string, error)
+	// Disable ปิดใช้ 2FA — ต้องยืนยันด้วยรหัส TOTP หรือ recovery code
+	Disable(ctx context.Context, userID domain.ID, code string) error
+	// RegenerateRecoveryCodes สร้าง recovery codes ชุดใหม่แทนชุดเดิม — ต้องยืนยันด้วยรหัส TOTP
+	RegenerateRecoveryCodes(ctx context.Context, userID domain.ID, code string) ([]string, error)
+}
+
+type MFARepository interface {
+	// Find ค้นหาสถานะ 2FA ของผู้ใช้ — คืน UserMFA ว่างถ้ายังไม่เคยลงทะเบียน
+	Find(ctx context.Context, userID domain.ID) (*domain.UserMFA, error)
+	// SavePendingSecret บันทึก secret ที่รอยืนยัน (แทนที่ secret ที่รอยืนยันเดิม)
+	SavePendingSecret(ctx context.Context, userID domain.ID, secret string) error
+	// Enable ย้าย pending secret เป็น secret ที่ใช้งาน พร้อมบันทึก recovery codes
+	Enable(ctx context.Context, userID domain.ID, secret string, recoveryCodeHashes []string) error
+	// Disable ลบข้อมูล 2FA ทั้งหมดของผู้ใช้
+	Disable(ctx context.Context, userID domain.ID) error
+	// ReplaceRecoveryCodes แทนที่ recovery codes ทั้งชุด
+	ReplaceRecoveryCodes(ctx context.Context, userID domain.ID, recoveryCodeHashes []string) error
+	// ConsumeRecoveryCode ลบ recovery code ที่ใช้แล้วแบบ atomic — คืน ErrInvalidMFACode ถ้าไม่มี code นี้
+	ConsumeRecoveryCode(ctx context.Context, userID domain.ID, codeHash string) error
+	// MarkStepUsed บันทึก time step ที่ใช้แล้วแบบ atomic — คืน ErrInvalidMFACode ถ้าใช้ step นี้หรือใหม่กว่าไปแล้ว
+	MarkStepUsed(ctx context.Context, userID domain.ID, step int64) error
+}
+
+type MFAChallengeRepository interface {
+	// Create บันทึก challenge ใหม่
+	Create(ctx context.Context, challenge *domain.MFAChallenge) error
+	// FindByHash ค้นหา challenge จาก hash — คืน ErrInvalidMFAChallenge ถ้าไม่พบ
+	FindByHash(ctx context.Context, tokenHash string) (*domain.MFAChallenge, error)
+	// Consume ลบ challenge ที่ยืนยันสำเร็จแบบ atomic — คืน ErrInvalidMFAChallenge ถ้าถูกใช้ไปแล้ว
+	Consume(ctx context.Context, id domain.ID) error
+}
@@ -0,0 +1,7 @@
+package ports
+
+// NotificationService แจ้งเตือนเหตุการณ์ของใบลาทางอีเมล — รับเหตุการณ์จาก outbox ในฐานะ EventHandler
+// และส่งอีเมลก่อนคืนค่า ส่งไม่สำเร็จ outbox จะลองใหม่
+type NotificationService interface {
+	EventHandler
+}
@@ -0,0 +1,28 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type OIDCService interface {
+	// BeginLogin สร้าง state และ URL สำหรับ redirect ไป IdP — state ต้องผูกกับ browser ของผู้ใช้ (เช่น cookie)
+	BeginLogin(ctx context.Context) (state, authURL string, err error)
+	// CompleteLogin แลก authorization code, จับคู่หรือสร้างผู้ใช้จาก claims แล้วออก token ของระบบ
+	CompleteLogin(ctx context.Context, state, code string) (*domain.AuthTokens, *domain.User, error)
+}
+
+type IdentityProvider interface {
+	// AuthCodeURL สร้าง URL หน้า login ของ IdP (authorization code flow + PKCE)
+	AuthCodeURL(ctx context.Context, state, nonce, codeChallenge string) (string, error)
+	// Exchange แลก code เป็น ID token และตรวจสอบลายเซ็น/issuer/audience/nonce — คืน ErrOIDCLoginFailed ถ้าไม่ผ่าน
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*domain.ExternalIdentity, error)
+}
+
+type OIDCStateRepository interface {
+	// Create บันทึก state ใหม่
+	Create(ctx context.Context, state *domain.OIDCLoginState) error
+	// Consume ดึงและลบ state แบบ atomic — คืน ErrInvalidOIDCState ถ้าไม่พบหรือถูกใช้ไปแล้ว
+	Consume(ctx context.Context, stateHash string) (*domain.OIDCLoginState, error)
+}
@@ -0,0 +1,27 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type PasswordService interface {
+	// ChangePassword เปลี่ยนรหัสผ่านของผู้ใช้ที่ login อยู่ — ยกเลิกทุก session หลังเปลี่ยนสำเร็จ
+	ChangePassword(ctx context.Context, userID domain.ID, currentPassword, newPassword string) error
+	// RequestPasswordReset ส่งลิงก์ตั้งรหัสผ่านใหม่ทางอีเมล — ไม่แจ้งว่าอีเมลมีอยู่ในระบบหรือไม่
+	RequestPasswordReset(ctx context.Context, email string) error
+	// ResetPassword ตั้งรหัสผ่านใหม่ด้วย reset token (ใช้ได้ครั้งเดียว) — ยกเลิกทุก session หลังตั้งสำเร็จ
+	ResetPassword(ctx context.Context, token, newPassword string) error
+}
+
+type PasswordResetRepository interface {
+	// Create บันทึก reset token ใหม่
+	Create(ctx context.Context, token *domain.PasswordResetToken) error
+	// FindByHash ค้นหา reset token จาก hash
+	FindByHash(ctx context.Context, tokenHash string) (*domain.PasswordResetToken, error)
+	// Consume ทำเครื่องหมายว่า token ถูกใช้แล้วแบบ atomic — คืน ErrInvalidResetToken ถ้าถูกใช้ไปก่อนแล้ว
+	Consume(ctx context.Context, id domain.ID) error
+	// InvalidateByUserID ยกเลิก reset token ที่ยังไม่ถูกใช้ทั้งหมดของผู้ใช้
+	InvalidateByUserID(ctx context.Context, userID domain.ID) error
+}
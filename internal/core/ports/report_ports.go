@@ -0,0 +1,35 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// ReportService รายงานสถิติการลาสำหรับ HR — ตรวจเงื่อนไขและคำนวณอัตราจากผลรวมของ ReportRepository
+type ReportService interface {
+	// LeaveUsageByMonth วันลาที่อนุมัติแล้วแยกตามประเภทและเดือน
+	LeaveUsageByMonth(ctx context.Context, filter domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error)
+	// AbsenteeismByTeam อัตราการขาดงานแยกตามแผนก
+	AbsenteeismByTeam(ctx context.Context, filter domain.ReportFilter) ([]domain.TeamAbsenteeism, error)
+	// ApprovalTurnaround เวลาเฉลี่ยตั้งแต่ยื่นจนพิจารณาแยกตามประเภทการลา
+	ApprovalTurnaround(ctx context.Context, filter domain.ReportFilter) ([]domain.ApprovalTurnaround, error)
+	// RejectionRateByReviewer อัตราการปฏิเสธแยกตามผู้อนุมัติ
+	RejectionRateByReviewer(ctx context.Context, filter domain.ReportFilter) ([]domain.ReviewerRejectionRate, error)
+	// CarryOverRisks พนักงานที่มีวันลาคงเหลือเกินจำนวนที่ยกไปปีถัดไปได้ เรียงจากเสี่ยงมากสุด
+	CarryOverRisks(ctx context.Context, filter domain.ReportFilter, limit int) ([]domain.CarryOverRisk, error)
+}
+
+// ReportRepository aggregation pipeline บน leave_requests และ leave_balances — คืนเฉพาะผลรวม ไม่คำนวณอัตรา
+type ReportRepository interface {
+	// LeaveUsageByMonth รวมวันลาที่อนุมัติตามประเภทและเดือนของ start_date
+	LeaveUsageByMonth(ctx context.Context, filter domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error)
+	// AbsenteeismByTeam จำนวนพนักงานและวันลาที่อนุมัติของแต่ละแผนก
+	AbsenteeismByTeam(ctx context.Context, filter domain.ReportFilter) ([]domain.TeamAbsenteeism, error)
+	// ApprovalTurnaround เวลาเฉลี่ยและนานที่สุดของใบลาที่พิจารณาแล้ว
+	ApprovalTurnaround(ctx context.Context, filter domain.ReportFilter) ([]domain.ApprovalTurnaround, error)
+	// RejectionCountsByReviewer จำนวนใบลาที่พิจารณาและที่ปฏิเสธของผู้อนุมัติแต่ละคน
+	RejectionCountsByReviewer(ctx context.Context, filter domain.ReportFilter) ([]domain.ReviewerRejectionRate, error)
+	// CarryOverRisks ยอดวันลาที่คงเหลือเกิน carryOverDays เรียงจากส่วนที่เกินมากสุด
+	CarryOverRisks(ctx context.Context, filter domain.ReportFilter, carryOverDays float64, limit int) ([]domain.CarryOverRisk, error)
+}
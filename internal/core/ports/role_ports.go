@@ -0,0 +1,45 @@
+package ports
+
+import (
+	"context"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// Authorizer ตรวจสิทธิ์ตามบทบาทที่เก็บในฐานข้อมูล — ใช้ทั้งใน middleware และใน service
+type Authorizer interface {
+	// Authorize ตรวจว่าผู้ใช้ (ตามบทบาทปัจจุบันในฐานข้อมูล) มีสิทธิ์ที่ระบุ — คืน ErrPermissionDenied ถ้าไม่มี
+	Authorize(ctx context.Context, userID domain.ID, permission domain.Permission) error
+	// RoleHasPermission ตรวจว่าบทบาทมีสิทธิ์ที่ระบุ — บทบาทที่ไม่มีในฐานข้อมูลถือว่าไม่มีสิทธิ์
+	RoleHasPermission(ctx context.Context, role domain.Role, permission domain.Permission) (bool, error)
+	// UserRole บทบาทปัจจุบันของผู้ใช้ในฐานข้อมูลพร้อมสิทธิ์ — บทบาทที่ไม่มีในฐานข้อมูลได้บทบาทที่ไม่มีสิทธิ์ใด
+	UserRole(ctx context.Context, userID domain.ID) (*domain.RoleDefinition, error)
+}
+
+type RoleService interface {
+	Authorizer
+	// EnsureDefaultRoles สร้างบทบาทเริ่มต้นที่ยังไม่มีในฐานข้อมูล — ไม่แก้บทบาทที่ผู้ดูแลระบบปรับสิทธิ์ไว้แล้ว
+	EnsureDefaultRoles(ctx context.Context) error
+	// ListRoles ดูบทบาททั้งหมดพร้อมสิทธิ์
+	ListRoles(ctx context.Context) ([]domain.RoleDefinition, error)
+	// SaveRole สร้างบทบาทใหม่หรือแก้สิทธิ์ของบทบาทเดิม (ต้องมีสิทธิ์ user.manage)
+	SaveRole(ctx context.Context, actorID domain.ID, name domain.Role, description string,
+		permissions []domain.Permission) (*domain.RoleDefinition, error)
+	// DeleteRole ลบบทบาทที่สร้างเอง — ลบบทบาทเริ่มต้นหรือบทบาทที่ยังมีผู้ใช้ไม่ได้ (ต้องมีสิทธิ์ user.manage)
+	DeleteRole(ctx context.Context, actorID domain.ID, name domain.Role) error
+	// AssignRole เปลี่ยนบทบาทของผู้ใช้ (ต้องมีสิทธิ์ user.manage)
+	AssignRole(ctx context.Context, actorID, userID domain.ID, role domain.Role) error
+}
+
+type RoleRepository interface {
+	// FindAll ดึงบทบาททั้งหมด เรียงตามชื่อ
+	FindAll(ctx context.Context) ([]domain.RoleDefinition, error)
+	// FindByName ค้นหาบทบาทจากชื่อ — คืน ErrRoleNotFound ถ้าไม่พบ
+	FindByName(ctx context.Context, name domain.Role) (*domain.RoleDefinition, error)
+	// Save สร้างหรือแก้ไขบทบาท — คง created_at และ built_in ของบทบาทเดิมไว้
+	Save(ctx context.Context, role *domain.RoleDefinition) (*domain.RoleDefinition, error)
+	// InsertIfMissing สร้างบทบาทเฉพาะเมื่อยังไม่มีในฐานข้อมูล
+	InsertIfMissing(ctx context.Context, role *domain.RoleDefinition) error
+	// Delete ลบบทบาทที่ไม่ใช่บทบาทเริ่มต้น — คืน ErrRoleNotFound ถ้าไม่พบ
+	Delete(ctx context.Context, name domain.Role) error
+}
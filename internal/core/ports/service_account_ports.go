@@ -0,0 +1,32 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type APIKeyService interface {
+	// CreateServiceAccount สร้าง service account พร้อม API key — คืน key จริงเพียงครั้งเดียว
+	CreateServiceAccount(ctx context.Context, actorID domain.ID, name string, scopes []domain.APIScope) (*domain.ServiceAccount, string, error)
+	// ListServiceAccounts ดู service account ทั้งหมด (รวมที่ถูกยกเลิกแล้ว)
+	ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error)
+	// RevokeServiceAccount ยกเลิก API key ของ service account — ใช้ไม่ได้อีกทันที
+	RevokeServiceAccount(ctx context.Context, id domain.ID) error
+	// Authenticate ตรวจสอบ API key และบันทึกเวลาที่ใช้ล่าสุด — คืน ErrInvalidAPIKey ถ้าไม่พบหรือถูกยกเลิก
+	Authenticate(ctx context.Context, key string) (*domain.ServiceAccount, error)
+}
+
+type ServiceAccountRepository interface {
+	// Create บันทึก service account ใหม่
+	Create(ctx context.Context, account *domain.ServiceAccount) error
+	// FindByKeyHash ค้นหา service account จาก hash ของ API key
+	FindByKeyHash(ctx context.Context, keyHash string) (*domain.ServiceAccount, error)
+	// FindAll ดึง service account ทั้งหมด เรียงจากใหม่ไปเก่า
+	FindAll(ctx context.Context) ([]domain.ServiceAccount, error)
+	// Revoke ยกเลิก service account — คืน ErrServiceAccountNotFound ถ้าไม่พบหรือถูกยกเลิกไปแล้ว
+	Revoke(ctx context.Context, id domain.ID) error
+	// TouchLastUsed บันทึกเวลาที่ใช้ API key ล่าสุด
+	TouchLastUsed(ctx context.Context, id domain.ID, usedAt time.Time) error
+}
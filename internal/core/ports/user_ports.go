@@ -9,4 +9,37 @@ import (
 type UserRepository interface {
 	// FindByEmail ค้นหาผู้ใช้จากอีเมล
 	FindByEmail(ctx context.Context, email string) (*domain.User, error)
+	// FindByID ค้นหาผู้ใช้จากรหัส
+	FindByID(ctx context.Context, id domain.ID) (*domain.User, error)
+	// FindByIDs ค้นหาผู้ใช้หลายคนใน query เดียว — รหัสที่ไม่พบไม่อยู่ในผลลัพธ์
+	FindByIDs(ctx context.Context, ids []domain.ID) ([]domain.User, error)
+	// FindByExternalID ค้นหาผู้ใช้จากบัญชีใน IdP (issuer + subject)
+	FindByExternalID(ctx context.Context, provider, subject string) (*domain.User, error)
+	// Create สร้างผู้ใช้ใหม่ — คืน ErrEmailAlreadyExists ถ้าอีเมลซ้ำ
+	Create(ctx context.Context, user *domain.User) error
+	// UpdatePassword เปลี่ยน password hash ของผู้ใช้
+	UpdatePassword(ctx context.Context, id domain.ID, passwordHash string) error
+	// UpdateExternalIdentity บันทึกการผูกบัญชีกับ IdP พร้อมชื่อและบทบาทล่าสุด
+	UpdateExternalIdentity(ctx context.Context, user *domain.User) error
+	// UpdateRole เปลี่ยนบทบาทของผู้ใช้
+	UpdateRole(ctx context.Context, id domain.ID, role domain.Role) error
+	// CountByRole นับจำนวนผู้ใช้ในบทบาท
+	CountByRole(ctx context.Context, role domain.Role) (int64, error)
+	// FindByRoles ค้นหาผู้ใช้ทั้งหมดในบทบาทที่ระบุ
+	FindByRoles(ctx context.Context, roles []domain.Role) ([]domain.User, error)
+}
+
+// UserProfileRepository แก้ข้อมูลโปรไฟล์ของผู้ใช้ — แยกจาก UserRepository เพื่อไม่ให้ interface ใหญ่เกินไป
+type UserProfileRepository interface {
+	// UpdateProfile บันทึกชื่อ แผนก และบทบาทของผู้ใช้ — คืน ErrUserNotFound ถ้าไม่พบ
+	UpdateProfile(ctx context.Context, user *domain.User) error
+}
+
+// UserImportService นำเข้าผู้ใช้และยอดวันลายกมาจากไฟล์ — ตรวจทีละแถว แถวที่ไม่ผ่านถูกรายงานและไม่หยุดแถวอื่น
+type UserImportService interface {
+	// ImportUsers นำเข้าโดยผู้ใช้ในระบบ (ต้องมีสิทธิ์ user.manage และ balance.adjust)
+	ImportUsers(ctx context.Context, actorID domain.ID, file domain.UserImportFile,
+		opts domain.UserImportOptions) (*domain.UserImportReport, error)
+	// Import นำเข้าโดยไม่ตรวจสิทธิ์ (ใช้จาก command line) — ไฟล์ที่หัวตารางไม่ถูกต้องคืน ErrInvalidImportFile
+	Import(ctx context.Context, file domain.UserImportFile, opts domain.UserImportOptions) (*domain.UserImportReport, error)
 }
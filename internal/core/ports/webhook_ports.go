@@ -0,0 +1,66 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// WebhookService จัดการ webhook ของผู้ดูแลระบบ และรับเหตุการณ์จาก outbox ไปสร้างรายการส่ง webhook
+type WebhookService interface {
+	EventHandler
+	// CreateSubscription สร้าง webhook ใหม่
+	CreateSubscription(ctx context.Context, actorID domain.ID, url, secret string, events []domain.LeaveEventType) (*domain.WebhookSubscription, error)
+	// UpdateSubscription แก้ปลายทาง secret (ว่าง = ใช้เดิม) เหตุการณ์ และสถานะเปิด/ปิด
+	UpdateSubscription(ctx context.Context, actorID, id domain.ID, url, secret string, events []domain.LeaveEventType, active bool) (*domain.WebhookSubscription, error)
+	// DeleteSubscription ลบ webhook — รายการที่ยังไม่ได้ส่งจะไม่ถูกส่งอีก
+	DeleteSubscription(ctx context.Context, actorID, id domain.ID) error
+	// ListSubscriptions ดู webhook ทั้งหมด
+	ListSubscriptions(ctx context.Context, actorID domain.ID) ([]domain.WebhookSubscription, error)
+	// ListDeliveries ค้นหารายการส่ง webhook เรียงจากใหม่ไปเก่า (รองรับ pagination)
+	ListDeliveries(ctx context.Context, actorID domain.ID, filter domain.WebhookDeliveryFilter, params domain.PaginationParams) (*domain.PaginatedResult[domain.WebhookDelivery], error)
+	// Redeliver สั่งส่งรายการที่ส่งสำเร็จแล้วหรือ dead ใหม่ด้วย payload เดิม
+	Redeliver(ctx context.Context, actorID, deliveryID domain.ID) (*domain.WebhookDelivery, error)
+}
+
+// WebhookDispatcher ส่งรายการที่ถึงเวลาใน outbox ไปยังปลายทาง
+type WebhookDispatcher interface {
+	// Run วนส่งรายการจนกว่า ctx จะถูกยกเลิก
+	Run(ctx context.Context)
+}
+
+// WebhookSender ส่ง HTTP POST ไปยังปลายทางของ webhook
+type WebhookSender interface {
+	// Send ส่ง body พร้อม header — คืน HTTP status ที่ได้ (error = เชื่อมต่อหรืออ่าน response ไม่ได้)
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+type WebhookSubscriptionRepository interface {
+	// Create บันทึก webhook ใหม่
+	Create(ctx context.Context, sub *domain.WebhookSubscription) error
+	// FindByID ค้นหา webhook — คืน ErrWebhookNotFound ถ้าไม่พบ
+	FindByID(ctx context.Context, id domain.ID) (*domain.WebhookSubscription, error)
+	// FindAll ดึง webhook ทั้งหมด เรียงจากใหม่ไปเก่า
+	FindAll(ctx context.Context) ([]domain.WebhookSubscription, error)
+	// FindActiveByEvent ดึง webhook ที่เปิดอยู่และรับเหตุการณ์นี้
+	FindActiveByEvent(ctx context.Context, eventType domain.LeaveEventType) ([]domain.WebhookSubscription, error)
+	// Update บันทึกการแก้ไข webhook
+	Update(ctx context.Context, sub *domain.WebhookSubscription) error
+	// Delete ลบ webhook — คืน ErrWebhookNotFound ถ้าไม่พบ
+	Delete(ctx context.Context, id domain.ID) error
+}
+
+type WebhookDeliveryRepository interface {
+	// CreateMany บันทึกรายการส่งของเหตุการณ์หนึ่ง — ข้ามรายการที่มีรหัสเหตุการณ์และ webhook ซ้ำกับที่บันทึกไว้แล้ว
+	CreateMany(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	// FindByID ค้นหารายการส่ง — คืน ErrWebhookDeliveryNotFound ถ้าไม่พบ
+	FindByID(ctx context.Context, id domain.ID) (*domain.WebhookDelivery, error)
+	// Search ค้นหารายการส่งตามเงื่อนไข เรียงจากใหม่ไปเก่า
+	Search(ctx context.Context, filter domain.WebhookDeliveryFilter, params domain.PaginationParams) (*domain.PaginatedResult[domain.WebhookDelivery], error)
+	// ClaimDue จองรายการ pending ที่ถึงเวลาส่งหนึ่งรายการแบบ atomic โดยเลื่อน next_attempt_at ออกไป lease
+	// — instance อื่นจึงไม่ส่งซ้ำ คืน nil ถ้าไม่มีรายการที่ถึงเวลา
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error)
+	// Update บันทึกผลการส่ง
+	Update(ctx context.Context, delivery *domain.WebhookDelivery) error
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type accountLockService struct {
+	userRepo    ports.UserRepository
+	attemptRepo ports.LoginAttemptRepository
+	eventRepo   ports.SecurityEventRepository
+	authorizer  ports.Authorizer
+	audit       ports.AuditLogger
+}
+
+// NewAccountLockService สร้าง AccountLockService สำหรับผู้ดูแลระบบปลดล็อกบัญชี
+func NewAccountLockService(
+	userRepo ports.UserRepository,
+	attemptRepo ports.LoginAttemptRepository,
+	eventRepo ports.SecurityEventRepository,
+	authorizer ports.Authorizer,
+	audit ports.AuditLogger,
+) ports.AccountLockService {
+	return &accountLockService{
+		userRepo:    userRepo,
+		attemptRepo: attemptRepo,
+		eventRepo:   eventRepo,
+		authorizer:  authorizer,
+		audit:       audit,
+	}
+}
+
+// UnlockAccount ล้างสถิติ login ผิดของผู้ใช้ และบันทึกว่าใครเป็นผู้ปลดล็อก
+func (s *accountLockService) UnlockAccount(ctx context.Context, actorID, userID domain.ID) error {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.attemptRepo.Reset(ctx, user.Email); err != nil {
+		return fmt.Errorf("ปลดล็อกบัญชีล้มเหลว: %w", err)
+	}
+
+	event := domain.NewSecurityEvent(
+		domain.SecurityEventAccountUnlocked, user.Email, &user.ID, &actorID, "ปลดล็อกโดยผู้ดูแลระบบ",
+	)
+	if err = s.eventRepo.Record(ctx, event); err != nil {
+		return fmt.Errorf("บันทึกเหตุการณ์ปลดล็อกบัญชีล้มเหลว: %w", err)
+	}
+
+	audit := domain.NewAuditEvent(domain.AuditUserUnlocked, domain.AuditTargetUser, user.ID.String(), nil).By(actorID)
+	return recordAudit(ctx, s.audit, audit)
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// lastUsedResolution ความละเอียดของ last_used_at — ไม่เขียนฐานข้อมูลทุก request ของ integration ที่เรียกถี่
+const lastUsedResolution = time.Minute
+
+type apiKeyService struct {
+	accountRepo ports.ServiceAccountRepository
+	audit       ports.AuditLogger
+}
+
+// NewAPIKeyService สร้าง APIKeyService สำหรับจัดการ service account และตรวจสอบ API key
+func NewAPIKeyService(accountRepo ports.ServiceAccountRepository, audit ports.AuditLogger) ports.APIKeyService {
+	return &apiKeyService{accountRepo: accountRepo, audit: audit}
+}
+
+// CreateServiceAccount สร้าง API key แบบสุ่ม เก็บเฉพาะ hash แล้วคืน key จริงให้ผู้ดูแลระบบนำไปตั้งค่า
+func (s *apiKeyService) CreateServiceAccount(
+	ctx context.Context,
+	actorID domain.ID,
+	name string,
+	scopes []domain.APIScope,
+) (*domain.ServiceAccount, string, error) {
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, _, err := generateOpaqueToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("สร้าง API key ล้มเหลว: %w", err)
+	}
+	key := domain.APIKeyPrefix + token
+
+	account := domain.NewServiceAccount(strings.TrimSpace(name), key, hashOpaqueToken(key), scopes, actorID)
+	if err = s.accountRepo.Create(ctx, account); err != nil {
+		return nil, "", err
+	}
+
+	event := domain.NewAuditEvent(
+		domain.AuditServiceAccountCreated, domain.AuditTargetServiceAccount, account.ID.String(),
+		domain.DiffChanges(nil, account.AuditSnapshot()),
+	).By(actorID)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, "", err
+	}
+
+	return account, key, nil
+}
+
+// ListServiceAccounts ดู service account ทั้งหมด
+func (s *apiKeyService) ListServiceAccounts(ctx context.Context) ([]domain.ServiceAccount, error) {
+	return s.accountRepo.FindAll(ctx)
+}
+
+// RevokeServiceAccount ยกเลิก API key
+func (s *apiKeyService) RevokeServiceAccount(ctx context.Context, id domain.ID) error {
+	if err := s.accountRepo.Revoke(ctx, id); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(domain.AuditServiceAccountRevoked, domain.AuditTargetServiceAccount, id.String(),
+		domain.DiffChanges(map[string]any{"revoked": false}, map[string]any{"revoked": true}))
+	return recordAudit(ctx, s.audit, event)
+}
+
+// Authenticate ค้นหา service account จาก hash ของ key
+func (s *apiKeyService) Authenticate(ctx context.Context, key string) (*domain.ServiceAccount, error) {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	account, err := s.accountRepo.FindByKeyHash(ctx, hashOpaqueToken(key))
+	if err != nil {
+		return nil, err
+	}
+	if account.IsRevoked() {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	now := time.Now()
+	if account.LastUsedAt == nil || now.Sub(*account.LastUsedAt) >= lastUsedResolution {
+		if err = s.accountRepo.TouchLastUsed(ctx, account.ID, now); err != nil {
+			return nil, fmt.Errorf("บันทึกเวลาใช้ API key ล้มเหลว: %w", err)
+		}
+		account.LastUsedAt = &now
+	}
+
+	return account, nil
+}
+
+// normalizeScopes ตรวจ scope ทุกตัวและตัดตัวที่ซ้ำ — ต้องมีอย่างน้อยหนึ่ง scope
+func normalizeScopes(scopes []domain.APIScope) ([]domain.APIScope, error) {
+	seen := make(map[domain.APIScope]bool, len(scopes))
+	result := make([]domain.APIScope, 0, len(scopes))
+	for _, scope := range scopes {
+		if !scope.IsValid() {
+			return nil, domain.ErrInvalidAPIScope
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			result = append(result, scope)
+		}
+	}
+	if len(result) == 0 {
+		return nil, domain.ErrInvalidAPIScope
+	}
+	return result, nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// createTestServiceAccount สร้าง service account ที่มี scope ที่ระบุแล้วคืน key จริง
+func createTestServiceAccount(t *testing.T, svc *apiKeyService, scopes ...domain.APIScope) (*domain.ServiceAccount, string) {
+	t.Helper()
+	account, key, err := svc.CreateServiceAccount(context.Background(), domain.NewID(), "payroll-sync", scopes)
+	require.NoError(t, err)
+	return account, key
+}
+
+func TestAPIKeyService_CreateServiceAccount_StoresOnlyHash(t *testing.T) {
+	repo := newMockServiceAccountRepository()
+	svc := NewAPIKeyService(repo, &mockAuditLogger{}).(*apiKeyService)
+
+	account, key := createTestServiceAccount(t, svc, domain.ScopeLeavesRead, domain.ScopeLeavesRead, domain.ScopeBalancesWrite)
+
+	assert.True(t, strings.HasPrefix(key, domain.APIKeyPrefix))
+	stored := repo.accounts[account.ID]
+	require.NotNil(t, stored)
+	assert.Equal(t, hashOpaqueToken(key), stored.KeyHash, "ต้องเก็บเฉพาะ hash ของ key")
+	assert.NotContains(t, stored.KeyHash, key)
+	assert.True(t, strings.HasPrefix(key, stored.KeyPrefix), "key_prefix ต้องเป็นส่วนต้นของ key")
+	assert.Less(t, len(stored.KeyPrefix), len(key)/2, "key_prefix ต้องไม่เปิดเผย secret ส่วนใหญ่")
+	assert.Equal(t, []domain.APIScope{domain.ScopeLeavesRead, domain.ScopeBalancesWrite}, stored.Scopes, "scope ที่ซ้ำต้องถูกตัดออก")
+}
+
+func TestAPIKeyService_CreateServiceAccount_InvalidScope(t *testing.T) {
+	svc := NewAPIKeyService(newMockServiceAccountRepository(), &mockAuditLogger{})
+
+	tests := []struct {
+		name   string
+		scopes []domain.APIScope
+	}{
+		{"ไม่มี scope", nil},
+		{"scope ที่ไม่รู้จัก", []domain.APIScope{domain.ScopeLeavesRead, "users:write"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := svc.CreateServiceAccount(context.Background(), domain.NewID(), "hr-sync", tt.scopes)
+			assert.ErrorIs(t, err, domain.ErrInvalidAPIScope)
+		})
+	}
+}
+
+func TestAPIKeyService_Authenticate_TracksLastUsed(t *testing.T) {
+	repo := newMockServiceAccountRepository()
+	svc := NewAPIKeyService(repo, &mockAuditLogger{}).(*apiKeyService)
+	created, key := createTestServiceAccount(t, svc, domain.ScopeLeavesRead)
+
+	account, err := svc.Authenticate(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, account.ID)
+	require.NotNil(t, repo.accounts[created.ID].LastUsedAt, "ต้องบันทึกเวลาที่ใช้ key ล่าสุด")
+
+	_, err = svc.Authenticate(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.touches, "ใช้ซ้ำภายในหนึ่งนาทีต้องไม่เขียนฐานข้อมูลอีก")
+
+	stale := time.Now().Add(-2 * lastUsedResolution)
+	repo.accounts[created.ID].LastUsedAt = &stale
+	_, err = svc.Authenticate(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.touches)
+}
+
+func TestAPIKeyService_Authenticate_RevokedKey(t *testing.T) {
+	repo := newMockServiceAccountRepository()
+	svc := NewAPIKeyService(repo, &mockAuditLogger{}).(*apiKeyService)
+	account, key := createTestServiceAccount(t, svc, domain.ScopeLeavesRead)
+
+	require.NoError(t, svc.RevokeServiceAccount(context.Background(), account.ID))
+
+	_, err := svc.Authenticate(context.Background(), key)
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey, "key ที่ถูกยกเลิกต้องใช้ไม่ได้ทันที")
+
+	err = svc.RevokeServiceAccount(context.Background(), account.ID)
+	assert.ErrorIs(t, err, domain.ErrServiceAccountNotFound)
+}
+
+func TestAPIKeyService_Authenticate_UnknownKey(t *testing.T) {
+	svc := NewAPIKeyService(newMockServiceAccountRepository(), &mockAuditLogger{})
+
+	for _, key := range []string{"lms_unknown", "eyJhbGciOiJIUzI1NiIs", ""} {
+		_, err := svc.Authenticate(context.Background(), key)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey, key)
+	}
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	auditAppendRetries = 5   // จำนวนครั้งที่ลองต่อท้าย chain ใหม่เมื่อมีผู้บันทึกพร้อมกัน
+	auditVerifyBatch   = 500 // จำนวน event ที่โหลดต่อรอบตอนตรวจ chain
+)
+
+type auditService struct {
+	auditRepo ports.AuditRepository
+}
+
+// NewAuditService สร้าง AuditService สำหรับบันทึกและค้นหา audit log
+func NewAuditService(auditRepo ports.AuditRepository) ports.AuditService {
+	return &auditService{auditRepo: auditRepo}
+}
+
+// Record ต่อ event ท้าย hash chain — ถ้า sequence ชนกับ instance อื่นจะอ่าน event ล่าสุดแล้วลองใหม่
+func (s *auditService) Record(ctx context.Context, event *domain.AuditEvent) error {
+	applyRequestMeta(ctx, event)
+
+	for range auditAppendRetries {
+		last, err := s.auditRepo.Last(ctx)
+		if err != nil {
+			return fmt.Errorf("อ่าน audit event ล่าสุดล้มเหลว: %w", err)
+		}
+
+		event.Seal(last)
+		err = s.auditRepo.Append(ctx, event)
+		if !errors.Is(err, domain.ErrAuditSequenceConflict) {
+			return err
+		}
+	}
+	return domain.ErrAuditSequenceConflict
+}
+
+// Search ค้นหา audit event ตามเงื่อนไข
+func (s *auditService) Search(
+	ctx context.Context,
+	filter domain.AuditFilter,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.AuditEvent], error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	return s.auditRepo.Search(ctx, filter, params)
+}
+
+// VerifyChain ไล่ตรวจ hash chain ทีละชุดตั้งแต่ event แรก และหยุดที่จุดแรกที่ไม่ถูกต้อง
+func (s *auditService) VerifyChain(ctx context.Context) (*domain.AuditChainReport, error) {
+	report := &domain.AuditChainReport{Valid: true}
+	var prev *domain.AuditEvent
+
+	for {
+		var after int64
+		if prev != nil {
+			after = prev.Sequence
+		}
+		events, err := s.auditRepo.FindAfter(ctx, after, auditVerifyBatch)
+		if err != nil {
+			return nil, fmt.Errorf("อ่าน audit log ล้มเหลว: %w", err)
+		}
+
+		for i := range events {
+			if reason := events[i].VerifyAfter(prev); reason != "" {
+				seq := events[i].Sequence
+				report.Valid, report.BrokenAt, report.Reason = false, &seq, reason
+				return report, nil
+			}
+			report.Checked++
+			prev = &events[i]
+		}
+
+		if len(events) < auditVerifyBatch {
+			return report, nil
+		}
+	}
+}
+
+// applyRequestMeta เติมผู้กระทำ IP และ User-Agent จาก request ปัจจุบัน — ผู้กระทำที่ service ระบุไว้แล้วมีลำดับก่อน
+// meta ที่มีแต่ประเภทผู้กระทำ (เช่น system จาก cmd/import) ใช้เป็นประเภทของ event ที่ไม่มีผู้กระทำ
+func applyRequestMeta(ctx context.Context, event *domain.AuditEvent) {
+	meta := domain.RequestMetaFromContext(ctx)
+	if meta != nil {
+		event.IP, event.UserAgent = meta.IP, meta.UserAgent
+		if event.ActorID == nil && meta.ActorID != nil {
+			event.ActorID, event.ActorType = meta.ActorID, meta.ActorType
+		} else if event.ActorType == "" {
+			event.ActorType = meta.ActorType
+		}
+	}
+	if event.ActorType == "" {
+		event.ActorType = domain.AuditActorAnonymous
+	}
+}
+
+// recordAudit บันทึก audit event และห่อ error ให้ service ที่เรียกคืนต่อได้ทันที
+func recordAudit(ctx context.Context, logger ports.AuditLogger, event *domain.AuditEvent) error {
+	if err := logger.Record(ctx, event); err != nil {
+		return fmt.Errorf("บันทึก audit log ล้มเหลว: %w", err)
+	}
+	return nil
+}
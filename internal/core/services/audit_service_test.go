@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestAuditService_Record_ChainsEventsAndFillsRequestMeta(t *testing.T) {
+	repo := &mockAuditRepository{}
+	svc := NewAuditService(repo)
+	actorID := domain.NewID()
+	ctx := domain.ContextWithRequestMeta(context.Background(), &domain.RequestMeta{
+		ActorID: &actorID, ActorType: domain.AuditActorServiceAccount, IP: "10.0.0.1", UserAgent: "payroll/1.0",
+	})
+
+	require.NoError(t, svc.Record(ctx, domain.NewAuditEvent(domain.AuditBalanceAdjusted, domain.AuditTargetLeaveBalance, "b1", nil)))
+	require.NoError(t, svc.Record(context.Background(), domain.NewAuditEvent(domain.AuditLoginFailed, domain.AuditTargetUser, "a@b.c", nil)))
+
+	require.Len(t, repo.events, 2)
+	first, second := repo.events[0], repo.events[1]
+	assert.Equal(t, int64(1), first.Sequence)
+	assert.Equal(t, actorID, *first.ActorID)
+	assert.Equal(t, domain.AuditActorServiceAccount, first.ActorType)
+	assert.Equal(t, "10.0.0.1", first.IP)
+	assert.Equal(t, "payroll/1.0", first.UserAgent)
+
+	assert.Equal(t, int64(2), second.Sequence)
+	assert.Equal(t, first.Hash, second.PrevHash)
+	assert.Nil(t, second.ActorID)
+	assert.Equal(t, domain.AuditActorAnonymous, second.ActorType)
+}
+
+func TestAuditService_Record_ServiceActorTakesPrecedence(t *testing.T) {
+	repo := &mockAuditRepository{}
+	svc := NewAuditService(repo)
+	metaActor, userID := domain.NewID(), domain.NewID()
+	ctx := domain.ContextWithRequestMeta(context.Background(), &domain.RequestMeta{ActorID: &metaActor, ActorType: domain.AuditActorUser})
+
+	require.NoError(t, svc.Record(ctx, domain.NewAuditEvent(domain.AuditLoginSucceeded, domain.AuditTargetUser, userID.String(), nil).By(userID)))
+
+	assert.Equal(t, userID, *repo.events[0].ActorID)
+}
+
+func TestAuditService_Record_SystemActorWithoutID(t *testing.T) {
+	repo := &mockAuditRepository{}
+	svc := NewAuditService(repo)
+	ctx := domain.ContextWithRequestMeta(context.Background(), &domain.RequestMeta{
+		ActorType: domain.AuditActorSystem, UserAgent: "cmd/import",
+	})
+
+	require.NoError(t, svc.Record(ctx, domain.NewAuditEvent(domain.AuditUserImported, domain.AuditTargetUser, "u1", nil)))
+
+	assert.Nil(t, repo.events[0].ActorID)
+	assert.Equal(t, domain.AuditActorSystem, repo.events[0].ActorType)
+	assert.Equal(t, "cmd/import", repo.events[0].UserAgent)
+}
+
+func TestAuditService_Record_RetriesOnSequenceConflict(t *testing.T) {
+	repo := &mockAuditRepository{conflicts: 2}
+	svc := NewAuditService(repo)
+
+	require.NoError(t, svc.Record(context.Background(), domain.NewAuditEvent(domain.AuditRoleSaved, domain.AuditTargetRole, "hr", nil)))
+	assert.Len(t, repo.events, 1)
+
+	repo.conflicts = auditAppendRetries
+	err := svc.Record(context.Background(), domain.NewAuditEvent(domain.AuditRoleSaved, domain.AuditTargetRole, "hr", nil))
+	assert.ErrorIs(t, err, domain.ErrAuditSequenceConflict)
+}
+
+func TestAuditService_VerifyChain_DetectsTampering(t *testing.T) {
+	repo := &mockAuditRepository{}
+	svc := NewAuditService(repo)
+	ctx := context.Background()
+	for range 3 {
+		require.NoError(t, svc.Record(ctx, domain.NewAuditEvent(domain.AuditLeaveApproved, domain.AuditTargetLeaveRequest, "r1", nil)))
+	}
+
+	report, err := svc.VerifyChain(ctx)
+	require.NoError(t, err)
+	assert.True(t, report.Valid)
+	assert.Equal(t, int64(3), report.Checked)
+
+	repo.events[1].Action = domain.AuditLeaveRejected
+
+	report, err = svc.VerifyChain(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	require.NotNil(t, report.BrokenAt)
+	assert.Equal(t, int64(2), *report.BrokenAt)
+	assert.Equal(t, int64(1), report.Checked)
+}
+
+func TestAuditService_VerifyChain_DetectsDeletedEvent(t *testing.T) {
+	repo := &mockAuditRepository{}
+	svc := NewAuditService(repo)
+	ctx := context.Background()
+	for range 3 {
+		require.NoError(t, svc.Record(ctx, domain.NewAuditEvent(domain.AuditUserUnlocked, domain.AuditTargetUser, "u1", nil)))
+	}
+
+	repo.events = append(repo.events[:1], repo.events[2:]...)
+
+	report, err := svc.VerifyChain(ctx)
+	require.NoError(t, err)
+	assert.False(t, report.Valid)
+	assert.Equal(t, int64(3), *report.BrokenAt)
+}
+
+func TestAuditService_Search_RejectsInvertedRange(t *testing.T) {
+	svc := NewAuditService(&mockAuditRepository{})
+	from := time.Now()
+	to := from.Add(-time.Hour)
+
+	_, err := svc.Search(context.Background(), domain.AuditFilter{From: &from, To: &to}, domain.NewPaginationParams(1, 10))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidAuditFilter)
+}
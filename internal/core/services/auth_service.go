@@ -2,51 +2,388 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
-
-	"golang.org/x/crypto/bcrypt"
+	"time"
 
 	"github/be2bag/leave-management-system/internal/core/domain"
 	"github/be2bag/leave-management-system/internal/core/ports"
 )
 
+const mfaChallengeTTL = 5 * time.Minute // เวลาที่ให้กรอกรหัส 2FA หลังผ่านรหัสผ่าน
+
+// AuthOptions ค่าตั้งค่าของ AuthService
+type AuthOptions struct {
+	LockoutPolicy domain.LockoutPolicy // การหน่วงเวลาและล็อกบัญชีเมื่อ login ผิดติดต่อกัน
+	RefreshTTL    time.Duration        // อายุของ refresh token
+	Directory     DirectoryOptions     // ยืนยันตัวตนกับ LDAP/Active Directory (ไม่บังคับ)
+}
+
+// DirectoryOptions การยืนยันตัวตนกับ directory แทนรหัสผ่านในระบบ
+// ใช้กับผู้ใช้ที่ผูกกับ directory แล้ว และอีเมลใน EmailDomains ที่ยังไม่มีรหัสผ่านในระบบ (ผู้ใช้ใหม่ถูกสร้างอัตโนมัติ)
+type DirectoryOptions struct {
+	Authenticator ports.Authenticator     // nil = ปิดใช้
+	EmailDomains  []string                // โดเมนอีเมล (lowercase) ที่ยืนยันตัวตนกับ directory
+	Roles         domain.GroupRoleMapping // แปลงกลุ่มใน directory เป็นบทบาท
+}
+
+// handles ตรวจว่าผู้ใช้ (หรืออีเมลที่ยังไม่มีในระบบ) ต้องยืนยันตัวตนกับ directory หรือไม่
+// ผู้ใช้ในระบบที่มีรหัสผ่านอยู่แล้วใช้รหัสผ่านเดิมแม้อยู่ในโดเมนของ directory — ไม่ผูกบัญชีให้อัตโนมัติ
+func (d DirectoryOptions) handles(email string, user *domain.User) bool {
+	if d.Authenticator == nil {
+		return false
+	}
+	if user != nil && user.IsExternal() {
+		return user.AuthProvider == domain.AuthProviderLDAP // บัญชี OIDC ไม่ใช้ directory
+	}
+	if user != nil && user.PasswordHash != "" {
+		return false
+	}
+
+	_, emailDomain, _ := strings.Cut(email, "@")
+	for _, allowed := range d.EmailDomains {
+		if emailDomain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
 type authService struct {
-	userRepo     ports.UserRepository
-	tokenService ports.TokenService
+	userRepo      ports.UserRepository
+	refreshRepo   ports.RefreshTokenRepository
+	attemptRepo   ports.LoginAttemptRepository
+	eventRepo     ports.SecurityEventRepository
+	mfaRepo       ports.MFARepository
+	challengeRepo ports.MFAChallengeRepository
+	tokenService  ports.TokenService
+	passwords     ports.Authenticator
+	audit         ports.AuditLogger
+	directory     DirectoryOptions
+	lockoutPolicy domain.LockoutPolicy
+	refreshTTL    time.Duration
 }
 
 // NewAuthService สร้าง AuthService instance
 func NewAuthService(
 	userRepo ports.UserRepository,
+	refreshRepo ports.RefreshTokenRepository,
+	attemptRepo ports.LoginAttemptRepository,
+	eventRepo ports.SecurityEventRepository,
+	mfaRepo ports.MFARepository,
+	challengeRepo ports.MFAChallengeRepository,
 	tokenService ports.TokenService,
+	audit ports.AuditLogger,
+	opts AuthOptions,
 ) ports.AuthService {
 	return &authService{
-		userRepo:     userRepo,
-		tokenService: tokenService,
+		userRepo:      userRepo,
+		refreshRepo:   refreshRepo,
+		attemptRepo:   attemptRepo,
+		eventRepo:     eventRepo,
+		mfaRepo:       mfaRepo,
+		challengeRepo: challengeRepo,
+		tokenService:  tokenService,
+		passwords:     NewPasswordAuthenticator(),
+		audit:         audit,
+		directory:     opts.Directory,
+		lockoutPolicy: opts.LockoutPolicy,
+		refreshTTL:    opts.RefreshTTL,
 	}
 }
 
-// Login เข้าสู่ระบบ — ตรวจสอบอีเมลและรหัสผ่าน แล้วสร้าง JWT token
-func (s *authService) Login(
-	ctx context.Context,
-	email, password string,
-) (string, *domain.User, error) {
+// Login เข้าสู่ระบบ — ตรวจสอบอีเมลและรหัสผ่าน (ในระบบหรือกับ directory) แล้วออก access token + refresh token family ใหม่
+// ผู้ใช้ที่เปิด 2FA จะได้ challenge แทน และต้องเรียก VerifyMFA ต่อ
+// login ผิดติดต่อกันจะถูกหน่วงเวลาและล็อกตามอีเมล ไม่ว่าอีเมลนั้นจะมีอยู่ในระบบหรือไม่
+func (s *authService) Login(ctx context.Context, email, password string) (*domain.LoginResult, error) {
 	email = strings.ToLower(strings.TrimSpace(email))
 
+	reservation, err := s.reserveAttempt(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.authenticate(ctx, email, password, reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	mfa, err := s.mfaRepo.Find(ctx, user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ตรวจสอบสถานะ 2FA ล้มเหลว: %w", err)
+	}
+	if mfa.IsEnabled() {
+		// ยังไม่ล้างสถิติ — ครั้งนี้ยังนับอยู่จนกว่าจะยืนยัน 2FA สำเร็จ และรหัส 2FA ที่ผิดนับรวมกับรหัสผ่านที่ผิด
+		challenge, err := s.createChallenge(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.LoginResult{User: user, Challenge: challenge}, nil
+	}
+
+	if err = s.attemptRepo.Reset(ctx, email); err != nil {
+		return nil, fmt.Errorf("ล้างสถิติการเข้าสู่ระบบล้มเหลว: %w", err)
+	}
+
+	tokens, err := s.issueTokens(ctx, user, domain.NewID(), false)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.recordLogin(ctx, user, loginMethod(user)); err != nil {
+		return nil, err
+	}
+
+	return &domain.LoginResult{Tokens: tokens, User: user}, nil
+}
+
+// authenticate ตรวจรหัสผ่านด้วย Authenticator ที่ผู้ใช้หรือโดเมนอีเมลกำหนด — รหัสผ่านผิดถูกนับใน failLogin
+func (s *authService) authenticate(
+	ctx context.Context, email, password string, reservation *attemptReservation,
+) (*domain.User, error) {
 	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return nil, err
+	}
+
+	authenticator := s.passwords
+	if s.directory.handles(email, user) {
+		authenticator = s.directory.Authenticator
+	}
+
+	identity, err := authenticator.Authenticate(ctx, email, password, user)
+	if errors.Is(err, domain.ErrInvalidCredentials) {
+		var userID *domain.ID
+		if user != nil {
+			userID = &user.ID
+		}
+		return nil, s.failLogin(ctx, email, userID, reservation, err)
+	}
 	if err != nil {
-		return "", nil, domain.ErrInvalidCredentials
+		return nil, err
 	}
 
-	if err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", nil, domain.ErrInvalidCredentials
+	if identity == nil {
+		return user, nil
 	}
+	return s.syncDirectoryUser(ctx, user, identity)
+}
 
-	token, err := s.tokenService.GenerateToken(user)
+// syncDirectoryUser ผูกหรือสร้างผู้ใช้จากข้อมูลใน directory — บทบาทของผู้ใช้เดิมเปลี่ยนเฉพาะเมื่อ mapping กำหนดให้
+func (s *authService) syncDirectoryUser(ctx context.Context, user *domain.User, identity *domain.ExternalIdentity) (*domain.User, error) {
+	if user == nil {
+		user = domain.NewExternalUser(identity, s.directory.Roles.Resolve(identity.Groups))
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, fmt.Errorf("สร้างผู้ใช้จาก directory ล้มเหลว: %w", err)
+		}
+		return user, nil
+	}
+
+	user.ApplyExternalIdentity(identity, s.directory.Roles.Sync(user.Role, identity.Groups))
+	if err := s.userRepo.UpdateExternalIdentity(ctx, user); err != nil {
+		return nil, fmt.Errorf("อัปเดตข้อมูลผู้ใช้จาก directory ล้มเหลว: %w", err)
+	}
+	return user, nil
+}
+
+// VerifyMFA ยืนยัน challenge จาก Login ด้วยรหัส TOTP หรือ recovery code แล้วออก token ชุดเต็ม (claim "mfa")
+func (s *authService) VerifyMFA(ctx context.Context, challengeToken, code string) (*domain.AuthTokens, *domain.User, error) {
+	challenge, err := s.challengeRepo.FindByHash(ctx, hashOpaqueToken(challengeToken))
 	if err != nil {
-		return "", nil, fmt.Errorf("สร้าง token ล้มเหลว: %w", err)
+		return nil, nil, err
+	}
+	if challenge.IsExpired(time.Now()) {
+		return nil, nil, domain.ErrInvalidMFAChallenge
+	}
+
+	user, err := s.userRepo.FindByID(ctx, challenge.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, nil, err
+	}
+
+	reservation, err := s.reserveAttempt(ctx, user.Email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mfa, err := s.mfaRepo.Find(ctx, user.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("ตรวจสอบสถานะ 2FA ล้มเหลว: %w", err)
+	}
+	if !mfa.IsEnabled() {
+		return nil, nil, domain.ErrInvalidMFAChallenge
+	}
+
+	if err = verifySecondFactor(ctx, s.mfaRepo, mfa, code); err != nil {
+		if errors.Is(err, domain.ErrInvalidMFACode) {
+			return nil, nil, s.failLogin(ctx, user.Email, &user.ID, reservation, err)
+		}
+		return nil, nil, err
+	}
+
+	if err = s.challengeRepo.Consume(ctx, challenge.ID); err != nil {
+		return nil, nil, err
+	}
+	if err = s.attemptRepo.Reset(ctx, user.Email); err != nil {
+		return nil, nil, fmt.Errorf("ล้างสถิติการเข้าสู่ระบบล้มเหลว: %w", err)
 	}
 
-	return token, user, nil
+	tokens, err := s.issueTokens(ctx, user, domain.NewID(), true)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = s.recordLogin(ctx, user, loginMethod(user)+"+mfa"); err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
+}
+
+// Refresh แลก refresh token เป็น token ชุดใหม่ — token เดิมใช้ไม่ได้อีก (rotation)
+func (s *authService) Refresh(ctx context.Context, refreshToken string) (*domain.AuthTokens, *domain.User, error) {
+	stored, err := s.refreshRepo.FindByHash(ctx, hashOpaqueToken(refreshToken))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// token ที่ถูกใช้ไปแล้วถูกนำมาใช้อีก — อาจถูกขโมย จึงยกเลิกทั้ง family
+	if stored.IsRevoked() {
+		return nil, nil, s.revokeReusedFamily(ctx, stored)
+	}
+
+	if stored.IsExpired(time.Now()) {
+		return nil, nil, domain.ErrInvalidRefreshToken
+	}
+
+	if err := s.refreshRepo.Consume(ctx, stored.ID); err != nil {
+		// มี request อื่นใช้ token เดียวกันไปก่อนหน้าเพียงเสี้ยววินาที — ถือว่าใช้ซ้ำ
+		if errors.Is(err, domain.ErrRefreshTokenReused) {
+			return nil, nil, s.revokeReusedFamily(ctx, stored)
+		}
+		return nil, nil, err
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, nil, err
+	}
+
+	tokens, err := s.issueTokens(ctx, user, stored.FamilyID, stored.MFA)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return tokens, user, nil
+}
+
+// issueTokens ออก access token และ refresh token ใหม่ใน family ที่ระบุ — mfa ระบุว่า session ยืนยัน 2FA แล้ว
+func (s *authService) issueTokens(ctx context.Context, user *domain.User, familyID domain.ID, mfa bool) (*domain.AuthTokens, error) {
+	issuer := tokenIssuer{refreshRepo: s.refreshRepo, tokenService: s.tokenService, refreshTTL: s.refreshTTL}
+	return issuer.issue(ctx, user, familyID, mfa)
+}
+
+// createChallenge ออก challenge token สำหรับขั้นตอนยืนยัน 2FA
+func (s *authService) createChallenge(ctx context.Context, userID domain.ID) (*domain.MFAChallenge, error) {
+	token, tokenHash, err := generateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("สร้าง challenge token ล้มเหลว: %w", err)
+	}
+
+	challenge := domain.NewMFAChallenge(userID, token, tokenHash, mfaChallengeTTL)
+	if err = s.challengeRepo.Create(ctx, challenge); err != nil {
+		return nil, fmt.Errorf("บันทึก challenge ล้มเหลว: %w", err)
+	}
+	return challenge, nil
+}
+
+// attemptReservation ความพยายาม login ที่นับไว้แล้วก่อนตรวจรหัสผ่าน
+type attemptReservation struct {
+	attempt *domain.LoginAttempt
+	locked  bool // ครั้งนี้ทำให้อีเมลถูกล็อก
+}
+
+// reserveAttempt ตรวจว่าอีเมลยังถูกหน่วงเวลาหรือถูกล็อกอยู่หรือไม่ แล้วนับความพยายามครั้งนี้ไว้ก่อนตรวจรหัสผ่าน
+// การนับมีเงื่อนไขว่าสถิติยังไม่ถูกคำขออื่นเปลี่ยน — คำขอที่ยิงพร้อมกันจึงผ่านได้ทีละคำขอและไม่ข้าม backoff
+// ความพยายามที่สำเร็จถูกล้างทีหลังด้วย Reset
+func (s *authService) reserveAttempt(ctx context.Context, email string) (*attemptReservation, error) {
+	attempt, err := s.attemptRepo.Find(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("ตรวจสอบสถิติการเข้าสู่ระบบล้มเหลว: %w", err)
+	}
+
+	now := time.Now()
+	if now.Before(s.lockoutPolicy.NextAllowedAt(attempt)) {
+		return nil, domain.ErrTooManyLoginAttempts
+	}
+
+	locked := s.lockoutPolicy.RecordAttempt(attempt, now)
+	attempt, err = s.attemptRepo.RecordAttempt(ctx, attempt)
+	if err != nil {
+		if errors.Is(err, domain.ErrTooManyLoginAttempts) {
+			return nil, err // คำขออื่นนับไปก่อน — ต้องผ่านการตรวจ backoff ใหม่
+		}
+		return nil, fmt.Errorf("บันทึกการเข้าสู่ระบบล้มเหลว: %w", err)
+	}
+	return &attemptReservation{attempt: attempt, locked: locked}, nil
+}
+
+// failLogin บันทึกการยืนยันตัวตนที่ผิด (นับไว้แล้วใน reserveAttempt) และแจ้งเมื่อครั้งนี้ทำให้ถูกล็อก — คืน cause เมื่อบันทึกสำเร็จ
+func (s *authService) failLogin(
+	ctx context.Context, email string, userID *domain.ID, reservation *attemptReservation, cause error,
+) error {
+	// อีเมลที่ไม่มีในระบบใช้อีเมลเป็น target — ผู้กระทำเป็น anonymous เสมอเพราะยังยืนยันตัวตนไม่สำเร็จ
+	targetID := email
+	if userID != nil {
+		targetID = userID.String()
+	}
+	failure := domain.NewAuditEvent(domain.AuditLoginFailed, domain.AuditTargetUser, targetID,
+		domain.DiffChanges(nil, map[string]any{"email": email, "reason": cause.Error()}))
+	if err := recordAudit(ctx, s.audit, failure); err != nil {
+		return err
+	}
+
+	if !reservation.locked {
+		return cause
+	}
+
+	attempt := reservation.attempt
+	event := domain.NewSecurityEvent(
+		domain.SecurityEventAccountLocked, email, userID, nil,
+		fmt.Sprintf("ยืนยันตัวตนผิด %d ครั้งติดต่อกัน — ล็อกครั้งที่ %d ถึง %s",
+			s.lockoutPolicy.MaxFailedAttempts, attempt.LockCount, attempt.LockedUntil.Format(time.RFC3339)),
+	)
+	if err := s.eventRepo.Record(ctx, event); err != nil {
+		return fmt.Errorf("บันทึกเหตุการณ์ล็อกบัญชีล้มเหลว: %w", err)
+	}
+
+	return cause
+}
+
+// recordLogin บันทึกการเข้าสู่ระบบที่สำเร็จ — method คือวิธียืนยันตัวตน (เช่น password, ldap+mfa)
+func (s *authService) recordLogin(ctx context.Context, user *domain.User, method string) error {
+	event := domain.NewAuditEvent(domain.AuditLoginSucceeded, domain.AuditTargetUser, user.ID.String(),
+		domain.DiffChanges(nil, map[string]any{"method": method})).By(user.ID)
+	return recordAudit(ctx, s.audit, event)
+}
+
+// loginMethod วิธียืนยันตัวตนด้วยรหัสผ่านของผู้ใช้ — ผู้ใช้ที่ผูกกับ directory ใช้ ldap
+func loginMethod(user *domain.User) string {
+	if user.AuthProvider == domain.AuthProviderLDAP {
+		return string(domain.AuthProviderLDAP)
+	}
+	return "password"
+}
+
+// revokeReusedFamily ยกเลิก refresh token ทั้ง family เมื่อพบการใช้ซ้ำ
+func (s *authService) revokeReusedFamily(ctx context.Context, token *domain.RefreshToken) error {
+	if err := s.refreshRepo.RevokeFamily(ctx, token.FamilyID); err != nil {
+		return fmt.Errorf("ยกเลิก refresh token family ล้มเหลว: %w", err)
+	}
+	return domain.ErrRefreshTokenReused
 }
@@ -2,7 +2,10 @@ package services
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,6 +14,29 @@ import (
 	"github/be2bag/leave-management-system/internal/core/domain"
 )
 
+const testRefreshTTL = 7 * 24 * time.Hour
+
+// newTestAuthService สร้าง AuthService พร้อม mock สำหรับนับ login ผิด (ใช้นโยบายล็อกเริ่มต้น)
+func newTestAuthService(
+	userRepo *mockUserRepository,
+	refreshRepo *mockRefreshTokenRepository,
+	tokenSvc *mockTokenService,
+) *authService {
+	return &authService{
+		userRepo:      userRepo,
+		refreshRepo:   refreshRepo,
+		attemptRepo:   newMockLoginAttemptRepository(),
+		eventRepo:     &mockSecurityEventRepository{},
+		mfaRepo:       newMockMFARepository(),
+		challengeRepo: newMockMFAChallengeRepository(),
+		tokenService:  tokenSvc,
+		passwords:     NewPasswordAuthenticator(),
+		audit:         &mockAuditLogger{},
+		lockoutPolicy: domain.DefaultLockoutPolicy(),
+		refreshTTL:    testRefreshTTL,
+	}
+}
+
 func TestAuthService_Login_Success(t *testing.T) {
 	// สร้างรหัสผ่าน hash สำหรับ "password123"
 	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
@@ -25,20 +51,30 @@ func TestAuthService_Login_Success(t *testing.T) {
 		},
 	}
 	tokenSvc := &mockTokenService{
-		generateFn: func(_ *domain.User) (string, error) {
-			return "jwt-token-123", nil
+		generateFn: func(_ *domain.User, _ bool) (string, time.Time, error) {
+			return "jwt-token-123", time.Now().Add(15 * time.Minute), nil
 		},
 	}
+	refreshRepo := newMockRefreshTokenRepository()
 
-	svc := NewAuthService(userRepo, tokenSvc)
+	svc := newTestAuthService(userRepo, refreshRepo, tokenSvc)
 
 	// Act
-	token, user, err := svc.Login(context.Background(), "somchai@company.com", "password123")
+	result, err := svc.Login(context.Background(), "somchai@company.com", "password123")
 
 	// Assert
 	require.NoError(t, err)
-	assert.Equal(t, "jwt-token-123", token)
+	require.Nil(t, result.Challenge, "ผู้ใช้ที่ไม่ได้เปิด 2FA ต้องได้ token ทันที")
+	tokens, user := result.Tokens, result.User
+	assert.Equal(t, "jwt-token-123", tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken, "ต้องออก refresh token ด้วย")
+	assert.Len(t, refreshRepo.tokens, 1, "ต้องบันทึก refresh token ลงฐานข้อมูล")
 	assert.Equal(t, "สมชาย", user.FirstName)
+	assert.Equal(t, []domain.AuditAction{domain.AuditLoginSucceeded}, svc.audit.(*mockAuditLogger).actions())
+
+	for _, stored := range refreshRepo.tokens {
+		assert.NotEqual(t, tokens.RefreshToken, stored.TokenHash, "ต้องเก็บเฉพาะ hash ไม่เก็บ token จริง")
+	}
 }
 
 func TestAuthService_Login_InvalidEmail(t *testing.T) {
@@ -47,9 +83,9 @@ func TestAuthService_Login_InvalidEmail(t *testing.T) {
 			return nil, domain.ErrUserNotFound
 		},
 	}
-	svc := NewAuthService(userRepo, &mockTokenService{})
+	svc := newTestAuthService(userRepo, newMockRefreshTokenRepository(), &mockTokenService{})
 
-	_, _, err := svc.Login(context.Background(), "nonexistent@company.com", "password123")
+	_, err := svc.Login(context.Background(), "nonexistent@company.com", "password123")
 
 	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
 }
@@ -63,9 +99,463 @@ func TestAuthService_Login_WrongPassword(t *testing.T) {
 			return testUser, nil
 		},
 	}
-	svc := NewAuthService(userRepo, &mockTokenService{})
+	svc := newTestAuthService(userRepo, newMockRefreshTokenRepository(), &mockTokenService{})
+	audit := &mockAuditLogger{}
+	svc.audit = audit
 
-	_, _, err := svc.Login(context.Background(), "test@test.com", "wrong_password")
+	_, err := svc.Login(context.Background(), "test@test.com", "wrong_password")
 
 	assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	require.Len(t, audit.events, 1)
+	assert.Equal(t, domain.AuditLoginFailed, audit.events[0].Action)
+	assert.Equal(t, testUser.ID.String(), audit.events[0].TargetID)
+	assert.Nil(t, audit.events[0].ActorID, "login ที่ไม่สำเร็จต้องไม่ระบุผู้กระทำ")
+}
+
+// ─── Refresh Token Tests ────────────────────────────────────────────────
+// ทดสอบการ rotate refresh token และการตรวจจับการใช้ซ้ำ
+// ─────────────────────────────────────────────────────────────────────────
+
+// newRefreshTestService สร้าง AuthService พร้อมผู้ใช้ทดสอบที่ login แล้ว
+func newRefreshTestService(t *testing.T) (*mockRefreshTokenRepository, *domain.AuthTokens, *authService) {
+	t.Helper()
+
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	testUser := domain.NewUser("สมหญิง", "พนักงาน", "employee@company.com", string(hashedPassword), domain.RoleEmployee)
+
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return testUser, nil
+		},
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.User, error) {
+			return testUser, nil
+		},
+	}
+	refreshRepo := newMockRefreshTokenRepository()
+	svc := newTestAuthService(userRepo, refreshRepo, &mockTokenService{})
+
+	result, err := svc.Login(context.Background(), "employee@company.com", "password123")
+	require.NoError(t, err)
+
+	return refreshRepo, result.Tokens, svc
+}
+
+func TestAuthService_Refresh_RotatesToken(t *testing.T) {
+	refreshRepo, loginTokens, svc := newRefreshTestService(t)
+
+	tokens, user, err := svc.Refresh(context.Background(), loginTokens.RefreshToken)
+
+	require.NoError(t, err)
+	assert.Equal(t, "employee@company.com", user.Email)
+	assert.NotEqual(t, loginTokens.RefreshToken, tokens.RefreshToken, "ต้องได้ refresh token ใหม่")
+
+	oldToken, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(loginTokens.RefreshToken))
+	require.NoError(t, err)
+	newToken, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(tokens.RefreshToken))
+	require.NoError(t, err)
+
+	assert.True(t, oldToken.IsRevoked(), "refresh token เดิมต้องใช้ไม่ได้อีก")
+	assert.False(t, newToken.IsRevoked())
+	assert.Equal(t, oldToken.FamilyID, newToken.FamilyID, "token ใหม่ต้องอยู่ใน family เดิม")
+}
+
+func TestAuthService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	refreshRepo, loginTokens, svc := newRefreshTestService(t)
+
+	rotated, _, err := svc.Refresh(context.Background(), loginTokens.RefreshToken)
+	require.NoError(t, err)
+
+	// นำ token เดิมที่ถูกใช้ไปแล้วมาใช้ซ้ำ
+	_, _, err = svc.Refresh(context.Background(), loginTokens.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+
+	// token ล่าสุดใน family เดียวกันต้องถูกยกเลิกด้วย
+	latest, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(rotated.RefreshToken))
+	require.NoError(t, err)
+	assert.True(t, latest.IsRevoked(), "ต้องยกเลิก token ทั้ง family เมื่อพบการใช้ซ้ำ")
+
+	_, _, err = svc.Refresh(context.Background(), rotated.RefreshToken)
+	assert.ErrorIs(t, err, domain.ErrRefreshTokenReused)
+}
+
+func TestAuthService_Refresh_Expired(t *testing.T) {
+	refreshRepo, loginTokens, svc := newRefreshTestService(t)
+
+	stored, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(loginTokens.RefreshToken))
+	require.NoError(t, err)
+	stored.ExpiresAt = time.Now().Add(-time.Minute)
+
+	_, _, err = svc.Refresh(context.Background(), loginTokens.RefreshToken)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+func TestAuthService_Refresh_UnknownToken(t *testing.T) {
+	_, _, svc := newRefreshTestService(t)
+
+	_, _, err := svc.Refresh(context.Background(), "not-a-real-token")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidRefreshToken)
+}
+
+// ─── Account Lockout Tests ──────────────────────────────────────────────
+// ทดสอบการหน่วงเวลาและล็อกบัญชีเมื่อ login ผิดติดต่อกัน
+// ─────────────────────────────────────────────────────────────────────────
+
+// newLockoutTestService สร้าง AuthService ที่ไม่หน่วงเวลา (ทดสอบเฉพาะการล็อก) พร้อมผู้ใช้รหัสผ่าน "password123"
+func newLockoutTestService(t *testing.T) (*authService, *mockLoginAttemptRepository, *mockSecurityEventRepository, *domain.User) {
+	t.Helper()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	testUser := domain.NewUser("Test", "User", "test@test.com", string(hashedPassword), domain.RoleEmployee)
+
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
+			if email == testUser.Email {
+				return testUser, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+	}
+	svc := newTestAuthService(userRepo, newMockRefreshTokenRepository(), &mockTokenService{})
+	svc.lockoutPolicy.BaseBackoff = 0
+	svc.lockoutPolicy.MaxBackoff = 0
+
+	attempts, ok := svc.attemptRepo.(*mockLoginAttemptRepository)
+	require.True(t, ok)
+	events, ok := svc.eventRepo.(*mockSecurityEventRepository)
+	require.True(t, ok)
+	return svc, attempts, events, testUser
+}
+
+func TestAuthService_Login_LocksAfterMaxFailures(t *testing.T) {
+	svc, _, events, user := newLockoutTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < svc.lockoutPolicy.MaxFailedAttempts; i++ {
+		_, err := svc.Login(ctx, user.Email, "wrong_password")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials, "ครั้งที่ผิดยังต้องตอบเหมือนเดิม")
+	}
+
+	// รหัสผ่านถูกก็ยังเข้าไม่ได้ระหว่างถูกล็อก
+	_, err := svc.Login(ctx, user.Email, "password123")
+	assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+
+	require.Len(t, events.events, 1, "ต้องบันทึกเหตุการณ์ล็อกบัญชี")
+	assert.Equal(t, domain.SecurityEventAccountLocked, events.events[0].Type)
+	assert.Equal(t, user.ID, *events.events[0].UserID)
+}
+
+func TestAuthService_Login_UnknownEmailBehavesTheSame(t *testing.T) {
+	svc, _, events, _ := newLockoutTestService(t)
+	ctx := context.Background()
+
+	for i := 0; i < svc.lockoutPolicy.MaxFailedAttempts; i++ {
+		_, err := svc.Login(ctx, "nobody@test.com", "whatever")
+		assert.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	}
+
+	_, err := svc.Login(ctx, "nobody@test.com", "whatever")
+	assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts, "อีเมลที่ไม่มีในระบบต้องถูกล็อกเหมือนกัน ไม่ให้เดาได้")
+
+	require.Len(t, events.events, 1)
+	assert.Nil(t, events.events[0].UserID)
+}
+
+func TestAuthService_Login_SuccessResetsFailures(t *testing.T) {
+	svc, attempts, _, user := newLockoutTestService(t)
+	ctx := context.Background()
+
+	_, err := svc.Login(ctx, user.Email, "wrong_password")
+	require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+
+	_, err = svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+
+	attempt, err := attempts.Find(ctx, user.Email)
+	require.NoError(t, err)
+	assert.Zero(t, attempt.FailedCount, "login สำเร็จต้องล้างจำนวนครั้งที่ผิด")
+}
+
+func TestAuthService_Login_ProgressiveBackoff(t *testing.T) {
+	svc, _, _, user := newLockoutTestService(t)
+	svc.lockoutPolicy = domain.DefaultLockoutPolicy()
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := svc.Login(ctx, user.Email, "wrong_password")
+		require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	}
+
+	// ผิด 2 ครั้งแล้ว ต้องรออย่างน้อย BaseBackoff ก่อนลองใหม่
+	_, err := svc.Login(ctx, user.Email, "password123")
+	assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+}
+
+func TestAuthService_Login_ConcurrentAttemptsCannotSkipBackoff(t *testing.T) {
+	svc, attempts, _, user := newLockoutTestService(t)
+	svc.lockoutPolicy = domain.DefaultLockoutPolicy()
+	ctx := context.Background()
+
+	const parallel = 20
+	errs := make([]error, parallel)
+	var wg sync.WaitGroup
+	for i := range parallel {
+		wg.Go(func() {
+			_, errs[i] = svc.Login(ctx, user.Email, "wrong_password")
+		})
+	}
+	wg.Wait()
+
+	var checked int
+	for _, err := range errs {
+		if errors.Is(err, domain.ErrInvalidCredentials) {
+			checked++
+			continue
+		}
+		require.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+	}
+	// ผิดครั้งแรกและครั้งที่สองยังไม่หน่วง — ที่เหลือต้องรอ backoff แม้ยิงพร้อมกัน
+	assert.LessOrEqual(t, checked, 2, "คำขอที่ยิงพร้อมกันต้องไม่ได้ตรวจรหัสผ่านทั้งหมด")
+
+	attempt, err := attempts.Find(ctx, user.Email)
+	require.NoError(t, err)
+	assert.Equal(t, checked, attempt.FailedCount, "ทุกครั้งที่ได้ตรวจรหัสผ่านต้องถูกนับ")
+}
+
+func TestAuthService_Login_RepeatedLocksLastLonger(t *testing.T) {
+	svc, attempts, events, user := newLockoutTestService(t)
+	ctx := context.Background()
+
+	var durations []time.Duration
+	for range 3 {
+		for i := 0; i < svc.lockoutPolicy.MaxFailedAttempts; i++ {
+			_, err := svc.Login(ctx, user.Email, "wrong_password")
+			require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+		}
+		attempt := attempts.attempts[user.Email]
+		require.NotNil(t, attempt.LockedUntil)
+		durations = append(durations, time.Until(*attempt.LockedUntil).Round(time.Minute))
+
+		expired := time.Now().Add(-time.Second) // จำลองว่าพ้นการล็อกแล้ว
+		attempt.LockedUntil = &expired
+	}
+
+	assert.Equal(t, []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour}, durations,
+		"ล็อกซ้ำต้องนานขึ้นเป็นเท่าตัว ไม่เริ่มนับใหม่ทุกรอบ")
+	assert.Len(t, events.events, 3)
+
+	_, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+	_, ok := attempts.attempts[user.Email]
+	assert.False(t, ok, "login สำเร็จต้องล้างทั้งจำนวนครั้งที่ผิดและจำนวนครั้งที่ถูกล็อก")
+}
+
+func TestAccountLockService_UnlockAccount(t *testing.T) {
+	svc, attempts, events, user := newLockoutTestService(t)
+	ctx := context.Background()
+	for i := 0; i < svc.lockoutPolicy.MaxFailedAttempts; i++ {
+		_, err := svc.Login(ctx, user.Email, "wrong_password")
+		require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	}
+
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	adminID := domain.NewID()
+	lockSvc := NewAccountLockService(userRepo, attempts, events, &mockAuthorizer{}, &mockAuditLogger{})
+
+	require.NoError(t, lockSvc.UnlockAccount(ctx, adminID, user.ID))
+
+	_, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err, "ต้อง login ได้ทันทีหลังปลดล็อก")
+
+	last := events.events[len(events.events)-1]
+	assert.Equal(t, domain.SecurityEventAccountUnlocked, last.Type)
+	assert.Equal(t, adminID, *last.ActorID)
+}
+
+func TestAuthService_Login_ExternalUserRejected(t *testing.T) {
+	identity := &domain.ExternalIdentity{Provider: "https://idp.example.com", Subject: "sub-1", Email: "sso@company.com"}
+	ssoUser := domain.NewExternalUser(identity, domain.RoleEmployee)
+
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return ssoUser, nil
+		},
+	}
+	svc := newTestAuthService(userRepo, newMockRefreshTokenRepository(), &mockTokenService{})
+
+	_, err := svc.Login(context.Background(), "sso@company.com", "")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCredentials, "ผู้ใช้จาก IdP ต้อง login ผ่าน IdP เท่านั้น")
+}
+
+// ─── Directory (LDAP) Login Tests ───────────────────────────────────────
+// ทดสอบการเลือก Authenticator ตามผู้ใช้/โดเมนอีเมล และการ sync บทบาทจากกลุ่ม
+// ─────────────────────────────────────────────────────────────────────────
+
+// newDirectoryTestService สร้าง AuthService ที่ให้โดเมน corp.com ยืนยันตัวตนกับ directory
+func newDirectoryTestService(userRepo *mockUserRepository, directory *mockAuthenticator) *authService {
+	svc := newTestAuthService(userRepo, newMockRefreshTokenRepository(), &mockTokenService{})
+	svc.directory = DirectoryOptions{
+		Authenticator: directory,
+		EmailDomains:  []string{"corp.com"},
+		Roles: domain.GroupRoleMapping{
+			Groups:      map[string]domain.Role{"hr-managers": domain.RoleManager},
+			DefaultRole: domain.RoleEmployee,
+		},
+	}
+	return svc
+}
+
+// newDirectoryIdentity ข้อมูลผู้ใช้ที่ directory คืนเมื่อ bind สำเร็จ
+func newDirectoryIdentity(groups ...string) *domain.ExternalIdentity {
+	return &domain.ExternalIdentity{
+		Provider: domain.AuthProviderLDAP, Subject: "somchai", Email: "somchai@corp.com",
+		FirstName: "สมชาย", LastName: "ใจดี", Groups: groups, EmailVerified: true,
+	}
+}
+
+func TestAuthService_Login_DirectoryProvisionsNewUser(t *testing.T) {
+	var created *domain.User
+	userRepo := &mockUserRepository{
+		createFn: func(_ context.Context, user *domain.User) error {
+			created = user
+			return nil
+		},
+	}
+	directory := &mockAuthenticator{identity: newDirectoryIdentity("HR-Managers"), password: "ldap-pass"}
+	svc := newDirectoryTestService(userRepo, directory)
+
+	result, err := svc.Login(context.Background(), "Somchai@Corp.com", "ldap-pass")
+
+	require.NoError(t, err)
+	require.NotNil(t, created, "ผู้ใช้ใหม่ในโดเมนของ directory ต้องถูกสร้างอัตโนมัติ")
+	assert.Equal(t, created.ID, result.User.ID)
+	assert.Equal(t, domain.AuthProviderLDAP, created.AuthProvider)
+	assert.Equal(t, domain.RoleManager, created.Role, "บทบาทต้องมาจากกลุ่มใน directory")
+	assert.NotNil(t, result.Tokens)
+}
+
+func TestAuthService_Login_LocalUserInDirectoryDomainKeepsPassword(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("local-pass"), bcrypt.MinCost)
+	local := domain.NewUser("สมชาย", "ใจดี", "somchai@corp.com", string(hashedPassword), domain.RoleAdmin)
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return local, nil
+		},
+		updateExternalFn: func(_ context.Context, _ *domain.User) error {
+			t.Fatal("ผู้ใช้ในระบบต้องไม่ถูกผูกกับ directory อัตโนมัติ")
+			return nil
+		},
+	}
+	directory := &mockAuthenticator{identity: newDirectoryIdentity(), password: "ldap-pass"}
+	svc := newDirectoryTestService(userRepo, directory)
+
+	_, err := svc.Login(context.Background(), "somchai@corp.com", "ldap-pass")
+	require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+
+	result, err := svc.Login(context.Background(), "somchai@corp.com", "local-pass")
+
+	require.NoError(t, err)
+	assert.False(t, directory.called, "ผู้ใช้ที่มีรหัสผ่านในระบบต้องไม่ถูกส่งไป directory แม้อยู่ในโดเมนของ directory")
+	assert.Equal(t, domain.RoleAdmin, result.User.Role)
+	assert.NotEmpty(t, local.PasswordHash)
+}
+
+func TestAuthService_Login_DirectoryLinksPasswordlessUserKeepingRole(t *testing.T) {
+	imported := domain.NewUser("สมชาย", "ใจดี", "somchai@corp.com", "", domain.RoleAdmin)
+	var updated *domain.User
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return imported, nil
+		},
+		updateExternalFn: func(_ context.Context, user *domain.User) error {
+			updated = user
+			return nil
+		},
+	}
+	directory := &mockAuthenticator{identity: newDirectoryIdentity("sales"), password: "ldap-pass"}
+	svc := newDirectoryTestService(userRepo, directory)
+
+	result, err := svc.Login(context.Background(), "somchai@corp.com", "ldap-pass")
+
+	require.NoError(t, err)
+	assert.Equal(t, imported.ID, result.User.ID)
+	require.NotNil(t, updated, "ผู้ใช้ที่นำเข้าโดยไม่มีรหัสผ่านต้องผูกกับ directory ได้")
+	assert.Equal(t, domain.AuthProviderLDAP, updated.AuthProvider)
+	assert.Equal(t, domain.RoleAdmin, updated.Role, "บทบาทที่กำหนดในระบบต้องไม่ถูกเขียนทับเมื่อ mapping ไม่ได้กำหนดให้")
+}
+
+func TestAuthService_Login_DirectoryRoleFollowsMappedGroups(t *testing.T) {
+	ldapUser := domain.NewExternalUser(newDirectoryIdentity(), domain.RoleEmployee)
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return ldapUser, nil
+		},
+	}
+	directory := &mockAuthenticator{identity: newDirectoryIdentity("HR-Managers"), password: "ldap-pass"}
+	svc := newDirectoryTestService(userRepo, directory)
+
+	_, err := svc.Login(context.Background(), "somchai@corp.com", "ldap-pass")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleManager, ldapUser.Role, "อยู่ในกลุ่มที่กำหนดต้องได้บทบาทของกลุ่ม")
+
+	directory.identity = newDirectoryIdentity()
+	_, err = svc.Login(context.Background(), "somchai@corp.com", "ldap-pass")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RoleEmployee, ldapUser.Role, "ออกจากกลุ่มแล้วบทบาทที่ได้จากกลุ่มต้องถูกถอน")
+}
+
+func TestAuthService_Login_DirectoryUserOutsideDomain(t *testing.T) {
+	ldapUser := domain.NewExternalUser(newDirectoryIdentity(), domain.RoleEmployee)
+	ldapUser.Email = "somchai@subsidiary.com"
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return ldapUser, nil
+		},
+	}
+	directory := &mockAuthenticator{identity: newDirectoryIdentity(), password: "ldap-pass"}
+	svc := newDirectoryTestService(userRepo, directory)
+
+	_, err := svc.Login(context.Background(), "somchai@subsidiary.com", "ldap-pass")
+
+	require.NoError(t, err)
+	assert.True(t, directory.called, "ผู้ใช้ที่ผูกกับ directory ต้องใช้ directory แม้อยู่นอกโดเมนที่กำหนด")
+}
+
+func TestAuthService_Login_LocalDomainSkipsDirectory(t *testing.T) {
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("local-pass"), bcrypt.MinCost)
+	local := domain.NewUser("Test", "User", "test@company.com", string(hashedPassword), domain.RoleEmployee)
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return local, nil
+		},
+	}
+	directory := &mockAuthenticator{identity: newDirectoryIdentity(), password: "ldap-pass"}
+	svc := newDirectoryTestService(userRepo, directory)
+
+	_, err := svc.Login(context.Background(), "test@company.com", "local-pass")
+
+	require.NoError(t, err)
+	assert.False(t, directory.called)
+}
+
+func TestAuthService_Login_DirectoryFailureCountsTowardLockout(t *testing.T) {
+	directory := &mockAuthenticator{identity: newDirectoryIdentity(), password: "ldap-pass"}
+	svc := newDirectoryTestService(&mockUserRepository{}, directory)
+	svc.lockoutPolicy.BaseBackoff = 0
+	svc.lockoutPolicy.MaxBackoff = 0
+
+	for i := 0; i < svc.lockoutPolicy.MaxFailedAttempts; i++ {
+		_, err := svc.Login(context.Background(), "somchai@corp.com", "wrong")
+		require.ErrorIs(t, err, domain.ErrInvalidCredentials)
+	}
+
+	_, err := svc.Login(context.Background(), "somchai@corp.com", "ldap-pass")
+	assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
 }
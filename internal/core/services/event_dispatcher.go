@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	defaultEventPollInterval   = time.Second
+	defaultEventLease          = time.Minute
+	defaultEventHandlerTimeout = 30 * time.Second
+)
+
+// EventDispatcherOptions ค่าตั้งค่าของ dispatcher — ค่าที่เป็นศูนย์ใช้ค่าเริ่มต้น
+type EventDispatcherOptions struct {
+	OnError        func(err error) // เรียกเมื่อ handler ไม่สำเร็จหรืออ่าน/บันทึก outbox ไม่สำเร็จ (เช่น เขียน log)
+	PollInterval   time.Duration   // ระยะห่างของการตรวจเหตุการณ์ที่ถึงเวลา
+	Lease          time.Duration   // เวลาที่จองเหตุการณ์ไว้ระหว่างส่งต่อ — เกินแล้ว instance อื่นส่งต่อซ้ำได้
+	HandlerTimeout time.Duration   // เวลาสูงสุดของ handler แต่ละตัว
+}
+
+type eventDispatcher struct {
+	outboxRepo ports.OutboxRepository
+	handlers   []ports.EventHandler
+	opts       EventDispatcherOptions
+}
+
+// NewEventDispatcher สร้าง dispatcher ที่ส่งต่อเหตุการณ์ใน outbox ให้ handler ทุกตัวตามลำดับ
+// — รันได้หลาย instance พร้อมกันเพราะจองเหตุการณ์แบบ atomic
+func NewEventDispatcher(
+	outboxRepo ports.OutboxRepository,
+	handlers []ports.EventHandler,
+	opts EventDispatcherOptions,
+) ports.EventDispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultEventPollInterval
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaultEventLease
+	}
+	if opts.HandlerTimeout <= 0 {
+		opts.HandlerTimeout = defaultEventHandlerTimeout
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+	return &eventDispatcher{
+		outboxRepo: outboxRepo,
+		handlers:   handlers,
+		opts:       opts,
+	}
+}
+
+// Run ส่งต่อเหตุการณ์ที่ถึงเวลาทุก PollInterval จนกว่า ctx จะถูกยกเลิก
+func (d *eventDispatcher) Run(ctx context.Context) {
+	pollUntilDone(ctx, d.opts.PollInterval, d.drain)
+}
+
+// pollUntilDone เรียก drain ทันทีและทุก interval จนกว่า ctx จะถูกยกเลิก
+func pollUntilDone(ctx context.Context, interval time.Duration, drain func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain ส่งต่อเหตุการณ์ที่ถึงเวลาจนหมด
+func (d *eventDispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		msg, err := d.outboxRepo.ClaimDue(ctx, time.Now(), d.opts.Lease)
+		if err != nil {
+			d.opts.OnError(err)
+			return
+		}
+		if msg == nil {
+			return
+		}
+
+		d.dispatch(ctx, msg)
+		if err = d.outboxRepo.Update(ctx, msg); err != nil {
+			// ไม่ได้บันทึกผล — เหตุการณ์จะถูกส่งต่อซ้ำเมื่อหมด lease (at-least-once)
+			d.opts.OnError(fmt.Errorf("บันทึกผลการส่งต่อเหตุการณ์ %s ล้มเหลว: %w", msg.ID, err))
+		}
+	}
+}
+
+// dispatch เรียก handler ที่ยังไม่สำเร็จกับเหตุการณ์นี้ และบันทึกผลลงใน msg
+// — handler ที่ไม่สำเร็จไม่กระทบ handler ตัวอื่น และจะถูกเรียกอีกครั้งตอนลองใหม่
+func (d *eventDispatcher) dispatch(ctx context.Context, msg *domain.OutboxMessage) {
+	var errs []error
+	for _, h := range d.handlers {
+		name := h.HandlerName()
+		if msg.IsCompleted(name) {
+			continue
+		}
+
+		handlerCtx, cancel := context.WithTimeout(ctx, d.opts.HandlerTimeout)
+		err := h.Handle(handlerCtx, msg.Event)
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		msg.MarkCompleted(name)
+	}
+
+	if len(errs) == 0 {
+		msg.RecordSuccess(time.Now())
+		return
+	}
+	err := errors.Join(errs...)
+	msg.RecordFailure(err.Error(), time.Now())
+	d.opts.OnError(fmt.Errorf("ส่งต่อเหตุการณ์ %s (%s) ครั้งที่ %d ไม่สำเร็จ: %w", msg.Event.Type, msg.ID, msg.Attempts, err))
+}
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// stubEventHandler นับจำนวนครั้งที่ถูกเรียกและคืน err ที่กำหนด
+type stubEventHandler struct {
+	err   error
+	name  string
+	calls int
+}
+
+func (h *stubEventHandler) HandlerName() string { return h.name }
+
+func (h *stubEventHandler) Handle(_ context.Context, _ domain.LeaveEvent) error {
+	h.calls++
+	return h.err
+}
+
+func newTestOutbox(eventType domain.LeaveEventType) *mockOutboxRepository {
+	return &mockOutboxRepository{messages: []*domain.OutboxMessage{domain.NewOutboxMessage(newTestLeaveEvent(eventType))}}
+}
+
+func TestEventDispatcher_AllHandlersSucceed(t *testing.T) {
+	outbox := newTestOutbox(domain.LeaveEventSubmitted)
+	email, webhook := &stubEventHandler{name: "email"}, &stubEventHandler{name: "webhook"}
+
+	dispatcher := NewEventDispatcher(outbox, []ports.EventHandler{email, webhook}, EventDispatcherOptions{}).(*eventDispatcher)
+	dispatcher.drain(context.Background())
+
+	msg := outbox.messages[0]
+	assert.Equal(t, domain.OutboxProcessed, msg.Status)
+	assert.Equal(t, []string{"email", "webhook"}, msg.Completed)
+	assert.Equal(t, 1, email.calls)
+	assert.Equal(t, 1, webhook.calls)
+}
+
+func TestEventDispatcher_RetriesOnlyFailedHandlers(t *testing.T) {
+	outbox := newTestOutbox(domain.LeaveEventApproved)
+	email := &stubEventHandler{name: "email"}
+	webhook := &stubEventHandler{name: "webhook", err: errors.New("mongo unavailable")}
+	var reported []error
+
+	dispatcher := NewEventDispatcher(outbox, []ports.EventHandler{email, webhook}, EventDispatcherOptions{
+		OnError: func(err error) { reported = append(reported, err) },
+	}).(*eventDispatcher)
+	dispatcher.drain(context.Background())
+
+	msg := outbox.messages[0]
+	assert.Equal(t, domain.OutboxPending, msg.Status)
+	assert.Contains(t, msg.LastError, "mongo unavailable")
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), msg.NextAttemptAt, time.Second, "ไม่สำเร็จแล้วต้องรอ backoff")
+	require.Len(t, reported, 1)
+
+	msg.NextAttemptAt = time.Now()
+	webhook.err = nil
+	dispatcher.drain(context.Background())
+
+	assert.Equal(t, domain.OutboxProcessed, msg.Status)
+	assert.Equal(t, 1, email.calls, "handler ที่สำเร็จแล้วต้องไม่ถูกเรียกซ้ำตอนลองใหม่")
+	assert.Equal(t, 2, webhook.calls)
+}
+
+func TestEventDispatcher_FailsAfterMaxAttempts(t *testing.T) {
+	outbox := newTestOutbox(domain.LeaveEventRejected)
+	handler := &stubEventHandler{name: "email", err: errors.New("smtp: connection refused")}
+	dispatcher := NewEventDispatcher(outbox, []ports.EventHandler{handler}, EventDispatcherOptions{}).(*eventDispatcher)
+
+	msg := outbox.messages[0]
+	msg.Attempts = domain.MaxOutboxAttempts - 1
+	dispatcher.drain(context.Background())
+
+	assert.Equal(t, domain.OutboxFailed, msg.Status)
+	assert.Equal(t, 1, handler.calls, "เหตุการณ์ที่ failed แล้วต้องไม่ถูกจองซ้ำ")
+}
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	eventStreamReplayLimit = 256 // เหตุการณ์ที่พลาดไปได้มากที่สุดก่อนให้ client โหลดข้อมูลใหม่แทนการต่อจากเดิม
+	eventStreamBufferSize  = 32  // เหตุการณ์ที่รอส่งให้ client ได้ก่อนถูกตัดการเชื่อมต่อ
+	eventStreamPageSize    = 500 // เหตุการณ์ที่อ่านจาก outbox ต่อครั้ง
+
+	defaultEventStreamPollInterval    = time.Second
+	defaultEventStreamLookback        = time.Minute
+	defaultEventStreamRecheckInterval = time.Minute
+)
+
+// EventStreamOptions ค่าตั้งค่าของ event stream — ค่าที่เป็นศูนย์ใช้ค่าเริ่มต้น
+type EventStreamOptions struct {
+	OnError         func(err error) // เรียกเมื่ออ่าน outbox หรือตรวจสิทธิ์ซ้ำไม่สำเร็จ (เช่น เขียน log)
+	PollInterval    time.Duration   // ระยะห่างของการอ่านเหตุการณ์ใหม่จาก outbox
+	Lookback        time.Duration   // อ่านย้อนหลังจากเหตุการณ์ล่าสุดเผื่อ transaction ที่ commit ช้ากว่าเวลาที่สร้างเหตุการณ์
+	RecheckInterval time.Duration   // ระยะห่างของการตรวจ token และสิทธิ์ของผู้เชื่อมต่อซ้ำ
+}
+
+// streamSubscriber การเชื่อมต่อหนึ่งรายการ
+type streamSubscriber struct {
+	claims  *domain.TokenClaims
+	viewer  domain.EventStreamViewer
+	events  chan domain.LeaveEvent // nil ระหว่างอ่านเหตุการณ์ที่พลาดไป
+	pending []domain.LeaveEvent    // เหตุการณ์ใหม่ที่มาระหว่างอ่านเหตุการณ์ที่พลาดไป
+}
+
+type eventStream struct {
+	outboxRepo  ports.OutboxRepository
+	authorizer  ports.Authorizer
+	revocations ports.TokenRevocationStore
+	opts        EventStreamOptions
+	subscribers map[*streamSubscriber]struct{}
+	mu          sync.Mutex
+
+	// ใช้เฉพาะใน Run
+	cursor time.Time               // created_at ล่าสุดที่อ่านแล้ว
+	seen   map[domain.ID]time.Time // เหตุการณ์ในช่วง Lookback ที่ส่งแล้ว → created_at
+}
+
+// NewEventStream สร้าง EventStream ที่อ่านเหตุการณ์จาก outbox เอง — ทุก instance ได้เหตุการณ์ครบ
+// แม้ instance อื่นเป็นผู้ส่งต่อเหตุการณ์นั้นให้อีเมลและ webhook
+func NewEventStream(
+	outboxRepo ports.OutboxRepository,
+	authorizer ports.Authorizer,
+	revocations ports.TokenRevocationStore,
+	opts EventStreamOptions,
+) ports.EventStream {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultEventStreamPollInterval
+	}
+	if opts.Lookback <= 0 {
+		opts.Lookback = defaultEventStreamLookback
+	}
+	if opts.RecheckInterval <= 0 {
+		opts.RecheckInterval = defaultEventStreamRecheckInterval
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+	return &eventStream{
+		outboxRepo:  outboxRepo,
+		authorizer:  authorizer,
+		revocations: revocations,
+		opts:        opts,
+		subscribers: make(map[*streamSubscriber]struct{}),
+		cursor:      time.Now(),
+		seen:        make(map[domain.ID]time.Time),
+	}
+}
+
+// Run อ่านเหตุการณ์ใหม่ทุก PollInterval และตรวจผู้เชื่อมต่อซ้ำทุก RecheckInterval จนกว่า ctx จะถูกยกเลิก
+func (s *eventStream) Run(ctx context.Context) {
+	lastCheck := time.Now()
+	pollUntilDone(ctx, s.opts.PollInterval, func(ctx context.Context) {
+		s.tail(ctx)
+		if time.Since(lastCheck) >= s.opts.RecheckInterval {
+			lastCheck = time.Now()
+			s.recheck(ctx)
+		}
+	})
+}
+
+// tail ส่งเหตุการณ์ที่สร้างหลัง cursor (ย้อนหลัง Lookback) ที่ยังไม่เคยส่ง
+func (s *eventStream) tail(ctx context.Context) {
+	since := s.cursor.Add(-s.opts.Lookback)
+	for ctx.Err() == nil {
+		msgs, err := s.outboxRepo.ListCreatedSince(ctx, since, eventStreamPageSize)
+		if err != nil {
+			s.opts.OnError(fmt.Errorf("อ่านเหตุการณ์ใหม่สำหรับ event stream ล้มเหลว: %w", err))
+			return
+		}
+		for i := range msgs {
+			if _, ok := s.seen[msgs[i].ID]; ok {
+				continue
+			}
+			s.seen[msgs[i].ID] = msgs[i].CreatedAt
+			if msgs[i].CreatedAt.After(s.cursor) {
+				s.cursor = msgs[i].CreatedAt
+			}
+			s.publish(&msgs[i].Event)
+		}
+		// หน้าเต็มและเวลาเลื่อนไปได้ — อ่านหน้าถัดไป (เหตุการณ์ที่เวลาเท่ากันถูกกรองด้วย seen)
+		if len(msgs) < eventStreamPageSize || !msgs[len(msgs)-1].CreatedAt.After(since) {
+			break
+		}
+		since = msgs[len(msgs)-1].CreatedAt
+	}
+
+	horizon := s.cursor.Add(-s.opts.Lookback)
+	maps.DeleteFunc(s.seen, func(_ domain.ID, createdAt time.Time) bool { return createdAt.Before(horizon) })
+}
+
+// publish ส่งเหตุการณ์ให้ผู้เชื่อมต่อที่เห็นเหตุการณ์นี้ — ไม่รอ client ที่รับไม่ทัน
+func (s *eventStream) publish(event *domain.LeaveEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for sub := range s.subscribers {
+		if !sub.viewer.CanSee(event) {
+			continue
+		}
+		if sub.events == nil {
+			if len(sub.pending) >= eventStreamBufferSize {
+				s.remove(sub)
+				continue
+			}
+			sub.pending = append(sub.pending, *event)
+			continue
+		}
+		select {
+		case sub.events <- *event:
+		default:
+			// client รับไม่ทัน — ตัดการเชื่อมต่อ ให้ต่อใหม่ด้วย Last-Event-ID
+			s.remove(sub)
+		}
+	}
+}
+
+// Subscribe ตรวจสิทธิ์ดูใบลาของทีม ส่งเหตุการณ์ที่พลาดไปจาก outbox แล้วรับเหตุการณ์ใหม่จนกว่า ctx ถูกยกเลิก
+func (s *eventStream) Subscribe(
+	ctx context.Context,
+	claims *domain.TokenClaims,
+	lastEventID string,
+) (<-chan domain.LeaveEvent, bool, error) {
+	viewer, err := s.viewer(ctx, claims.UserID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// ลงทะเบียนก่อนอ่านเหตุการณ์ที่พลาดไป — เหตุการณ์ใหม่ระหว่างอ่านถูกเก็บไว้ใน pending จึงไม่หาย
+	sub := &streamSubscriber{claims: claims, viewer: viewer}
+	s.mu.Lock()
+	s.subscribers[sub] = struct{}{}
+	s.mu.Unlock()
+	go func() {
+		<-ctx.Done()
+		s.mu.Lock()
+		s.remove(sub)
+		s.mu.Unlock()
+	}()
+
+	missed, resumed, err := s.missedSince(ctx, lastEventID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		s.remove(sub)
+		return nil, false, err
+	}
+	return s.start(sub, missed), resumed, nil
+}
+
+// start สร้าง channel ของ sub ที่มีเหตุการณ์ที่พลาดไปตามด้วย pending (ไม่ซ้ำกัน) — ต้องถือ mu อยู่
+func (s *eventStream) start(sub *streamSubscriber, missed []domain.LeaveEvent) <-chan domain.LeaveEvent {
+	events := make(chan domain.LeaveEvent, eventStreamBufferSize+len(missed)+len(sub.pending))
+	if _, ok := s.subscribers[sub]; !ok {
+		close(events) // ถูกตัดระหว่างอ่านเหตุการณ์ที่พลาดไป
+		return events
+	}
+
+	sent := make(map[domain.ID]struct{}, len(missed))
+	for _, event := range slices.Concat(missed, sub.pending) {
+		if _, ok := sent[event.ID]; ok || !sub.viewer.CanSee(&event) {
+			continue
+		}
+		sent[event.ID] = struct{}{}
+		events <- event
+	}
+	sub.events, sub.pending = events, nil
+	return events
+}
+
+// missedSince อ่านเหตุการณ์หลัง lastEventID จาก outbox — resumed = false เมื่อไม่พบเหตุการณ์นั้นแล้ว
+// หรือพลาดไปเกิน eventStreamReplayLimit
+func (s *eventStream) missedSince(ctx context.Context, lastEventID string) ([]domain.LeaveEvent, bool, error) {
+	if lastEventID == "" {
+		return nil, true, nil
+	}
+	id, err := domain.ParseID(lastEventID)
+	if err != nil {
+		return nil, false, nil
+	}
+	last, err := s.outboxRepo.FindByID(ctx, id)
+	if err != nil || last == nil {
+		return nil, false, err
+	}
+
+	msgs, err := s.outboxRepo.ListCreatedSince(ctx, last.CreatedAt, eventStreamReplayLimit+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(msgs) > eventStreamReplayLimit {
+		return nil, false, nil
+	}
+	missed := make([]domain.LeaveEvent, 0, len(msgs))
+	for i := range msgs {
+		if msgs[i].ID != id {
+			missed = append(missed, msgs[i].Event)
+		}
+	}
+	return missed, true, nil
+}
+
+// recheck ตรวจ token และสิทธิ์ของผู้เชื่อมต่อทุกรายซ้ำ — ตัดการเชื่อมต่อเมื่อ token หมดอายุหรือถูกยกเลิก
+// และใช้สิทธิ์ปัจจุบันกับเหตุการณ์ถัดไป
+func (s *eventStream) recheck(ctx context.Context) {
+	s.mu.Lock()
+	subs := slices.Collect(maps.Keys(s.subscribers))
+	s.mu.Unlock()
+
+	now := time.Now()
+	for _, sub := range subs {
+		viewer, err := s.revalidate(ctx, sub.claims, now)
+
+		s.mu.Lock()
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized), errors.Is(err, domain.ErrUserNotFound):
+			s.remove(sub)
+		case err != nil:
+			// ตรวจไม่สำเร็จ — คงสิทธิ์เดิมไว้และตรวจใหม่รอบหน้า
+			s.opts.OnError(fmt.Errorf("ตรวจสิทธิ์ผู้เชื่อมต่อ event stream ซ้ำล้มเหลว: %w", err))
+		default:
+			sub.viewer = viewer
+		}
+		s.mu.Unlock()
+	}
+}
+
+// revalidate ตรวจว่า token ยังใช้ได้และคืนสิทธิ์ปัจจุบันของผู้ใช้ — คืน ErrUnauthorized เมื่อ token หมดอายุหรือถูกยกเลิก
+func (s *eventStream) revalidate(ctx context.Context, claims *domain.TokenClaims, now time.Time) (domain.EventStreamViewer, error) {
+	if !now.Before(claims.ExpiresAt) {
+		return domain.EventStreamViewer{}, domain.ErrUnauthorized
+	}
+	revoked, err := s.revocations.IsRevoked(ctx, claims)
+	if err != nil {
+		return domain.EventStreamViewer{}, err
+	}
+	if revoked {
+		return domain.EventStreamViewer{}, domain.ErrUnauthorized
+	}
+	return s.viewer(ctx, claims.UserID)
+}
+
+// viewer ตรวจสิทธิ์ดูใบลาของทีมจากบทบาทปัจจุบันในฐานข้อมูล
+func (s *eventStream) viewer(ctx context.Context, userID domain.ID) (domain.EventStreamViewer, error) {
+	viewer := domain.EventStreamViewer{UserID: userID}
+	switch err := s.authorizer.Authorize(ctx, userID, domain.PermissionLeaveViewTeam); {
+	case err == nil:
+		viewer.CanViewTeam = true
+	case !errors.Is(err, domain.ErrPermissionDenied):
+		return domain.EventStreamViewer{}, err
+	}
+	return viewer, nil
+}
+
+// Close ปิดการเชื่อมต่อทั้งหมด — client จะต่อใหม่กับ instance ที่ยังทำงานอยู่
+func (s *eventStream) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for sub := range s.subscribers {
+		s.remove(sub)
+	}
+}
+
+// remove เลิกส่งเหตุการณ์ให้ sub และปิด channel — ต้องถือ mu อยู่
+func (s *eventStream) remove(sub *streamSubscriber) {
+	if _, ok := s.subscribers[sub]; !ok {
+		return
+	}
+	delete(s.subscribers, sub)
+	if sub.events != nil {
+		close(sub.events)
+	}
+}
@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// receive อ่านเหตุการณ์ที่รออยู่ใน channel ทั้งหมดโดยไม่รอเหตุการณ์ใหม่
+func receive(events <-chan domain.LeaveEvent) []domain.LeaveEventType {
+	var types []domain.LeaveEventType
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return types
+			}
+			types = append(types, e.Type)
+		default:
+			return types
+		}
+	}
+}
+
+// isClosed channel ถูกปิดแล้วหรือไม่ — อ่านเหตุการณ์ที่ค้างอยู่ทิ้ง
+func isClosed(events <-chan domain.LeaveEvent) bool {
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return true
+			}
+		default:
+			return false
+		}
+	}
+}
+
+func newTestRequestEvent(eventType domain.LeaveEventType, ownerID domain.ID) domain.LeaveEvent {
+	request := domain.NewLeaveRequest(
+		ownerID, domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), "ไปเที่ยวกับครอบครัว",
+	)
+	return domain.NewLeaveEvent(eventType, request, ownerID)
+}
+
+func newTestStreamClaims(userID domain.ID) *domain.TokenClaims {
+	now := time.Now()
+	return &domain.TokenClaims{
+		UserID:    userID,
+		TokenID:   domain.NewID().String(),
+		IssuedAt:  now.Add(-time.Minute),
+		ExpiresAt: now.Add(15 * time.Minute),
+	}
+}
+
+func newTestEventStream(outbox *mockOutboxRepository, authorizer ports.Authorizer) *eventStream {
+	stream, ok := NewEventStream(outbox, authorizer, newMockTokenRevocationStore(), EventStreamOptions{}).(*eventStream)
+	if !ok {
+		panic("NewEventStream ต้องคืน *eventStream")
+	}
+	return stream
+}
+
+func TestEventStream_FiltersByRoleAndOwnership(t *testing.T) {
+	employeeID, otherID, managerID := domain.NewID(), domain.NewID(), domain.NewID()
+	outbox := &mockOutboxRepository{}
+	stream := newTestEventStream(outbox, &mockRoleAuthorizer{roles: map[domain.ID]domain.Role{
+		employeeID: domain.RoleEmployee, otherID: domain.RoleEmployee, managerID: domain.RoleManager,
+	}})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	employee, _, err := stream.Subscribe(ctx, newTestStreamClaims(employeeID), "")
+	require.NoError(t, err)
+	manager, _, err := stream.Subscribe(ctx, newTestStreamClaims(managerID), "")
+	require.NoError(t, err)
+
+	balance := domain.NewLeaveBalance(employeeID, domain.LeaveTypeAnnual, 15, 2026)
+	for _, event := range []domain.LeaveEvent{
+		newTestRequestEvent(domain.LeaveEventSubmitted, otherID),
+		newTestRequestEvent(domain.LeaveEventApproved, employeeID),
+		domain.NewBalanceAdjustedEvent(balance, managerID),
+	} {
+		require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(event)))
+	}
+	stream.tail(ctx)
+
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventApproved, domain.LeaveEventBalanceAdjusted}, receive(employee),
+		"พนักงานต้องเห็นเฉพาะเหตุการณ์ของตัวเอง")
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventSubmitted, domain.LeaveEventApproved}, receive(manager),
+		"ผู้จัดการเห็นใบลาของทุกคนแต่ไม่เห็นการปรับยอดวันลาของผู้อื่น")
+}
+
+func TestEventStream_BroadcastsToEveryInstance(t *testing.T) {
+	outbox := &mockOutboxRepository{}
+	first, second := newTestEventStream(outbox, &mockAuthorizer{}), newTestEventStream(outbox, &mockAuthorizer{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	onFirst, _, err := first.Subscribe(ctx, newTestStreamClaims(domain.NewID()), "")
+	require.NoError(t, err)
+	onSecond, _, err := second.Subscribe(ctx, newTestStreamClaims(domain.NewID()), "")
+	require.NoError(t, err)
+
+	msg := domain.NewOutboxMessage(newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID()))
+	msg.RecordSuccess(time.Now()) // instance อื่นส่งต่อให้อีเมลและ webhook ไปแล้ว
+	require.NoError(t, outbox.Append(ctx, msg))
+	first.tail(ctx)
+	second.tail(ctx)
+	first.tail(ctx)
+
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventSubmitted}, receive(onFirst), "อ่านซ้ำแล้วต้องไม่ส่งซ้ำ")
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventSubmitted}, receive(onSecond),
+		"ทุก instance ต้องได้เหตุการณ์ ไม่ใช่เฉพาะ instance ที่จองเหตุการณ์ใน outbox")
+}
+
+func TestEventStream_DeliversLateCommittedEvents(t *testing.T) {
+	outbox := &mockOutboxRepository{}
+	stream := newTestEventStream(outbox, &mockAuthorizer{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events, _, err := stream.Subscribe(ctx, newTestStreamClaims(domain.NewID()), "")
+	require.NoError(t, err)
+
+	late := newTestRequestEvent(domain.LeaveEventApproved, domain.NewID())
+	require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID()))))
+	stream.tail(ctx)
+	// transaction ที่สร้างเหตุการณ์ก่อนแต่ commit ทีหลัง
+	require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(late)))
+	stream.tail(ctx)
+
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventSubmitted, domain.LeaveEventApproved}, receive(events))
+}
+
+func TestEventStream_ResumeFromLastEventID(t *testing.T) {
+	managerID := domain.NewID()
+	outbox := &mockOutboxRepository{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID())
+	second := newTestRequestEvent(domain.LeaveEventApproved, first.Request.UserID)
+	require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(first)))
+	require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(second)))
+
+	// instance ใหม่ที่ไม่เคยส่งเหตุการณ์เหล่านี้ — ต้องอ่านจาก outbox
+	stream := newTestEventStream(outbox, &mockAuthorizer{})
+	events, resumed, err := stream.Subscribe(ctx, newTestStreamClaims(managerID), first.ID.String())
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventApproved}, receive(events))
+
+	events, resumed, err = stream.Subscribe(ctx, newTestStreamClaims(managerID), domain.NewID().String())
+	require.NoError(t, err)
+	assert.False(t, resumed, "เหตุการณ์ที่ไม่อยู่ใน outbox ต้องให้ client โหลดข้อมูลใหม่")
+	assert.Empty(t, receive(events))
+}
+
+func TestEventStream_ResumeTooFarBehindResets(t *testing.T) {
+	outbox := &mockOutboxRepository{}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	last := newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID())
+	require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(last)))
+	for range eventStreamReplayLimit {
+		require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID()))))
+	}
+
+	stream := newTestEventStream(outbox, &mockAuthorizer{})
+	events, resumed, err := stream.Subscribe(ctx, newTestStreamClaims(domain.NewID()), last.ID.String())
+	require.NoError(t, err)
+	assert.False(t, resumed, "พลาดไปเกินขีดจำกัดต้องให้ client โหลดข้อมูลใหม่")
+	assert.Empty(t, receive(events))
+}
+
+func TestEventStream_DisconnectsSlowAndCancelledSubscribers(t *testing.T) {
+	outbox := &mockOutboxRepository{}
+	stream := newTestEventStream(outbox, &mockAuthorizer{})
+	slow, _, err := stream.Subscribe(context.Background(), newTestStreamClaims(domain.NewID()), "")
+	require.NoError(t, err)
+
+	for range eventStreamBufferSize + 1 {
+		event := newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID())
+		require.NoError(t, outbox.Append(context.Background(), domain.NewOutboxMessage(event)))
+	}
+	stream.tail(context.Background())
+	assert.Len(t, receive(slow), eventStreamBufferSize, "client ที่รับไม่ทันต้องถูกตัดการเชื่อมต่อหลัง buffer เต็ม")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, _, err := stream.Subscribe(ctx, newTestStreamClaims(domain.NewID()), "")
+	require.NoError(t, err)
+	cancel()
+	assert.Eventually(t, func() bool { return isClosed(events) }, time.Second, 10*time.Millisecond,
+		"ยกเลิก ctx แล้ว channel ต้องถูกปิด")
+}
+
+func TestEventStream_RecheckAppliesRevocationAndRoleChanges(t *testing.T) {
+	revokedID, expiredID, promotedID := domain.NewID(), domain.NewID(), domain.NewID()
+	authorizer := &mockRoleAuthorizer{roles: map[domain.ID]domain.Role{
+		revokedID: domain.RoleManager, expiredID: domain.RoleManager, promotedID: domain.RoleEmployee,
+	}}
+	outbox, revocations := &mockOutboxRepository{}, newMockTokenRevocationStore()
+	stream, ok := NewEventStream(outbox, authorizer, revocations, EventStreamOptions{}).(*eventStream)
+	require.True(t, ok)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	revokedClaims, expiredClaims := newTestStreamClaims(revokedID), newTestStreamClaims(expiredID)
+	expiredClaims.ExpiresAt = time.Now().Add(-time.Second)
+	revoked, _, err := stream.Subscribe(ctx, revokedClaims, "")
+	require.NoError(t, err)
+	expired, _, err := stream.Subscribe(ctx, expiredClaims, "")
+	require.NoError(t, err)
+	promoted, _, err := stream.Subscribe(ctx, newTestStreamClaims(promotedID), "")
+	require.NoError(t, err)
+
+	require.NoError(t, revocations.RevokeUserTokens(ctx, revokedID, time.Now()))
+	authorizer.roles[promotedID] = domain.RoleManager
+	stream.recheck(ctx)
+
+	assert.True(t, isClosed(revoked), "token ที่ถูกยกเลิกต้องถูกตัดการเชื่อมต่อ")
+	assert.True(t, isClosed(expired), "token ที่หมดอายุต้องถูกตัดการเชื่อมต่อ")
+
+	require.NoError(t, outbox.Append(ctx, domain.NewOutboxMessage(newTestRequestEvent(domain.LeaveEventSubmitted, domain.NewID()))))
+	stream.tail(ctx)
+	assert.Equal(t, []domain.LeaveEventType{domain.LeaveEventSubmitted}, receive(promoted),
+		"สิทธิ์ที่ได้รับเพิ่มต้องมีผลโดยไม่ต้องต่อใหม่")
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// byStartDate ลำดับเริ่มต้นของไฟล์ส่งออก — ตรงกับรอบการคิดเงินเดือนที่นับตามวันลา
+var byStartDate = domain.LeaveSort{Field: domain.LeaveSortStartDate}
+
+type exportService struct {
+	exportRepo ports.ExportRepository
+}
+
+func NewExportService(exportRepo ports.ExportRepository) ports.ExportService {
+	return &exportService{exportRepo: exportRepo}
+}
+
+// ExportRequests ส่งออกใบลาตามเงื่อนไขเดียวกับการค้นหาของผู้จัดการ
+func (s *exportService) ExportRequests(
+	ctx context.Context,
+	query domain.LeaveRequestQuery,
+	fn func(*domain.LeaveRequestView) error,
+) error {
+	if err := query.Validate(); err != nil {
+		return err
+	}
+	if err := s.exportRepo.EachRequest(ctx, query.WithDefaultSort(byStartDate), fn); err != nil {
+		return fmt.Errorf("ส่งออกใบลาล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// ExportBalances ส่งออกยอดวันลาตามเงื่อนไข
+func (s *exportService) ExportBalances(
+	ctx context.Context,
+	query domain.LeaveBalanceQuery,
+	fn func(*domain.LeaveBalanceView) error,
+) error {
+	if err := query.Validate(); err != nil {
+		return err
+	}
+	if err := s.exportRepo.EachBalance(ctx, query, fn); err != nil {
+		return fmt.Errorf("ส่งออกยอดวันลาล้มเหลว: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestExportService_ExportRequests_InvalidQuery(t *testing.T) {
+	called := false
+	repo := &mockExportRepository{
+		eachRequestFn: func(_ context.Context, _ domain.LeaveRequestQuery, _ func(*domain.LeaveRequestView) error) error {
+			called = true
+			return nil
+		},
+	}
+	svc := NewExportService(repo)
+
+	query := domain.LeaveRequestQuery{LeaveTypes: []domain.LeaveType{"vacation"}}
+	err := svc.ExportRequests(context.Background(), query, func(*domain.LeaveRequestView) error { return nil })
+
+	assert.ErrorIs(t, err, domain.ErrInvalidLeaveQuery)
+	assert.False(t, called, "เงื่อนไขไม่ถูกต้องต้องไม่ query")
+}
+
+func TestExportService_ExportRequests_DefaultSortAndRows(t *testing.T) {
+	var gotSort []domain.LeaveSort
+	repo := &mockExportRepository{
+		eachRequestFn: func(_ context.Context, q domain.LeaveRequestQuery, fn func(*domain.LeaveRequestView) error) error {
+			gotSort = q.Sort
+			for range 3 {
+				if err := fn(&domain.LeaveRequestView{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	svc := NewExportService(repo)
+
+	rows := 0
+	err := svc.ExportRequests(context.Background(), domain.LeaveRequestQuery{}, func(*domain.LeaveRequestView) error {
+		rows++
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, []domain.LeaveSort{{Field: domain.LeaveSortStartDate}}, gotSort)
+}
+
+func TestExportService_ExportBalances_StopsOnWriteError(t *testing.T) {
+	errWrite := errors.New("client disconnected")
+	repo := &mockExportRepository{
+		eachBalanceFn: func(_ context.Context, _ domain.LeaveBalanceQuery, fn func(*domain.LeaveBalanceView) error) error {
+			return fn(&domain.LeaveBalanceView{})
+		},
+	}
+	svc := NewExportService(repo)
+
+	err := svc.ExportBalances(context.Background(), domain.LeaveBalanceQuery{}, func(*domain.LeaveBalanceView) error {
+		return errWrite
+	})
+
+	assert.ErrorIs(t, err, errWrite)
+}
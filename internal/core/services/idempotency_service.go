@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type idempotencyService struct {
+	repo ports.IdempotencyRepository
+	ttl  time.Duration
+}
+
+// NewIdempotencyService สร้าง IdempotencyService — ttl คือเวลาที่เก็บ response ไว้ตอบ request ที่ส่งซ้ำ
+func NewIdempotencyService(repo ports.IdempotencyRepository, ttl time.Duration) ports.IdempotencyService {
+	if ttl <= 0 {
+		ttl = domain.DefaultIdempotencyTTL
+	}
+	return &idempotencyService{repo: repo, ttl: ttl}
+}
+
+// Begin จอง key — ถ้ามีผู้จองไว้แล้ว ตรวจว่าเป็น request เดียวกันและทำเสร็จแล้วหรือยัง
+func (s *idempotencyService) Begin(
+	ctx context.Context,
+	userID domain.ID,
+	key, fingerprint string,
+) (*domain.IdempotencyRecord, *domain.IdempotentResponse, error) {
+	now := time.Now()
+	record, err := domain.NewIdempotencyRecord(userID, key, fingerprint, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	reserved, err := s.repo.Reserve(ctx, record, now)
+	if err != nil {
+		return nil, nil, err
+	}
+	if reserved {
+		return record, nil, nil
+	}
+
+	existing, err := s.repo.Find(ctx, userID, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if existing == nil {
+		// key ถูกลบระหว่างนั้น (request แรกล้มเหลว) — ให้ client ลองใหม่
+		return nil, nil, domain.ErrIdempotencyKeyInProgress
+	}
+	resp, err := existing.Replay(fingerprint)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, resp, nil
+}
+
+// Complete บันทึก response และเก็บไว้ตามเวลาที่กำหนด
+func (s *idempotencyService) Complete(ctx context.Context, record *domain.IdempotencyRecord, resp domain.IdempotentResponse) error {
+	record.Complete(resp, time.Now(), s.ttl)
+	return s.repo.Update(ctx, record)
+}
+
+// Release ลบการจอง key
+func (s *idempotencyService) Release(ctx context.Context, record *domain.IdempotencyRecord) error {
+	return s.repo.Delete(ctx, record.ID)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestIdempotencyService_ReplaysCompletedRequest(t *testing.T) {
+	svc := NewIdempotencyService(newMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	userID := domain.NewID()
+
+	record, replay, err := svc.Begin(ctx, userID, "key-1", "fp")
+	require.NoError(t, err)
+	require.NotNil(t, record)
+	assert.Nil(t, replay)
+
+	stored := domain.IdempotentResponse{StatusCode: http.StatusCreated, ContentType: "application/json", Body: []byte(`{"ok":true}`)}
+	require.NoError(t, svc.Complete(ctx, record, stored))
+
+	record, replay, err = svc.Begin(ctx, userID, "key-1", "fp")
+	require.NoError(t, err)
+	assert.Nil(t, record)
+	require.NotNil(t, replay)
+	assert.Equal(t, stored, *replay)
+
+	// key เดียวกันของผู้ใช้อื่นไม่ชนกัน
+	record, replay, err = svc.Begin(ctx, domain.NewID(), "key-1", "fp")
+	require.NoError(t, err)
+	assert.NotNil(t, record)
+	assert.Nil(t, replay)
+}
+
+func TestIdempotencyService_RejectsReusedKeyWithDifferentRequest(t *testing.T) {
+	svc := NewIdempotencyService(newMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	userID := domain.NewID()
+
+	record, _, err := svc.Begin(ctx, userID, "key-1", "fp-a")
+	require.NoError(t, err)
+	require.NoError(t, svc.Complete(ctx, record, domain.IdempotentResponse{StatusCode: http.StatusOK}))
+
+	_, _, err = svc.Begin(ctx, userID, "key-1", "fp-b")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+}
+
+func TestIdempotencyService_InProgressAndRelease(t *testing.T) {
+	svc := NewIdempotencyService(newMockIdempotencyRepository(), time.Hour)
+	ctx := context.Background()
+	userID := domain.NewID()
+
+	record, _, err := svc.Begin(ctx, userID, "key-1", "fp")
+	require.NoError(t, err)
+
+	_, _, err = svc.Begin(ctx, userID, "key-1", "fp")
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyInProgress)
+
+	// request แรกล้มเหลว — ปล่อย key ให้ retry ทำงานใหม่ได้
+	require.NoError(t, svc.Release(ctx, record))
+	record, replay, err := svc.Begin(ctx, userID, "key-1", "fp")
+	require.NoError(t, err)
+	assert.NotNil(t, record)
+	assert.Nil(t, replay)
+}
+
+func TestIdempotencyService_InvalidKey(t *testing.T) {
+	svc := NewIdempotencyService(newMockIdempotencyRepository(), 0)
+
+	_, _, err := svc.Begin(context.Background(), domain.NewID(), "bad key", "fp")
+	assert.ErrorIs(t, err, domain.ErrInvalidIdempotencyKey)
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type leaveAmendService struct {
+	requestRepo ports.LeaveRequestRepository
+	historyRepo ports.LeaveHistoryRepository
+	balanceRepo ports.LeaveBalanceRepository
+	audit       ports.AuditLogger
+	tx          ports.TransactionManager
+}
+
+func NewLeaveAmendService(
+	requestRepo ports.LeaveRequestRepository,
+	historyRepo ports.LeaveHistoryRepository,
+	balanceRepo ports.LeaveBalanceRepository,
+	audit ports.AuditLogger,
+	tx ports.TransactionManager,
+) ports.LeaveAmendService {
+	return &leaveAmendService{
+		requestRepo: requestRepo,
+		historyRepo: historyRepo,
+		balanceRepo: balanceRepo,
+		audit:       audit,
+		tx:          tx,
+	}
+}
+
+// Edit เจ้าของแก้ใบลาที่ยังรออนุมัติ — ตรวจเงื่อนไขเดียวกับการยื่นใบลา
+func (s *leaveAmendService) Edit(
+	ctx context.Context,
+	requestID, userID domain.ID,
+	leaveType domain.LeaveType,
+	startDate, endDate time.Time,
+	reason string,
+) (*domain.LeaveRequest, error) {
+	if !leaveType.IsValid() {
+		return nil, domain.ErrInvalidLeaveType
+	}
+	if endDate.Before(startDate) {
+		return nil, domain.ErrInvalidDateRange
+	}
+
+	var request *domain.LeaveRequest
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		request, err = s.edit(ctx, requestID, userID, leaveType, startDate, endDate, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// edit แก้ใบลา จองวันลาใหม่ และบันทึกประวัติกับ audit log — เรียกภายใน transaction
+func (s *leaveAmendService) edit(
+	ctx context.Context,
+	requestID, userID domain.ID,
+	leaveType domain.LeaveType,
+	startDate, endDate time.Time,
+	reason string,
+) (*domain.LeaveRequest, error) {
+	request, err := s.findOwnRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, before := *request, request.AuditSnapshot()
+	if err = request.Edit(leaveType, startDate, endDate, reason); err != nil {
+		return nil, err
+	}
+
+	overlap, err := s.requestRepo.HasOverlap(ctx, userID, request.StartDate, request.EndDate, &request.ID)
+	if err != nil {
+		return nil, fmt.Errorf("ตรวจสอบวันลาซ้ำซ้อนล้มเหลว: %w", err)
+	}
+	if overlap {
+		return nil, domain.ErrOverlappingLeave
+	}
+
+	if err = s.requestRepo.UpdateWithStatusCheck(ctx, request, domain.LeaveStatusPending); err != nil {
+		return nil, err
+	}
+
+	if err = s.rebook(ctx, &previous, request); err != nil {
+		return nil, s.restore(ctx, &previous, err)
+	}
+
+	if err = s.record(ctx, domain.LeaveHistoryEdited, domain.AuditLeaveEdited, request, userID, before, ""); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// rebook ปล่อยวันลาที่ใบลาเดิมจองไว้แล้วจองตามใบลาที่แก้ — จองไม่สำเร็จจะจองคืนให้ใบลาเดิม (จำเป็นเมื่อไม่มี transaction)
+func (s *leaveAmendService) rebook(ctx context.Context, previous, edited *domain.LeaveRequest) error {
+	if err := s.balanceRepo.ReleasePending(
+		ctx, previous.UserID, previous.LeaveType, previous.StartDate.Year(), previous.TotalDays,
+	); err != nil {
+		return err
+	}
+
+	if err := s.balanceRepo.ReservePending(
+		ctx, edited.UserID, edited.LeaveType, edited.StartDate.Year(), edited.TotalDays,
+	); err != nil {
+		if rbErr := s.balanceRepo.ReservePending(
+			ctx, previous.UserID, previous.LeaveType, previous.StartDate.Year(), previous.TotalDays,
+		); rbErr != nil {
+			return fmt.Errorf("จองวันลาตามใบลาที่แก้ล้มเหลว: %w (rollback ล้มเหลว: %v)", err, rbErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// Cancel เจ้าของยกเลิกใบลาที่ยังรออนุมัติ — ปล่อยวันลาที่จองไว้กลับคืน แบบ atomic
+func (s *leaveAmendService) Cancel(ctx context.Context, requestID, userID domain.ID, reason string) (*domain.LeaveRequest, error) {
+	var request *domain.LeaveRequest
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		request, err = s.cancel(ctx, requestID, userID, reason)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// cancel ยกเลิกใบลาภายใน transaction
+func (s *leaveAmendService) cancel(ctx context.Context, requestID, userID domain.ID, reason string) (*domain.LeaveRequest, error) {
+	request, err := s.findOwnRequest(ctx, requestID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, before := *request, request.AuditSnapshot()
+	if err = request.Cancel(); err != nil {
+		return nil, err
+	}
+
+	if err = s.requestRepo.UpdateWithStatusCheck(ctx, request, domain.LeaveStatusPending); err != nil {
+		return nil, err
+	}
+
+	// ปล่อย pending_days กลับคืน
+	if err = s.balanceRepo.ReleasePending(
+		ctx, request.UserID, request.LeaveType, request.StartDate.Year(), request.TotalDays,
+	); err != nil {
+		return nil, s.restore(ctx, &previous, err)
+	}
+
+	if err = s.record(ctx, domain.LeaveHistoryCancelled, domain.AuditLeaveCancelled, request, userID, before, reason); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// findOwnRequest ค้นหาใบลาของผู้ใช้ — ใบลาของผู้อื่นได้ ErrRequestNotFound เหมือนใบลาที่ไม่มีอยู่
+func (s *leaveAmendService) findOwnRequest(ctx context.Context, requestID, userID domain.ID) (*domain.LeaveRequest, error) {
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if request.UserID != userID {
+		return nil, domain.ErrRequestNotFound
+	}
+	return request, nil
+}
+
+// restore คืนใบลาเป็นค่าก่อนแก้ไขหรือยกเลิกเมื่อปรับยอดวันลาไม่สำเร็จ (จำเป็นเมื่อไม่มี transaction)
+func (s *leaveAmendService) restore(ctx context.Context, previous *domain.LeaveRequest, err error) error {
+	if rbErr := s.requestRepo.Update(ctx, previous); rbErr != nil {
+		return fmt.Errorf("ปรับยอดวันลาล้มเหลว: %w (rollback ล้มเหลว: %v)", err, rbErr)
+	}
+	return err
+}
+
+// record บันทึกการแก้ไขหรือยกเลิกลงประวัติใบลาและ audit log พร้อมค่าที่เปลี่ยน
+func (s *leaveAmendService) record(
+	ctx context.Context,
+	historyAction domain.LeaveHistoryAction,
+	action domain.AuditAction,
+	request *domain.LeaveRequest,
+	userID domain.ID,
+	before map[string]any,
+	note string,
+) error {
+	entry := domain.NewLeaveHistoryEntry(historyAction, &userID, domain.LeaveStatusPending, request, before, note)
+	if err := s.historyRepo.Append(ctx, entry); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(
+		action, domain.AuditTargetLeaveRequest, request.ID.String(),
+		domain.DiffChanges(before, request.AuditSnapshot()),
+	).By(userID)
+	return recordAudit(ctx, s.audit, event)
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// balanceCall การเรียก ReservePending/ReleasePending ที่บันทึกไว้ตรวจในเทสต์
+type balanceCall struct {
+	op        string
+	leaveType domain.LeaveType
+	year      int
+	days      float64
+}
+
+// recordingBalanceRepository บันทึกการจองและปล่อยวันลา — reserveErr คืน error เมื่อจองประเภทนั้น
+func recordingBalanceRepository(calls *[]balanceCall, reserveErr map[domain.LeaveType]error) *mockLeaveBalanceRepository {
+	return &mockLeaveBalanceRepository{
+		reservePendingFn: func(_ context.Context, _ domain.ID, leaveType domain.LeaveType, year int, days float64) error {
+			*calls = append(*calls, balanceCall{"reserve", leaveType, year, days})
+			return reserveErr[leaveType]
+		},
+		releasePendingFn: func(_ context.Context, _ domain.ID, leaveType domain.LeaveType, year int, days float64) error {
+			*calls = append(*calls, balanceCall{"release", leaveType, year, days})
+			return nil
+		},
+	}
+}
+
+func newPendingSickLeave() *domain.LeaveRequest {
+	return domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeSick,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), "ไม่สบาย",
+	)
+}
+
+func findRequestRepository(request *domain.LeaveRequest) *mockLeaveRequestRepository {
+	return &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			return request, nil
+		},
+	}
+}
+
+func TestLeaveAmendService_Edit_RebooksBalanceAndRecordsHistory(t *testing.T) {
+	request := newPendingSickLeave()
+	requestRepo := findRequestRepository(request)
+	var excluded *domain.ID
+	requestRepo.hasOverlapFn = func(_ context.Context, _ domain.ID, _, _ time.Time, excludeID *domain.ID) (bool, error) {
+		excluded = excludeID
+		return false, nil
+	}
+	var calls []balanceCall
+	history, audit := &mockLeaveHistoryRepository{}, &mockAuditLogger{}
+	svc := NewLeaveAmendService(requestRepo, history, recordingBalanceRepository(&calls, nil), audit, &mockTransactionManager{})
+
+	edited, err := svc.Edit(context.Background(), request.ID, request.UserID, domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC), "พักผ่อนกับครอบครัว")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.LeaveTypeAnnual, edited.LeaveType)
+	assert.Equal(t, 3.0, edited.TotalDays)
+	require.NotNil(t, excluded)
+	assert.Equal(t, request.ID, *excluded, "ตรวจวันลาซ้ำซ้อนต้องไม่นับใบลาที่กำลังแก้")
+	assert.Equal(t, []balanceCall{
+		{"release", domain.LeaveTypeSick, 2026, 1},
+		{"reserve", domain.LeaveTypeAnnual, 2026, 3},
+	}, calls)
+
+	require.Equal(t, []domain.LeaveHistoryAction{domain.LeaveHistoryEdited}, history.actions())
+	entry := history.entries[0]
+	assert.Equal(t, request.UserID, *entry.ActorID)
+	assert.Equal(t, domain.LeaveStatusPending, entry.ToStatus)
+	assert.Contains(t, entry.Changes, domain.AuditChange{Field: "leave_type", Before: "sick_leave", After: "annual_leave"})
+	assert.Equal(t, []domain.AuditAction{domain.AuditLeaveEdited}, audit.actions())
+}
+
+func TestLeaveAmendService_Edit_InsufficientBalanceRestoresRequest(t *testing.T) {
+	request := newPendingSickLeave()
+	requestRepo := findRequestRepository(request)
+	var restored *domain.LeaveRequest
+	requestRepo.updateFn = func(_ context.Context, r *domain.LeaveRequest) error {
+		restored = r
+		return nil
+	}
+	var calls []balanceCall
+	balanceRepo := recordingBalanceRepository(&calls, map[domain.LeaveType]error{domain.LeaveTypeAnnual: domain.ErrInsufficientBalance})
+	history := &mockLeaveHistoryRepository{}
+	svc := NewLeaveAmendService(requestRepo, history, balanceRepo, &mockAuditLogger{}, &mockTransactionManager{})
+
+	_, err := svc.Edit(context.Background(), request.ID, request.UserID, domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC), "พักผ่อนยาว")
+
+	require.ErrorIs(t, err, domain.ErrInsufficientBalance)
+	assert.Equal(t, balanceCall{"reserve", domain.LeaveTypeSick, 2026, 1}, calls[len(calls)-1], "ต้องจองวันลาคืนให้ใบลาเดิม")
+	require.NotNil(t, restored)
+	assert.Equal(t, domain.LeaveTypeSick, restored.LeaveType, "ใบลาต้องกลับเป็นค่าก่อนแก้")
+	assert.Equal(t, 1.0, restored.TotalDays)
+	assert.Empty(t, history.actions())
+}
+
+func TestLeaveAmendService_Edit_OverlappingLeave(t *testing.T) {
+	request := newPendingSickLeave()
+	requestRepo := findRequestRepository(request)
+	requestRepo.hasOverlapFn = func(_ context.Context, _ domain.ID, _, _ time.Time, _ *domain.ID) (bool, error) {
+		return true, nil
+	}
+	var calls []balanceCall
+	svc := NewLeaveAmendService(requestRepo, &mockLeaveHistoryRepository{}, recordingBalanceRepository(&calls, nil), &mockAuditLogger{}, &mockTransactionManager{})
+
+	_, err := svc.Edit(context.Background(), request.ID, request.UserID, domain.LeaveTypeSick,
+		request.StartDate, request.EndDate.AddDate(0, 0, 1), "ไม่สบายต่อ")
+
+	assert.ErrorIs(t, err, domain.ErrOverlappingLeave)
+	assert.Empty(t, calls, "ใบลาที่ซ้ำซ้อนต้องไม่เปลี่ยนยอดวันลา")
+}
+
+func TestLeaveAmendService_Cancel_ReleasesPendingDays(t *testing.T) {
+	request := newPendingSickLeave()
+	var calls []balanceCall
+	history, audit := &mockLeaveHistoryRepository{}, &mockAuditLogger{}
+	svc := NewLeaveAmendService(findRequestRepository(request), history, recordingBalanceRepository(&calls, nil), audit, &mockTransactionManager{})
+
+	cancelled, err := svc.Cancel(context.Background(), request.ID, request.UserID, "หายป่วยแล้ว")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.LeaveStatusCancelled, cancelled.Status)
+	assert.Equal(t, []balanceCall{{"release", domain.LeaveTypeSick, 2026, 1}}, calls)
+
+	require.Equal(t, []domain.LeaveHistoryAction{domain.LeaveHistoryCancelled}, history.actions())
+	entry := history.entries[0]
+	assert.Equal(t, domain.LeaveStatusPending, entry.FromStatus)
+	assert.Equal(t, domain.LeaveStatusCancelled, entry.ToStatus)
+	assert.Equal(t, "หายป่วยแล้ว", entry.Note)
+	assert.Equal(t, []domain.AuditAction{domain.AuditLeaveCancelled}, audit.actions())
+}
+
+func TestLeaveAmendService_Cancel_ReviewedRequest(t *testing.T) {
+	request := newPendingSickLeave()
+	require.NoError(t, request.Approve(domain.NewID(), "อนุมัติ"))
+	var calls []balanceCall
+	svc := NewLeaveAmendService(findRequestRepository(request), &mockLeaveHistoryRepository{}, recordingBalanceRepository(&calls, nil), &mockAuditLogger{}, &mockTransactionManager{})
+
+	_, err := svc.Cancel(context.Background(), request.ID, request.UserID, "")
+
+	assert.ErrorIs(t, err, domain.ErrRequestNotPending)
+	assert.Empty(t, calls)
+}
+
+func TestLeaveAmendService_OtherEmployeeGetsNotFound(t *testing.T) {
+	request := newPendingSickLeave()
+	var calls []balanceCall
+	history := &mockLeaveHistoryRepository{}
+	svc := NewLeaveAmendService(findRequestRepository(request), history, recordingBalanceRepository(&calls, nil), &mockAuditLogger{}, &mockTransactionManager{})
+	otherID := domain.NewID()
+
+	_, err := svc.Edit(context.Background(), request.ID, otherID, domain.LeaveTypeSick, request.StartDate, request.EndDate, "แก้ใบลาของคนอื่น")
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	_, err = svc.Cancel(context.Background(), request.ID, otherID, "")
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+
+	assert.Equal(t, domain.LeaveStatusPending, request.Status)
+	assert.Empty(t, calls)
+	assert.Empty(t, history.actions())
+}
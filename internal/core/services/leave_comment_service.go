@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type leaveCommentService struct {
+	requestRepo ports.LeaveRequestRepository
+	commentRepo ports.LeaveCommentRepository
+	historyRepo ports.LeaveHistoryRepository
+	userRepo    ports.UserRepository
+	authorizer  ports.Authorizer
+	outboxRepo  ports.OutboxRepository
+	tx          ports.TransactionManager
+}
+
+func NewLeaveCommentService(
+	requestRepo ports.LeaveRequestRepository,
+	commentRepo ports.LeaveCommentRepository,
+	historyRepo ports.LeaveHistoryRepository,
+	userRepo ports.UserRepository,
+	authorizer ports.Authorizer,
+	outboxRepo ports.OutboxRepository,
+	tx ports.TransactionManager,
+) ports.LeaveCommentService {
+	return &leaveCommentService{
+		requestRepo: requestRepo,
+		commentRepo: commentRepo,
+		historyRepo: historyRepo,
+		userRepo:    userRepo,
+		authorizer:  authorizer,
+		outboxRepo:  outboxRepo,
+		tx:          tx,
+	}
+}
+
+// AddComment แสดงความคิดเห็นในใบลา — ผู้ที่ถูกกล่าวถึงต้องเห็นความคิดเห็นนี้ได้จึงจะได้รับอีเมล
+func (s *leaveCommentService) AddComment(
+	ctx context.Context,
+	requestID, authorID domain.ID,
+	body string,
+	internal bool,
+	mentions []domain.ID,
+) (*domain.LeaveComment, error) {
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorizeLeaveViewer(ctx, s.authorizer, request, authorID); err != nil {
+		return nil, err
+	}
+	approver, err := s.isApprover(ctx, request, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if internal && !approver {
+		return nil, domain.ErrInternalCommentForbidden
+	}
+
+	recipients, err := s.resolveMentions(ctx, request, internal, mentions)
+	if err != nil {
+		return nil, err
+	}
+
+	mentionIDs := make([]domain.ID, 0, len(recipients))
+	for _, u := range recipients {
+		mentionIDs = append(mentionIDs, u.ID)
+	}
+
+	comment := domain.NewLeaveComment(requestID, authorID, body, internal, mentionIDs)
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.save(ctx, request, comment)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// save บันทึกความคิดเห็น ประวัติใบลา และเหตุการณ์กล่าวถึงใน transaction เดียว
+// — อีเมลถึงผู้ที่ถูกกล่าวถึงถูกส่งโดย dispatcher หลัง commit ความคิดเห็นจึงไม่ขึ้นกับ SMTP
+func (s *leaveCommentService) save(ctx context.Context, request *domain.LeaveRequest, comment *domain.LeaveComment) error {
+	if err := s.commentRepo.Create(ctx, comment); err != nil {
+		return err
+	}
+
+	// บันทึกภายในไม่ลงประวัติใบลา เพราะเจ้าของใบลาเห็นประวัติได้
+	if !comment.Internal {
+		entry := domain.NewLeaveHistoryEntry(
+			domain.LeaveHistoryCommented, &comment.AuthorID, request.Status, request, request.AuditSnapshot(), "",
+		)
+		if err := s.historyRepo.Append(ctx, entry); err != nil {
+			return err
+		}
+	}
+
+	if len(comment.Mentions) == 0 {
+		return nil
+	}
+	event := domain.NewCommentMentionedEvent(request, comment)
+	return s.outboxRepo.Append(ctx, domain.NewOutboxMessage(event))
+}
+
+// ListComments ดูความคิดเห็นของใบลา — เจ้าของใบลาไม่เห็นบันทึกภายในแม้จะมีสิทธิ์ leave.approve
+func (s *leaveCommentService) ListComments(ctx context.Context, requestID, viewerID domain.ID) ([]domain.LeaveComment, error) {
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorizeLeaveViewer(ctx, s.authorizer, request, viewerID); err != nil {
+		return nil, err
+	}
+	approver, err := s.isApprover(ctx, request, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.FindByRequestID(ctx, requestID, approver)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงความคิดเห็นของใบลาล้มเหลว: %w", err)
+	}
+	return comments, nil
+}
+
+// resolveMentions ค้นหาผู้ใช้ที่ถูกกล่าวถึง (ตัดรหัสซ้ำ) — ทุกคนต้องเห็นความคิดเห็นนี้ได้
+func (s *leaveCommentService) resolveMentions(
+	ctx context.Context,
+	request *domain.LeaveRequest,
+	internal bool,
+	mentions []domain.ID,
+) ([]*domain.User, error) {
+	seen := make(map[domain.ID]bool, len(mentions))
+	users := make([]*domain.User, 0, len(mentions))
+
+	for _, id := range mentions {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if len(seen) > domain.MaxCommentMentions {
+			return nil, domain.ErrInvalidMention
+		}
+
+		user, err := s.userRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				return nil, domain.ErrInvalidMention
+			}
+			return nil, err
+		}
+
+		ok, err := s.canSeeComment(ctx, request, user, internal)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, domain.ErrInvalidMention
+		}
+		users = append(users, user)
+	}
+
+	return users, nil
+}
+
+// isApprover ผู้ใช้เป็นผู้มีสิทธิ์ leave.approve ที่ไม่ใช่เจ้าของใบลาหรือไม่ — เห็นและเขียนบันทึกภายในได้
+func (s *leaveCommentService) isApprover(ctx context.Context, request *domain.LeaveRequest, userID domain.ID) (bool, error) {
+	if request.UserID == userID {
+		return false, nil
+	}
+	switch err := s.authorizer.Authorize(ctx, userID, domain.PermissionLeaveApprove); {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, domain.ErrPermissionDenied):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// canSeeComment ตรวจว่าผู้ใช้เห็นความคิดเห็นได้ — ใช้กฎเดียวกับ ListComments
+func (s *leaveCommentService) canSeeComment(
+	ctx context.Context,
+	request *domain.LeaveRequest,
+	user *domain.User,
+	internal bool,
+) (bool, error) {
+	if user.ID == request.UserID {
+		return !internal, nil
+	}
+	if internal {
+		return s.authorizer.RoleHasPermission(ctx, user.Role, domain.PermissionLeaveApprove)
+	}
+	return roleCanViewLeaves(ctx, s.authorizer, user.Role)
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// commentFixture ใบลาของพนักงาน 1 คน + ผู้จัดการ 1 คน + ผู้ดูแลระบบ 1 คน + พนักงานอื่นที่ไม่เกี่ยวข้อง
+type commentFixture struct {
+	employee, manager, admin, outsider *domain.User
+	request                            *domain.LeaveRequest
+	comments                           *mockLeaveCommentRepository
+	history                            *mockLeaveHistoryRepository
+	outbox                             *mockOutboxRepository
+	svc                                *leaveCommentService
+}
+
+func newCommentFixture() *commentFixture {
+	f := &commentFixture{
+		employee: domain.NewUser("สมชาย", "ใจดี", "somchai@company.com", "", domain.RoleEmployee),
+		manager:  domain.NewUser("สมหญิง", "รักงาน", "somying@company.com", "", domain.RoleManager),
+		admin:    domain.NewUser("สมพร", "ดูแลระบบ", "somporn@company.com", "", domain.RoleAdmin),
+		outsider: domain.NewUser("สมศักดิ์", "ทั่วไป", "somsak@company.com", "", domain.RoleEmployee),
+		comments: &mockLeaveCommentRepository{},
+		history:  &mockLeaveHistoryRepository{},
+		outbox:   &mockOutboxRepository{},
+	}
+	f.request = domain.NewLeaveRequest(
+		f.employee.ID, domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), "พักผ่อน",
+	)
+
+	users := map[domain.ID]*domain.User{
+		f.employee.ID: f.employee, f.manager.ID: f.manager, f.admin.ID: f.admin, f.outsider.ID: f.outsider,
+	}
+	roles := map[domain.ID]domain.Role{}
+	for id, u := range users {
+		roles[id] = u.Role
+	}
+
+	requestRepo := &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.LeaveRequest, error) {
+			if id != f.request.ID {
+				return nil, domain.ErrRequestNotFound
+			}
+			return f.request, nil
+		},
+	}
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.User, error) {
+			if u, ok := users[id]; ok {
+				return u, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+	}
+
+	f.svc = NewLeaveCommentService(
+		requestRepo, f.comments, f.history, userRepo, &mockRoleAuthorizer{roles: roles},
+		f.outbox, &mockTransactionManager{},
+	).(*leaveCommentService)
+	return f
+}
+
+func TestLeaveCommentService_AddComment_MentionNotifiesAndRecordsHistory(t *testing.T) {
+	f := newCommentFixture()
+
+	comment, err := f.svc.AddComment(context.Background(), f.request.ID, f.employee.ID,
+		"ขอลาต่อเนื่องได้ไหมครับ", false, []domain.ID{f.manager.ID, f.manager.ID})
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ID{f.manager.ID}, comment.Mentions)
+	require.Len(t, f.outbox.messages, 1, "อีเมลถูกส่งผ่าน outbox หลัง commit")
+	event := f.outbox.messages[0].Event
+	assert.Equal(t, domain.LeaveEventCommentMentioned, event.Type)
+	assert.Equal(t, f.employee.ID, event.ActorID)
+	assert.Equal(t, comment.ID, event.Comment.ID)
+	assert.Equal(t, []domain.LeaveHistoryAction{domain.LeaveHistoryCommented}, f.history.actions())
+}
+
+func TestLeaveCommentService_AddComment_OutsiderGetsNotFound(t *testing.T) {
+	f := newCommentFixture()
+
+	_, err := f.svc.AddComment(context.Background(), f.request.ID, f.outsider.ID, "ขอดูหน่อย", false, nil)
+
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+	assert.Empty(t, f.comments.comments)
+}
+
+func TestLeaveCommentService_AddComment_EmployeeCannotWriteInternal(t *testing.T) {
+	f := newCommentFixture()
+
+	_, err := f.svc.AddComment(context.Background(), f.request.ID, f.employee.ID, "บันทึกลับ", true, nil)
+
+	assert.ErrorIs(t, err, domain.ErrInternalCommentForbidden)
+}
+
+func TestLeaveCommentService_AddComment_InternalCannotMentionOwner(t *testing.T) {
+	f := newCommentFixture()
+
+	_, err := f.svc.AddComment(context.Background(), f.request.ID, f.manager.ID,
+		"ช่วงนี้งานเยอะ", true, []domain.ID{f.employee.ID})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidMention)
+	assert.Empty(t, f.comments.comments)
+	assert.Empty(t, f.outbox.messages)
+}
+
+func TestLeaveCommentService_ListComments_HidesInternalFromOwner(t *testing.T) {
+	f := newCommentFixture()
+	ctx := context.Background()
+
+	_, err := f.svc.AddComment(ctx, f.request.ID, f.employee.ID, "รบกวนพิจารณาครับ", false, nil)
+	require.NoError(t, err)
+	_, err = f.svc.AddComment(ctx, f.request.ID, f.manager.ID, "รอคุยกับ HR ก่อน", true, nil)
+	require.NoError(t, err)
+
+	ownerView, err := f.svc.ListComments(ctx, f.request.ID, f.employee.ID)
+	require.NoError(t, err)
+	managerView, err := f.svc.ListComments(ctx, f.request.ID, f.manager.ID)
+	require.NoError(t, err)
+
+	assert.Len(t, ownerView, 1)
+	assert.Len(t, managerView, 2)
+	// บันทึกภายในไม่ลงประวัติที่เจ้าของใบลาเห็น
+	assert.Len(t, f.history.entries, 1)
+	assert.Empty(t, f.outbox.messages, "ไม่มีการกล่าวถึงต้องไม่สร้างเหตุการณ์")
+}
+
+func TestLeaveCommentService_AddComment_OutboxErrorFails(t *testing.T) {
+	f := newCommentFixture()
+	f.outbox.err = errors.New("transaction aborted")
+
+	_, err := f.svc.AddComment(context.Background(), f.request.ID, f.employee.ID,
+		"ขอลาต่อเนื่องได้ไหมครับ", false, []domain.ID{f.manager.ID})
+
+	assert.Error(t, err, "บันทึกเหตุการณ์ไม่สำเร็จต้องยกเลิก transaction ของความคิดเห็นทั้งหมด")
+}
+
+func TestLeaveCommentService_AdminSeesSameRequestsAsDetail(t *testing.T) {
+	f := newCommentFixture()
+	ctx := context.Background()
+
+	// ผู้ดูแลระบบ (user.manage) เห็นใบลาได้เหมือน GetRequest จึงแสดงความคิดเห็นและถูกกล่าวถึงได้
+	_, err := f.svc.AddComment(ctx, f.request.ID, f.employee.ID, "ฝากตรวจยอดวันลาด้วยครับ", false, []domain.ID{f.admin.ID})
+	require.NoError(t, err)
+	_, err = f.svc.AddComment(ctx, f.request.ID, f.admin.ID, "ตรวจแล้วครับ", false, nil)
+	require.NoError(t, err)
+	_, err = f.svc.AddComment(ctx, f.request.ID, f.manager.ID, "รอคุยกับ HR ก่อน", true, nil)
+	require.NoError(t, err)
+
+	// แต่บันทึกภายในยังเป็นของผู้มีสิทธิ์ leave.approve เท่านั้น
+	_, err = f.svc.AddComment(ctx, f.request.ID, f.admin.ID, "บันทึกลับ", true, nil)
+	assert.ErrorIs(t, err, domain.ErrInternalCommentForbidden)
+	_, err = f.svc.AddComment(ctx, f.request.ID, f.manager.ID, "แจ้ง admin", true, []domain.ID{f.admin.ID})
+	assert.ErrorIs(t, err, domain.ErrInvalidMention)
+
+	adminView, err := f.svc.ListComments(ctx, f.request.ID, f.admin.ID)
+	require.NoError(t, err)
+	assert.Len(t, adminView, 2)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// leaveReaderPermissions สิทธิ์ที่อ่านใบลาของผู้อื่นได้ — ผู้จัดการ (leave.view_team) และผู้ดูแลระบบ (user.manage)
+// ใช้กับรายละเอียด ประวัติ และความคิดเห็นของใบลา — ยังไม่จำกัดตามแผนกของผู้ดู เหมือน /manager/requests ที่ค้นหาใบลาของทุกคน
+var leaveReaderPermissions = []domain.Permission{domain.PermissionLeaveViewTeam, domain.PermissionUserManage}
+
+// authorizeLeaveViewer ตรวจว่าผู้ใช้เห็นใบลานี้ได้หรือไม่ — เจ้าของใบลาหรือผู้มีสิทธิ์ใดสิทธิ์หนึ่งใน leaveReaderPermissions
+// ผู้ที่ไม่มีสิทธิ์ได้ ErrRequestNotFound เหมือนใบลาที่ไม่มีอยู่ เพื่อไม่เปิดเผยว่ามีรหัสใบลานี้
+func authorizeLeaveViewer(
+	ctx context.Context,
+	authorizer ports.Authorizer,
+	request *domain.LeaveRequest,
+	viewerID domain.ID,
+) error {
+	if request.UserID == viewerID {
+		return nil
+	}
+	for _, permission := range leaveReaderPermissions {
+		err := authorizer.Authorize(ctx, viewerID, permission)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, domain.ErrPermissionDenied) {
+			return err
+		}
+	}
+	return domain.ErrRequestNotFound
+}
+
+// roleCanViewLeaves บทบาทนี้เห็นใบลาของผู้อื่นได้หรือไม่ — กฎเดียวกับ authorizeLeaveViewer
+func roleCanViewLeaves(ctx context.Context, authorizer ports.Authorizer, role domain.Role) (bool, error) {
+	for _, permission := range leaveReaderPermissions {
+		ok, err := authorizer.RoleHasPermission(ctx, role, permission)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+type leaveReadService struct {
+	requestRepo ports.LeaveRequestRepository
+	balanceRepo ports.LeaveBalanceRepository
+	userRepo    ports.UserRepository
+	authorizer  ports.Authorizer
+}
+
+func NewLeaveReadService(
+	requestRepo ports.LeaveRequestRepository,
+	balanceRepo ports.LeaveBalanceRepository,
+	userRepo ports.UserRepository,
+	authorizer ports.Authorizer,
+) ports.LeaveReadService {
+	return &leaveReadService{
+		requestRepo: requestRepo,
+		balanceRepo: balanceRepo,
+		userRepo:    userRepo,
+		authorizer:  authorizer,
+	}
+}
+
+// GetRequest ดูใบลาพร้อมผู้ยื่น ผู้อนุมัติ และผลต่อยอดวันลา — ผู้ที่ไม่มีสิทธิ์ได้ ErrRequestNotFound เหมือนใบลาที่ไม่มีอยู่
+func (s *leaveReadService) GetRequest(ctx context.Context, requestID, viewerID domain.ID) (*domain.LeaveRequestDetail, error) {
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if err = authorizeLeaveViewer(ctx, s.authorizer, request, viewerID); err != nil {
+		return nil, err
+	}
+
+	userIDs := []domain.ID{request.UserID}
+	if request.ReviewerID != nil {
+		userIDs = append(userIDs, *request.ReviewerID)
+	}
+	users, err := findUserSummaries(ctx, s.userRepo, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	detail := &domain.LeaveRequestDetail{LeaveRequestView: domain.NewLeaveRequestView(request, users)}
+
+	balance, err := s.findBalance(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	detail.BalanceImpact = domain.NewLeaveBalanceImpact(request, balance)
+	return detail, nil
+}
+
+// findUserSummaries ค้นข้อมูลย่อของผู้ใช้หลายคนใน query เดียว — ผู้ใช้ที่ถูกลบไปแล้วไม่อยู่ใน map
+// เพื่อให้ยังดูใบลาและประวัติเก่าได้
+func findUserSummaries(ctx context.Context, userRepo ports.UserRepository, ids []domain.ID) (map[domain.ID]domain.UserSummary, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	users, err := userRepo.FindByIDs(ctx, uniqueIDs(ids))
+	if err != nil {
+		return nil, fmt.Errorf("ดึงข้อมูลผู้ใช้ล้มเหลว: %w", err)
+	}
+	summaries := make(map[domain.ID]domain.UserSummary, len(users))
+	for i := range users {
+		summaries[users[i].ID] = users[i].Summary()
+	}
+	return summaries, nil
+}
+
+func uniqueIDs(ids []domain.ID) []domain.ID {
+	seen := make(map[domain.ID]bool, len(ids))
+	unique := make([]domain.ID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// findBalance ยอดวันลาของประเภทและปีที่ใบลานี้ใช้ — คืน nil ถ้ายังไม่มี
+func (s *leaveReadService) findBalance(ctx context.Context, request *domain.LeaveRequest) (*domain.LeaveBalance, error) {
+	balances, err := s.balanceRepo.FindByUserID(ctx, request.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงข้อมูลยอดวันลาล้มเหลว: %w", err)
+	}
+	year := request.StartDate.Year()
+	for i := range balances {
+		if balances[i].LeaveType == request.LeaveType && balances[i].Year == year {
+			return &balances[i], nil
+		}
+	}
+	return nil, nil
+}
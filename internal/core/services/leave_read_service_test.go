@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func newReadTestRequest() *domain.LeaveRequest {
+	return domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), "พักร้อนกับครอบครัว",
+	)
+}
+
+func newReadTestService(request *domain.LeaveRequest, authz *mockAuthorizer) *leaveReadService {
+	requestRepo := &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			return request, nil
+		},
+	}
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.User, error) {
+			return &domain.User{ID: id, FullName: "สมชาย ใจดี"}, nil
+		},
+	}
+	return NewLeaveReadService(requestRepo, &mockLeaveBalanceRepository{}, userRepo, authz).(*leaveReadService)
+}
+
+func TestLeaveReadService_GetRequest_Access(t *testing.T) {
+	request := newReadTestRequest()
+	allDenied := map[domain.Permission]bool{domain.PermissionLeaveViewTeam: true, domain.PermissionUserManage: true}
+
+	tests := []struct {
+		denied  map[domain.Permission]bool
+		name    string
+		viewer  domain.ID
+		wantErr error
+	}{
+		{name: "เจ้าของใบลา", viewer: request.UserID, denied: allDenied},
+		{name: "ผู้จัดการ", viewer: domain.NewID(), denied: map[domain.Permission]bool{domain.PermissionUserManage: true}},
+		{name: "ผู้ดูแลระบบ", viewer: domain.NewID(), denied: map[domain.Permission]bool{domain.PermissionLeaveViewTeam: true}},
+		{name: "พนักงานอื่นได้ 404", viewer: domain.NewID(), denied: allDenied, wantErr: domain.ErrRequestNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newReadTestService(request, &mockAuthorizer{denied: tt.denied})
+
+			detail, err := svc.GetRequest(context.Background(), request.ID, tt.viewer)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, detail)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, request.ID, detail.ID)
+		})
+	}
+}
+
+func TestLeaveReadService_GetRequest_Enriched(t *testing.T) {
+	request := newReadTestRequest()
+	reviewerID := domain.NewID()
+	require.NoError(t, request.Approve(reviewerID, "อนุมัติ"))
+
+	svc := newReadTestService(request, &mockAuthorizer{})
+	var lookups [][]domain.ID
+	svc.userRepo = &mockUserRepository{
+		findByIDsFn: func(_ context.Context, ids []domain.ID) ([]domain.User, error) {
+			lookups = append(lookups, ids)
+			return []domain.User{
+				{ID: request.UserID, FullName: "สมชาย ใจดี", Department: "วิศวกรรม"},
+				{ID: reviewerID, FullName: "หัวหน้า ทีม"},
+			}, nil
+		},
+	}
+	svc.balanceRepo = &mockLeaveBalanceRepository{
+		findByUserIDFn: func(_ context.Context, userID domain.ID) ([]domain.LeaveBalance, error) {
+			return []domain.LeaveBalance{
+				{UserID: userID, LeaveType: domain.LeaveTypeAnnual, Year: 2025, TotalDays: 10},
+				{UserID: userID, LeaveType: domain.LeaveTypeAnnual, Year: 2026, TotalDays: 10, UsedDays: 2},
+			}, nil
+		},
+	}
+
+	detail, err := svc.GetRequest(context.Background(), request.ID, request.UserID)
+
+	require.NoError(t, err)
+	require.NotNil(t, detail.Requester)
+	require.NotNil(t, detail.Reviewer)
+	assert.Equal(t, "สมชาย ใจดี", detail.Requester.FullName)
+	assert.Equal(t, "หัวหน้า ทีม", detail.Reviewer.FullName)
+	assert.Equal(t, "วิศวกรรม", detail.Requester.Department)
+	assert.Len(t, lookups, 1, "ผู้ยื่นและผู้อนุมัติต้องค้นใน query เดียว")
+	assert.Equal(t, domain.LeaveBalanceEffectDeducted, detail.BalanceImpact.Effect)
+	assert.InDelta(t, 2.0, detail.BalanceImpact.Days, 0.001)
+	require.NotNil(t, detail.BalanceImpact.Balance)
+	assert.Equal(t, 2026, detail.BalanceImpact.Balance.Year)
+	assert.InDelta(t, 8.0, detail.BalanceImpact.Balance.RemainingDays(), 0.001)
+}
+
+func TestLeaveReadService_GetRequest_DeletedRequester(t *testing.T) {
+	request := newReadTestRequest()
+	svc := newReadTestService(request, &mockAuthorizer{})
+	svc.userRepo = &mockUserRepository{
+		findByIDsFn: func(_ context.Context, _ []domain.ID) ([]domain.User, error) {
+			return []domain.User{}, nil
+		},
+	}
+
+	detail, err := svc.GetRequest(context.Background(), request.ID, request.UserID)
+
+	require.NoError(t, err)
+	assert.Nil(t, detail.Requester)
+	assert.Equal(t, domain.LeaveBalanceEffectReserved, detail.BalanceImpact.Effect)
+	assert.Nil(t, detail.BalanceImpact.Balance)
+}
@@ -9,18 +9,42 @@ import (
 	"github/be2bag/leave-management-system/internal/core/ports"
 )
 
+// การเรียงเริ่มต้นของรายการใบลา
+var (
+	newestFirst = domain.LeaveSort{Field: domain.LeaveSortCreatedAt, Descending: true} // ประวัติและผลค้นหา
+	oldestFirst = domain.LeaveSort{Field: domain.LeaveSortCreatedAt}                   // รายการรออนุมัติ (มาก่อนได้ก่อน)
+)
+
 type leaveService struct {
 	requestRepo ports.LeaveRequestRepository
+	historyRepo ports.LeaveHistoryRepository
 	balanceRepo ports.LeaveBalanceRepository
+	userRepo    ports.UserRepository
+	authorizer  ports.Authorizer
+	audit       ports.AuditLogger
+	outboxRepo  ports.OutboxRepository
+	tx          ports.TransactionManager
 }
 
 func NewLeaveService(
 	requestRepo ports.LeaveRequestRepository,
+	historyRepo ports.LeaveHistoryRepository,
 	balanceRepo ports.LeaveBalanceRepository,
+	userRepo ports.UserRepository,
+	authorizer ports.Authorizer,
+	audit ports.AuditLogger,
+	outboxRepo ports.OutboxRepository,
+	tx ports.TransactionManager,
 ) ports.LeaveService {
 	return &leaveService{
 		requestRepo: requestRepo,
+		historyRepo: historyRepo,
 		balanceRepo: balanceRepo,
+		userRepo:    userRepo,
+		authorizer:  authorizer,
+		audit:       audit,
+		outboxRepo:  outboxRepo,
+		tx:          tx,
 	}
 }
 
@@ -41,24 +65,52 @@ func (s *leaveService) Submit(
 	}
 
 	request := domain.NewLeaveRequest(userID, leaveType, startDate, endDate, reason)
-
-	if err := s.checkOverlap(ctx, userID, startDate, endDate); err != nil {
+	if err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.submit(ctx, request)
+	}); err != nil {
 		return nil, err
 	}
+	return request, nil
+}
+
+// submit จองวันลา บันทึกใบลา ประวัติ audit log และเหตุการณ์ leave.submitted — เรียกภายใน transaction
+func (s *leaveService) submit(ctx context.Context, request *domain.LeaveRequest) error {
+	userID, year := request.UserID, request.StartDate.Year()
+	if err := s.checkOverlap(ctx, userID, request.StartDate, request.EndDate); err != nil {
+		return err
+	}
 
-	if err := s.balanceRepo.ReservePending(ctx, userID, leaveType, startDate.Year(), request.TotalDays); err != nil {
-		return nil, err
+	if err := s.balanceRepo.ReservePending(ctx, userID, request.LeaveType, year, request.TotalDays); err != nil {
+		return err
 	}
 
 	if err := s.requestRepo.Create(ctx, request); err != nil {
-		// Rollback: ปล่อยวันลาที่จองไว้กลับ
-		if rbErr := s.balanceRepo.ReleasePending(ctx, userID, leaveType, startDate.Year(), request.TotalDays); rbErr != nil {
-			return nil, fmt.Errorf("บันทึกใบลาล้มเหลว: %w (rollback ล้มเหลว: %v)", err, rbErr)
+		// Rollback: ปล่อยวันลาที่จองไว้กลับ (จำเป็นเมื่อไม่มี transaction)
+		if rbErr := s.balanceRepo.ReleasePending(ctx, userID, request.LeaveType, year, request.TotalDays); rbErr != nil {
+			return fmt.Errorf("บันทึกใบลาล้มเหลว: %w (rollback ล้มเหลว: %v)", err, rbErr)
 		}
-		return nil, fmt.Errorf("บันทึกใบลาล้มเหลว: %w", err)
+		return fmt.Errorf("บันทึกใบลาล้มเหลว: %w", err)
 	}
 
-	return request, nil
+	created := domain.NewLeaveHistoryEntry(domain.LeaveHistoryCreated, &userID, "", request, nil, "")
+	if err := s.historyRepo.Append(ctx, created); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(
+		domain.AuditLeaveSubmitted, domain.AuditTargetLeaveRequest, request.ID.String(),
+		domain.DiffChanges(nil, request.AuditSnapshot()),
+	).By(userID)
+	if err := recordAudit(ctx, s.audit, event); err != nil {
+		return err
+	}
+
+	return s.publish(ctx, domain.NewLeaveEvent(domain.LeaveEventSubmitted, request, userID))
+}
+
+// publish บันทึกเหตุการณ์ลง outbox ด้วย ctx เดียวกับการเปลี่ยนแปลง — dispatcher ส่งต่อให้ handler หลัง commit
+func (s *leaveService) publish(ctx context.Context, event domain.LeaveEvent) error {
+	return s.outboxRepo.Append(ctx, domain.NewOutboxMessage(event))
 }
 
 // checkOverlap ตรวจสอบว่าวันลาซ้ำซ้อนกับใบลาอื่นหรือไม่
@@ -73,13 +125,23 @@ func (s *leaveService) checkOverlap(ctx context.Context, userID domain.ID, start
 	return nil
 }
 
-// GetMyRequests ดูประวัติใบลาทั้งหมดของพนักงาน (รองรับ pagination)
+// GetMyRequests ดูประวัติใบลาของพนักงานตามเงื่อนไข — ค่าเริ่มต้นเรียงจากใหม่สุด (รองรับ pagination)
 func (s *leaveService) GetMyRequests(
 	ctx context.Context,
 	userID domain.ID,
+	query domain.LeaveRequestQuery,
 	params domain.PaginationParams,
-) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-	result, err := s.requestRepo.FindByUserID(ctx, userID, params)
+) (*domain.PaginatedResult[domain.LeaveRequestView], error) {
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	query.UserID = &userID // เห็นเฉพาะใบลาของตนเองเสมอ
+	query = query.WithDefaultSort(newestFirst)
+	if err := query.ValidatePagination(params); err != nil {
+		return nil, err
+	}
+
+	result, err := s.requestRepo.SearchWithUsers(ctx, query, params)
 	if err != nil {
 		return nil, fmt.Errorf("ดึงข้อมูลใบลาล้มเหลว: %w", err)
 	}
@@ -95,20 +157,185 @@ func (s *leaveService) GetMyBalance(ctx context.Context, userID domain.ID) ([]do
 	return balances, nil
 }
 
-// GetPendingRequests ดูใบลาที่รอการอนุมัติ (สำหรับผู้จัดการ, รองรับ pagination)
+// GetPendingRequests ดูใบลาที่รอการอนุมัติ (ต้องมีสิทธิ์ leave.view_team, รองรับ pagination)
 func (s *leaveService) GetPendingRequests(
 	ctx context.Context,
+	viewerID domain.ID,
 	params domain.PaginationParams,
-) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-	result, err := s.requestRepo.FindByStatus(ctx, domain.LeaveStatusPending, params)
+) (*domain.PaginatedResult[domain.LeaveRequestView], error) {
+	if err := s.authorizer.Authorize(ctx, viewerID, domain.PermissionLeaveViewTeam); err != nil {
+		return nil, err
+	}
+
+	query := domain.LeaveRequestQuery{Statuses: []domain.LeaveStatus{domain.LeaveStatusPending}, Sort: []domain.LeaveSort{oldestFirst}}
+	if err := query.ValidatePagination(params); err != nil {
+		return nil, err
+	}
+	result, err := s.requestRepo.SearchWithUsers(ctx, query, params)
 	if err != nil {
 		return nil, fmt.Errorf("ดึงข้อมูลใบลารอการอนุมัติล้มเหลว: %w", err)
 	}
 	return result, nil
 }
 
+// SearchRequests ค้นหาใบลาของทุกคนตามเงื่อนไข (ต้องมีสิทธิ์ leave.view_team) — ค่าเริ่มต้นเรียงจากใหม่สุด
+func (s *leaveService) SearchRequests(
+	ctx context.Context,
+	viewerID domain.ID,
+	query domain.LeaveRequestQuery,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.LeaveRequestView], error) {
+	if err := s.authorizer.Authorize(ctx, viewerID, domain.PermissionLeaveViewTeam); err != nil {
+		return nil, err
+	}
+	if err := query.Validate(); err != nil {
+		return nil, err
+	}
+	query = query.WithDefaultSort(newestFirst)
+	if err := query.ValidatePagination(params); err != nil {
+		return nil, err
+	}
+
+	result, err := s.requestRepo.SearchWithUsers(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาใบลาล้มเหลว: %w", err)
+	}
+	return result, nil
+}
+
+// GetRequestsByStatus ดูใบลาทั้งหมดตามสถานะ (รองรับ pagination)
+func (s *leaveService) GetRequestsByStatus(
+	ctx context.Context,
+	status domain.LeaveStatus,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+	if !status.IsValid() {
+		return nil, domain.ErrInvalidLeaveStatus
+	}
+
+	query := domain.LeaveRequestQuery{Statuses: []domain.LeaveStatus{status}, Sort: []domain.LeaveSort{oldestFirst}}
+	if err := query.ValidatePagination(params); err != nil {
+		return nil, err
+	}
+	result, err := s.requestRepo.Search(ctx, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงข้อมูลใบลาล้มเหลว: %w", err)
+	}
+	return result, nil
+}
+
+// AdjustEntitlement ผู้ใช้ที่มีสิทธิ์ balance.adjust กำหนดจำนวนวันลาที่พนักงานได้รับ
+func (s *leaveService) AdjustEntitlement(
+	ctx context.Context,
+	actorID, userID domain.ID,
+	leaveType domain.LeaveType,
+	year int,
+	totalDays float64,
+) (*domain.LeaveBalance, error) {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionBalanceAdjust); err != nil {
+		return nil, err
+	}
+	return s.SetEntitlement(ctx, userID, leaveType, year, totalDays)
+}
+
+// SetEntitlement กำหนดจำนวนวันลาที่พนักงานได้รับ — ต้องไม่น้อยกว่าวันที่ใช้และจองไว้แล้ว
+func (s *leaveService) SetEntitlement(
+	ctx context.Context,
+	userID domain.ID,
+	leaveType domain.LeaveType,
+	year int,
+	totalDays float64,
+) (*domain.LeaveBalance, error) {
+	if !leaveType.IsValid() {
+		return nil, domain.ErrInvalidLeaveType
+	}
+	if totalDays < 0 {
+		return nil, domain.ErrEntitlementBelowUsage
+	}
+	// ไม่สร้างยอดวันลาให้ผู้ใช้ที่ไม่มีอยู่ในระบบ
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	var balance *domain.LeaveBalance
+	err := s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		balance, err = s.setEntitlement(ctx, userID, leaveType, year, totalDays)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// setEntitlement ปรับยอดวันลา บันทึก audit log และเหตุการณ์ balance.adjusted — เรียกภายใน transaction
+func (s *leaveService) setEntitlement(
+	ctx context.Context,
+	userID domain.ID,
+	leaveType domain.LeaveType,
+	year int,
+	totalDays float64,
+) (*domain.LeaveBalance, error) {
+	before, err := s.findBalance(ctx, userID, leaveType, year)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.balanceRepo.SetTotalDays(ctx, userID, leaveType, year, totalDays)
+	if err != nil {
+		return nil, err
+	}
+
+	// ผู้กระทำมาจาก request — ผู้ใช้ที่มีสิทธิ์ balance.adjust หรือ service account
+	event := domain.NewAuditEvent(
+		domain.AuditBalanceAdjusted, domain.AuditTargetLeaveBalance, balance.ID.String(),
+		domain.DiffChanges(before.AuditSnapshot(), balance.AuditSnapshot()),
+	)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, err
+	}
+
+	if err = s.publish(ctx, domain.NewBalanceAdjustedEvent(balance, requestActorID(ctx))); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// requestActorID ผู้เรียกจาก RequestMeta — ค่าว่างเมื่อไม่ได้เรียกผ่าน HTTP request (เช่น seed)
+func requestActorID(ctx context.Context) domain.ID {
+	if meta := domain.RequestMetaFromContext(ctx); meta != nil && meta.ActorID != nil {
+		return *meta.ActorID
+	}
+	return domain.ID{}
+}
+
+// findBalance ค้นหายอดวันลาของประเภทและปีที่ระบุ — คืน nil ถ้ายังไม่มี
+func (s *leaveService) findBalance(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int) (*domain.LeaveBalance, error) {
+	balances, err := s.balanceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงข้อมูลยอดวันลาล้มเหลว: %w", err)
+	}
+	for i := range balances {
+		if balances[i].LeaveType == leaveType && balances[i].Year == year {
+			return &balances[i], nil
+		}
+	}
+	return nil, nil
+}
+
 // Approve อนุมัติใบลา — ย้ายวันลาจาก pending ไป used แบบ atomic
 func (s *leaveService) Approve(ctx context.Context, requestID, reviewerID domain.ID, note string) error {
+	if err := s.authorizer.Authorize(ctx, reviewerID, domain.PermissionLeaveApprove); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.approve(ctx, requestID, reviewerID, note)
+	})
+}
+
+// approve อนุมัติใบลาภายใน transaction
+func (s *leaveService) approve(ctx context.Context, requestID, reviewerID domain.ID, note string) error {
 	request, err := s.requestRepo.FindByID(ctx, requestID)
 	if err != nil {
 		return err
@@ -118,6 +345,7 @@ func (s *leaveService) Approve(ctx context.Context, requestID, reviewerID domain
 		return domain.ErrSelfApproval
 	}
 
+	before := request.AuditSnapshot()
 	if err := request.Approve(reviewerID, note); err != nil {
 		return err
 	}
@@ -137,11 +365,25 @@ func (s *leaveService) Approve(ctx context.Context, requestID, reviewerID domain
 		return err
 	}
 
-	return nil
+	if err := s.recordReview(ctx, domain.LeaveHistoryApproved, domain.AuditLeaveApproved, request, reviewerID, before); err != nil {
+		return err
+	}
+
+	return s.publish(ctx, domain.NewLeaveEvent(domain.LeaveEventApproved, request, reviewerID))
 }
 
 // Reject ปฏิเสธใบลา — ปล่อยวันลาที่จองไว้กลับคืน แบบ atomic
 func (s *leaveService) Reject(ctx context.Context, requestID, reviewerID domain.ID, note string) error {
+	if err := s.authorizer.Authorize(ctx, reviewerID, domain.PermissionLeaveApprove); err != nil {
+		return err
+	}
+	return s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.reject(ctx, requestID, reviewerID, note)
+	})
+}
+
+// reject ปฏิเสธใบลาภายใน transaction
+func (s *leaveService) reject(ctx context.Context, requestID, reviewerID domain.ID, note string) error {
 	request, err := s.requestRepo.FindByID(ctx, requestID)
 	if err != nil {
 		return err
@@ -150,6 +392,7 @@ func (s *leaveService) Reject(ctx context.Context, requestID, reviewerID domain.
 		return domain.ErrSelfApproval
 	}
 
+	before := request.AuditSnapshot()
 	if err := request.Reject(reviewerID, note); err != nil {
 		return err
 	}
@@ -169,14 +412,93 @@ func (s *leaveService) Reject(ctx context.Context, requestID, reviewerID domain.
 		return err
 	}
 
-	return nil
+	if err := s.recordReview(ctx, domain.LeaveHistoryRejected, domain.AuditLeaveRejected, request, reviewerID, before); err != nil {
+		return err
+	}
+
+	return s.publish(ctx, domain.NewLeaveEvent(domain.LeaveEventRejected, request, reviewerID))
+}
+
+// recordReview บันทึกการอนุมัติหรือปฏิเสธใบลาลงประวัติใบลาและ audit log พร้อมค่าที่เปลี่ยน
+func (s *leaveService) recordReview(
+	ctx context.Context,
+	historyAction domain.LeaveHistoryAction,
+	action domain.AuditAction,
+	request *domain.LeaveRequest,
+	reviewerID domain.ID,
+	before map[string]any,
+) error {
+	entry := domain.NewLeaveHistoryEntry(
+		historyAction, &reviewerID, domain.LeaveStatusPending, request, before, request.ReviewNote,
+	)
+	if err := s.historyRepo.Append(ctx, entry); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(
+		action, domain.AuditTargetLeaveRequest, request.ID.String(),
+		domain.DiffChanges(before, request.AuditSnapshot()),
+	).By(reviewerID)
+	return recordAudit(ctx, s.audit, event)
 }
 
 // rollbackRequestStatus คืนสถานะใบลากลับเป็น pending เมื่อ balance update ล้มเหลว
+// — ผู้อนุมัติและหมายเหตุที่ถูกล้างยังคงอยู่ในประวัติใบลา
 func (s *leaveService) rollbackRequestStatus(ctx context.Context, request *domain.LeaveRequest) error {
+	before := request.AuditSnapshot()
+	fromStatus := request.Status
+
 	request.Status = domain.LeaveStatusPending
 	request.ReviewerID = nil
 	request.ReviewNote = ""
 	request.ReviewedAt = nil
-	return s.requestRepo.Update(ctx, request)
+	if err := s.requestRepo.Update(ctx, request); err != nil {
+		return err
+	}
+
+	entry := domain.NewLeaveHistoryEntry(
+		domain.LeaveHistoryRolledBack, nil, fromStatus, request, before,
+		"ปรับยอดวันลาไม่สำเร็จ ระบบคืนสถานะใบลาเป็นรอดำเนินการ",
+	)
+	return s.historyRepo.Append(ctx, entry)
+}
+
+// GetHistory ดูประวัติของใบลาพร้อมผู้กระทำ — เฉพาะผู้ที่เห็นใบลาได้ (authorizeLeaveViewer)
+func (s *leaveService) GetHistory(ctx context.Context, requestID, viewerID domain.ID) ([]domain.LeaveHistoryView, error) {
+	request, err := s.requestRepo.FindByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = authorizeLeaveViewer(ctx, s.authorizer, request, viewerID); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.historyRepo.FindByRequestID(ctx, requestID)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงประวัติใบลาล้มเหลว: %w", err)
+	}
+
+	actorIDs := make([]domain.ID, 0, len(entries))
+	for i := range entries {
+		if entries[i].ActorID != nil {
+			actorIDs = append(actorIDs, *entries[i].ActorID)
+		}
+	}
+	actors, err := findUserSummaries(ctx, s.userRepo, actorIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]domain.LeaveHistoryView, 0, len(entries))
+	for i := range entries {
+		view := domain.LeaveHistoryView{LeaveHistoryEntry: entries[i]}
+		if entries[i].ActorID != nil {
+			if actor, ok := actors[*entries[i].ActorID]; ok {
+				view.Actor = &actor
+			}
+		}
+		views = append(views, view)
+	}
+	return views, nil
 }
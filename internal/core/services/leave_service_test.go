@@ -26,7 +26,7 @@ func TestLeaveService_Submit_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, balanceRepo)
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	startDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
@@ -41,8 +41,28 @@ func TestLeaveService_Submit_Success(t *testing.T) {
 	assert.Equal(t, float64(3), request.TotalDays)
 }
 
+func TestLeaveService_Submit_OutboxFailureFailsTransaction(t *testing.T) {
+	requestRepo := &mockLeaveRequestRepository{
+		hasOverlapFn: func(_ context.Context, _ domain.ID, _, _ time.Time, _ *domain.ID) (bool, error) {
+			return false, nil
+		},
+	}
+	balanceRepo := &mockLeaveBalanceRepository{
+		reservePendingFn: func(_ context.Context, _ domain.ID, _ domain.LeaveType, _ int, _ float64) error {
+			return nil
+		},
+	}
+	outboxErr := fmt.Errorf("write conflict")
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{err: outboxErr}, &mockTransactionManager{})
+
+	startDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	_, err := svc.Submit(context.Background(), domain.NewID(), domain.LeaveTypeSick, startDate, startDate, "ไม่สบาย")
+
+	assert.ErrorIs(t, err, outboxErr, "บันทึกเหตุการณ์ไม่สำเร็จต้องคืน error เพื่อให้ transaction ถูกยกเลิก")
+}
+
 func TestLeaveService_Submit_InvalidLeaveType(t *testing.T) {
-	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	startDate := time.Now()
 	endDate := startDate.Add(24 * time.Hour)
@@ -53,7 +73,7 @@ func TestLeaveService_Submit_InvalidLeaveType(t *testing.T) {
 }
 
 func TestLeaveService_Submit_InvalidDateRange(t *testing.T) {
-	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	startDate := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC) // วันสิ้นสุดก่อนวันเริ่มต้น
@@ -70,7 +90,7 @@ func TestLeaveService_Submit_OverlappingLeave(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	startDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC)
@@ -93,7 +113,7 @@ func TestLeaveService_Submit_InsufficientBalance(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, balanceRepo)
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	startDate := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
 	endDate := time.Date(2026, 3, 12, 0, 0, 0, 0, time.UTC) // 3 วัน
@@ -135,13 +155,30 @@ func TestLeaveService_Approve_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, balanceRepo)
+	audit := &mockAuditLogger{}
+	outbox := &mockOutboxRepository{}
+	tx := &mockTransactionManager{}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, audit, outbox, tx)
 
 	err := svc.Approve(context.Background(), request.ID, managerID, "อนุมัติ")
 
 	require.NoError(t, err)
 	assert.Equal(t, domain.LeaveStatusApproved, updatedRequest.Status)
 	assert.Equal(t, "อนุมัติ", updatedRequest.ReviewNote)
+
+	require.Len(t, audit.events, 1)
+	event := audit.events[0]
+	assert.Equal(t, domain.AuditLeaveApproved, event.Action)
+	assert.Equal(t, request.ID.String(), event.TargetID)
+	assert.Equal(t, managerID, *event.ActorID)
+	assert.Contains(t, event.Changes, domain.AuditChange{Field: "status", Before: "pending", After: "approved"})
+	assert.Contains(t, event.Changes, domain.AuditChange{Field: "reviewer_id", Before: "", After: managerID.String()})
+
+	assert.Equal(t, 1, tx.calls, "การอนุมัติทั้งหมดต้องอยู่ใน transaction เดียว")
+	require.Len(t, outbox.events(), 1)
+	assert.Equal(t, domain.LeaveEventApproved, outbox.events()[0].Type)
+	assert.Equal(t, managerID, outbox.events()[0].ActorID)
+	assert.Equal(t, outbox.events()[0].ID, outbox.messages[0].ID, "รหัสข้อความใน outbox คือรหัสเหตุการณ์")
 }
 
 func TestLeaveService_Approve_AlreadyProcessed(t *testing.T) {
@@ -165,7 +202,7 @@ func TestLeaveService_Approve_AlreadyProcessed(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	err := svc.Approve(context.Background(), request.ID, managerID, "อนุมัติ")
 
@@ -188,7 +225,7 @@ func TestLeaveService_Approve_SelfApproval(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	err := svc.Approve(context.Background(), request.ID, userID, "อนุมัติ")
 
@@ -216,7 +253,7 @@ func TestLeaveService_Approve_NotPending(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	err := svc.Approve(context.Background(), request.ID, reviewerID, "อนุมัติอีกครั้ง")
 
@@ -246,7 +283,7 @@ func TestLeaveService_Reject_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	err := svc.Reject(context.Background(), request.ID, managerID, "ช่วงเวลานี้มีงานเร่งด่วน")
 
@@ -268,14 +305,16 @@ func TestLeaveService_GetMyRequests_Success(t *testing.T) {
 	}
 
 	requestRepo := &mockLeaveRequestRepository{
-		findByUserIDFn: func(_ context.Context, _ domain.ID, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+		searchFn: func(_ context.Context, q domain.LeaveRequestQuery, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			assert.Equal(t, &userID, q.UserID, "ต้องค้นหาเฉพาะใบลาของตนเอง")
+			assert.Equal(t, []domain.LeaveSort{{Field: domain.LeaveSortCreatedAt, Descending: true}}, q.Sort, "ค่าเริ่มต้นเรียงจากใหม่สุด")
 			return domain.NewPaginatedResult(expected, 2, p), nil
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
-	result, err := svc.GetMyRequests(context.Background(), userID, params)
+	result, err := svc.GetMyRequests(context.Background(), userID, domain.LeaveRequestQuery{}, params)
 
 	require.NoError(t, err)
 	assert.Len(t, result.Items, 2)
@@ -299,7 +338,7 @@ func TestLeaveService_GetMyBalance_Success(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(&mockLeaveRequestRepository{}, balanceRepo)
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	balances, err := svc.GetMyBalance(context.Background(), userID)
 
@@ -316,15 +355,15 @@ func TestLeaveService_GetPendingRequests_Success(t *testing.T) {
 	}
 
 	requestRepo := &mockLeaveRequestRepository{
-		findByStatusFn: func(_ context.Context, status domain.LeaveStatus, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-			assert.Equal(t, domain.LeaveStatusPending, status)
+		searchFn: func(_ context.Context, q domain.LeaveRequestQuery, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			assert.Equal(t, []domain.LeaveStatus{domain.LeaveStatusPending}, q.Statuses)
 			return domain.NewPaginatedResult(expected, 1, p), nil
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
-	result, err := svc.GetPendingRequests(context.Background(), params)
+	result, err := svc.GetPendingRequests(context.Background(), domain.NewID(), params)
 
 	require.NoError(t, err)
 	assert.Len(t, result.Items, 1)
@@ -348,7 +387,7 @@ func TestLeaveService_Reject_SelfRejection(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, &mockLeaveBalanceRepository{})
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	err := svc.Reject(context.Background(), request.ID, userID, "note")
 
@@ -381,7 +420,7 @@ func TestLeaveService_Submit_DoubleSubmit_SecondRequestFails(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, balanceRepo)
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	// request แรก — สำเร็จ
 	req1, err := svc.Submit(context.Background(), userID, domain.LeaveTypeSick,
@@ -431,7 +470,7 @@ func TestLeaveService_Reject_ReleasesPendingDays(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, balanceRepo)
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	err := svc.Reject(context.Background(), request.ID, managerID, "ไม่อนุมัติ")
 
@@ -461,7 +500,7 @@ func TestLeaveService_Submit_RollbackOnCreateFailure(t *testing.T) {
 		},
 	}
 
-	svc := NewLeaveService(requestRepo, balanceRepo)
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
 
 	_, err := svc.Submit(context.Background(), domain.NewID(), domain.LeaveTypeSick,
 		time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
@@ -472,3 +511,339 @@ func TestLeaveService_Submit_RollbackOnCreateFailure(t *testing.T) {
 	assert.Error(t, err)
 	assert.True(t, released, "ต้อง rollback pending reservation เมื่อบันทึกใบลาล้มเหลว")
 }
+
+func TestLeaveService_GetRequestsByStatus_InvalidStatus(t *testing.T) {
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.GetRequestsByStatus(context.Background(), domain.LeaveStatus("archived"), domain.NewPaginationParams(1, 10))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidLeaveStatus)
+}
+
+func TestLeaveService_SearchRequests_PassesQuery(t *testing.T) {
+	reviewerID := domain.NewID()
+	query := domain.LeaveRequestQuery{
+		ReviewerID: &reviewerID,
+		Statuses:   []domain.LeaveStatus{domain.LeaveStatusApproved, domain.LeaveStatusRejected},
+		Search:     "ไม่สบาย",
+		Sort:       []domain.LeaveSort{{Field: domain.LeaveSortStartDate}},
+	}
+	var got domain.LeaveRequestQuery
+	requestRepo := &mockLeaveRequestRepository{
+		searchFn: func(_ context.Context, q domain.LeaveRequestQuery, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			got = q
+			return domain.NewPaginatedResult([]domain.LeaveRequest{}, 0, p), nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.SearchRequests(context.Background(), domain.NewID(), query, domain.NewPaginationParams(1, 10))
+
+	require.NoError(t, err)
+	assert.Equal(t, query, got, "การเรียงที่ผู้เรียกระบุต้องไม่ถูกแทนด้วยค่าเริ่มต้น")
+}
+
+func TestLeaveService_SearchRequests_InvalidQuery(t *testing.T) {
+	requestRepo := &mockLeaveRequestRepository{
+		searchFn: func(_ context.Context, _ domain.LeaveRequestQuery, _ domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			t.Fatal("ห้ามค้นหาเมื่อเงื่อนไขไม่ถูกต้อง")
+			return nil, nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.SearchRequests(context.Background(), domain.NewID(),
+		domain.LeaveRequestQuery{Statuses: []domain.LeaveStatus{"archived"}}, domain.NewPaginationParams(1, 10))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidLeaveQuery)
+}
+
+func TestLeaveService_GetMyRequests_CannotQueryOtherUsers(t *testing.T) {
+	userID, otherID := domain.NewID(), domain.NewID()
+	requestRepo := &mockLeaveRequestRepository{
+		searchFn: func(_ context.Context, q domain.LeaveRequestQuery, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			assert.Equal(t, &userID, q.UserID)
+			return domain.NewPaginatedResult([]domain.LeaveRequest{}, 0, p), nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.GetMyRequests(context.Background(), userID, domain.LeaveRequestQuery{UserID: &otherID}, domain.NewPaginationParams(1, 10))
+
+	require.NoError(t, err)
+}
+
+func TestLeaveService_GetMyRequests_CursorRequiresCreatedAtSort(t *testing.T) {
+	requestRepo := &mockLeaveRequestRepository{
+		searchFn: func(_ context.Context, _ domain.LeaveRequestQuery, _ domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			t.Fatal("ห้ามค้นหาเมื่อแบ่งหน้าแบบ cursor กับการเรียงที่ไม่รองรับ")
+			return nil, nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+	query := domain.LeaveRequestQuery{Sort: []domain.LeaveSort{{Field: domain.LeaveSortStartDate}}}
+
+	_, err := svc.GetMyRequests(context.Background(), domain.NewID(), query, domain.NewCursorParams(nil, 10, false))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestLeaveService_GetPendingRequests_Cursor(t *testing.T) {
+	after := &domain.Cursor{CreatedAt: time.Now(), ID: domain.NewID()}
+	requestRepo := &mockLeaveRequestRepository{
+		searchFn: func(_ context.Context, _ domain.LeaveRequestQuery, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error) {
+			assert.True(t, p.Keyset)
+			assert.Equal(t, after, p.After)
+			return domain.NewCursorResult([]domain.LeaveRequest{}, nil, nil, p), nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	result, err := svc.GetPendingRequests(context.Background(), domain.NewID(), domain.NewCursorParams(after, 10, false))
+	require.NoError(t, err)
+	assert.False(t, result.HasMore())
+
+	// cursor จากรายการที่เรียงใหม่สุดก่อนใช้กับรายการรออนุมัติ (เก่าสุดก่อน) ไม่ได้
+	after.Descending = true
+	_, err = svc.GetPendingRequests(context.Background(), domain.NewID(), domain.NewCursorParams(after, 10, false))
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestLeaveService_SetEntitlement_Success(t *testing.T) {
+	userID := domain.NewID()
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.User, error) {
+			return &domain.User{ID: id}, nil
+		},
+	}
+	var setDays float64
+	balanceRepo := &mockLeaveBalanceRepository{
+		setTotalDaysFn: func(_ context.Context, id domain.ID, leaveType domain.LeaveType, year int, totalDays float64) (*domain.LeaveBalance, error) {
+			setDays = totalDays
+			return domain.NewLeaveBalance(id, leaveType, totalDays, year), nil
+		},
+	}
+	outbox := &mockOutboxRepository{}
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, balanceRepo, userRepo, &mockAuthorizer{}, &mockAuditLogger{}, outbox, &mockTransactionManager{})
+
+	balance, err := svc.SetEntitlement(context.Background(), userID, domain.LeaveTypeAnnual, 2026, 12)
+
+	require.NoError(t, err)
+	assert.Equal(t, float64(12), setDays)
+	assert.Equal(t, userID, balance.UserID)
+	require.Len(t, outbox.events(), 1)
+	assert.Equal(t, domain.LeaveEventBalanceAdjusted, outbox.events()[0].Type)
+	assert.Equal(t, userID, outbox.events()[0].Balance.UserID)
+}
+
+func TestLeaveService_SetEntitlement_UnknownUser(t *testing.T) {
+	balanceRepo := &mockLeaveBalanceRepository{
+		setTotalDaysFn: func(_ context.Context, _ domain.ID, _ domain.LeaveType, _ int, _ float64) (*domain.LeaveBalance, error) {
+			t.Fatal("ห้ามสร้างยอดวันลาให้ผู้ใช้ที่ไม่มีในระบบ")
+			return nil, nil
+		},
+	}
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.SetEntitlement(context.Background(), domain.NewID(), domain.LeaveTypeAnnual, 2026, 12)
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
+
+// ─── Permission Tests ───────────────────────────────────────────────────
+
+func TestLeaveService_Approve_PermissionDenied(t *testing.T) {
+	requestRepo := &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			t.Fatal("ห้ามอ่านใบลาก่อนตรวจสิทธิ์")
+			return nil, nil
+		},
+	}
+	authz := &mockAuthorizer{denied: map[domain.Permission]bool{domain.PermissionLeaveApprove: true}}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, authz, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	err := svc.Approve(context.Background(), domain.NewID(), domain.NewID(), "ok")
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestLeaveService_GetPendingRequests_PermissionDenied(t *testing.T) {
+	authz := &mockAuthorizer{denied: map[domain.Permission]bool{domain.PermissionLeaveViewTeam: true}}
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, authz, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.GetPendingRequests(context.Background(), domain.NewID(), domain.NewPaginationParams(1, 10))
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestLeaveService_SearchRequests_PermissionDenied(t *testing.T) {
+	authz := &mockAuthorizer{denied: map[domain.Permission]bool{domain.PermissionLeaveViewTeam: true}}
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, authz, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.SearchRequests(context.Background(), domain.NewID(), domain.LeaveRequestQuery{}, domain.NewPaginationParams(1, 10))
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestLeaveService_AdjustEntitlement_PermissionDenied(t *testing.T) {
+	balanceRepo := &mockLeaveBalanceRepository{
+		setTotalDaysFn: func(_ context.Context, _ domain.ID, _ domain.LeaveType, _ int, _ float64) (*domain.LeaveBalance, error) {
+			t.Fatal("ห้ามแก้ยอดวันลาเมื่อไม่มีสิทธิ์ balance.adjust")
+			return nil, nil
+		},
+	}
+	authz := &mockAuthorizer{denied: map[domain.Permission]bool{domain.PermissionBalanceAdjust: true}}
+	svc := NewLeaveService(&mockLeaveRequestRepository{}, &mockLeaveHistoryRepository{}, balanceRepo, &mockUserRepository{}, authz, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.AdjustEntitlement(context.Background(), domain.NewID(), domain.NewID(), domain.LeaveTypeAnnual, 2026, 12)
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+// ─── History Tests ──────────────────────────────────────────────────────
+
+func TestLeaveService_History_SubmitThenApprove(t *testing.T) {
+	employeeID := domain.NewID()
+	managerID := domain.NewID()
+
+	var stored *domain.LeaveRequest
+	requestRepo := &mockLeaveRequestRepository{
+		createFn: func(_ context.Context, r *domain.LeaveRequest) error {
+			stored = r
+			return nil
+		},
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			return stored, nil
+		},
+	}
+	history := &mockLeaveHistoryRepository{}
+	svc := NewLeaveService(requestRepo, history, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	request, err := svc.Submit(context.Background(), employeeID, domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), "พักผ่อน")
+	require.NoError(t, err)
+	require.NoError(t, svc.Approve(context.Background(), request.ID, managerID, "เที่ยวให้สนุก"))
+
+	entries, err := svc.GetHistory(context.Background(), request.ID, employeeID)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, domain.LeaveHistoryCreated, entries[0].Action)
+	assert.Equal(t, domain.LeaveStatusPending, entries[0].ToStatus)
+	assert.Equal(t, domain.LeaveHistoryApproved, entries[1].Action)
+	assert.Equal(t, domain.LeaveStatusPending, entries[1].FromStatus)
+	assert.Equal(t, domain.LeaveStatusApproved, entries[1].ToStatus)
+	assert.Equal(t, managerID, *entries[1].ActorID)
+	assert.Equal(t, "เที่ยวให้สนุก", entries[1].Note)
+}
+
+func TestLeaveService_Approve_RollbackKeepsReviewInHistory(t *testing.T) {
+	managerID := domain.NewID()
+	request := domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), "พักผ่อน",
+	)
+	requestRepo := &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			return request, nil
+		},
+	}
+	balanceRepo := &mockLeaveBalanceRepository{
+		confirmPendingFn: func(_ context.Context, _ domain.ID, _ domain.LeaveType, _ int, _ float64) error {
+			return domain.ErrInsufficientBalance
+		},
+	}
+	history := &mockLeaveHistoryRepository{}
+	outbox := &mockOutboxRepository{}
+	svc := NewLeaveService(requestRepo, history, balanceRepo, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, outbox, &mockTransactionManager{})
+
+	err := svc.Approve(context.Background(), request.ID, managerID, "อนุมัติ")
+
+	require.ErrorIs(t, err, domain.ErrInsufficientBalance)
+	assert.Empty(t, outbox.events(), "ใบลาที่ rollback แล้วต้องไม่แจ้งว่าอนุมัติ")
+	assert.Equal(t, domain.LeaveStatusPending, request.Status)
+	assert.Nil(t, request.ReviewerID)
+
+	// ผู้อนุมัติและหมายเหตุที่ถูกล้างยังอยู่ในประวัติ
+	require.Equal(t, []domain.LeaveHistoryAction{domain.LeaveHistoryRolledBack}, history.actions())
+	entry := history.entries[0]
+	assert.Nil(t, entry.ActorID)
+	assert.Equal(t, domain.LeaveStatusApproved, entry.FromStatus)
+	assert.Equal(t, domain.LeaveStatusPending, entry.ToStatus)
+	assert.Contains(t, entry.Changes, domain.AuditChange{Field: "reviewer_id", Before: managerID.String(), After: ""})
+	assert.Contains(t, entry.Changes, domain.AuditChange{Field: "review_note", Before: "อนุมัติ", After: ""})
+}
+
+func TestLeaveService_GetHistory_HiddenFromOtherEmployees(t *testing.T) {
+	request := domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeSick,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), "ไม่สบาย",
+	)
+	requestRepo := &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			return request, nil
+		},
+	}
+	authz := &mockAuthorizer{denied: map[domain.Permission]bool{
+		domain.PermissionLeaveViewTeam: true, domain.PermissionUserManage: true,
+	}}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, authz, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	_, err := svc.GetHistory(context.Background(), request.ID, domain.NewID())
+
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+}
+
+func TestLeaveService_GetHistory_EmbedsActorsInOneLookup(t *testing.T) {
+	request := domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeSick,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), "ไม่สบาย",
+	)
+	reviewerID := domain.NewID()
+	history := &mockLeaveHistoryRepository{entries: []domain.LeaveHistoryEntry{
+		*domain.NewLeaveHistoryEntry(domain.LeaveHistoryCreated, &request.UserID, "", request, nil, ""),
+		*domain.NewLeaveHistoryEntry(domain.LeaveHistoryCommented, &request.UserID, domain.LeaveStatusPending, request, nil, ""),
+		*domain.NewLeaveHistoryEntry(domain.LeaveHistoryApproved, &reviewerID, domain.LeaveStatusPending, request, nil, ""),
+		*domain.NewLeaveHistoryEntry(domain.LeaveHistoryRolledBack, nil, domain.LeaveStatusApproved, request, nil, ""),
+	}}
+	requestRepo := &mockLeaveRequestRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.LeaveRequest, error) {
+			return request, nil
+		},
+	}
+	var lookups [][]domain.ID
+	userRepo := &mockUserRepository{
+		findByIDsFn: func(_ context.Context, ids []domain.ID) ([]domain.User, error) {
+			lookups = append(lookups, ids)
+			return []domain.User{{ID: request.UserID, FullName: "สมชาย ใจดี"}, {ID: reviewerID, FullName: "หัวหน้า ทีม"}}, nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, history, &mockLeaveBalanceRepository{}, userRepo, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	views, err := svc.GetHistory(context.Background(), request.ID, request.UserID)
+
+	require.NoError(t, err)
+	require.Len(t, views, 4)
+	require.Len(t, lookups, 1)
+	assert.ElementsMatch(t, []domain.ID{request.UserID, reviewerID}, lookups[0], "รหัสซ้ำต้องถูกค้นครั้งเดียว")
+	assert.Equal(t, "สมชาย ใจดี", views[0].Actor.FullName)
+	assert.Equal(t, "หัวหน้า ทีม", views[2].Actor.FullName)
+	assert.Nil(t, views[3].Actor)
+}
+
+func TestLeaveService_GetPendingRequests_EmbedsUsers(t *testing.T) {
+	requester := domain.UserSummary{ID: domain.NewID(), FullName: "สมชาย ใจดี", Email: "somchai@company.com", Department: "วิศวกรรม"}
+	requestRepo := &mockLeaveRequestRepository{
+		searchWithUsersFn: func(_ context.Context, _ domain.LeaveRequestQuery, p domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequestView], error) {
+			view := domain.LeaveRequestView{Requester: &requester, LeaveRequest: domain.LeaveRequest{ID: domain.NewID(), UserID: requester.ID}}
+			return domain.NewPaginatedResult([]domain.LeaveRequestView{view}, 1, p), nil
+		},
+	}
+	svc := NewLeaveService(requestRepo, &mockLeaveHistoryRepository{}, &mockLeaveBalanceRepository{}, &mockUserRepository{}, &mockAuthorizer{}, &mockAuditLogger{}, &mockOutboxRepository{}, &mockTransactionManager{})
+
+	result, err := svc.GetPendingRequests(context.Background(), domain.NewID(), domain.NewPaginationParams(1, 20))
+
+	require.NoError(t, err)
+	require.Len(t, result.Items, 1)
+	assert.Equal(t, requester, *result.Items[0].Requester)
+}
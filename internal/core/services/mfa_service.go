@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type mfaService struct {
+	mfaRepo  ports.MFARepository
+	userRepo ports.UserRepository
+	issuer   string
+}
+
+// NewMFAService สร้าง MFAService — issuer คือชื่อที่แสดงในแอป authenticator
+func NewMFAService(mfaRepo ports.MFARepository, userRepo ports.UserRepository, issuer string) ports.MFAService {
+	return &mfaService{
+		mfaRepo:  mfaRepo,
+		userRepo: userRepo,
+		issuer:   issuer,
+	}
+}
+
+// BeginEnrollment สร้าง secret ใหม่และ otpauth URI — เรียกซ้ำได้ (secret ที่รอยืนยันเดิมจะถูกแทนที่)
+func (s *mfaService) BeginEnrollment(ctx context.Context, userID domain.ID) (*domain.TOTPEnrollment, error) {
+	state, err := s.mfaRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.mfaRepo.SavePendingSecret(ctx, userID, secret); err != nil {
+		return nil, fmt.Errorf("บันทึก TOTP secret ล้มเหลว: %w", err)
+	}
+
+	return &domain.TOTPEnrollment{
+		Secret:     secret,
+		OTPAuthURI: totpURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// ConfirmEnrollment ตรวจรหัสจาก secret ที่รอยืนยัน แล้วเปิดใช้ 2FA
+func (s *mfaService) ConfirmEnrollment(ctx context.Context, userID domain.ID, code string) ([]string, error) {
+	state, err := s.mfaRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if state.IsEnabled() {
+		return nil, domain.ErrMFAAlreadyEnabled
+	}
+	if state.PendingSecret == "" {
+		return nil, domain.ErrMFANotEnrolled
+	}
+
+	step, ok := verifyTOTP(state.PendingSecret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.mfaRepo.Enable(ctx, userID, state.PendingSecret, hashes); err != nil {
+		return nil, fmt.Errorf("เปิดใช้ 2FA ล้มเหลว: %w", err)
+	}
+
+	// รหัสที่ใช้ยืนยันการลงทะเบียนนำไป login ซ้ำไม่ได้
+	if err = s.mfaRepo.MarkStepUsed(ctx, userID, step); err != nil {
+		return nil, fmt.Errorf("บันทึก TOTP step ล้มเหลว: %w", err)
+	}
+
+	return codes, nil
+}
+
+// Disable ปิดใช้ 2FA หลังยืนยันรหัส
+func (s *mfaService) Disable(ctx context.Context, userID domain.ID, code string) error {
+	state, err := s.enabledState(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = verifySecondFactor(ctx, s.mfaRepo, state, code); err != nil {
+		return err
+	}
+
+	if err = s.mfaRepo.Disable(ctx, userID); err != nil {
+		return fmt.Errorf("ปิดใช้ 2FA ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes สร้าง recovery codes ชุดใหม่ — ต้องใช้รหัส TOTP เท่านั้น (ไม่รับ recovery code)
+func (s *mfaService) RegenerateRecoveryCodes(ctx context.Context, userID domain.ID, code string) ([]string, error) {
+	state, err := s.enabledState(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	code = strings.TrimSpace(code)
+	if !isTOTPCode(code) {
+		return nil, domain.ErrInvalidMFACode
+	}
+	if err = verifySecondFactor(ctx, s.mfaRepo, state, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, fmt.Errorf("บันทึก recovery codes ล้มเหลว: %w", err)
+	}
+	return codes, nil
+}
+
+// enabledState คืนสถานะ 2FA ของผู้ใช้ที่เปิดใช้แล้ว
+func (s *mfaService) enabledState(ctx context.Context, userID domain.ID) (*domain.UserMFA, error) {
+	state, err := s.mfaRepo.Find(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !state.IsEnabled() {
+		return nil, domain.ErrMFANotEnabled
+	}
+	return state, nil
+}
+
+// verifySecondFactor ตรวจรหัส TOTP (ตัวเลข 6 หลัก) หรือ recovery code — ทั้งสองแบบใช้ได้ครั้งเดียว
+func verifySecondFactor(ctx context.Context, repo ports.MFARepository, state *domain.UserMFA, code string) error {
+	code = strings.TrimSpace(code)
+
+	if !isTOTPCode(code) {
+		return repo.ConsumeRecoveryCode(ctx, state.UserID, hashRecoveryCode(code))
+	}
+
+	step, ok := verifyTOTP(state.Secret, code, time.Now())
+	if !ok || step <= state.LastUsedStep {
+		return domain.ErrInvalidMFACode
+	}
+	return repo.MarkStepUsed(ctx, state.UserID, step)
+}
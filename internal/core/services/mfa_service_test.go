@@ -0,0 +1,277 @@
+package services
+
+import (
+	"context"
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// codeAt คำนวณรหัส TOTP ของ secret ณ เวลาที่ระบุ (แทนแอป authenticator)
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := base32NoPadding.DecodeString(secret)
+	require.NoError(t, err)
+	return totpCode(key, totpStep(at))
+}
+
+// enrollMFA ลงทะเบียนและเปิดใช้ 2FA ให้ผู้ใช้ — คืน secret และ recovery codes
+func enrollMFA(t *testing.T, svc *mfaService, userID domain.ID) (string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	enrollment, err := svc.BeginEnrollment(ctx, userID)
+	require.NoError(t, err)
+
+	codes, err := svc.ConfirmEnrollment(ctx, userID, codeAt(t, enrollment.Secret, time.Now()))
+	require.NoError(t, err)
+	return enrollment.Secret, codes
+}
+
+func newTestMFAService(user *domain.User) (*mfaService, *mockMFARepository) {
+	mfaRepo := newMockMFARepository()
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	return &mfaService{mfaRepo: mfaRepo, userRepo: userRepo, issuer: "Leave Management System"}, mfaRepo
+}
+
+func TestTOTP_RFC6238Vectors(t *testing.T) {
+	// ค่าทดสอบจาก RFC 6238 Appendix B (SHA1) — ใช้ 6 หลักท้ายของรหัส 8 หลัก
+	key := []byte("12345678901234567890")
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(key)
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tc := range tests {
+		at := time.Unix(tc.unix, 0)
+		assert.Equal(t, tc.code, totpCode(key, totpStep(at)))
+
+		step, ok := verifyTOTP(secret, tc.code, at)
+		assert.True(t, ok)
+		assert.Equal(t, totpStep(at), step)
+	}
+}
+
+func TestTOTP_AllowsOneStepClockSkew(t *testing.T) {
+	secret, err := generateTOTPSecret()
+	require.NoError(t, err)
+	now := time.Now()
+
+	_, ok := verifyTOTP(secret, codeAt(t, secret, now.Add(-totpPeriod*time.Second)), now)
+	assert.True(t, ok, "รหัสของ step ก่อนหน้ายังต้องใช้ได้")
+
+	_, ok = verifyTOTP(secret, codeAt(t, secret, now.Add(3*totpPeriod*time.Second)), now)
+	assert.False(t, ok, "รหัสที่ห่างเกิน skew ต้องใช้ไม่ได้")
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := totpURI("Leave Management System", "somchai@company.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Leave%20Management%20System:somchai@company.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Leave+Management+System")
+}
+
+func TestMFAService_Enrollment(t *testing.T) {
+	user := domain.NewUser("สมชาย", "ผู้จัดการ", "manager@company.com", "hash", domain.RoleManager)
+	svc, mfaRepo := newTestMFAService(user)
+	ctx := context.Background()
+
+	enrollment, err := svc.BeginEnrollment(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Contains(t, enrollment.OTPAuthURI, enrollment.Secret)
+
+	_, err = svc.ConfirmEnrollment(ctx, user.ID, "000000")
+	require.ErrorIs(t, err, domain.ErrInvalidMFACode)
+
+	codes, err := svc.ConfirmEnrollment(ctx, user.ID, codeAt(t, enrollment.Secret, time.Now()))
+	require.NoError(t, err)
+	assert.Len(t, codes, recoveryCodeCount)
+
+	state, err := mfaRepo.Find(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, state.IsEnabled())
+	assert.Empty(t, state.PendingSecret)
+	for i, hash := range state.RecoveryCodeHashes {
+		assert.NotEqual(t, codes[i], hash, "ต้องเก็บเฉพาะ hash ของ recovery code")
+	}
+
+	_, err = svc.BeginEnrollment(ctx, user.ID)
+	assert.ErrorIs(t, err, domain.ErrMFAAlreadyEnabled)
+}
+
+func TestMFAService_ConfirmWithoutEnrollment(t *testing.T) {
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+	svc, _ := newTestMFAService(user)
+
+	_, err := svc.ConfirmEnrollment(context.Background(), user.ID, "123456")
+
+	assert.ErrorIs(t, err, domain.ErrMFANotEnrolled)
+}
+
+func TestMFAService_Disable_RecoveryCodeIsSingleUse(t *testing.T) {
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+	svc, _ := newTestMFAService(user)
+	ctx := context.Background()
+	_, codes := enrollMFA(t, svc, user.ID)
+
+	require.NoError(t, svc.Disable(ctx, user.ID, strings.ToUpper(codes[0])), "recovery code ไม่สนตัวพิมพ์เล็ก/ใหญ่")
+
+	err := svc.Disable(ctx, user.ID, codes[0])
+	assert.ErrorIs(t, err, domain.ErrMFANotEnabled)
+}
+
+func TestMFAService_RegenerateRecoveryCodes_RequiresTOTP(t *testing.T) {
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+	svc, _ := newTestMFAService(user)
+	ctx := context.Background()
+	secret, codes := enrollMFA(t, svc, user.ID)
+
+	_, err := svc.RegenerateRecoveryCodes(ctx, user.ID, codes[0])
+	require.ErrorIs(t, err, domain.ErrInvalidMFACode, "ต้องไม่รับ recovery code")
+
+	fresh, err := svc.RegenerateRecoveryCodes(ctx, user.ID, codeAt(t, secret, time.Now().Add(totpPeriod*time.Second)))
+	require.NoError(t, err)
+	assert.NotEqual(t, codes, fresh)
+
+	err = svc.Disable(ctx, user.ID, codes[1])
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode, "recovery codes ชุดเดิมต้องใช้ไม่ได้แล้ว")
+}
+
+// ─── Two-Step Login Tests ───────────────────────────────────────────────
+// ผู้ใช้ที่เปิด 2FA ต้องยืนยัน challenge ก่อนได้ token
+// ─────────────────────────────────────────────────────────────────────────
+
+// newMFALoginTestService สร้าง AuthService ที่ผู้ใช้ทดสอบเปิด 2FA แล้ว
+func newMFALoginTestService(t *testing.T) (*authService, *domain.User, string, []string) {
+	t.Helper()
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := domain.NewUser("สมชาย", "ผู้จัดการ", "manager@company.com", string(hashedPassword), domain.RoleManager)
+
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return user, nil
+		},
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.User, error) {
+			return user, nil
+		},
+	}
+	svc := newTestAuthService(userRepo, newMockRefreshTokenRepository(), &mockTokenService{})
+	svc.lockoutPolicy.BaseBackoff = 0
+	svc.lockoutPolicy.MaxBackoff = 0
+
+	mfaRepo, ok := svc.mfaRepo.(*mockMFARepository)
+	require.True(t, ok)
+	mfaSvc := &mfaService{mfaRepo: mfaRepo, userRepo: userRepo, issuer: "test"}
+	secret, codes := enrollMFA(t, mfaSvc, user.ID)
+
+	return svc, user, secret, codes
+}
+
+func TestAuthService_Login_MFAReturnsChallenge(t *testing.T) {
+	svc, user, secret, _ := newMFALoginTestService(t)
+	ctx := context.Background()
+	var issuedWithMFA bool
+	svc.tokenService = &mockTokenService{
+		generateFn: func(_ *domain.User, mfa bool) (string, time.Time, error) {
+			issuedWithMFA = mfa
+			return "jwt-token", time.Now().Add(15 * time.Minute), nil
+		},
+	}
+
+	result, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+	require.NotNil(t, result.Challenge)
+	assert.Nil(t, result.Tokens, "ต้องยังไม่ออก token ก่อนยืนยัน 2FA")
+
+	tokens, _, err := svc.VerifyMFA(ctx, result.Challenge.Token, codeAt(t, secret, time.Now().Add(totpPeriod*time.Second)))
+	require.NoError(t, err)
+	assert.Equal(t, "jwt-token", tokens.AccessToken)
+	assert.True(t, issuedWithMFA, "access token ต้องมี claim mfa")
+
+	_, _, err = svc.VerifyMFA(ctx, result.Challenge.Token, codeAt(t, secret, time.Now()))
+	assert.ErrorIs(t, err, domain.ErrInvalidMFAChallenge, "challenge ใช้ได้ครั้งเดียว")
+}
+
+func TestAuthService_VerifyMFA_RejectsReplayedCode(t *testing.T) {
+	svc, user, secret, _ := newMFALoginTestService(t)
+	ctx := context.Background()
+	code := codeAt(t, secret, time.Now().Add(totpPeriod*time.Second))
+
+	first, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+	_, _, err = svc.VerifyMFA(ctx, first.Challenge.Token, code)
+	require.NoError(t, err)
+
+	second, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+	_, _, err = svc.VerifyMFA(ctx, second.Challenge.Token, code)
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode, "รหัสเดิมต้องใช้ซ้ำไม่ได้")
+}
+
+func TestAuthService_VerifyMFA_RecoveryCode(t *testing.T) {
+	svc, user, _, codes := newMFALoginTestService(t)
+	ctx := context.Background()
+
+	result, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+	_, _, err = svc.VerifyMFA(ctx, result.Challenge.Token, codes[0])
+	require.NoError(t, err)
+
+	result, err = svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+	_, _, err = svc.VerifyMFA(ctx, result.Challenge.Token, codes[0])
+	assert.ErrorIs(t, err, domain.ErrInvalidMFACode, "recovery code ใช้ได้ครั้งเดียว")
+}
+
+func TestAuthService_VerifyMFA_WrongCodesLockAccount(t *testing.T) {
+	svc, user, secret, _ := newMFALoginTestService(t)
+	ctx := context.Background()
+
+	result, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+
+	// ขั้นรหัสผ่านนับเป็นหนึ่งครั้งจนกว่าจะยืนยัน 2FA สำเร็จ
+	for i := 1; i < svc.lockoutPolicy.MaxFailedAttempts; i++ {
+		_, _, err = svc.VerifyMFA(ctx, result.Challenge.Token, "not-a-code")
+		require.ErrorIs(t, err, domain.ErrInvalidMFACode)
+	}
+
+	_, _, err = svc.VerifyMFA(ctx, result.Challenge.Token, codeAt(t, secret, time.Now().Add(totpPeriod*time.Second)))
+	assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts, "เดารหัส 2FA ต้องถูกล็อกเหมือนเดารหัสผ่าน")
+}
+
+func TestAuthService_VerifyMFA_ExpiredChallenge(t *testing.T) {
+	svc, user, secret, _ := newMFALoginTestService(t)
+	ctx := context.Background()
+
+	result, err := svc.Login(ctx, user.Email, "password123")
+	require.NoError(t, err)
+
+	challenges, ok := svc.challengeRepo.(*mockMFAChallengeRepository)
+	require.True(t, ok)
+	challenges.challenges[result.Challenge.ID].ExpiresAt = time.Now().Add(-time.Second)
+
+	_, _, err = svc.VerifyMFA(ctx, result.Challenge.Token, codeAt(t, secret, time.Now().Add(totpPeriod*time.Second)))
+	assert.ErrorIs(t, err, domain.ErrInvalidMFAChallenge)
+}
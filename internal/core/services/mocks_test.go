@@ -2,6 +2,9 @@ package services
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"sync"
 	"time"
 
 	"github/be2bag/leave-management-system/internal/core/domain"
@@ -14,6 +17,16 @@ import (
 // mockUserRepository จำลอง UserRepository สำหรับทดสอบ
 type mockUserRepository struct {
 	findByEmailFn func(ctx context.Context, email string) (*domain.User, error)
+	findByIDFn    func(ctx context.Context, id domain.ID) (*domain.User, error)
+	findByIDsFn   func(ctx context.Context, ids []domain.ID) ([]domain.User, error)
+	updatePwdFn   func(ctx context.Context, id domain.ID, passwordHash string) error
+
+	findByExternalIDFn func(ctx context.Context, provider, subject string) (*domain.User, error)
+	createFn           func(ctx context.Context, user *domain.User) error
+	updateExternalFn   func(ctx context.Context, user *domain.User) error
+	updateRoleFn       func(ctx context.Context, id domain.ID, role domain.Role) error
+	countByRoleFn      func(ctx context.Context, role domain.Role) (int64, error)
+	findByRolesFn      func(ctx context.Context, roles []domain.Role) ([]domain.User, error)
 }
 
 func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*domain.User, error) {
@@ -23,12 +36,538 @@ func (m *mockUserRepository) FindByEmail(ctx context.Context, email string) (*do
 	return nil, domain.ErrUserNotFound
 }
 
+// FindByIDs ถ้าไม่ได้กำหนด findByIDsFn จะค้นทีละคนผ่าน FindByID และข้ามผู้ใช้ที่ไม่พบ
+func (m *mockUserRepository) FindByIDs(ctx context.Context, ids []domain.ID) ([]domain.User, error) {
+	if m.findByIDsFn != nil {
+		return m.findByIDsFn(ctx, ids)
+	}
+	users := []domain.User{}
+	for _, id := range ids {
+		user, err := m.FindByID(ctx, id)
+		if errors.Is(err, domain.ErrUserNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+func (m *mockUserRepository) FindByID(ctx context.Context, id domain.ID) (*domain.User, error) {
+	if m.findByIDFn != nil {
+		return m.findByIDFn(ctx, id)
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (m *mockUserRepository) UpdatePassword(ctx context.Context, id domain.ID, passwordHash string) error {
+	if m.updatePwdFn != nil {
+		return m.updatePwdFn(ctx, id, passwordHash)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) FindByExternalID(ctx context.Context, provider, subject string) (*domain.User, error) {
+	if m.findByExternalIDFn != nil {
+		return m.findByExternalIDFn(ctx, provider, subject)
+	}
+	return nil, domain.ErrUserNotFound
+}
+
+func (m *mockUserRepository) Create(ctx context.Context, user *domain.User) error {
+	if m.createFn != nil {
+		return m.createFn(ctx, user)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) UpdateExternalIdentity(ctx context.Context, user *domain.User) error {
+	if m.updateExternalFn != nil {
+		return m.updateExternalFn(ctx, user)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) UpdateRole(ctx context.Context, id domain.ID, role domain.Role) error {
+	if m.updateRoleFn != nil {
+		return m.updateRoleFn(ctx, id, role)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) CountByRole(ctx context.Context, role domain.Role) (int64, error) {
+	if m.countByRoleFn != nil {
+		return m.countByRoleFn(ctx, role)
+	}
+	return 0, nil
+}
+
+func (m *mockUserRepository) FindByRoles(ctx context.Context, roles []domain.Role) ([]domain.User, error) {
+	if m.findByRolesFn != nil {
+		return m.findByRolesFn(ctx, roles)
+	}
+	return []domain.User{}, nil
+}
+
+// mockUserProfileRepository เก็บผู้ใช้ที่ถูกแก้โปรไฟล์ไว้ตรวจสอบ
+type mockUserProfileRepository struct {
+	updated []domain.User
+}
+
+func (m *mockUserProfileRepository) UpdateProfile(_ context.Context, user *domain.User) error {
+	m.updated = append(m.updated, *user)
+	return nil
+}
+
+// mockAuthorizer จำลอง Authorizer — อนุญาตทุกสิทธิ์ยกเว้นที่อยู่ใน denied
+type mockAuthorizer struct {
+	denied map[domain.Permission]bool
+}
+
+func (m *mockAuthorizer) Authorize(_ context.Context, _ domain.ID, permission domain.Permission) error {
+	if m.denied[permission] {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (m *mockAuthorizer) RoleHasPermission(_ context.Context, _ domain.Role, permission domain.Permission) (bool, error) {
+	return !m.denied[permission], nil
+}
+
+func (m *mockAuthorizer) UserRole(context.Context, domain.ID) (*domain.RoleDefinition, error) {
+	role := &domain.RoleDefinition{Name: "mock"}
+	for _, def := range domain.DefaultRoles() {
+		for _, p := range def.Permissions {
+			if !m.denied[p] && !role.Has(p) {
+				role.Permissions = append(role.Permissions, p)
+			}
+		}
+	}
+	return role, nil
+}
+
+// mockRoleAuthorizer จำลอง Authorizer ตามบทบาทของผู้ใช้ — ใช้สิทธิ์ของบทบาทเริ่มต้น
+type mockRoleAuthorizer struct {
+	roles map[domain.ID]domain.Role
+}
+
+func (m *mockRoleAuthorizer) Authorize(ctx context.Context, userID domain.ID, permission domain.Permission) error {
+	ok, _ := m.RoleHasPermission(ctx, m.roles[userID], permission)
+	if !ok {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+func (m *mockRoleAuthorizer) RoleHasPermission(ctx context.Context, role domain.Role, permission domain.Permission) (bool, error) {
+	def, _ := m.definition(role)
+	return def.Has(permission), nil
+}
+
+func (m *mockRoleAuthorizer) UserRole(_ context.Context, userID domain.ID) (*domain.RoleDefinition, error) {
+	return m.definition(m.roles[userID])
+}
+
+// definition สิทธิ์ของบทบาทเริ่มต้น — บทบาทอื่นไม่มีสิทธิ์ใด
+func (m *mockRoleAuthorizer) definition(role domain.Role) (*domain.RoleDefinition, error) {
+	for _, def := range domain.DefaultRoles() {
+		if def.Name == role {
+			return &def, nil
+		}
+	}
+	return &domain.RoleDefinition{Name: role}, nil
+}
+
+// mockRefreshTokenRepository จำลอง RefreshTokenRepository แบบเก็บข้อมูลใน memory
+type mockRefreshTokenRepository struct {
+	tokens map[domain.ID]*domain.RefreshToken
+}
+
+func newMockRefreshTokenRepository() *mockRefreshTokenRepository {
+	return &mockRefreshTokenRepository{tokens: make(map[domain.ID]*domain.RefreshToken)}
+}
+
+func (m *mockRefreshTokenRepository) Create(_ context.Context, token *domain.RefreshToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) FindByHash(_ context.Context, tokenHash string) (*domain.RefreshToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, domain.ErrInvalidRefreshToken
+}
+
+func (m *mockRefreshTokenRepository) Consume(_ context.Context, id domain.ID) error {
+	t, ok := m.tokens[id]
+	if !ok || t.RevokedAt != nil {
+		return domain.ErrRefreshTokenReused
+	}
+	now := time.Now()
+	t.RevokedAt = &now
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) RevokeFamily(_ context.Context, familyID domain.ID) error {
+	now := time.Now()
+	for _, t := range m.tokens {
+		if t.FamilyID == familyID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockRefreshTokenRepository) RevokeByUserID(_ context.Context, userID domain.ID) error {
+	now := time.Now()
+	for _, t := range m.tokens {
+		if t.UserID == userID && t.RevokedAt == nil {
+			t.RevokedAt = &now
+		}
+	}
+	return nil
+}
+
+// mockTokenRevocationStore จำลอง TokenRevocationStore แบบเก็บข้อมูลใน memory
+type mockTokenRevocationStore struct {
+	revokedTokens map[string]time.Time
+	revokedUsers  map[domain.ID]time.Time
+}
+
+func newMockTokenRevocationStore() *mockTokenRevocationStore {
+	return &mockTokenRevocationStore{
+		revokedTokens: make(map[string]time.Time),
+		revokedUsers:  make(map[domain.ID]time.Time),
+	}
+}
+
+func (m *mockTokenRevocationStore) RevokeToken(_ context.Context, tokenID string, expiresAt time.Time) error {
+	m.revokedTokens[tokenID] = expiresAt
+	return nil
+}
+
+func (m *mockTokenRevocationStore) RevokeUserTokens(_ context.Context, userID domain.ID, revokedAt time.Time) error {
+	m.revokedUsers[userID] = revokedAt.Truncate(time.Millisecond)
+	return nil
+}
+
+func (m *mockTokenRevocationStore) IsRevoked(_ context.Context, claims *domain.TokenClaims) (bool, error) {
+	if _, ok := m.revokedTokens[claims.TokenID]; ok {
+		return true, nil
+	}
+	if revokedAt, ok := m.revokedUsers[claims.UserID]; ok && claims.IssuedAt.Before(revokedAt) {
+		return true, nil
+	}
+	return false, nil
+}
+
+// mockPasswordResetRepository จำลอง PasswordResetRepository แบบเก็บข้อมูลใน memory
+type mockPasswordResetRepository struct {
+	tokens map[domain.ID]*domain.PasswordResetToken
+}
+
+func newMockPasswordResetRepository() *mockPasswordResetRepository {
+	return &mockPasswordResetRepository{tokens: make(map[domain.ID]*domain.PasswordResetToken)}
+}
+
+func (m *mockPasswordResetRepository) Create(_ context.Context, token *domain.PasswordResetToken) error {
+	m.tokens[token.ID] = token
+	return nil
+}
+
+func (m *mockPasswordResetRepository) FindByHash(_ context.Context, tokenHash string) (*domain.PasswordResetToken, error) {
+	for _, t := range m.tokens {
+		if t.TokenHash == tokenHash {
+			return t, nil
+		}
+	}
+	return nil, domain.ErrInvalidResetToken
+}
+
+func (m *mockPasswordResetRepository) Consume(_ context.Context, id domain.ID) error {
+	t, ok := m.tokens[id]
+	if !ok || t.UsedAt != nil {
+		return domain.ErrInvalidResetToken
+	}
+	now := time.Now()
+	t.UsedAt = &now
+	return nil
+}
+
+func (m *mockPasswordResetRepository) InvalidateByUserID(_ context.Context, userID domain.ID) error {
+	now := time.Now()
+	for _, t := range m.tokens {
+		if t.UserID == userID && t.UsedAt == nil {
+			t.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+// mockMailer เก็บอีเมลที่ถูกส่งไว้ตรวจสอบ
+type mockMailer struct {
+	sent     []domain.EmailMessage
+	failures int // จำนวนครั้งแรกที่ Send จะคืน error
+}
+
+func (m *mockMailer) Send(_ context.Context, msg domain.EmailMessage) error {
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("smtp: 421 service not available")
+	}
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+// mockSessionService บันทึกผู้ใช้ที่ถูกยกเลิกทุก session
+type mockSessionService struct {
+	revokedUsers []domain.ID
+}
+
+func (m *mockSessionService) Logout(_ context.Context, _ *domain.TokenClaims, _ string) error {
+	return nil
+}
+
+func (m *mockSessionService) RevokeAllSessions(_ context.Context, userID domain.ID) error {
+	m.revokedUsers = append(m.revokedUsers, userID)
+	return nil
+}
+
+// mockLoginAttemptRepository จำลอง LoginAttemptRepository แบบเก็บข้อมูลใน memory
+type mockLoginAttemptRepository struct {
+	attempts map[string]*domain.LoginAttempt
+	mu       sync.Mutex
+}
+
+func newMockLoginAttemptRepository() *mockLoginAttemptRepository {
+	return &mockLoginAttemptRepository{attempts: make(map[string]*domain.LoginAttempt)}
+}
+
+func (m *mockLoginAttemptRepository) Find(_ context.Context, email string) (*domain.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if a, ok := m.attempts[email]; ok {
+		copied := *a
+		return &copied, nil
+	}
+	return &domain.LoginAttempt{Email: email}, nil
+}
+
+// RecordAttempt บันทึกเฉพาะเมื่อ version ยังเป็นค่าที่อ่านมา เหมือน findOneAndUpdate แบบมีเงื่อนไข
+func (m *mockLoginAttemptRepository) RecordAttempt(_ context.Context, attempt *domain.LoginAttempt) (*domain.LoginAttempt, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var current int
+	if a, ok := m.attempts[attempt.Email]; ok {
+		current = a.Version
+	}
+	if current != attempt.Version-1 {
+		return nil, domain.ErrTooManyLoginAttempts
+	}
+	stored := *attempt
+	m.attempts[attempt.Email] = &stored
+	copied := stored
+	return &copied, nil
+}
+
+func (m *mockLoginAttemptRepository) Reset(_ context.Context, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.attempts, email)
+	return nil
+}
+
+// mockSecurityEventRepository เก็บ security events ที่ถูกบันทึกไว้ตรวจสอบ
+type mockSecurityEventRepository struct {
+	events []*domain.SecurityEvent
+}
+
+func (m *mockSecurityEventRepository) Record(_ context.Context, event *domain.SecurityEvent) error {
+	m.events = append(m.events, event)
+	return nil
+}
+
+// mockMFARepository จำลอง MFARepository แบบเก็บข้อมูลใน memory
+type mockMFARepository struct {
+	states map[domain.ID]*domain.UserMFA
+}
+
+func newMockMFARepository() *mockMFARepository {
+	return &mockMFARepository{states: make(map[domain.ID]*domain.UserMFA)}
+}
+
+func (m *mockMFARepository) state(userID domain.ID) *domain.UserMFA {
+	s, ok := m.states[userID]
+	if !ok {
+		s = &domain.UserMFA{UserID: userID}
+		m.states[userID] = s
+	}
+	return s
+}
+
+func (m *mockMFARepository) Find(_ context.Context, userID domain.ID) (*domain.UserMFA, error) {
+	copied := *m.state(userID)
+	copied.RecoveryCodeHashes = append([]string(nil), copied.RecoveryCodeHashes...)
+	return &copied, nil
+}
+
+func (m *mockMFARepository) SavePendingSecret(_ context.Context, userID domain.ID, secret string) error {
+	m.state(userID).PendingSecret = secret
+	return nil
+}
+
+func (m *mockMFARepository) Enable(_ context.Context, userID domain.ID, secret string, hashes []string) error {
+	s := m.state(userID)
+	if s.PendingSecret != secret || s.EnabledAt != nil {
+		return domain.ErrMFANotEnrolled
+	}
+	now := time.Now()
+	s.Secret, s.PendingSecret, s.RecoveryCodeHashes, s.LastUsedStep, s.EnabledAt = secret, "", hashes, 0, &now
+	return nil
+}
+
+func (m *mockMFARepository) Disable(_ context.Context, userID domain.ID) error {
+	delete(m.states, userID)
+	return nil
+}
+
+func (m *mockMFARepository) ReplaceRecoveryCodes(_ context.Context, userID domain.ID, hashes []string) error {
+	m.state(userID).RecoveryCodeHashes = hashes
+	return nil
+}
+
+func (m *mockMFARepository) ConsumeRecoveryCode(_ context.Context, userID domain.ID, codeHash string) error {
+	s := m.state(userID)
+	for i, h := range s.RecoveryCodeHashes {
+		if h == codeHash {
+			s.RecoveryCodeHashes = append(s.RecoveryCodeHashes[:i], s.RecoveryCodeHashes[i+1:]...)
+			return nil
+		}
+	}
+	return domain.ErrInvalidMFACode
+}
+
+func (m *mockMFARepository) MarkStepUsed(_ context.Context, userID domain.ID, step int64) error {
+	s := m.state(userID)
+	if step <= s.LastUsedStep {
+		return domain.ErrInvalidMFACode
+	}
+	s.LastUsedStep = step
+	return nil
+}
+
+// mockMFAChallengeRepository จำลอง MFAChallengeRepository แบบเก็บข้อมูลใน memory
+type mockMFAChallengeRepository struct {
+	challenges map[domain.ID]*domain.MFAChallenge
+}
+
+func newMockMFAChallengeRepository() *mockMFAChallengeRepository {
+	return &mockMFAChallengeRepository{challenges: make(map[domain.ID]*domain.MFAChallenge)}
+}
+
+func (m *mockMFAChallengeRepository) Create(_ context.Context, challenge *domain.MFAChallenge) error {
+	stored := *challenge
+	stored.Token = ""
+	m.challenges[challenge.ID] = &stored
+	return nil
+}
+
+func (m *mockMFAChallengeRepository) FindByHash(_ context.Context, tokenHash string) (*domain.MFAChallenge, error) {
+	for _, c := range m.challenges {
+		if c.TokenHash == tokenHash {
+			copied := *c
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrInvalidMFAChallenge
+}
+
+func (m *mockMFAChallengeRepository) Consume(_ context.Context, id domain.ID) error {
+	if _, ok := m.challenges[id]; !ok {
+		return domain.ErrInvalidMFAChallenge
+	}
+	delete(m.challenges, id)
+	return nil
+}
+
+// mockAuthenticator จำลอง Authenticator ของ directory — บันทึกว่าถูกเรียกหรือไม่
+type mockAuthenticator struct {
+	identity *domain.ExternalIdentity
+	password string // รหัสผ่านที่ถูกต้อง
+	called   bool
+}
+
+func (m *mockAuthenticator) Authenticate(_ context.Context, _, password string, _ *domain.User) (*domain.ExternalIdentity, error) {
+	m.called = true
+	if password != m.password {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return m.identity, nil
+}
+
+// mockOIDCStateRepository จำลอง OIDCStateRepository แบบเก็บข้อมูลใน memory
+type mockOIDCStateRepository struct {
+	states map[string]*domain.OIDCLoginState
+}
+
+func newMockOIDCStateRepository() *mockOIDCStateRepository {
+	return &mockOIDCStateRepository{states: make(map[string]*domain.OIDCLoginState)}
+}
+
+func (m *mockOIDCStateRepository) Create(_ context.Context, state *domain.OIDCLoginState) error {
+	m.states[state.StateHash] = state
+	return nil
+}
+
+func (m *mockOIDCStateRepository) Consume(_ context.Context, stateHash string) (*domain.OIDCLoginState, error) {
+	state, ok := m.states[stateHash]
+	if !ok {
+		return nil, domain.ErrInvalidOIDCState
+	}
+	delete(m.states, stateHash)
+	return state, nil
+}
+
+// mockIdentityProvider จำลอง IdentityProvider — บันทึกค่าที่ service ส่งมาไว้ตรวจสอบ
+type mockIdentityProvider struct {
+	identity      *domain.ExternalIdentity
+	exchangeErr   error
+	codeChallenge string
+	nonce         string
+	codeVerifier  string
+	exchangeNonce string
+}
+
+func (m *mockIdentityProvider) AuthCodeURL(_ context.Context, state, nonce, codeChallenge string) (string, error) {
+	m.nonce = nonce
+	m.codeChallenge = codeChallenge
+	return "https://idp.example.com/authorize?state=" + state, nil
+}
+
+func (m *mockIdentityProvider) Exchange(_ context.Context, _, codeVerifier, nonce string) (*domain.ExternalIdentity, error) {
+	m.codeVerifier = codeVerifier
+	m.exchangeNonce = nonce
+	if m.exchangeErr != nil {
+		return nil, m.exchangeErr
+	}
+	return m.identity, nil
+}
+
 // mockLeaveBalanceRepository จำลอง LeaveBalanceRepository สำหรับทดสอบ
 type mockLeaveBalanceRepository struct {
 	findByUserIDFn   func(ctx context.Context, userID domain.ID) ([]domain.LeaveBalance, error)
 	reservePendingFn func(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, days float64) error
 	confirmPendingFn func(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, days float64) error
 	releasePendingFn func(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, days float64) error
+	setTotalDaysFn   func(ctx context.Context, userID domain.ID, leaveType domain.LeaveType, year int, totalDays float64) (*domain.LeaveBalance, error)
+
+	setOpeningBalanceFn func(ctx context.Context, userID domain.ID, year int, balance domain.OpeningBalance) (*domain.LeaveBalance, error)
 }
 
 func (m *mockLeaveBalanceRepository) FindByUserID(ctx context.Context, userID domain.ID) ([]domain.LeaveBalance, error) {
@@ -59,12 +598,39 @@ func (m *mockLeaveBalanceRepository) ReleasePending(ctx context.Context, userID
 	return nil
 }
 
+func (m *mockLeaveBalanceRepository) SetTotalDays(
+	ctx context.Context,
+	userID domain.ID,
+	leaveType domain.LeaveType,
+	year int,
+	totalDays float64,
+) (*domain.LeaveBalance, error) {
+	if m.setTotalDaysFn != nil {
+		return m.setTotalDaysFn(ctx, userID, leaveType, year, totalDays)
+	}
+	return domain.NewLeaveBalance(userID, leaveType, totalDays, year), nil
+}
+
+func (m *mockLeaveBalanceRepository) SetOpeningBalance(
+	ctx context.Context,
+	userID domain.ID,
+	year int,
+	opening domain.OpeningBalance,
+) (*domain.LeaveBalance, error) {
+	if m.setOpeningBalanceFn != nil {
+		return m.setOpeningBalanceFn(ctx, userID, year, opening)
+	}
+	balance := domain.NewLeaveBalance(userID, opening.LeaveType, opening.TotalDays, year)
+	balance.UsedDays = opening.UsedDays
+	return balance, nil
+}
+
 // mockLeaveRequestRepository จำลอง LeaveRequestRepository สำหรับทดสอบ
 type mockLeaveRequestRepository struct {
 	createFn                func(ctx context.Context, request *domain.LeaveRequest) error
 	findByIDFn              func(ctx context.Context, id domain.ID) (*domain.LeaveRequest, error)
-	findByUserIDFn          func(ctx context.Context, userID domain.ID, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
-	findByStatusFn          func(ctx context.Context, status domain.LeaveStatus, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
+	searchFn                func(ctx context.Context, query domain.LeaveRequestQuery, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequest], error)
+	searchWithUsersFn       func(ctx context.Context, query domain.LeaveRequestQuery, params domain.PaginationParams) (*domain.PaginatedResult[domain.LeaveRequestView], error)
 	updateFn                func(ctx context.Context, request *domain.LeaveRequest) error
 	updateWithStatusCheckFn func(ctx context.Context, request *domain.LeaveRequest, expectedStatus domain.LeaveStatus) error
 	hasOverlapFn            func(ctx context.Context, userID domain.ID, startDate, endDate time.Time, excludeID *domain.ID) (bool, error)
@@ -84,26 +650,38 @@ func (m *mockLeaveRequestRepository) FindByID(ctx context.Context, id domain.ID)
 	return nil, domain.ErrRequestNotFound
 }
 
-func (m *mockLeaveRequestRepository) FindByUserID(
+func (m *mockLeaveRequestRepository) Search(
 	ctx context.Context,
-	userID domain.ID,
+	query domain.LeaveRequestQuery,
 	params domain.PaginationParams,
 ) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-	if m.findByUserIDFn != nil {
-		return m.findByUserIDFn(ctx, userID, params)
+	if m.searchFn != nil {
+		return m.searchFn(ctx, query, params)
 	}
 	return domain.NewPaginatedResult([]domain.LeaveRequest{}, 0, params), nil
 }
 
-func (m *mockLeaveRequestRepository) FindByStatus(
+// SearchWithUsers ถ้าไม่ได้กำหนด searchWithUsersFn จะใช้ผลของ Search โดยไม่มีข้อมูลผู้ใช้
+func (m *mockLeaveRequestRepository) SearchWithUsers(
 	ctx context.Context,
-	status domain.LeaveStatus,
+	query domain.LeaveRequestQuery,
 	params domain.PaginationParams,
-) (*domain.PaginatedResult[domain.LeaveRequest], error) {
-	if m.findByStatusFn != nil {
-		return m.findByStatusFn(ctx, status, params)
+) (*domain.PaginatedResult[domain.LeaveRequestView], error) {
+	if m.searchWithUsersFn != nil {
+		return m.searchWithUsersFn(ctx, query, params)
 	}
-	return domain.NewPaginatedResult([]domain.LeaveRequest{}, 0, params), nil
+	result, err := m.Search(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]domain.LeaveRequestView, 0, len(result.Items))
+	for i := range result.Items {
+		views = append(views, domain.LeaveRequestView{LeaveRequest: result.Items[i]})
+	}
+	return &domain.PaginatedResult[domain.LeaveRequestView]{
+		Items: views, Total: result.Total, Page: result.Page, PageSize: result.PageSize, TotalPages: result.TotalPages,
+		NextCursor: result.NextCursor, Keyset: result.Keyset, TotalCounted: result.TotalCounted,
+	}, nil
 }
 
 func (m *mockLeaveRequestRepository) Update(ctx context.Context, request *domain.LeaveRequest) error {
@@ -134,20 +712,552 @@ func (m *mockLeaveRequestRepository) HasOverlap(
 
 // mockTokenService จำลอง TokenService สำหรับทดสอบ
 type mockTokenService struct {
-	generateFn func(user *domain.User) (string, error)
-	validateFn func(tokenString string) (*domain.TokenClaims, error)
+	generateFn func(user *domain.User, mfa bool) (string, time.Time, error)
+	validateFn func(ctx context.Context, tokenString string) (*domain.TokenClaims, error)
 }
 
-func (m *mockTokenService) GenerateToken(user *domain.User) (string, error) {
+func (m *mockTokenService) GenerateToken(user *domain.User, mfa bool) (string, time.Time, error) {
 	if m.generateFn != nil {
-		return m.generateFn(user)
+		return m.generateFn(user, mfa)
 	}
-	return "mock-token", nil
+	return "mock-token", time.Now().Add(15 * time.Minute), nil
 }
 
-func (m *mockTokenService) ValidateToken(tokenString string) (*domain.TokenClaims, error) {
+func (m *mockTokenService) ValidateToken(ctx context.Context, tokenString string) (*domain.TokenClaims, error) {
 	if m.validateFn != nil {
-		return m.validateFn(tokenString)
+		return m.validateFn(ctx, tokenString)
 	}
 	return nil, domain.ErrUnauthorized
 }
+
+// mockServiceAccountRepository จำลอง ServiceAccountRepository แบบเก็บข้อมูลใน memory
+type mockServiceAccountRepository struct {
+	accounts map[domain.ID]*domain.ServiceAccount
+	touches  int // จำนวนครั้งที่บันทึก last_used_at
+}
+
+func newMockServiceAccountRepository() *mockServiceAccountRepository {
+	return &mockServiceAccountRepository{accounts: make(map[domain.ID]*domain.ServiceAccount)}
+}
+
+func (m *mockServiceAccountRepository) Create(_ context.Context, account *domain.ServiceAccount) error {
+	m.accounts[account.ID] = account
+	return nil
+}
+
+func (m *mockServiceAccountRepository) FindByKeyHash(_ context.Context, keyHash string) (*domain.ServiceAccount, error) {
+	for _, a := range m.accounts {
+		if a.KeyHash == keyHash {
+			copied := *a
+			return &copied, nil
+		}
+	}
+	return nil, domain.ErrInvalidAPIKey
+}
+
+func (m *mockServiceAccountRepository) FindAll(_ context.Context) ([]domain.ServiceAccount, error) {
+	accounts := make([]domain.ServiceAccount, 0, len(m.accounts))
+	for _, a := range m.accounts {
+		accounts = append(accounts, *a)
+	}
+	return accounts, nil
+}
+
+func (m *mockServiceAccountRepository) Revoke(_ context.Context, id domain.ID) error {
+	a, ok := m.accounts[id]
+	if !ok || a.IsRevoked() {
+		return domain.ErrServiceAccountNotFound
+	}
+	now := time.Now()
+	a.RevokedAt = &now
+	return nil
+}
+
+func (m *mockServiceAccountRepository) TouchLastUsed(_ context.Context, id domain.ID, usedAt time.Time) error {
+	if a, ok := m.accounts[id]; ok {
+		a.LastUsedAt = &usedAt
+	}
+	m.touches++
+	return nil
+}
+
+// mockRoleRepository จำลอง RoleRepository แบบเก็บข้อมูลใน memory
+type mockRoleRepository struct {
+	roles map[domain.Role]*domain.RoleDefinition
+	loads int // จำนวนครั้งที่เรียก FindAll — ใช้ตรวจ cache
+}
+
+func newMockRoleRepository() *mockRoleRepository {
+	return &mockRoleRepository{roles: make(map[domain.Role]*domain.RoleDefinition)}
+}
+
+func (m *mockRoleRepository) FindAll(_ context.Context) ([]domain.RoleDefinition, error) {
+	m.loads++
+	roles := make([]domain.RoleDefinition, 0, len(m.roles))
+	for _, r := range m.roles {
+		roles = append(roles, *r)
+	}
+	return roles, nil
+}
+
+func (m *mockRoleRepository) FindByName(_ context.Context, name domain.Role) (*domain.RoleDefinition, error) {
+	r, ok := m.roles[name]
+	if !ok {
+		return nil, domain.ErrRoleNotFound
+	}
+	return r, nil
+}
+
+func (m *mockRoleRepository) Save(_ context.Context, role *domain.RoleDefinition) (*domain.RoleDefinition, error) {
+	if existing, ok := m.roles[role.Name]; ok {
+		role.BuiltIn, role.CreatedAt = existing.BuiltIn, existing.CreatedAt
+	}
+	m.roles[role.Name] = role
+	return role, nil
+}
+
+func (m *mockRoleRepository) InsertIfMissing(_ context.Context, role *domain.RoleDefinition) error {
+	if _, ok := m.roles[role.Name]; !ok {
+		copied := *role
+		m.roles[role.Name] = &copied
+	}
+	return nil
+}
+
+func (m *mockRoleRepository) Delete(_ context.Context, name domain.Role) error {
+	r, ok := m.roles[name]
+	if !ok || r.BuiltIn {
+		return domain.ErrRoleNotFound
+	}
+	delete(m.roles, name)
+	return nil
+}
+
+// mockLeaveHistoryRepository จำลอง LeaveHistoryRepository ในหน่วยความจำ
+type mockLeaveHistoryRepository struct {
+	entries []domain.LeaveHistoryEntry
+}
+
+func (m *mockLeaveHistoryRepository) Append(_ context.Context, entry *domain.LeaveHistoryEntry) error {
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *mockLeaveHistoryRepository) FindByRequestID(_ context.Context, requestID domain.ID) ([]domain.LeaveHistoryEntry, error) {
+	entries := []domain.LeaveHistoryEntry{}
+	for _, e := range m.entries {
+		if e.RequestID == requestID {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// actions คืนประเภทเหตุการณ์ทั้งหมดตามลำดับที่บันทึก
+func (m *mockLeaveHistoryRepository) actions() []domain.LeaveHistoryAction {
+	actions := make([]domain.LeaveHistoryAction, 0, len(m.entries))
+	for _, e := range m.entries {
+		actions = append(actions, e.Action)
+	}
+	return actions
+}
+
+// mockLeaveCommentRepository จำลอง LeaveCommentRepository ในหน่วยความจำ
+type mockLeaveCommentRepository struct {
+	comments []domain.LeaveComment
+}
+
+func (m *mockLeaveCommentRepository) Create(_ context.Context, comment *domain.LeaveComment) error {
+	m.comments = append(m.comments, *comment)
+	return nil
+}
+
+func (m *mockLeaveCommentRepository) FindByRequestID(_ context.Context, requestID domain.ID, includeInternal bool) ([]domain.LeaveComment, error) {
+	comments := []domain.LeaveComment{}
+	for _, c := range m.comments {
+		if c.RequestID == requestID && (includeInternal || !c.Internal) {
+			comments = append(comments, c)
+		}
+	}
+	return comments, nil
+}
+
+// mockOutboxRepository เก็บข้อความใน outbox ตามลำดับที่บันทึก
+type mockOutboxRepository struct {
+	messages []*domain.OutboxMessage
+	err      error
+}
+
+func (m *mockOutboxRepository) Append(_ context.Context, msg *domain.OutboxMessage) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func (m *mockOutboxRepository) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*domain.OutboxMessage, error) {
+	for _, msg := range m.messages {
+		if msg.Status == domain.OutboxPending && !msg.NextAttemptAt.After(now) {
+			msg.NextAttemptAt = now.Add(lease)
+			return msg, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOutboxRepository) Update(_ context.Context, _ *domain.OutboxMessage) error {
+	return nil
+}
+
+func (m *mockOutboxRepository) FindByID(_ context.Context, id domain.ID) (*domain.OutboxMessage, error) {
+	for _, msg := range m.messages {
+		if msg.ID == id {
+			return msg, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOutboxRepository) ListCreatedSince(_ context.Context, since time.Time, limit int) ([]domain.OutboxMessage, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	var msgs []domain.OutboxMessage
+	for _, msg := range m.messages {
+		if !msg.CreatedAt.Before(since) {
+			msgs = append(msgs, *msg)
+		}
+	}
+	slices.SortStableFunc(msgs, func(a, b domain.OutboxMessage) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return msgs[:min(limit, len(msgs))], nil
+}
+
+// events คืนเหตุการณ์ทั้งหมดใน outbox ตามลำดับที่บันทึก
+func (m *mockOutboxRepository) events() []domain.LeaveEvent {
+	events := make([]domain.LeaveEvent, 0, len(m.messages))
+	for _, msg := range m.messages {
+		events = append(events, msg.Event)
+	}
+	return events
+}
+
+// mockTransactionManager เรียก fn ตรง ๆ และนับจำนวน transaction
+type mockTransactionManager struct {
+	calls int
+}
+
+func (m *mockTransactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.calls++
+	return fn(ctx)
+}
+
+// mockAuditLogger เก็บ audit event ที่ถูกบันทึกไว้ตรวจในการทดสอบ
+type mockAuditLogger struct {
+	events []*domain.AuditEvent
+	err    error
+	mu     sync.Mutex
+}
+
+func (m *mockAuditLogger) Record(_ context.Context, event *domain.AuditEvent) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.events = append(m.events, event)
+	return nil
+}
+
+// actions คืนการกระทำของ event ทั้งหมดตามลำดับที่บันทึก
+func (m *mockAuditLogger) actions() []domain.AuditAction {
+	actions := make([]domain.AuditAction, 0, len(m.events))
+	for _, e := range m.events {
+		actions = append(actions, e.Action)
+	}
+	return actions
+}
+
+// mockAuditRepository จำลอง AuditRepository ในหน่วยความจำ — conflicts คือจำนวนครั้งที่ Append จะคืน sequence ชน
+type mockAuditRepository struct {
+	events    []domain.AuditEvent
+	conflicts int
+}
+
+func (m *mockAuditRepository) Last(_ context.Context) (*domain.AuditEvent, error) {
+	if len(m.events) == 0 {
+		return nil, nil
+	}
+	last := m.events[len(m.events)-1]
+	return &last, nil
+}
+
+func (m *mockAuditRepository) Append(_ context.Context, event *domain.AuditEvent) error {
+	if m.conflicts > 0 {
+		m.conflicts--
+		return domain.ErrAuditSequenceConflict
+	}
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *mockAuditRepository) Search(
+	_ context.Context,
+	_ domain.AuditFilter,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.AuditEvent], error) {
+	return domain.NewPaginatedResult(m.events, int64(len(m.events)), params), nil
+}
+
+func (m *mockAuditRepository) FindAfter(_ context.Context, afterSequence int64, limit int64) ([]domain.AuditEvent, error) {
+	var result []domain.AuditEvent
+	for _, e := range m.events {
+		if e.Sequence > afterSequence && int64(len(result)) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+// mockWebhookSubscriptionRepository จำลอง WebhookSubscriptionRepository ในหน่วยความจำ
+type mockWebhookSubscriptionRepository struct {
+	subs map[domain.ID]*domain.WebhookSubscription
+}
+
+func newMockWebhookSubscriptionRepository(subs ...*domain.WebhookSubscription) *mockWebhookSubscriptionRepository {
+	m := &mockWebhookSubscriptionRepository{subs: make(map[domain.ID]*domain.WebhookSubscription)}
+	for _, s := range subs {
+		m.subs[s.ID] = s
+	}
+	return m
+}
+
+func (m *mockWebhookSubscriptionRepository) Create(_ context.Context, sub *domain.WebhookSubscription) error {
+	m.subs[sub.ID] = sub
+	return nil
+}
+
+func (m *mockWebhookSubscriptionRepository) FindByID(_ context.Context, id domain.ID) (*domain.WebhookSubscription, error) {
+	if s, ok := m.subs[id]; ok {
+		return s, nil
+	}
+	return nil, domain.ErrWebhookNotFound
+}
+
+func (m *mockWebhookSubscriptionRepository) FindAll(_ context.Context) ([]domain.WebhookSubscription, error) {
+	result := make([]domain.WebhookSubscription, 0, len(m.subs))
+	for _, s := range m.subs {
+		result = append(result, *s)
+	}
+	return result, nil
+}
+
+func (m *mockWebhookSubscriptionRepository) FindActiveByEvent(
+	_ context.Context,
+	eventType domain.LeaveEventType,
+) ([]domain.WebhookSubscription, error) {
+	var result []domain.WebhookSubscription
+	for _, s := range m.subs {
+		if s.Subscribes(eventType) {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockWebhookSubscriptionRepository) Update(_ context.Context, sub *domain.WebhookSubscription) error {
+	m.subs[sub.ID] = sub
+	return nil
+}
+
+func (m *mockWebhookSubscriptionRepository) Delete(_ context.Context, id domain.ID) error {
+	if _, ok := m.subs[id]; !ok {
+		return domain.ErrWebhookNotFound
+	}
+	delete(m.subs, id)
+	return nil
+}
+
+// mockWebhookDeliveryRepository จำลอง outbox ของ webhook ในหน่วยความจำ
+type mockWebhookDeliveryRepository struct {
+	deliveries []*domain.WebhookDelivery
+}
+
+// CreateMany ข้ามรายการที่รหัสเหตุการณ์และ webhook ซ้ำ เหมือน unique index ของ MongoDB
+func (m *mockWebhookDeliveryRepository) CreateMany(_ context.Context, deliveries []*domain.WebhookDelivery) error {
+	for _, d := range deliveries {
+		if !slices.ContainsFunc(m.deliveries, func(existing *domain.WebhookDelivery) bool {
+			return existing.EventID == d.EventID && existing.SubscriptionID == d.SubscriptionID
+		}) {
+			m.deliveries = append(m.deliveries, d)
+		}
+	}
+	return nil
+}
+
+func (m *mockWebhookDeliveryRepository) FindByID(_ context.Context, id domain.ID) (*domain.WebhookDelivery, error) {
+	for _, d := range m.deliveries {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, domain.ErrWebhookDeliveryNotFound
+}
+
+func (m *mockWebhookDeliveryRepository) Search(
+	_ context.Context,
+	_ domain.WebhookDeliveryFilter,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.WebhookDelivery], error) {
+	items := make([]domain.WebhookDelivery, 0, len(m.deliveries))
+	for _, d := range m.deliveries {
+		items = append(items, *d)
+	}
+	return domain.NewPaginatedResult(items, int64(len(items)), params), nil
+}
+
+func (m *mockWebhookDeliveryRepository) ClaimDue(_ context.Context, now time.Time, lease time.Duration) (*domain.WebhookDelivery, error) {
+	for _, d := range m.deliveries {
+		if d.Status == domain.WebhookDeliveryPending && !d.NextAttemptAt.After(now) {
+			d.NextAttemptAt = now.Add(lease)
+			return d, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockWebhookDeliveryRepository) Update(_ context.Context, _ *domain.WebhookDelivery) error {
+	return nil // เก็บเป็น pointer อยู่แล้ว
+}
+
+// mockWebhookSender เก็บ request ที่ส่งไว้ตรวจ และตอบด้วย status/err ที่กำหนด
+type mockWebhookSender struct {
+	requests []sentWebhook
+	status   int
+	err      error
+}
+
+type sentWebhook struct {
+	headers map[string]string
+	url     string
+	body    []byte
+}
+
+func (m *mockWebhookSender) Send(_ context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	m.requests = append(m.requests, sentWebhook{url: url, headers: headers, body: body})
+	return m.status, m.err
+}
+
+// mockIdempotencyRepository เก็บการจอง key ไว้ใน map ตามผู้ใช้และ key
+type mockIdempotencyRepository struct {
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMockIdempotencyRepository() *mockIdempotencyRepository {
+	return &mockIdempotencyRepository{records: make(map[string]*domain.IdempotencyRecord)}
+}
+
+func idempotencyMapKey(userID domain.ID, key string) string {
+	return userID.String() + "/" + key
+}
+
+func (m *mockIdempotencyRepository) Reserve(_ context.Context, record *domain.IdempotencyRecord, now time.Time) (bool, error) {
+	k := idempotencyMapKey(record.UserID, record.Key)
+	if existing, ok := m.records[k]; ok && existing.ExpiresAt.After(now) {
+		return false, nil
+	}
+	m.records[k] = record
+	return true, nil
+}
+
+func (m *mockIdempotencyRepository) Find(_ context.Context, userID domain.ID, key string) (*domain.IdempotencyRecord, error) {
+	return m.records[idempotencyMapKey(userID, key)], nil
+}
+
+func (m *mockIdempotencyRepository) Update(_ context.Context, _ *domain.IdempotencyRecord) error {
+	return nil // เก็บเป็น pointer อยู่แล้ว
+}
+
+func (m *mockIdempotencyRepository) Delete(_ context.Context, id domain.ID) error {
+	for k, r := range m.records {
+		if r.ID == id {
+			delete(m.records, k)
+		}
+	}
+	return nil
+}
+
+type mockReportRepository struct {
+	leaveUsageFn         func(ctx context.Context, filter domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error)
+	absenteeismFn        func(ctx context.Context, filter domain.ReportFilter) ([]domain.TeamAbsenteeism, error)
+	approvalTurnaroundFn func(ctx context.Context, filter domain.ReportFilter) ([]domain.ApprovalTurnaround, error)
+	rejectionCountsFn    func(ctx context.Context, filter domain.ReportFilter) ([]domain.ReviewerRejectionRate, error)
+	carryOverRisksFn     func(ctx context.Context, filter domain.ReportFilter, carryOverDays float64, limit int) ([]domain.CarryOverRisk, error)
+}
+
+func (m *mockReportRepository) LeaveUsageByMonth(ctx context.Context, filter domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error) {
+	if m.leaveUsageFn != nil {
+		return m.leaveUsageFn(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *mockReportRepository) AbsenteeismByTeam(ctx context.Context, filter domain.ReportFilter) ([]domain.TeamAbsenteeism, error) {
+	if m.absenteeismFn != nil {
+		return m.absenteeismFn(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *mockReportRepository) ApprovalTurnaround(ctx context.Context, filter domain.ReportFilter) ([]domain.ApprovalTurnaround, error) {
+	if m.approvalTurnaroundFn != nil {
+		return m.approvalTurnaroundFn(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *mockReportRepository) RejectionCountsByReviewer(ctx context.Context, filter domain.ReportFilter) ([]domain.ReviewerRejectionRate, error) {
+	if m.rejectionCountsFn != nil {
+		return m.rejectionCountsFn(ctx, filter)
+	}
+	return nil, nil
+}
+
+func (m *mockReportRepository) CarryOverRisks(
+	ctx context.Context,
+	filter domain.ReportFilter,
+	carryOverDays float64,
+	limit int,
+) ([]domain.CarryOverRisk, error) {
+	if m.carryOverRisksFn != nil {
+		return m.carryOverRisksFn(ctx, filter, carryOverDays, limit)
+	}
+	return nil, nil
+}
+
+type mockExportRepository struct {
+	eachRequestFn func(ctx context.Context, query domain.LeaveRequestQuery, fn func(*domain.LeaveRequestView) error) error
+	eachBalanceFn func(ctx context.Context, query domain.LeaveBalanceQuery, fn func(*domain.LeaveBalanceView) error) error
+}
+
+func (m *mockExportRepository) EachRequest(
+	ctx context.Context,
+	query domain.LeaveRequestQuery,
+	fn func(*domain.LeaveRequestView) error,
+) error {
+	if m.eachRequestFn != nil {
+		return m.eachRequestFn(ctx, query, fn)
+	}
+	return nil
+}
+
+func (m *mockExportRepository) EachBalance(
+	ctx context.Context,
+	query domain.LeaveBalanceQuery,
+	fn func(*domain.LeaveBalanceView) error,
+) error {
+	if m.eachBalanceFn != nil {
+		return m.eachBalanceFn(ctx, query, fn)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// NotificationOptions ค่าตั้งค่าของระบบแจ้งเตือน — ค่าที่เป็นศูนย์ใช้ค่าเริ่มต้น
+type NotificationOptions struct {
+	DefaultLocale domain.Locale // ภาษาของผู้ใช้ที่ไม่ได้เลือกภาษา
+}
+
+type notificationService struct {
+	userRepo ports.UserRepository
+	roles    ports.RoleService
+	mailer   ports.Mailer
+	opts     NotificationOptions
+}
+
+// NewNotificationService สร้างระบบแจ้งเตือนทางอีเมล — ส่งภายใน Handle ของ dispatcher ซึ่งทำงานเบื้องหลังอยู่แล้ว
+func NewNotificationService(
+	userRepo ports.UserRepository,
+	roles ports.RoleService,
+	mailer ports.Mailer,
+	opts NotificationOptions,
+) ports.NotificationService {
+	if !opts.DefaultLocale.IsValid() {
+		opts.DefaultLocale = domain.LocaleThai
+	}
+	return &notificationService{
+		userRepo: userRepo,
+		roles:    roles,
+		mailer:   mailer,
+		opts:     opts,
+	}
+}
+
+// HandlerName ชื่อของ handler ใน outbox
+func (s *notificationService) HandlerName() string {
+	return "email"
+}
+
+// Handle สร้างอีเมลของเหตุการณ์แล้วส่งถึงผู้รับทุกคนก่อนคืนค่า — ผู้รับที่ส่งไม่สำเร็จไม่กระทบผู้รับคนอื่น
+// error ทำให้ outbox ลองใหม่แบบ backoff (at-least-once: ผู้รับที่ส่งสำเร็จแล้วอาจได้อีเมลซ้ำ)
+// เหตุการณ์ที่ไม่มีเทมเพลตอีเมล (เช่น balance.adjusted) ไม่ต้องแจ้งพนักงาน
+func (s *notificationService) Handle(ctx context.Context, event domain.LeaveEvent) error {
+	if _, ok := notificationTemplates[event.Type]; !ok {
+		return nil
+	}
+
+	messages, err := s.compose(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, msg := range messages {
+		if err = s.mailer.Send(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("ส่งอีเมลถึง %s ล้มเหลว: %w", msg.To, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// compose หาผู้รับและสร้างอีเมลตามภาษาของผู้รับแต่ละคน
+func (s *notificationService) compose(ctx context.Context, event domain.LeaveEvent) ([]domain.EmailMessage, error) {
+	tmpl, ok := notificationTemplates[event.Type]
+	if !ok {
+		return nil, fmt.Errorf("ไม่มีเทมเพลตสำหรับเหตุการณ์ %s", event.Type)
+	}
+
+	employee, err := s.userRepo.FindByID(ctx, event.Request.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาเจ้าของใบลาล้มเหลว: %w", err)
+	}
+	actor := employee
+	if event.ActorID != employee.ID {
+		if actor, err = s.userRepo.FindByID(ctx, event.ActorID); err != nil {
+			return nil, fmt.Errorf("ค้นหาผู้พิจารณาใบลาล้มเหลว: %w", err)
+		}
+	}
+
+	recipients, err := s.recipients(ctx, event, employee)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]domain.EmailMessage, 0, len(recipients))
+	for i := range recipients {
+		msg, err := s.render(tmpl, event, &recipients[i], employee, actor)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, nil
+}
+
+// recipients ผู้รับอีเมล — ใบลาใหม่แจ้งผู้มีสิทธิ์ leave.approve ทุกคน (ยกเว้นเจ้าของใบลา) ผลการพิจารณาแจ้งเจ้าของใบลา
+// การกล่าวถึงแจ้งผู้ที่ถูกกล่าวถึง (ยกเว้นผู้แสดงความคิดเห็นเอง)
+func (s *notificationService) recipients(
+	ctx context.Context,
+	event domain.LeaveEvent,
+	employee *domain.User,
+) ([]domain.User, error) {
+	switch event.Type {
+	case domain.LeaveEventSubmitted:
+		return s.approvers(ctx, employee)
+	case domain.LeaveEventCommentMentioned:
+		return s.mentioned(ctx, event)
+	default:
+		return []domain.User{*employee}, nil
+	}
+}
+
+// mentioned ผู้ที่ถูกกล่าวถึงในความคิดเห็น
+func (s *notificationService) mentioned(ctx context.Context, event domain.LeaveEvent) ([]domain.User, error) {
+	if event.Comment == nil {
+		return nil, nil
+	}
+	users, err := s.userRepo.FindByIDs(ctx, event.Comment.Mentions)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาผู้ที่ถูกกล่าวถึงล้มเหลว: %w", err)
+	}
+	recipients := users[:0]
+	for _, u := range users {
+		if u.ID != event.ActorID {
+			recipients = append(recipients, u)
+		}
+	}
+	return recipients, nil
+}
+
+// approvers ผู้มีสิทธิ์ leave.approve ทุกคน ยกเว้นเจ้าของใบลา
+func (s *notificationService) approvers(ctx context.Context, employee *domain.User) ([]domain.User, error) {
+
+	roles, err := s.roles.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงบทบาทล้มเหลว: %w", err)
+	}
+	var approverRoles []domain.Role
+	for i := range roles {
+		if roles[i].Has(domain.PermissionLeaveApprove) {
+			approverRoles = append(approverRoles, roles[i].Name)
+		}
+	}
+	if len(approverRoles) == 0 {
+		return nil, nil
+	}
+
+	approvers, err := s.userRepo.FindByRoles(ctx, approverRoles)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหาผู้อนุมัติล้มเหลว: %w", err)
+	}
+	recipients := approvers[:0]
+	for _, u := range approvers {
+		if u.ID != employee.ID {
+			recipients = append(recipients, u)
+		}
+	}
+	return recipients, nil
+}
+
+// render แทนค่าในเทมเพลตตามภาษาของผู้รับ
+func (s *notificationService) render(
+	tmpl map[domain.Locale]notificationTemplate,
+	event domain.LeaveEvent,
+	recipient, employee, actor *domain.User,
+) (domain.EmailMessage, error) {
+	locale := recipient.Locale
+	if !locale.IsValid() {
+		locale = s.opts.DefaultLocale
+	}
+
+	request := event.Request
+	data := notificationData{
+		RecipientName: recipient.FullName,
+		EmployeeName:  employee.FullName,
+		ActorName:     actor.FullName,
+		LeaveType:     leaveTypeLabels[locale][request.LeaveType],
+		StartDate:     request.StartDate.Format("2006-01-02"),
+		EndDate:       request.EndDate.Format("2006-01-02"),
+		TotalDays:     request.TotalDays,
+		Reason:        request.Reason,
+		Note:          request.ReviewNote,
+		RequestID:     request.ID.String(),
+	}
+	if event.Comment != nil {
+		data.Comment = event.Comment.Body
+	}
+
+	var subject, body bytes.Buffer
+	if err := tmpl[locale].subject.Execute(&subject, data); err != nil {
+		return domain.EmailMessage{}, fmt.Errorf("สร้างหัวเรื่องอีเมลล้มเหลว: %w", err)
+	}
+	if err := tmpl[locale].body.Execute(&body, data); err != nil {
+		return domain.EmailMessage{}, fmt.Errorf("สร้างเนื้อหาอีเมลล้มเหลว: %w", err)
+	}
+
+	return domain.EmailMessage{To: recipient.Email, Subject: subject.String(), Body: body.String()}, nil
+}
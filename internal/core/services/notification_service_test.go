@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// notificationFixture พนักงาน ผู้จัดการ 2 คน (ภาษาไทยและอังกฤษ) และ admin ที่ไม่มีสิทธิ์อนุมัติ
+type notificationFixture struct {
+	employee, manager, englishManager, admin *domain.User
+	request                                  *domain.LeaveRequest
+	mailer                                   *mockMailer
+}
+
+func newNotificationFixture() *notificationFixture {
+	f := &notificationFixture{
+		employee:       domain.NewUser("สมชาย", "ใจดี", "somchai@company.com", "", domain.RoleEmployee),
+		manager:        domain.NewUser("สมหญิง", "รักงาน", "somying@company.com", "", domain.RoleManager),
+		englishManager: domain.NewUser("John", "Smith", "john@company.com", "", domain.RoleManager),
+		admin:          domain.NewUser("ผู้ดูแล", "ระบบ", "admin@company.com", "", domain.RoleAdmin),
+		mailer:         &mockMailer{},
+	}
+	f.englishManager.Locale = domain.LocaleEnglish
+	f.request = domain.NewLeaveRequest(
+		f.employee.ID, domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), "ไปเที่ยวกับครอบครัว",
+	)
+	return f
+}
+
+func (f *notificationFixture) newService() *notificationService {
+	users := []*domain.User{f.employee, f.manager, f.englishManager, f.admin}
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.User, error) {
+			for _, u := range users {
+				if u.ID == id {
+					return u, nil
+				}
+			}
+			return nil, domain.ErrUserNotFound
+		},
+		findByRolesFn: func(_ context.Context, roles []domain.Role) ([]domain.User, error) {
+			var found []domain.User
+			for _, u := range users {
+				for _, r := range roles {
+					if u.Role == r {
+						found = append(found, *u)
+					}
+				}
+			}
+			return found, nil
+		},
+	}
+
+	roleRepo := newMockRoleRepository()
+	for _, def := range domain.DefaultRoles() {
+		roleRepo.roles[def.Name] = &def
+	}
+	roles := NewRoleService(roleRepo, userRepo, &mockAuditLogger{})
+
+	return NewNotificationService(userRepo, roles, f.mailer, NotificationOptions{}).(*notificationService)
+}
+
+func TestNotificationService_Submitted_NotifiesApproversInTheirLanguage(t *testing.T) {
+	f := newNotificationFixture()
+	svc := f.newService()
+
+	require.NoError(t, svc.Handle(context.Background(), domain.NewLeaveEvent(domain.LeaveEventSubmitted, f.request, f.employee.ID)))
+
+	require.Len(t, f.mailer.sent, 2, "แจ้งเฉพาะผู้มีสิทธิ์ leave.approve")
+	byRecipient := map[string]domain.EmailMessage{}
+	for _, msg := range f.mailer.sent {
+		byRecipient[msg.To] = msg
+	}
+
+	thai := byRecipient[f.manager.Email]
+	assert.Contains(t, thai.Subject, "สมชาย ใจดี")
+	assert.Contains(t, thai.Body, "ลาพักร้อน วันที่ 2026-06-01 ถึง 2026-06-03 (3 วัน)")
+
+	english := byRecipient[f.englishManager.Email]
+	assert.Contains(t, english.Subject, "New leave request awaiting approval")
+	assert.Contains(t, english.Body, "annual leave from 2026-06-01 to 2026-06-03 (3 days)")
+}
+
+func TestNotificationService_Rejected_NotifiesEmployeeWithNote(t *testing.T) {
+	f := newNotificationFixture()
+	svc := f.newService()
+	require.NoError(t, f.request.Reject(f.manager.ID, "ช่วงนั้นทีมขาดคน"))
+
+	require.NoError(t, svc.Handle(context.Background(), domain.NewLeaveEvent(domain.LeaveEventRejected, f.request, f.manager.ID)))
+
+	require.Len(t, f.mailer.sent, 1)
+	msg := f.mailer.sent[0]
+	assert.Equal(t, f.employee.Email, msg.To)
+	assert.Contains(t, msg.Body, "สมหญิง รักงาน ปฏิเสธลาพักร้อนของคุณ")
+	assert.Contains(t, msg.Body, "หมายเหตุ: ช่วงนั้นทีมขาดคน")
+}
+
+func TestNotificationService_CommentMentioned_NotifiesMentionedExceptAuthor(t *testing.T) {
+	f := newNotificationFixture()
+	svc := f.newService()
+	comment := domain.NewLeaveComment(f.request.ID, f.manager.ID, "รบกวนส่งใบรับรองแพทย์ด้วยครับ", false,
+		[]domain.ID{f.employee.ID, f.manager.ID})
+
+	require.NoError(t, svc.Handle(context.Background(), domain.NewCommentMentionedEvent(f.request, comment)))
+
+	require.Len(t, f.mailer.sent, 1, "ผู้แสดงความคิดเห็นไม่ได้รับอีเมลของตัวเอง")
+	msg := f.mailer.sent[0]
+	assert.Equal(t, f.employee.Email, msg.To)
+	assert.Contains(t, msg.Body, "สมหญิง รักงาน กล่าวถึงคุณในลาพักร้อนของ สมชาย ใจดี")
+	assert.Contains(t, msg.Body, "รบกวนส่งใบรับรองแพทย์ด้วยครับ")
+}
+
+func TestNotificationService_FailureReturnsErrorForOutboxRetry(t *testing.T) {
+	f := newNotificationFixture()
+	f.mailer.failures = 1
+	svc := f.newService()
+	require.NoError(t, f.request.Approve(f.manager.ID, ""))
+	event := domain.NewLeaveEvent(domain.LeaveEventApproved, f.request, f.manager.ID)
+
+	err := svc.Handle(context.Background(), event)
+
+	require.Error(t, err, "ส่งไม่สำเร็จต้องคืน error ให้ outbox ลองใหม่")
+	assert.Contains(t, err.Error(), f.employee.Email)
+	assert.Empty(t, f.mailer.sent)
+
+	require.NoError(t, svc.Handle(context.Background(), event), "outbox ลองใหม่แล้วส่งสำเร็จ")
+	require.Len(t, f.mailer.sent, 1)
+	assert.NotContains(t, f.mailer.sent[0].Body, "หมายเหตุ")
+}
+
+func TestNotificationService_IgnoresEventsWithoutTemplate(t *testing.T) {
+	f := newNotificationFixture()
+	svc := f.newService()
+	balance := domain.NewLeaveBalance(f.employee.ID, domain.LeaveTypeAnnual, 10, 2026)
+
+	require.NoError(t, svc.Handle(context.Background(), domain.NewBalanceAdjustedEvent(balance, f.manager.ID)))
+	assert.Empty(t, f.mailer.sent)
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"strconv"
+	"text/template"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// notificationData ข้อมูลที่ใช้แทนค่าในเทมเพลตอีเมล
+type notificationData struct {
+	RecipientName string
+	EmployeeName  string
+	ActorName     string
+	LeaveType     string
+	StartDate     string
+	EndDate       string
+	Reason        string
+	Note          string
+	Comment       string
+	RequestID     string
+	TotalDays     float64
+}
+
+type notificationTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+var notificationFuncs = template.FuncMap{
+	"days": func(d float64) string { return strconv.FormatFloat(d, 'f', -1, 64) },
+}
+
+// leaveTypeLabels ชื่อประเภทการลาตามภาษา
+var leaveTypeLabels = map[domain.Locale]map[domain.LeaveType]string{
+	domain.LocaleThai: {
+		domain.LeaveTypeSick:     "ลาป่วย",
+		domain.LeaveTypeAnnual:   "ลาพักร้อน",
+		domain.LeaveTypePersonal: "ลากิจ",
+	},
+	domain.LocaleEnglish: {
+		domain.LeaveTypeSick:     "sick leave",
+		domain.LeaveTypeAnnual:   "annual leave",
+		domain.LeaveTypePersonal: "personal leave",
+	},
+}
+
+// notificationTemplates เทมเพลตอีเมลต่อเหตุการณ์และภาษา
+var notificationTemplates = map[domain.LeaveEventType]map[domain.Locale]notificationTemplate{
+	domain.LeaveEventSubmitted: {
+		domain.LocaleThai: newNotificationTemplate(
+			"ใบลาใหม่รออนุมัติ: {{.EmployeeName}} ({{.LeaveType}})",
+			`เรียน {{.RecipientName}}
+
+{{.EmployeeName}} ยื่น{{.LeaveType}} วันที่ {{.StartDate}} ถึง {{.EndDate}} ({{days .TotalDays}} วัน)
+เหตุผล: {{.Reason}}
+
+กรุณาพิจารณาที่หน้าใบลารออนุมัติ
+รหัสใบลา: {{.RequestID}}
+`),
+		domain.LocaleEnglish: newNotificationTemplate(
+			"New leave request awaiting approval: {{.EmployeeName}} ({{.LeaveType}})",
+			`Dear {{.RecipientName}},
+
+{{.EmployeeName}} has requested {{.LeaveType}} from {{.StartDate}} to {{.EndDate}} ({{days .TotalDays}} days).
+Reason: {{.Reason}}
+
+Please review it on the pending requests page.
+Request ID: {{.RequestID}}
+`),
+	},
+	domain.LeaveEventApproved: {
+		domain.LocaleThai: newNotificationTemplate(
+			"ใบลาของคุณได้รับการอนุมัติ ({{.LeaveType}} {{.StartDate}})",
+			`เรียน {{.RecipientName}}
+
+{{.ActorName}} อนุมัติ{{.LeaveType}}ของคุณ วันที่ {{.StartDate}} ถึง {{.EndDate}} ({{days .TotalDays}} วัน) แล้ว
+{{- if .Note}}
+หมายเหตุ: {{.Note}}
+{{- end}}
+
+รหัสใบลา: {{.RequestID}}
+`),
+		domain.LocaleEnglish: newNotificationTemplate(
+			"Your leave request has been approved ({{.LeaveType}} {{.StartDate}})",
+			`Dear {{.RecipientName}},
+
+{{.ActorName}} has approved your {{.LeaveType}} from {{.StartDate}} to {{.EndDate}} ({{days .TotalDays}} days).
+{{- if .Note}}
+Note: {{.Note}}
+{{- end}}
+
+Request ID: {{.RequestID}}
+`),
+	},
+	domain.LeaveEventRejected: {
+		domain.LocaleThai: newNotificationTemplate(
+			"ใบลาของคุณไม่ได้รับการอนุมัติ ({{.LeaveType}} {{.StartDate}})",
+			`เรียน {{.RecipientName}}
+
+{{.ActorName}} ปฏิเสธ{{.LeaveType}}ของคุณ วันที่ {{.StartDate}} ถึง {{.EndDate}} ({{days .TotalDays}} วัน)
+{{- if .Note}}
+หมายเหตุ: {{.Note}}
+{{- end}}
+
+รหัสใบลา: {{.RequestID}}
+`),
+		domain.LocaleEnglish: newNotificationTemplate(
+			"Your leave request has been rejected ({{.LeaveType}} {{.StartDate}})",
+			`Dear {{.RecipientName}},
+
+{{.ActorName}} has rejected your {{.LeaveType}} from {{.StartDate}} to {{.EndDate}} ({{days .TotalDays}} days).
+{{- if .Note}}
+Note: {{.Note}}
+{{- end}}
+
+Request ID: {{.RequestID}}
+`),
+	},
+	domain.LeaveEventCommentMentioned: {
+		domain.LocaleThai: newNotificationTemplate(
+			"มีผู้กล่าวถึงคุณในความคิดเห็นของใบลา",
+			`เรียน {{.RecipientName}}
+
+{{.ActorName}} กล่าวถึงคุณใน{{.LeaveType}}ของ {{.EmployeeName}} วันที่ {{.StartDate}} ถึง {{.EndDate}}
+
+{{.Comment}}
+
+รหัสใบลา: {{.RequestID}}
+`),
+		domain.LocaleEnglish: newNotificationTemplate(
+			"You were mentioned in a leave request comment",
+			`Dear {{.RecipientName}},
+
+{{.ActorName}} mentioned you on {{.EmployeeName}}'s {{.LeaveType}} from {{.StartDate}} to {{.EndDate}}.
+
+{{.Comment}}
+
+Request ID: {{.RequestID}}
+`),
+	},
+}
+
+func newNotificationTemplate(subject, body string) notificationTemplate {
+	return notificationTemplate{
+		subject: template.Must(template.New("subject").Funcs(notificationFuncs).Parse(subject)),
+		body:    template.Must(template.New("body").Funcs(notificationFuncs).Parse(body)),
+	}
+}
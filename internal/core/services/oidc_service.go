@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const oidcStateTTL = 10 * time.Minute // เวลาที่ให้ผู้ใช้ login ที่ IdP ให้เสร็จ
+
+type oidcService struct {
+	provider  ports.IdentityProvider
+	stateRepo ports.OIDCStateRepository
+	userRepo  ports.UserRepository
+	issuer    tokenIssuer
+	audit     ports.AuditLogger
+	roles     domain.GroupRoleMapping
+}
+
+// NewOIDCService สร้าง OIDCService — roles คือการแปลงกลุ่มใน IdP เป็นบทบาทในระบบ
+func NewOIDCService(
+	provider ports.IdentityProvider,
+	stateRepo ports.OIDCStateRepository,
+	userRepo ports.UserRepository,
+	refreshRepo ports.RefreshTokenRepository,
+	tokenService ports.TokenService,
+	audit ports.AuditLogger,
+	roles domain.GroupRoleMapping,
+	refreshTTL time.Duration,
+) ports.OIDCService {
+	return &oidcService{
+		provider:  provider,
+		stateRepo: stateRepo,
+		userRepo:  userRepo,
+		issuer:    tokenIssuer{refreshRepo: refreshRepo, tokenService: tokenService, refreshTTL: refreshTTL},
+		audit:     audit,
+		roles:     roles,
+	}
+}
+
+// BeginLogin สุ่ม state, nonce และ PKCE verifier เก็บไว้ฝั่ง server แล้วสร้าง URL ของ IdP
+func (s *oidcService) BeginLogin(ctx context.Context) (state, authURL string, err error) {
+	state, stateHash, err := generateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	nonce, _, err := generateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	verifier, _, err := generateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+
+	if err = s.stateRepo.Create(ctx, domain.NewOIDCLoginState(stateHash, nonce, verifier, oidcStateTTL)); err != nil {
+		return "", "", fmt.Errorf("บันทึก OIDC state ล้มเหลว: %w", err)
+	}
+
+	authURL, err = s.provider.AuthCodeURL(ctx, state, nonce, pkceChallenge(verifier))
+	if err != nil {
+		return "", "", err
+	}
+	return state, authURL, nil
+}
+
+// CompleteLogin ตรวจ state แลก code กับ IdP แล้วออก token ของระบบให้ผู้ใช้ที่จับคู่ได้
+func (s *oidcService) CompleteLogin(ctx context.Context, state, code string) (*domain.AuthTokens, *domain.User, error) {
+	loginState, err := s.stateRepo.Consume(ctx, hashOpaqueToken(state))
+	if err != nil {
+		return nil, nil, err
+	}
+	if loginState.IsExpired(time.Now()) {
+		return nil, nil, domain.ErrInvalidOIDCState
+	}
+
+	identity, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user, err := s.provisionUser(ctx, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := s.issuer.issue(ctx, user, domain.NewID(), identity.MFA)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	method := "oidc"
+	if identity.MFA {
+		method += "+mfa"
+	}
+	event := domain.NewAuditEvent(domain.AuditLoginSucceeded, domain.AuditTargetUser, user.ID.String(),
+		domain.DiffChanges(nil, map[string]any{"method": method})).By(user.ID)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, nil, err
+	}
+	return tokens, user, nil
+}
+
+// provisionUser หาผู้ใช้ที่ผูกกับบัญชี IdP นี้ — ถ้ายังไม่มีจะผูกกับอีเมลเดิมหรือสร้างใหม่ (just-in-time)
+// ชื่อและบทบาทถูกปรับตาม IdP ทุกครั้งที่ login
+func (s *oidcService) provisionUser(ctx context.Context, identity *domain.ExternalIdentity) (*domain.User, error) {
+	role := s.roles.Resolve(identity.Groups)
+
+	user, err := s.userRepo.FindByExternalID(ctx, identity.Provider, identity.Subject)
+	if errors.Is(err, domain.ErrUserNotFound) {
+		return s.linkOrCreateUser(ctx, identity, role)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user.ApplyExternalIdentity(identity, role)
+	if err = s.userRepo.UpdateExternalIdentity(ctx, user); err != nil {
+		return nil, fmt.Errorf("อัปเดตข้อมูลผู้ใช้จาก IdP ล้มเหลว: %w", err)
+	}
+	return user, nil
+}
+
+// linkOrCreateUser ผูกบัญชี IdP กับผู้ใช้ที่มีอีเมลตรงกัน หรือสร้างผู้ใช้ใหม่ — ต้องเป็นอีเมลที่ IdP ยืนยันแล้ว
+func (s *oidcService) linkOrCreateUser(ctx context.Context, identity *domain.ExternalIdentity, role domain.Role) (*domain.User, error) {
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, domain.ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.userRepo.FindByEmail(ctx, strings.ToLower(identity.Email))
+	if errors.Is(err, domain.ErrUserNotFound) {
+		user = domain.NewExternalUser(identity, role)
+		if err = s.userRepo.Create(ctx, user); err != nil {
+			if errors.Is(err, domain.ErrEmailAlreadyExists) {
+				return nil, domain.ErrOIDCAccountConflict // ผู้ใช้อื่นสร้างอีเมลนี้ไปพร้อมกัน
+			}
+			return nil, fmt.Errorf("สร้างผู้ใช้จาก IdP ล้มเหลว: %w", err)
+		}
+		return user, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsExternal() {
+		return nil, domain.ErrOIDCAccountConflict
+	}
+
+	user.ApplyExternalIdentity(identity, role)
+	if err = s.userRepo.UpdateExternalIdentity(ctx, user); err != nil {
+		return nil, fmt.Errorf("ผูกบัญชีกับ IdP ล้มเหลว: %w", err)
+	}
+	return user, nil
+}
+
+// pkceChallenge คำนวณ PKCE code challenge แบบ S256 (RFC 7636)
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
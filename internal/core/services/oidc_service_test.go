@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+const testIssuer = "https://idp.example.com"
+
+// testRoleMapping การแปลงกลุ่มเป็นบทบาทที่ใช้ในการทดสอบ
+var testRoleMapping = domain.GroupRoleMapping{
+	Groups: map[string]domain.Role{
+		"hr-managers": domain.RoleManager,
+		"it-admins":   domain.RoleAdmin,
+	},
+	DefaultRole: domain.RoleEmployee,
+}
+
+// newTestIdentity สร้างข้อมูลผู้ใช้จาก IdP ที่ยืนยันอีเมลแล้ว
+func newTestIdentity(groups ...string) *domain.ExternalIdentity {
+	return &domain.ExternalIdentity{
+		Provider:      testIssuer,
+		Subject:       "sub-123",
+		Email:         "Somchai@Company.com",
+		FirstName:     "สมชาย",
+		LastName:      "ใจดี",
+		Groups:        groups,
+		EmailVerified: true,
+	}
+}
+
+// newTestOIDCService สร้าง OIDCService พร้อม mock ทั้งหมด
+func newTestOIDCService(idp *mockIdentityProvider, userRepo *mockUserRepository, tokenSvc *mockTokenService) (*oidcService, *mockOIDCStateRepository) {
+	stateRepo := newMockOIDCStateRepository()
+	svc := NewOIDCService(
+		idp, stateRepo, userRepo, newMockRefreshTokenRepository(), tokenSvc, &mockAuditLogger{}, testRoleMapping, testRefreshTTL,
+	).(*oidcService)
+	return svc, stateRepo
+}
+
+// beginTestLogin เริ่ม login แล้วคืน state ที่ต้องส่งกลับมาตอน callback
+func beginTestLogin(t *testing.T, svc *oidcService) string {
+	t.Helper()
+	state, authURL, err := svc.BeginLogin(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, authURL)
+	return state
+}
+
+func TestOIDCService_BeginLogin_UsesPKCEAndNonce(t *testing.T) {
+	idp := &mockIdentityProvider{identity: newTestIdentity()}
+	svc, stateRepo := newTestOIDCService(idp, &mockUserRepository{}, &mockTokenService{})
+
+	state := beginTestLogin(t, svc)
+
+	stored, ok := stateRepo.states[hashOpaqueToken(state)]
+	require.True(t, ok, "ต้องเก็บเฉพาะ hash ของ state")
+	assert.Equal(t, pkceChallenge(stored.CodeVerifier), idp.codeChallenge, "ต้องส่ง S256 challenge ของ verifier ที่เก็บไว้")
+	assert.NotEqual(t, stored.CodeVerifier, idp.codeChallenge, "ห้ามส่ง verifier ไปตอนขอ authorization")
+	assert.Equal(t, stored.Nonce, idp.nonce)
+}
+
+func TestOIDCService_CompleteLogin_ProvisionsNewUser(t *testing.T) {
+	var created *domain.User
+	userRepo := &mockUserRepository{
+		createFn: func(_ context.Context, user *domain.User) error {
+			created = user
+			return nil
+		},
+	}
+	idp := &mockIdentityProvider{identity: newTestIdentity("HR-Managers")}
+	svc, _ := newTestOIDCService(idp, userRepo, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+
+	tokens, user, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	require.NoError(t, err)
+	require.NotNil(t, created, "ต้องสร้างผู้ใช้ใหม่ (just-in-time)")
+	assert.Equal(t, created.ID, user.ID)
+	assert.Equal(t, "somchai@company.com", user.Email)
+	assert.Equal(t, domain.RoleManager, user.Role, "กลุ่มต้องแปลงเป็นบทบาทโดยไม่สนตัวพิมพ์")
+	assert.True(t, user.IsExternal())
+	assert.Empty(t, user.PasswordHash, "ผู้ใช้จาก IdP ต้องไม่มีรหัสผ่าน")
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, idp.codeChallenge, pkceChallenge(idp.codeVerifier), "ต้องส่ง verifier คู่กับ challenge ตอนแลก code")
+	assert.Equal(t, idp.nonce, idp.exchangeNonce, "ต้องตรวจ nonce เดียวกับที่ส่งไป")
+}
+
+func TestOIDCService_CompleteLogin_ExistingSubjectSyncsRole(t *testing.T) {
+	existing := domain.NewExternalUser(newTestIdentity(), domain.RoleManager)
+	var updated *domain.User
+	userRepo := &mockUserRepository{
+		findByExternalIDFn: func(_ context.Context, provider, subject string) (*domain.User, error) {
+			if provider == testIssuer && subject == "sub-123" {
+				return existing, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+		updateExternalFn: func(_ context.Context, user *domain.User) error {
+			updated = user
+			return nil
+		},
+	}
+	// ถูกย้ายออกจากกลุ่ม hr-managers ใน IdP แล้ว
+	idp := &mockIdentityProvider{identity: newTestIdentity()}
+	svc, _ := newTestOIDCService(idp, userRepo, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+
+	_, user, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, user.ID)
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.RoleEmployee, updated.Role, "บทบาทต้องตามกลุ่มล่าสุดใน IdP")
+}
+
+func TestOIDCService_CompleteLogin_LinksExistingEmail(t *testing.T) {
+	local := domain.NewUser("สมชาย", "ใจดี", "somchai@company.com", "hash", domain.RoleEmployee)
+	var linked *domain.User
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
+			if email == "somchai@company.com" {
+				return local, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+		updateExternalFn: func(_ context.Context, user *domain.User) error {
+			linked = user
+			return nil
+		},
+	}
+	idp := &mockIdentityProvider{identity: newTestIdentity("it-admins", "hr-managers")}
+	svc, _ := newTestOIDCService(idp, userRepo, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+
+	_, user, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	require.NoError(t, err)
+	assert.Equal(t, local.ID, user.ID, "ต้องผูกกับผู้ใช้เดิม ไม่สร้างใหม่")
+	require.NotNil(t, linked)
+	assert.Equal(t, "sub-123", linked.ExternalID)
+	assert.Equal(t, domain.RoleAdmin, linked.Role, "ต้องเลือกบทบาทสูงสุดจากทุกกลุ่ม")
+}
+
+func TestOIDCService_CompleteLogin_UnverifiedEmailRejected(t *testing.T) {
+	identity := newTestIdentity()
+	identity.EmailVerified = false
+	userRepo := &mockUserRepository{
+		createFn: func(_ context.Context, _ *domain.User) error {
+			t.Fatal("ห้ามสร้างผู้ใช้จากอีเมลที่ยังไม่ยืนยัน")
+			return nil
+		},
+	}
+	svc, _ := newTestOIDCService(&mockIdentityProvider{identity: identity}, userRepo, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+
+	_, _, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	assert.ErrorIs(t, err, domain.ErrOIDCEmailNotVerified)
+}
+
+func TestOIDCService_CompleteLogin_EmailOwnedByOtherIdentity(t *testing.T) {
+	other := newTestIdentity()
+	other.Subject = "another-sub"
+	owner := domain.NewExternalUser(other, domain.RoleEmployee)
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, _ string) (*domain.User, error) {
+			return owner, nil
+		},
+	}
+	svc, _ := newTestOIDCService(&mockIdentityProvider{identity: newTestIdentity()}, userRepo, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+
+	_, _, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	assert.ErrorIs(t, err, domain.ErrOIDCAccountConflict)
+}
+
+func TestOIDCService_CompleteLogin_StateSingleUse(t *testing.T) {
+	svc, _ := newTestOIDCService(&mockIdentityProvider{identity: newTestIdentity()}, &mockUserRepository{}, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+
+	_, _, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+	require.NoError(t, err)
+
+	_, _, err = svc.CompleteLogin(context.Background(), state, "auth-code")
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState, "state ต้องใช้ได้ครั้งเดียว")
+}
+
+func TestOIDCService_CompleteLogin_ExpiredState(t *testing.T) {
+	svc, stateRepo := newTestOIDCService(&mockIdentityProvider{identity: newTestIdentity()}, &mockUserRepository{}, &mockTokenService{})
+	state := beginTestLogin(t, svc)
+	stateRepo.states[hashOpaqueToken(state)].ExpiresAt = time.Now().Add(-time.Second)
+
+	_, _, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+}
+
+func TestOIDCService_CompleteLogin_PropagatesIdPMFA(t *testing.T) {
+	identity := newTestIdentity()
+	identity.MFA = true
+	var issuedWithMFA bool
+	tokenSvc := &mockTokenService{
+		generateFn: func(_ *domain.User, mfa bool) (string, time.Time, error) {
+			issuedWithMFA = mfa
+			return "mock-token", time.Now().Add(15 * time.Minute), nil
+		},
+	}
+	svc, _ := newTestOIDCService(&mockIdentityProvider{identity: identity}, &mockUserRepository{}, tokenSvc)
+	state := beginTestLogin(t, svc)
+
+	_, _, err := svc.CompleteLogin(context.Background(), state, "auth-code")
+
+	require.NoError(t, err)
+	assert.True(t, issuedWithMFA, "session ที่ IdP ยืนยันหลายปัจจัยแล้วต้องได้ claim mfa")
+}
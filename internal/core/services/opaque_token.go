@@ -0,0 +1,28 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+const opaqueTokenBytes = 32 // ความยาว token แบบสุ่ม (256 bits)
+
+// generateOpaqueToken สร้าง token แบบสุ่ม (base64url) พร้อม hash สำหรับเก็บในฐานข้อมูล
+func generateOpaqueToken() (token, tokenHash string, err error) {
+	buf := make([]byte, opaqueTokenBytes)
+	if _, err = rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("สุ่ม token ล้มเหลว: %w", err)
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashOpaqueToken(token), nil
+}
+
+// hashOpaqueToken คำนวณ SHA-256 hash ของ token — token สุ่มมี entropy สูงพอ ไม่ต้องใช้ bcrypt
+func hashOpaqueToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,34 @@
+package services
+
+import (
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// dummyPasswordHash bcrypt hash (cost 12) ที่ใช้เทียบเมื่อไม่พบอีเมล — ให้ใช้เวลาตอบใกล้เคียงกับกรณีพบผู้ใช้
+const dummyPasswordHash = "$2a$12$KySk5c9W/K1RFf/hhoh6xOv06PxC2W0qbmoNzC.vXaWxL5M0bi67W"
+
+type passwordAuthenticator struct{}
+
+// NewPasswordAuthenticator สร้าง Authenticator ที่ตรวจรหัสผ่าน bcrypt ที่เก็บไว้ในระบบ
+func NewPasswordAuthenticator() ports.Authenticator {
+	return passwordAuthenticator{}
+}
+
+// Authenticate เทียบรหัสผ่านกับ bcrypt hash ของผู้ใช้
+// ไม่พบอีเมล หรือบัญชีผูกกับ IdP/directory ตอบเหมือนรหัสผ่านผิดและใช้เวลาเท่ากัน
+func (passwordAuthenticator) Authenticate(_ context.Context, _, password string, user *domain.User) (*domain.ExternalIdentity, error) {
+	if user == nil || user.IsExternal() {
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(password)) //nolint:errcheck // เทียบเพื่อให้ใช้เวลาเท่ากันเท่านั้น
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+	return nil, nil
+}
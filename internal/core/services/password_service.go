@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	bcryptCost           = 12 // เท่ากับ cost ที่ใช้ใน seed script (~250ms ต่อครั้ง)
+	bcryptMaxPasswordLen = 72 // bcrypt ใช้ได้สูงสุด 72 bytes (ตัวอักษรไทย 1 ตัว = 3 bytes)
+)
+
+type passwordService struct {
+	userRepo       ports.UserRepository
+	resetRepo      ports.PasswordResetRepository
+	sessionService ports.SessionService
+	mailer         ports.Mailer
+	resetTTL       time.Duration
+	resetURL       string
+}
+
+// NewPasswordService สร้าง PasswordService — resetURL คือหน้าเว็บสำหรับตั้งรหัสผ่านใหม่ (ต่อท้ายด้วย token)
+func NewPasswordService(
+	userRepo ports.UserRepository,
+	resetRepo ports.PasswordResetRepository,
+	sessionService ports.SessionService,
+	mailer ports.Mailer,
+	resetTTL time.Duration,
+	resetURL string,
+) ports.PasswordService {
+	return &passwordService{
+		userRepo:       userRepo,
+		resetRepo:      resetRepo,
+		sessionService: sessionService,
+		mailer:         mailer,
+		resetTTL:       resetTTL,
+		resetURL:       resetURL,
+	}
+}
+
+// ChangePassword ตรวจสอบรหัสผ่านปัจจุบันแล้วเปลี่ยนเป็นรหัสผ่านใหม่
+func (s *passwordService) ChangePassword(ctx context.Context, userID domain.ID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)); err != nil {
+		return domain.ErrIncorrectPassword
+	}
+
+	if err = ensureNewPassword(user, newPassword); err != nil {
+		return err
+	}
+
+	return s.setPassword(ctx, user, newPassword)
+}
+
+// RequestPasswordReset ออก reset token และส่งลิงก์ทางอีเมล — อีเมลที่ไม่มีในระบบถือว่าสำเร็จเช่นกัน (ป้องกัน user enumeration)
+func (s *passwordService) RequestPasswordReset(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	user, err := s.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.IsExternal() {
+		return nil // บัญชีที่ผูกกับ IdP ไม่มีรหัสผ่านในระบบ — ตอบเหมือนกรณีอื่น
+	}
+
+	// ลิงก์ที่ขอไว้ก่อนหน้าใช้ไม่ได้อีก — มีลิงก์ที่ใช้ได้เพียงลิงก์เดียวต่อผู้ใช้
+	if err = s.resetRepo.InvalidateByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("ยกเลิก reset token เดิมล้มเหลว: %w", err)
+	}
+
+	token, tokenHash, err := generateOpaqueToken()
+	if err != nil {
+		return fmt.Errorf("สร้าง reset token ล้มเหลว: %w", err)
+	}
+
+	resetToken := domain.NewPasswordResetToken(user.ID, tokenHash, s.resetTTL)
+	if err = s.resetRepo.Create(ctx, resetToken); err != nil {
+		return fmt.Errorf("บันทึก reset token ล้มเหลว: %w", err)
+	}
+
+	if err = s.mailer.Send(ctx, s.resetEmail(user, token)); err != nil {
+		return fmt.Errorf("ส่งอีเมลตั้งรหัสผ่านใหม่ล้มเหลว: %w", err)
+	}
+
+	return nil
+}
+
+// ResetPassword ตั้งรหัสผ่านใหม่ด้วย reset token
+func (s *passwordService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	stored, err := s.resetRepo.FindByHash(ctx, hashOpaqueToken(token))
+	if err != nil {
+		return err
+	}
+
+	if stored.IsUsed() || stored.IsExpired(time.Now()) {
+		return domain.ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.FindByID(ctx, stored.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidResetToken
+		}
+		return err
+	}
+
+	// ตรวจรหัสผ่านซ้ำก่อน consume — ผู้ใช้จะได้ลองใหม่ด้วยลิงก์เดิมได้
+	if err = ensureNewPassword(user, newPassword); err != nil {
+		return err
+	}
+
+	if err = s.resetRepo.Consume(ctx, stored.ID); err != nil {
+		return err
+	}
+
+	return s.setPassword(ctx, user, newPassword)
+}
+
+// setPassword บันทึกรหัสผ่านใหม่ แล้วยกเลิกทุก session และ reset token ที่ค้างอยู่ของผู้ใช้
+func (s *passwordService) setPassword(ctx context.Context, user *domain.User, newPassword string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcryptCost)
+	if err != nil {
+		return fmt.Errorf("เข้ารหัสรหัสผ่านล้มเหลว: %w", err)
+	}
+
+	if err = s.userRepo.UpdatePassword(ctx, user.ID, string(hash)); err != nil {
+		return err
+	}
+
+	// token ทุกตัวที่ออกด้วยรหัสผ่านเดิมต้องใช้ไม่ได้อีก
+	if err = s.sessionService.RevokeAllSessions(ctx, user.ID); err != nil {
+		return fmt.Errorf("ยกเลิก session หลังเปลี่ยนรหัสผ่านล้มเหลว: %w", err)
+	}
+
+	if err = s.resetRepo.InvalidateByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("ยกเลิก reset token ที่ค้างอยู่ล้มเหลว: %w", err)
+	}
+
+	return nil
+}
+
+// ensureNewPassword ตรวจว่ารหัสผ่านใหม่ hash ได้ และไม่ซ้ำกับรหัสผ่านปัจจุบัน
+func ensureNewPassword(user *domain.User, newPassword string) error {
+	if len(newPassword) > bcryptMaxPasswordLen {
+		return domain.ErrPasswordTooLong
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(newPassword)) == nil {
+		return domain.ErrPasswordReused
+	}
+	return nil
+}
+
+// resetEmail สร้างอีเมลที่มีลิงก์ตั้งรหัสผ่านใหม่
+func (s *passwordService) resetEmail(user *domain.User, token string) domain.EmailMessage {
+	minutes := int(s.resetTTL.Minutes())
+	return domain.EmailMessage{
+		To:      user.Email,
+		Subject: "ตั้งรหัสผ่านใหม่ — Leave Management System",
+		Body: fmt.Sprintf(
+			"สวัสดีคุณ %s\n\nมีการขอตั้งรหัสผ่านใหม่สำหรับบัญชีของคุณ กรุณาเปิดลิงก์ด้านล่างภายใน %d นาที:\n\n%s%s\n\n"+
+				"ลิงก์นี้ใช้ได้เพียงครั้งเดียว หากคุณไม่ได้เป็นผู้ขอ สามารถเพิกเฉยต่ออีเมลนี้ได้\n",
+			user.FullName, minutes, s.resetURL, token,
+		),
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+const testResetURL = "http://localhost:3000/reset-password?token="
+
+// passwordTestEnv รวม mocks ที่ใช้ทดสอบ password service
+type passwordTestEnv struct {
+	user      *domain.User
+	resetRepo *mockPasswordResetRepository
+	sessions  *mockSessionService
+	mailer    *mockMailer
+	svc       *passwordService
+}
+
+// newPasswordTestEnv สร้างผู้ใช้ที่มีรหัสผ่าน "OldPassw0rd" และ service ที่บันทึก hash ใหม่กลับเข้า user
+func newPasswordTestEnv(t *testing.T) *passwordTestEnv {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("OldPassw0rd"), bcrypt.MinCost)
+	require.NoError(t, err)
+	user := domain.NewUser("สมชาย", "ใจดี", "somchai@company.com", string(hash), domain.RoleEmployee)
+
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
+			if email == user.Email {
+				return user, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.User, error) {
+			if id == user.ID {
+				return user, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+		updatePwdFn: func(_ context.Context, _ domain.ID, passwordHash string) error {
+			user.PasswordHash = passwordHash
+			return nil
+		},
+	}
+
+	env := &passwordTestEnv{
+		user:      user,
+		resetRepo: newMockPasswordResetRepository(),
+		sessions:  &mockSessionService{},
+		mailer:    &mockMailer{},
+	}
+	svc, ok := NewPasswordService(userRepo, env.resetRepo, env.sessions, env.mailer, 30*time.Minute, testResetURL).(*passwordService)
+	require.True(t, ok)
+	env.svc = svc
+	return env
+}
+
+// requestResetToken ขอลิงก์ตั้งรหัสผ่านใหม่แล้วดึง token ออกจากอีเมลล่าสุด
+func (e *passwordTestEnv) requestResetToken(t *testing.T) string {
+	t.Helper()
+	require.NoError(t, e.svc.RequestPasswordReset(context.Background(), e.user.Email))
+	require.NotEmpty(t, e.mailer.sent)
+
+	body := e.mailer.sent[len(e.mailer.sent)-1].Body
+	start := strings.Index(body, testResetURL)
+	require.GreaterOrEqual(t, start, 0, "อีเมลต้องมีลิงก์ตั้งรหัสผ่านใหม่")
+	return strings.Fields(body[start+len(testResetURL):])[0]
+}
+
+func TestPasswordService_ChangePassword_Success(t *testing.T) {
+	env := newPasswordTestEnv(t)
+
+	err := env.svc.ChangePassword(context.Background(), env.user.ID, "OldPassw0rd", "NewPassw0rd")
+
+	require.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(env.user.PasswordHash), []byte("NewPassw0rd")))
+	assert.Equal(t, []domain.ID{env.user.ID}, env.sessions.revokedUsers, "ต้องยกเลิกทุก session หลังเปลี่ยนรหัสผ่าน")
+}
+
+func TestPasswordService_ChangePassword_WrongCurrentPassword(t *testing.T) {
+	env := newPasswordTestEnv(t)
+
+	err := env.svc.ChangePassword(context.Background(), env.user.ID, "wrong", "NewPassw0rd")
+
+	assert.ErrorIs(t, err, domain.ErrIncorrectPassword)
+	assert.Empty(t, env.sessions.revokedUsers)
+}
+
+func TestPasswordService_ChangePassword_SamePassword(t *testing.T) {
+	env := newPasswordTestEnv(t)
+
+	err := env.svc.ChangePassword(context.Background(), env.user.ID, "OldPassw0rd", "OldPassw0rd")
+
+	assert.ErrorIs(t, err, domain.ErrPasswordReused)
+}
+
+func TestPasswordService_ChangePassword_TooLong(t *testing.T) {
+	env := newPasswordTestEnv(t)
+
+	// ตัวอักษรไทย 25 ตัว = 75 bytes เกินขีดจำกัดของ bcrypt
+	err := env.svc.ChangePassword(context.Background(), env.user.ID, "OldPassw0rd", strings.Repeat("ก", 25))
+
+	assert.ErrorIs(t, err, domain.ErrPasswordTooLong)
+}
+
+func TestPasswordService_ResetPassword_Success(t *testing.T) {
+	env := newPasswordTestEnv(t)
+	token := env.requestResetToken(t)
+
+	err := env.svc.ResetPassword(context.Background(), token, "ResetPassw0rd")
+
+	require.NoError(t, err)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(env.user.PasswordHash), []byte("ResetPassw0rd")))
+	assert.Equal(t, []domain.ID{env.user.ID}, env.sessions.revokedUsers)
+	assert.Equal(t, env.user.Email, env.mailer.sent[0].To)
+
+	for _, stored := range env.resetRepo.tokens {
+		assert.NotEqual(t, token, stored.TokenHash, "ต้องเก็บเฉพาะ hash ไม่เก็บ token จริง")
+	}
+}
+
+func TestPasswordService_ResetPassword_SingleUse(t *testing.T) {
+	env := newPasswordTestEnv(t)
+	token := env.requestResetToken(t)
+	require.NoError(t, env.svc.ResetPassword(context.Background(), token, "ResetPassw0rd"))
+
+	err := env.svc.ResetPassword(context.Background(), token, "AnotherPassw0rd")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+}
+
+func TestPasswordService_ResetPassword_Expired(t *testing.T) {
+	env := newPasswordTestEnv(t)
+	token := env.requestResetToken(t)
+	for _, stored := range env.resetRepo.tokens {
+		stored.ExpiresAt = time.Now().Add(-time.Minute)
+	}
+
+	err := env.svc.ResetPassword(context.Background(), token, "ResetPassw0rd")
+
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken)
+}
+
+func TestPasswordService_RequestPasswordReset_InvalidatesPreviousLink(t *testing.T) {
+	env := newPasswordTestEnv(t)
+	first := env.requestResetToken(t)
+	second := env.requestResetToken(t)
+
+	err := env.svc.ResetPassword(context.Background(), first, "ResetPassw0rd")
+	assert.ErrorIs(t, err, domain.ErrInvalidResetToken, "ลิงก์เก่าต้องใช้ไม่ได้เมื่อขอลิงก์ใหม่")
+
+	assert.NoError(t, env.svc.ResetPassword(context.Background(), second, "ResetPassw0rd"))
+}
+
+func TestPasswordService_RequestPasswordReset_UnknownEmail(t *testing.T) {
+	env := newPasswordTestEnv(t)
+
+	err := env.svc.RequestPasswordReset(context.Background(), "nobody@company.com")
+
+	require.NoError(t, err, "ต้องไม่เปิดเผยว่าอีเมลไม่มีในระบบ")
+	assert.Empty(t, env.mailer.sent)
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type reportService struct {
+	reportRepo    ports.ReportRepository
+	carryOverDays float64
+}
+
+// NewReportService สร้าง ReportService — carryOverDays คือจำนวนวันลาคงเหลือที่ยกไปปีถัดไปได้
+func NewReportService(reportRepo ports.ReportRepository, carryOverDays float64) ports.ReportService {
+	return &reportService{reportRepo: reportRepo, carryOverDays: carryOverDays}
+}
+
+// LeaveUsageByMonth วันลาที่อนุมัติแล้วแยกตามประเภทและเดือน
+func (s *reportService) LeaveUsageByMonth(ctx context.Context, filter domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	rows, err := s.reportRepo.LeaveUsageByMonth(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("สรุปวันลารายเดือนล้มเหลว: %w", err)
+	}
+	return rows, nil
+}
+
+// AbsenteeismByTeam อัตราการขาดงานแยกตามแผนก — คิดจากจำนวนวันทั้งปีของรายงาน
+func (s *reportService) AbsenteeismByTeam(ctx context.Context, filter domain.ReportFilter) ([]domain.TeamAbsenteeism, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	rows, err := s.reportRepo.AbsenteeismByTeam(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("สรุปอัตราการขาดงานล้มเหลว: %w", err)
+	}
+	days := filter.DaysInPeriod()
+	for i := range rows {
+		rows[i].ApplyRate(days)
+	}
+	return rows, nil
+}
+
+// ApprovalTurnaround เวลาเฉลี่ยตั้งแต่ยื่นจนพิจารณาแยกตามประเภทการลา
+func (s *reportService) ApprovalTurnaround(ctx context.Context, filter domain.ReportFilter) ([]domain.ApprovalTurnaround, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	rows, err := s.reportRepo.ApprovalTurnaround(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("สรุปเวลาพิจารณาใบลาล้มเหลว: %w", err)
+	}
+	return rows, nil
+}
+
+// RejectionRateByReviewer อัตราการปฏิเสธแยกตามผู้อนุมัติ
+func (s *reportService) RejectionRateByReviewer(ctx context.Context, filter domain.ReportFilter) ([]domain.ReviewerRejectionRate, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	rows, err := s.reportRepo.RejectionCountsByReviewer(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("สรุปอัตราการปฏิเสธล้มเหลว: %w", err)
+	}
+	for i := range rows {
+		rows[i].ApplyRate()
+	}
+	return rows, nil
+}
+
+// CarryOverRisks พนักงานที่มีวันลาคงเหลือเกินจำนวนที่ยกไปปีถัดไปได้ — ไม่ระบุประเภทการลาคือลาพักร้อน
+// เพราะเป็นประเภทเดียวที่ยกยอดได้
+func (s *reportService) CarryOverRisks(ctx context.Context, filter domain.ReportFilter, limit int) ([]domain.CarryOverRisk, error) {
+	if filter.LeaveType == "" {
+		filter.LeaveType = domain.LeaveTypeAnnual
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	switch {
+	case limit <= 0:
+		limit = domain.DefaultCarryOverLimit
+	case limit > domain.MaxCarryOverLimit:
+		limit = domain.MaxCarryOverLimit
+	}
+	rows, err := s.reportRepo.CarryOverRisks(ctx, filter, s.carryOverDays, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ค้นหายอดวันลาที่เสี่ยงหมดอายุล้มเหลว: %w", err)
+	}
+	for i := range rows {
+		rows[i].ApplyCarryOver(s.carryOverDays)
+	}
+	return rows, nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestReportService_InvalidFilter(t *testing.T) {
+	called := false
+	repo := &mockReportRepository{
+		leaveUsageFn: func(_ context.Context, _ domain.ReportFilter) ([]domain.MonthlyLeaveUsage, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	svc := NewReportService(repo, 5)
+
+	tests := []struct {
+		name   string
+		filter domain.ReportFilter
+	}{
+		{name: "ปีน้อยเกินไป", filter: domain.ReportFilter{Year: 1999}},
+		{name: "ปีมากเกินไป", filter: domain.ReportFilter{Year: 2101}},
+		{name: "ประเภทการลาไม่ถูกต้อง", filter: domain.ReportFilter{Year: 2026, LeaveType: "vacation"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.LeaveUsageByMonth(context.Background(), tt.filter)
+			assert.ErrorIs(t, err, domain.ErrInvalidReportFilter)
+		})
+	}
+	assert.False(t, called, "เงื่อนไขไม่ถูกต้องต้องไม่ query")
+}
+
+func TestReportService_AbsenteeismByTeam_AppliesRate(t *testing.T) {
+	repo := &mockReportRepository{
+		absenteeismFn: func(_ context.Context, _ domain.ReportFilter) ([]domain.TeamAbsenteeism, error) {
+			return []domain.TeamAbsenteeism{
+				{Department: "วิศวกรรม", Headcount: 2, DaysTaken: 7.32},
+				{Department: "", Headcount: 0, DaysTaken: 3},
+			}, nil
+		},
+	}
+	svc := NewReportService(repo, 5)
+
+	rows, err := svc.AbsenteeismByTeam(context.Background(), domain.ReportFilter{Year: 2024})
+
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.InDelta(t, 0.01, rows[0].Rate, 0.0001, "ปี 2024 มี 366 วัน")
+	assert.Zero(t, rows[1].Rate, "แผนกที่ไม่มีพนักงานต้องไม่หารด้วยศูนย์")
+}
+
+func TestReportService_RejectionRateByReviewer_AppliesRate(t *testing.T) {
+	repo := &mockReportRepository{
+		rejectionCountsFn: func(_ context.Context, _ domain.ReportFilter) ([]domain.ReviewerRejectionRate, error) {
+			return []domain.ReviewerRejectionRate{{ReviewerID: domain.NewID(), Reviewed: 8, Rejected: 2}}, nil
+		},
+	}
+	svc := NewReportService(repo, 5)
+
+	rows, err := svc.RejectionRateByReviewer(context.Background(), domain.ReportFilter{Year: 2026})
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.InDelta(t, 0.25, rows[0].Rate, 0.0001)
+}
+
+func TestReportService_CarryOverRisks(t *testing.T) {
+	var gotFilter domain.ReportFilter
+	var gotDays float64
+	var gotLimit int
+	repo := &mockReportRepository{
+		carryOverRisksFn: func(_ context.Context, filter domain.ReportFilter, carryOverDays float64, limit int) ([]domain.CarryOverRisk, error) {
+			gotFilter, gotDays, gotLimit = filter, carryOverDays, limit
+			return []domain.CarryOverRisk{{UserID: domain.NewID(), TotalDays: 15, RemainingDays: 12}}, nil
+		},
+	}
+	svc := NewReportService(repo, 5)
+
+	rows, err := svc.CarryOverRisks(context.Background(), domain.ReportFilter{Year: 2026}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.LeaveTypeAnnual, gotFilter.LeaveType, "ไม่ระบุประเภทการลาต้องเป็นลาพักร้อน")
+	assert.InDelta(t, 5.0, gotDays, 0.001)
+	assert.Equal(t, domain.DefaultCarryOverLimit, gotLimit)
+	require.Len(t, rows, 1)
+	assert.InDelta(t, 7.0, rows[0].DaysAtRisk, 0.001)
+
+	_, err = svc.CarryOverRisks(context.Background(), domain.ReportFilter{Year: 2026}, 1000)
+	require.NoError(t, err)
+	assert.Equal(t, domain.MaxCarryOverLimit, gotLimit)
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// roleCacheTTL อายุของสิทธิ์ที่ cache ไว้ในหน่วยความจำ — การแก้บทบาทจาก instance อื่นมีผลภายในช่วงเวลานี้
+const roleCacheTTL = 30 * time.Second
+
+type roleService struct {
+	roleRepo ports.RoleRepository
+	userRepo ports.UserRepository
+	audit    ports.AuditLogger
+
+	mu       sync.RWMutex
+	cache    map[domain.Role]*domain.RoleDefinition
+	loadedAt time.Time
+}
+
+// NewRoleService สร้าง RoleService สำหรับตรวจสิทธิ์และจัดการบทบาท
+func NewRoleService(roleRepo ports.RoleRepository, userRepo ports.UserRepository, audit ports.AuditLogger) ports.RoleService {
+	return &roleService{
+		roleRepo: roleRepo,
+		userRepo: userRepo,
+		audit:    audit,
+	}
+}
+
+// EnsureDefaultRoles สร้างบทบาทเริ่มต้น (employee/manager/admin) ที่ยังไม่มีในฐานข้อมูล
+func (s *roleService) EnsureDefaultRoles(ctx context.Context) error {
+	for _, role := range domain.DefaultRoles() {
+		if err := s.roleRepo.InsertIfMissing(ctx, &role); err != nil {
+			return err
+		}
+	}
+	s.invalidate()
+	return nil
+}
+
+// Authorize ตรวจสิทธิ์จากบทบาทปัจจุบันของผู้ใช้ในฐานข้อมูล — ไม่เชื่อบทบาทใน token ที่อาจล้าสมัย
+func (s *roleService) Authorize(ctx context.Context, userID domain.ID, permission domain.Permission) error {
+	role, err := s.UserRole(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !role.Has(permission) {
+		return domain.ErrPermissionDenied
+	}
+	return nil
+}
+
+// UserRole อ่านบทบาทปัจจุบันของผู้ใช้จากฐานข้อมูล และสิทธิ์ของบทบาทจาก cache
+func (s *roleService) UserRole(ctx context.Context, userID domain.ID) (*domain.RoleDefinition, error) {
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := s.roles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if def, ok := roles[user.Role]; ok {
+		return def, nil
+	}
+	return &domain.RoleDefinition{Name: user.Role}, nil
+}
+
+// RoleHasPermission ตรวจสิทธิ์ของบทบาทจาก cache — โหลดใหม่ทั้งหมดเมื่อ cache หมดอายุ
+func (s *roleService) RoleHasPermission(ctx context.Context, role domain.Role, permission domain.Permission) (bool, error) {
+	roles, err := s.roles(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	def, ok := roles[role]
+	return ok && def.Has(permission), nil
+}
+
+// ListRoles ดูบทบาททั้งหมดพร้อมสิทธิ์
+func (s *roleService) ListRoles(ctx context.Context) ([]domain.RoleDefinition, error) {
+	return s.roleRepo.FindAll(ctx)
+}
+
+// SaveRole สร้างบทบาทใหม่หรือแก้สิทธิ์ของบทบาทเดิม
+func (s *roleService) SaveRole(
+	ctx context.Context,
+	actorID domain.ID,
+	name domain.Role,
+	description string,
+	permissions []domain.Permission,
+) (*domain.RoleDefinition, error) {
+	if err := s.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	if !name.IsValidName() {
+		return nil, domain.ErrInvalidRole
+	}
+	permissions, err := normalizePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	// ป้องกันไม่ให้ระบบเหลือไม่มีใครจัดการบทบาทได้
+	def := domain.NewRoleDefinition(name, strings.TrimSpace(description), permissions)
+	if name == domain.RoleAdmin && !def.Has(domain.PermissionUserManage) {
+		return nil, domain.ErrBuiltInRole
+	}
+
+	before, err := s.findRole(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.roleRepo.Save(ctx, def)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidate()
+
+	event := domain.NewAuditEvent(domain.AuditRoleSaved, domain.AuditTargetRole, string(name),
+		domain.DiffChanges(before.AuditSnapshot(), role.AuditSnapshot())).By(actorID)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, err
+	}
+
+	return role, nil
+}
+
+// DeleteRole ลบบทบาทที่สร้างเอง
+func (s *roleService) DeleteRole(ctx context.Context, actorID domain.ID, name domain.Role) error {
+	if err := s.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return err
+	}
+	if name.IsValid() {
+		return domain.ErrBuiltInRole
+	}
+
+	count, err := s.userRepo.CountByRole(ctx, name)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrRoleInUse
+	}
+
+	before, err := s.roleRepo.FindByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if err = s.roleRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+	s.invalidate()
+
+	event := domain.NewAuditEvent(domain.AuditRoleDeleted, domain.AuditTargetRole, string(name),
+		domain.DiffChanges(before.AuditSnapshot(), nil)).By(actorID)
+	return recordAudit(ctx, s.audit, event)
+}
+
+// AssignRole เปลี่ยนบทบาทของผู้ใช้ — บทบาทต้องมีอยู่ในฐานข้อมูล
+func (s *roleService) AssignRole(ctx context.Context, actorID, userID domain.ID, role domain.Role) error {
+	if err := s.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return err
+	}
+	if _, err := s.roleRepo.FindByName(ctx, role); err != nil {
+		return err
+	}
+	user, err := s.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.userRepo.UpdateRole(ctx, userID, role); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(domain.AuditUserRoleAssigned, domain.AuditTargetUser, userID.String(),
+		domain.DiffChanges(map[string]any{"role": user.Role}, map[string]any{"role": role})).By(actorID)
+	return recordAudit(ctx, s.audit, event)
+}
+
+// findRole ค้นหาบทบาทจากชื่อ — คืน nil ถ้ายังไม่มี (ใช้ตอนสร้างบทบาทใหม่)
+func (s *roleService) findRole(ctx context.Context, name domain.Role) (*domain.RoleDefinition, error) {
+	role, err := s.roleRepo.FindByName(ctx, name)
+	if errors.Is(err, domain.ErrRoleNotFound) {
+		return nil, nil
+	}
+	return role, err
+}
+
+// roles คืนบทบาททั้งหมดจาก cache หรือโหลดจากฐานข้อมูลเมื่อ cache หมดอายุ
+func (s *roleService) roles(ctx context.Context) (map[domain.Role]*domain.RoleDefinition, error) {
+	s.mu.RLock()
+	cache, loadedAt := s.cache, s.loadedAt
+	s.mu.RUnlock()
+	if cache != nil && time.Since(loadedAt) < roleCacheTTL {
+		return cache, nil
+	}
+
+	all, err := s.roleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("โหลดบทบาทล้มเหลว: %w", err)
+	}
+	cache = make(map[domain.Role]*domain.RoleDefinition, len(all))
+	for i := range all {
+		cache[all[i].Name] = &all[i]
+	}
+
+	s.mu.Lock()
+	s.cache, s.loadedAt = cache, time.Now()
+	s.mu.Unlock()
+
+	return cache, nil
+}
+
+// invalidate ล้าง cache หลังแก้บทบาท — instance นี้เห็นสิทธิ์ใหม่ทันที
+func (s *roleService) invalidate() {
+	s.mu.Lock()
+	s.cache = nil
+	s.mu.Unlock()
+}
+
+// normalizePermissions ตรวจสิทธิ์ทุกตัวและตัดตัวที่ซ้ำ — บทบาทที่ไม่มีสิทธิ์ใดเลยได้ (เช่น employee)
+func normalizePermissions(permissions []domain.Permission) ([]domain.Permission, error) {
+	seen := make(map[domain.Permission]bool, len(permissions))
+	result := make([]domain.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return nil, domain.ErrInvalidRole
+		}
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
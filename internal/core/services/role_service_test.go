@@ -0,0 +1,170 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// newTestRoleService สร้าง roleService ที่มีบทบาทเริ่มต้น และผู้ใช้ตามบทบาทที่ระบุ
+func newTestRoleService(t *testing.T, users map[domain.ID]domain.Role) (*roleService, *mockRoleRepository, *mockUserRepository) {
+	t.Helper()
+	roleRepo := newMockRoleRepository()
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, id domain.ID) (*domain.User, error) {
+			role, ok := users[id]
+			if !ok {
+				return nil, domain.ErrUserNotFound
+			}
+			return &domain.User{ID: id, Role: role}, nil
+		},
+	}
+	svc := NewRoleService(roleRepo, userRepo, &mockAuditLogger{}).(*roleService)
+	require.NoError(t, svc.EnsureDefaultRoles(context.Background()))
+	return svc, roleRepo, userRepo
+}
+
+func TestRoleService_EnsureDefaultRoles_KeepsEditedPermissions(t *testing.T) {
+	adminID := domain.NewID()
+	svc, roleRepo, _ := newTestRoleService(t, map[domain.ID]domain.Role{adminID: domain.RoleAdmin})
+	ctx := context.Background()
+
+	_, err := svc.SaveRole(ctx, adminID, domain.RoleManager, "ผู้จัดการ", []domain.Permission{domain.PermissionLeaveViewTeam})
+	require.NoError(t, err)
+	require.NoError(t, svc.EnsureDefaultRoles(ctx))
+
+	assert.Len(t, roleRepo.roles, 3)
+	assert.False(t, roleRepo.roles[domain.RoleManager].Has(domain.PermissionLeaveApprove), "ห้ามคืนสิทธิ์ที่ผู้ดูแลระบบถอดออกแล้ว")
+}
+
+func TestRoleService_Authorize_DefaultRoles(t *testing.T) {
+	employeeID, managerID, adminID := domain.NewID(), domain.NewID(), domain.NewID()
+	svc, _, _ := newTestRoleService(t, map[domain.ID]domain.Role{
+		employeeID: domain.RoleEmployee,
+		managerID:  domain.RoleManager,
+		adminID:    domain.RoleAdmin,
+	})
+	ctx := context.Background()
+
+	assert.ErrorIs(t, svc.Authorize(ctx, employeeID, domain.PermissionLeaveApprove), domain.ErrPermissionDenied)
+	assert.NoError(t, svc.Authorize(ctx, managerID, domain.PermissionLeaveApprove))
+	assert.NoError(t, svc.Authorize(ctx, managerID, domain.PermissionLeaveViewTeam))
+	assert.ErrorIs(t, svc.Authorize(ctx, managerID, domain.PermissionUserManage), domain.ErrPermissionDenied)
+	assert.NoError(t, svc.Authorize(ctx, adminID, domain.PermissionUserManage))
+	assert.NoError(t, svc.Authorize(ctx, adminID, domain.PermissionBalanceAdjust))
+}
+
+func TestRoleService_RoleHasPermission_UnknownRoleDenied(t *testing.T) {
+	svc, _, _ := newTestRoleService(t, nil)
+
+	ok, err := svc.RoleHasPermission(context.Background(), domain.Role("superuser"), domain.PermissionUserManage)
+
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestRoleService_RoleHasPermission_UsesCacheUntilRoleChanges(t *testing.T) {
+	adminID := domain.NewID()
+	svc, roleRepo, _ := newTestRoleService(t, map[domain.ID]domain.Role{adminID: domain.RoleAdmin})
+	ctx := context.Background()
+
+	for range 3 {
+		ok, err := svc.RoleHasPermission(ctx, domain.RoleEmployee, domain.PermissionLeaveViewTeam)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, 1, roleRepo.loads, "ต้องโหลดบทบาทจากฐานข้อมูลครั้งเดียวภายในอายุ cache")
+
+	_, err := svc.SaveRole(ctx, adminID, domain.RoleEmployee, "", []domain.Permission{domain.PermissionLeaveViewTeam})
+	require.NoError(t, err)
+
+	ok, err := svc.RoleHasPermission(ctx, domain.RoleEmployee, domain.PermissionLeaveViewTeam)
+	require.NoError(t, err)
+	assert.True(t, ok, "การแก้บทบาทต้องมีผลทันทีใน instance เดียวกัน")
+}
+
+func TestRoleService_SaveRole_CustomRole(t *testing.T) {
+	adminID := domain.NewID()
+	svc, roleRepo, _ := newTestRoleService(t, map[domain.ID]domain.Role{adminID: domain.RoleAdmin})
+
+	role, err := svc.SaveRole(context.Background(), adminID, "hr_officer", " ฝ่ายบุคคล ", []domain.Permission{
+		domain.PermissionBalanceAdjust, domain.PermissionBalanceAdjust,
+	})
+
+	require.NoError(t, err)
+	assert.False(t, role.BuiltIn)
+	assert.Equal(t, "ฝ่ายบุคคล", role.Description)
+	assert.Equal(t, []domain.Permission{domain.PermissionBalanceAdjust}, roleRepo.roles["hr_officer"].Permissions, "สิทธิ์ที่ซ้ำต้องถูกตัดออก")
+}
+
+func TestRoleService_SaveRole_Validation(t *testing.T) {
+	adminID, managerID := domain.NewID(), domain.NewID()
+	svc, _, _ := newTestRoleService(t, map[domain.ID]domain.Role{adminID: domain.RoleAdmin, managerID: domain.RoleManager})
+	ctx := context.Background()
+
+	tests := []struct {
+		name        string
+		actorID     domain.ID
+		role        domain.Role
+		permissions []domain.Permission
+		wantErr     error
+	}{
+		{"ไม่มีสิทธิ์ user.manage", managerID, "hr_officer", nil, domain.ErrPermissionDenied},
+		{"ชื่อบทบาทไม่ถูกต้อง", adminID, "HR Officer", nil, domain.ErrInvalidRole},
+		{"สิทธิ์ไม่รู้จัก", adminID, "hr_officer", []domain.Permission{"leave.delete"}, domain.ErrInvalidRole},
+		{"ถอด user.manage จาก admin", adminID, domain.RoleAdmin, []domain.Permission{domain.PermissionBalanceAdjust}, domain.ErrBuiltInRole},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.SaveRole(ctx, tt.actorID, tt.role, "", tt.permissions)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRoleService_DeleteRole(t *testing.T) {
+	adminID := domain.NewID()
+	svc, roleRepo, userRepo := newTestRoleService(t, map[domain.ID]domain.Role{adminID: domain.RoleAdmin})
+	ctx := context.Background()
+	_, err := svc.SaveRole(ctx, adminID, "hr_officer", "", nil)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, svc.DeleteRole(ctx, adminID, domain.RoleManager), domain.ErrBuiltInRole)
+
+	userRepo.countByRoleFn = func(_ context.Context, _ domain.Role) (int64, error) { return 2, nil }
+	assert.ErrorIs(t, svc.DeleteRole(ctx, adminID, "hr_officer"), domain.ErrRoleInUse)
+
+	userRepo.countByRoleFn = nil
+	require.NoError(t, svc.DeleteRole(ctx, adminID, "hr_officer"))
+	assert.NotContains(t, roleRepo.roles, domain.Role("hr_officer"))
+}
+
+func TestRoleService_AssignRole(t *testing.T) {
+	adminID, userID := domain.NewID(), domain.NewID()
+	svc, _, userRepo := newTestRoleService(t, map[domain.ID]domain.Role{adminID: domain.RoleAdmin, userID: domain.RoleEmployee})
+	audit := &mockAuditLogger{}
+	svc.audit = audit
+	var assigned domain.Role
+	userRepo.updateRoleFn = func(_ context.Context, _ domain.ID, role domain.Role) error {
+		assigned = role
+		return nil
+	}
+	ctx := context.Background()
+
+	assert.ErrorIs(t, svc.AssignRole(ctx, adminID, userID, "superuser"), domain.ErrRoleNotFound)
+	assert.Empty(t, assigned)
+
+	require.NoError(t, svc.AssignRole(ctx, adminID, userID, domain.RoleManager))
+	assert.Equal(t, domain.RoleManager, assigned)
+
+	require.Len(t, audit.events, 1)
+	event := audit.events[0]
+	assert.Equal(t, domain.AuditUserRoleAssigned, event.Action)
+	assert.Equal(t, adminID, *event.ActorID)
+	assert.Equal(t, []domain.AuditChange{{Field: "role", Before: "employee", After: "manager"}}, event.Changes)
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+type sessionService struct {
+	userRepo        ports.UserRepository
+	refreshRepo     ports.RefreshTokenRepository
+	revocationStore ports.TokenRevocationStore
+	audit           ports.AuditLogger
+}
+
+// NewSessionService สร้าง SessionService สำหรับ logout และยกเลิก session
+func NewSessionService(
+	userRepo ports.UserRepository,
+	refreshRepo ports.RefreshTokenRepository,
+	revocationStore ports.TokenRevocationStore,
+	audit ports.AuditLogger,
+) ports.SessionService {
+	return &sessionService{
+		userRepo:        userRepo,
+		refreshRepo:     refreshRepo,
+		revocationStore: revocationStore,
+		audit:           audit,
+	}
+}
+
+// Logout ยกเลิก access token ปัจจุบัน และ refresh token family ที่ส่งมา (ถ้าเป็นของผู้ใช้คนเดียวกัน)
+func (s *sessionService) Logout(ctx context.Context, claims *domain.TokenClaims, refreshToken string) error {
+	if err := s.revocationStore.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+		return fmt.Errorf("ยกเลิก access token ล้มเหลว: %w", err)
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+
+	stored, err := s.refreshRepo.FindByHash(ctx, hashOpaqueToken(refreshToken))
+	if err != nil {
+		// refresh token ไม่ถูกต้องหรือหมดอายุไปแล้ว — ไม่มีอะไรต้องยกเลิก
+		if errors.Is(err, domain.ErrInvalidRefreshToken) {
+			return nil
+		}
+		return err
+	}
+
+	// ไม่ยอมให้ใช้ logout ยกเลิก session ของผู้ใช้อื่น
+	if stored.UserID != claims.UserID {
+		return nil
+	}
+
+	if err := s.refreshRepo.RevokeFamily(ctx, stored.FamilyID); err != nil {
+		return fmt.Errorf("ยกเลิก refresh token ล้มเหลว: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAllSessions ยกเลิกทุก session ของผู้ใช้ — access token ที่ออกก่อนหน้านี้ทั้งหมดและ refresh token ทุกตัว
+func (s *sessionService) RevokeAllSessions(ctx context.Context, userID domain.ID) error {
+	if _, err := s.userRepo.FindByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.revocationStore.RevokeUserTokens(ctx, userID, time.Now()); err != nil {
+		return fmt.Errorf("ยกเลิก access token ของผู้ใช้ล้มเหลว: %w", err)
+	}
+
+	if err := s.refreshRepo.RevokeByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("ยกเลิก refresh token ของผู้ใช้ล้มเหลว: %w", err)
+	}
+
+	// ผู้กระทำมาจาก request — ผู้ดูแลระบบ หรือเจ้าของบัญชีที่เปลี่ยน/ตั้งรหัสผ่านใหม่
+	event := domain.NewAuditEvent(domain.AuditUserSessionsRevoked, domain.AuditTargetUser, userID.String(), nil)
+	return recordAudit(ctx, s.audit, event)
+}
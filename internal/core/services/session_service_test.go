@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+func TestSessionService_Logout_RevokesAccessAndRefreshToken(t *testing.T) {
+	refreshRepo, loginTokens, authSvc := newRefreshTestService(t)
+	store := newMockTokenRevocationStore()
+
+	stored, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(loginTokens.RefreshToken))
+	require.NoError(t, err)
+
+	claims := &domain.TokenClaims{
+		UserID:    stored.UserID,
+		TokenID:   "jti-123",
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+
+	svc := NewSessionService(&mockUserRepository{}, refreshRepo, store, &mockAuditLogger{})
+
+	err = svc.Logout(context.Background(), claims, loginTokens.RefreshToken)
+
+	require.NoError(t, err)
+	assert.Contains(t, store.revokedTokens, "jti-123", "access token ต้องถูกเพิ่มลง denylist")
+	assert.True(t, stored.IsRevoked(), "refresh token ต้องถูกยกเลิก")
+
+	_, _, err = authSvc.Refresh(context.Background(), loginTokens.RefreshToken)
+	assert.Error(t, err, "refresh หลัง logout ต้องไม่สำเร็จ")
+}
+
+func TestSessionService_Logout_IgnoresOtherUsersRefreshToken(t *testing.T) {
+	refreshRepo, loginTokens, _ := newRefreshTestService(t)
+
+	claims := &domain.TokenClaims{
+		UserID:    domain.NewID(), // ผู้ใช้คนอื่น
+		TokenID:   "jti-456",
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}
+
+	svc := NewSessionService(&mockUserRepository{}, refreshRepo, newMockTokenRevocationStore(), &mockAuditLogger{})
+
+	err := svc.Logout(context.Background(), claims, loginTokens.RefreshToken)
+
+	require.NoError(t, err)
+	stored, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(loginTokens.RefreshToken))
+	require.NoError(t, err)
+	assert.False(t, stored.IsRevoked(), "ต้องไม่ยกเลิก refresh token ของผู้ใช้อื่น")
+}
+
+func TestSessionService_RevokeAllSessions(t *testing.T) {
+	refreshRepo, loginTokens, _ := newRefreshTestService(t)
+	store := newMockTokenRevocationStore()
+
+	stored, err := refreshRepo.FindByHash(context.Background(), hashOpaqueToken(loginTokens.RefreshToken))
+	require.NoError(t, err)
+	userRepo := &mockUserRepository{
+		findByIDFn: func(_ context.Context, _ domain.ID) (*domain.User, error) {
+			return &domain.User{ID: stored.UserID}, nil
+		},
+	}
+
+	svc := NewSessionService(userRepo, refreshRepo, store, &mockAuditLogger{})
+
+	err = svc.RevokeAllSessions(context.Background(), stored.UserID)
+
+	require.NoError(t, err)
+	assert.True(t, stored.IsRevoked(), "refresh token ทุกตัวของผู้ใช้ต้องถูกยกเลิก")
+
+	issuedBefore := &domain.TokenClaims{UserID: stored.UserID, TokenID: "old", IssuedAt: time.Now().Add(-time.Minute)}
+	revoked, err := store.IsRevoked(context.Background(), issuedBefore)
+	require.NoError(t, err)
+	assert.True(t, revoked, "access token ที่ออกก่อนการยกเลิกต้องใช้ไม่ได้")
+}
+
+func TestSessionService_RevokeAllSessions_UserNotFound(t *testing.T) {
+	svc := NewSessionService(&mockUserRepository{}, newMockRefreshTokenRepository(), newMockTokenRevocationStore(), &mockAuditLogger{})
+
+	err := svc.RevokeAllSessions(context.Background(), domain.NewID())
+
+	assert.ErrorIs(t, err, domain.ErrUserNotFound)
+}
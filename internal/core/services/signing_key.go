@@ -0,0 +1,209 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+// SigningKey คีย์สำหรับ sign/verify JWT พร้อมรหัสคีย์ (kid)
+type SigningKey struct {
+	Method  jwt.SigningMethod // algorithm ที่ใช้กับคีย์นี้ (HS256 / RS256 / EdDSA)
+	Private any               // คีย์สำหรับ sign — nil ถ้าเป็นคีย์สำหรับตรวจสอบอย่างเดียว
+	Public  any               // คีย์สำหรับตรวจสอบ signature
+	ID      string            // kid — RSA/Ed25519 ใช้ JWK thumbprint (RFC 7638)
+}
+
+// NewHMACSigningKey สร้างคีย์ HS256 จาก shared secret (โหมดเดิม — ไม่ถูกเผยแพร่ใน JWKS)
+func NewHMACSigningKey(secret string) SigningKey {
+	sum := sha256.Sum256([]byte("kid:" + secret))
+	return SigningKey{
+		ID:      "hs256-" + base64.RawURLEncoding.EncodeToString(sum[:6]),
+		Method:  jwt.SigningMethodHS256,
+		Private: []byte(secret),
+		Public:  []byte(secret),
+	}
+}
+
+// ParsePEMSigningKey แปลง PEM เป็น SigningKey — รองรับ RSA (PKCS#1/PKCS#8) และ Ed25519 (PKCS#8)
+// ทั้ง private key (ใช้ sign ได้) และ public key (ใช้ตรวจสอบ token อย่างเดียว)
+func ParsePEMSigningKey(data []byte) (SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return SigningKey{}, errors.New("ไม่พบข้อมูล PEM")
+	}
+
+	var (
+		parsed any
+		err    error
+	)
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return SigningKey{}, fmt.Errorf("ไม่รองรับ PEM ประเภท %q", block.Type)
+	}
+	if err != nil {
+		return SigningKey{}, fmt.Errorf("อ่านคีย์ล้มเหลว: %w", err)
+	}
+
+	return newAsymmetricSigningKey(parsed)
+}
+
+// newAsymmetricSigningKey สร้าง SigningKey จากคีย์ RSA หรือ Ed25519
+func newAsymmetricSigningKey(key any) (SigningKey, error) {
+	var sk SigningKey
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sk = SigningKey{Method: jwt.SigningMethodRS256, Private: k, Public: &k.PublicKey}
+	case *rsa.PublicKey:
+		sk = SigningKey{Method: jwt.SigningMethodRS256, Public: k}
+	case ed25519.PrivateKey:
+		pub, ok := k.Public().(ed25519.PublicKey)
+		if !ok {
+			return SigningKey{}, errors.New("อ่าน Ed25519 public key ล้มเหลว")
+		}
+		sk = SigningKey{Method: jwt.SigningMethodEdDSA, Private: k, Public: pub}
+	case ed25519.PublicKey:
+		sk = SigningKey{Method: jwt.SigningMethodEdDSA, Public: k}
+	default:
+		return SigningKey{}, fmt.Errorf("ไม่รองรับคีย์ประเภท %T (รองรับ RSA และ Ed25519)", key)
+	}
+
+	jwk, ok := sk.publicJWK()
+	if !ok {
+		return SigningKey{}, errors.New("สร้าง JWK จากคีย์ล้มเหลว")
+	}
+	sk.ID = jwkThumbprint(jwk)
+
+	return sk, nil
+}
+
+// publicJWK แปลง public key เป็น JWK — คืน false สำหรับคีย์ HMAC (ห้ามเผยแพร่)
+func (k SigningKey) publicJWK() (domain.JSONWebKey, bool) {
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		return domain.JSONWebKey{
+			Kty: "RSA",
+			Kid: k.ID,
+			Use: "sig",
+			Alg: k.Method.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}, true
+	case ed25519.PublicKey:
+		return domain.JSONWebKey{
+			Kty: "OKP",
+			Kid: k.ID,
+			Use: "sig",
+			Alg: k.Method.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(pub),
+		}, true
+	default:
+		return domain.JSONWebKey{}, false
+	}
+}
+
+// jwkThumbprint คำนวณ JWK thumbprint ตาม RFC 7638 (SHA-256) — ใช้เป็น kid ที่คงที่ต่อคีย์
+func jwkThumbprint(jwk domain.JSONWebKey) string {
+	// RFC 7638 กำหนดให้ใช้เฉพาะ required members เรียงตามตัวอักษร — json.Marshal ของ map เรียง key ให้แล้ว
+	members := map[string]string{"kty": jwk.Kty}
+	if jwk.Kty == "RSA" {
+		members["n"], members["e"] = jwk.N, jwk.E
+	} else {
+		members["crv"], members["x"] = jwk.Crv, jwk.X
+	}
+
+	canonical, _ := json.Marshal(members) //nolint:errcheck // marshal map[string]string ไม่มีทาง error
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// KeyRing ชุดคีย์ของ TokenService — คีย์ active ใช้ sign token ใหม่
+// คีย์ที่เหลือใช้ตรวจสอบ token ที่ออกไปก่อน rotate จนกว่าจะหมดอายุ
+type KeyRing struct {
+	keys   map[string]SigningKey
+	active SigningKey
+}
+
+// NewKeyRing สร้าง KeyRing จากคีย์ active และคีย์สำหรับตรวจสอบอย่างเดียว
+func NewKeyRing(active SigningKey, verificationKeys ...SigningKey) (*KeyRing, error) {
+	if active.Private == nil {
+		return nil, errors.New("คีย์ที่ใช้ sign ต้องเป็น private key")
+	}
+
+	ring := &KeyRing{
+		active: active,
+		keys:   map[string]SigningKey{active.ID: active},
+	}
+	for _, key := range verificationKeys {
+		if _, exists := ring.keys[key.ID]; exists {
+			continue
+		}
+		ring.keys[key.ID] = key
+	}
+
+	return ring, nil
+}
+
+// PublicJWKS คืน public keys ทั้งหมด (ไม่รวมคีย์ HMAC) — คีย์ active อยู่ลำดับแรก
+func (r *KeyRing) PublicJWKS() domain.JSONWebKeySet {
+	set := domain.JSONWebKeySet{Keys: []domain.JSONWebKey{}}
+	if jwk, ok := r.active.publicJWK(); ok {
+		set.Keys = append(set.Keys, jwk)
+	}
+	for id, key := range r.keys {
+		if id == r.active.ID {
+			continue
+		}
+		if jwk, ok := key.publicJWK(); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
+
+// verificationKey ค้นหาคีย์จาก kid — token ที่ไม่มี kid (ออกก่อนรองรับ rotation) ใช้คีย์ HMAC ถ้ามี
+func (r *KeyRing) verificationKey(kid string) (SigningKey, bool) {
+	if kid != "" {
+		key, ok := r.keys[kid]
+		return key, ok
+	}
+
+	for _, key := range r.keys {
+		if key.Method == jwt.SigningMethodHS256 {
+			return key, true
+		}
+	}
+	return SigningKey{}, false
+}
+
+// algorithms คืนรายชื่อ algorithm ที่ KeyRing ยอมรับ
+func (r *KeyRing) algorithms() []string {
+	seen := make(map[string]bool)
+	algs := make([]string, 0, len(r.keys))
+	for _, key := range r.keys {
+		alg := key.Method.Alg()
+		if !seen[alg] {
+			seen[alg] = true
+			algs = append(algs, alg)
+		}
+	}
+	return algs
+}
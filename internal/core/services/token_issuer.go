@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// tokenIssuer ออก access token + refresh token ให้ผู้ใช้ที่ยืนยันตัวตนแล้ว — ใช้ร่วมกันทุกช่องทาง login
+type tokenIssuer struct {
+	refreshRepo  ports.RefreshTokenRepository
+	tokenService ports.TokenService
+	refreshTTL   time.Duration
+}
+
+// issue ออก access token และ refresh token ใหม่ใน family ที่ระบุ — mfa ระบุว่า session ยืนยัน 2FA แล้ว
+func (i tokenIssuer) issue(ctx context.Context, user *domain.User, familyID domain.ID, mfa bool) (*domain.AuthTokens, error) {
+	accessToken, expiresAt, err := i.tokenService.GenerateToken(user, mfa)
+	if err != nil {
+		return nil, fmt.Errorf("สร้าง token ล้มเหลว: %w", err)
+	}
+
+	refreshToken, refreshHash, err := generateOpaqueToken()
+	if err != nil {
+		return nil, fmt.Errorf("สร้าง refresh token ล้มเหลว: %w", err)
+	}
+
+	if err := i.refreshRepo.Create(ctx, domain.NewRefreshToken(user.ID, familyID, refreshHash, mfa, i.refreshTTL)); err != nil {
+		return nil, fmt.Errorf("บันทึก refresh token ล้มเหลว: %w", err)
+	}
+
+	return &domain.AuthTokens{
+		AccessToken:     accessToken,
+		AccessExpiresAt: expiresAt,
+		RefreshToken:    refreshToken,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -11,42 +12,54 @@ import (
 )
 
 type tokenService struct {
-	secretKey   []byte
-	expireHours int
+	revocationStore ports.TokenRevocationStore
+	keyRing         *KeyRing
+	accessTTL       time.Duration
 }
 
-func NewTokenService(secretKey string, expireHours int) ports.TokenService {
+// NewTokenService สร้าง TokenService — accessTTL คืออายุของ access token (ควรสั้น เช่น 15 นาที)
+func NewTokenService(
+	keyRing *KeyRing,
+	accessTTL time.Duration,
+	revocationStore ports.TokenRevocationStore,
+) ports.TokenService {
 	return &tokenService{
-		secretKey:   []byte(secretKey),
-		expireHours: expireHours,
+		revocationStore: revocationStore,
+		keyRing:         keyRing,
+		accessTTL:       accessTTL,
 	}
 }
 
-// GenerateToken สร้าง JWT token จากข้อมูลผู้ใช้
-func (s *tokenService) GenerateToken(user *domain.User) (string, error) {
+// GenerateToken สร้าง JWT access token จากข้อมูลผู้ใช้ — mfa ระบุว่า session นี้ผ่านการยืนยัน 2FA แล้ว
+func (s *tokenService) GenerateToken(user *domain.User, mfa bool) (string, time.Time, error) {
 	now := time.Now()
+	expiresAt := now.Add(s.accessTTL)
 	claims := jwt.MapClaims{
-		"user_id": user.ID.String(),                   // รหัสผู้ใช้
-		"email":   user.Email,                         // อีเมล
-		"role":    string(user.Role),                  // บทบาท
-		"exp":     now.Add(s.expireDuration()).Unix(), // เวลาหมดอายุ
-		"iat":     now.Unix(),                         // เวลาที่สร้าง
-		"nbf":     now.Unix(),                         // ใช้ได้ตั้งแต่เวลานี้ (Not Before)
-		"jti":     domain.NewID().String(),            // รหัสเฉพาะของ token (ใช้ revoke ได้ในอนาคต)
+		"user_id": user.ID.String(),        // รหัสผู้ใช้
+		"email":   user.Email,              // อีเมล
+		"role":    string(user.Role),       // บทบาท
+		"exp":     expiresAt.Unix(),        // เวลาหมดอายุ
+		"iat":     now.Unix(),              // เวลาที่สร้าง
+		"iat_ms":  now.UnixMilli(),         // เวลาที่สร้างละเอียดถึงมิลลิวินาที (เทียบกับการยกเลิกทุก session)
+		"nbf":     now.Unix(),              // ใช้ได้ตั้งแต่เวลานี้ (Not Before)
+		"jti":     domain.NewID().String(), // รหัสเฉพาะของ token (ใช้ revoke ผ่าน denylist)
+		"mfa":     mfa,                     // ผ่านการยืนยัน 2FA แล้วหรือไม่
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(s.secretKey)
+	signingKey := s.keyRing.active
+	token := jwt.NewWithClaims(signingKey.Method, claims)
+	token.Header["kid"] = signingKey.ID // ให้ผู้ตรวจสอบเลือก public key ได้ถูกตัวระหว่าง rotate
+	signedToken, err := token.SignedString(signingKey.Private)
 	if err != nil {
-		return "", fmt.Errorf("สร้าง token ล้มเหลว: %w", err)
+		return "", time.Time{}, fmt.Errorf("สร้าง token ล้มเหลว: %w", err)
 	}
 
-	return signedToken, nil
+	return signedToken, expiresAt, nil
 }
 
-// ValidateToken ตรวจสอบและถอดรหัส JWT token
-func (s *tokenService) ValidateToken(tokenString string) (*domain.TokenClaims, error) {
-	token, err := jwt.Parse(tokenString, s.keyFunc)
+// ValidateToken ตรวจสอบและถอดรหัส JWT token — token ที่อยู่ใน denylist ถือว่าไม่ถูกต้อง
+func (s *tokenService) ValidateToken(ctx context.Context, tokenString string) (*domain.TokenClaims, error) {
+	token, err := jwt.Parse(tokenString, s.keyFunc, jwt.WithValidMethods(s.keyRing.algorithms()))
 	if err != nil {
 		return nil, domain.ErrUnauthorized
 	}
@@ -56,15 +69,47 @@ func (s *tokenService) ValidateToken(tokenString string) (*domain.TokenClaims, e
 		return nil, domain.ErrUnauthorized
 	}
 
-	return s.extractClaims(claims)
+	tokenClaims, err := s.extractClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.revocationStore.IsRevoked(ctx, tokenClaims)
+	if err != nil {
+		return nil, fmt.Errorf("ตรวจสอบ token denylist ล้มเหลว: %w", err)
+	}
+	if revoked {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return tokenClaims, nil
 }
 
-// keyFunc ฟังก์ชันตรวจสอบ signing method และคืน secret key
+// keyFunc เลือกคีย์ตรวจสอบจาก kid และตรวจว่า algorithm ตรงกับคีย์ (ป้องกัน algorithm confusion)
 func (s *tokenService) keyFunc(token *jwt.Token) (any, error) {
-	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+	kid, hasKid := token.Header["kid"].(string)
+	if !hasKid {
+		kid = "" // token ที่ออกก่อนรองรับ rotation ไม่มี kid — ใช้คีย์ HS256
+	}
+
+	key, ok := s.keyRing.verificationKey(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id: %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
 		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 	}
-	return s.secretKey, nil
+	return key.Public, nil
+}
+
+// preciseIssuedAt เวลาที่ออก token จาก iat_ms — token ที่ออกก่อนมี claim นี้ใช้ iat (ปัดลงเป็นวินาที)
+// ซึ่งทำให้ token ที่ออกในวินาทีเดียวกับการยกเลิกถูกนับว่าออกก่อนเสมอ
+func preciseIssuedAt(claims jwt.MapClaims, issuedAt time.Time) time.Time {
+	ms, ok := claims["iat_ms"].(float64)
+	if !ok || int64(ms)/1000 != issuedAt.Unix() {
+		return issuedAt
+	}
+	return time.UnixMilli(int64(ms))
 }
 
 // extractClaims ดึงข้อมูลผู้ใช้จาก JWT claims
@@ -84,19 +129,35 @@ func (s *tokenService) extractClaims(claims jwt.MapClaims) (*domain.TokenClaims,
 		return nil, domain.ErrUnauthorized
 	}
 
+	tokenID, ok := claims["jti"].(string)
+	if !ok || tokenID == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
 	userID, err := domain.ParseID(userIDStr)
 	if err != nil {
 		return nil, domain.ErrUnauthorized
 	}
 
+	issuedAt, err := claims.GetIssuedAt()
+	if err != nil || issuedAt == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	mfa, _ := claims["mfa"].(bool) // token ที่ออกก่อนรองรับ 2FA ไม่มี claim นี้ — ถือว่ายังไม่ยืนยัน
+
 	return &domain.TokenClaims{
-		UserID: userID,
-		Email:  email,
-		Role:   domain.Role(roleStr),
+		UserID:    userID,
+		Email:     email,
+		Role:      domain.Role(roleStr),
+		TokenID:   tokenID,
+		IssuedAt:  preciseIssuedAt(claims, issuedAt.Time),
+		ExpiresAt: expiresAt.Time,
+		MFA:       mfa,
 	}, nil
 }
-
-// expireDuration คืนระยะเวลาหมดอายุของ token
-func (s *tokenService) expireDuration() time.Duration {
-	return time.Duration(s.expireHours) * time.Hour
-}
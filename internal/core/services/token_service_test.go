@@ -0,0 +1,258 @@
+package services
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+const testJWTSecret = "test-secret-key-that-is-at-least-32-chars"
+
+// newHMACTokenService สร้าง TokenService แบบ HS256 สำหรับทดสอบ
+func newHMACTokenService(t *testing.T, store *mockTokenRevocationStore) *tokenService {
+	t.Helper()
+	ring, err := NewKeyRing(NewHMACSigningKey(testJWTSecret))
+	require.NoError(t, err)
+	svc, ok := NewTokenService(ring, 15*time.Minute, store).(*tokenService)
+	require.True(t, ok)
+	return svc
+}
+
+// newEd25519Key สร้าง Ed25519 SigningKey ผ่าน PEM เหมือนตอนโหลดจากไฟล์
+func newEd25519Key(t *testing.T) SigningKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	require.NoError(t, err)
+
+	key, err := ParsePEMSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+	return key
+}
+
+func TestTokenService_GenerateAndValidate(t *testing.T) {
+	user := domain.NewUser("สมชาย", "ใจดี", "somchai@company.com", "hash", domain.RoleManager)
+	svc := newHMACTokenService(t, newMockTokenRevocationStore())
+
+	token, expiresAt, err := svc.GenerateToken(user, false)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, 2*time.Second)
+
+	claims, err := svc.ValidateToken(context.Background(), token)
+
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	assert.Equal(t, domain.RoleManager, claims.Role)
+	assert.NotEmpty(t, claims.TokenID, "ต้องมี jti")
+	assert.False(t, claims.MFA)
+}
+
+func TestTokenService_MFAClaim(t *testing.T) {
+	user := domain.NewUser("สมชาย", "ใจดี", "somchai@company.com", "hash", domain.RoleManager)
+	svc := newHMACTokenService(t, newMockTokenRevocationStore())
+
+	token, _, err := svc.GenerateToken(user, true)
+	require.NoError(t, err)
+
+	claims, err := svc.ValidateToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.True(t, claims.MFA)
+}
+
+func TestTokenService_ValidateToken_RevokedJTI(t *testing.T) {
+	store := newMockTokenRevocationStore()
+	svc := newHMACTokenService(t, store)
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+
+	token, _, err := svc.GenerateToken(user, false)
+	require.NoError(t, err)
+	claims, err := svc.ValidateToken(context.Background(), token)
+	require.NoError(t, err)
+
+	require.NoError(t, store.RevokeToken(context.Background(), claims.TokenID, claims.ExpiresAt))
+
+	_, err = svc.ValidateToken(context.Background(), token)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized, "token ที่อยู่ใน denylist ต้องใช้ไม่ได้")
+}
+
+func TestTokenService_ValidateToken_RevokedUser(t *testing.T) {
+	store := newMockTokenRevocationStore()
+	svc := newHMACTokenService(t, store)
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+
+	token, _, err := svc.GenerateToken(user, false)
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+	require.NoError(t, store.RevokeUserTokens(context.Background(), user.ID, time.Now()))
+	time.Sleep(2 * time.Millisecond)
+
+	fresh, _, err := svc.GenerateToken(user, false)
+	require.NoError(t, err)
+
+	_, err = svc.ValidateToken(context.Background(), token)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized, "token ที่ออกก่อนยกเลิกทุก session ต้องใช้ไม่ได้แม้อยู่ในวินาทีเดียวกัน")
+	_, err = svc.ValidateToken(context.Background(), fresh)
+	assert.NoError(t, err, "token ที่ login ใหม่หลังการยกเลิกต้องใช้ได้")
+}
+
+func TestTokenService_ValidateToken_LegacyTokenWithoutMillisecondIssuedAt(t *testing.T) {
+	store := newMockTokenRevocationStore()
+	svc := newHMACTokenService(t, store)
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+	now := time.Now().Truncate(time.Second).Add(500 * time.Millisecond)
+
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": user.ID.String(), "email": user.Email, "role": string(user.Role),
+		"exp": now.Add(time.Minute).Unix(), "iat": now.Unix(), "jti": domain.NewID().String(),
+	})
+	legacy.Header["kid"] = NewHMACSigningKey(testJWTSecret).ID
+	token, err := legacy.SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+
+	require.NoError(t, store.RevokeUserTokens(context.Background(), user.ID, now))
+
+	_, err = svc.ValidateToken(context.Background(), token)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized, "token ที่ไม่มี iat_ms ต้องถูกนับว่าออกก่อนการยกเลิกในวินาทีเดียวกัน")
+}
+
+// ─── Asymmetric Signing & Key Rotation Tests ────────────────────────────
+// ทดสอบการ sign ด้วย RS256/EdDSA, การ rotate คีย์ และ JWKS
+// ─────────────────────────────────────────────────────────────────────────
+
+func TestTokenService_RS256(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key, err := ParsePEMSigningKey(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(rsaKey),
+	}))
+	require.NoError(t, err)
+	ring, err := NewKeyRing(key)
+	require.NoError(t, err)
+
+	svc := NewTokenService(ring, 15*time.Minute, newMockTokenRevocationStore())
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+
+	token, _, err := svc.GenerateToken(user, false)
+	require.NoError(t, err)
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+	require.NoError(t, err)
+	assert.Equal(t, "RS256", parsed.Method.Alg())
+	assert.Equal(t, key.ID, parsed.Header["kid"], "ต้องใส่ kid ใน header")
+
+	claims, err := svc.ValidateToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+}
+
+func TestTokenService_KeyRotation_OldTokensStillValid(t *testing.T) {
+	oldKey := newEd25519Key(t)
+	newKey := newEd25519Key(t)
+	user := domain.NewUser("Test", "User", "test@test.com", "hash", domain.RoleEmployee)
+	store := newMockTokenRevocationStore()
+
+	oldRing, err := NewKeyRing(oldKey)
+	require.NoError(t, err)
+	oldToken, _, err := NewTokenService(oldRing, 15*time.Minute, store).GenerateToken(user, false)
+	require.NoError(t, err)
+
+	// rotate: คีย์ใหม่เป็น active, คีย์เก่าเหลือไว้ตรวจสอบอย่างเดียว (public key พอ)
+	oldPublic := SigningKey{ID: oldKey.ID, Method: oldKey.Method, Public: oldKey.Public}
+	rotatedRing, err := NewKeyRing(newKey, oldPublic)
+	require.NoError(t, err)
+	rotated := NewTokenService(rotatedRing, 15*time.Minute, store)
+
+	_, err = rotated.ValidateToken(context.Background(), oldToken)
+	require.NoError(t, err, "token ที่ sign ด้วยคีย์เก่าต้องยังใช้ได้หลัง rotate")
+
+	newToken, _, err := rotated.GenerateToken(user, false)
+	require.NoError(t, err)
+	_, err = NewTokenService(oldRing, 15*time.Minute, store).ValidateToken(context.Background(), newToken)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized, "kid ที่ไม่รู้จักต้องถูกปฏิเสธ")
+}
+
+func TestTokenService_RejectsAlgorithmConfusion(t *testing.T) {
+	key := newEd25519Key(t)
+	ring, err := NewKeyRing(key)
+	require.NoError(t, err)
+	svc := NewTokenService(ring, 15*time.Minute, newMockTokenRevocationStore())
+
+	// ปลอม token HS256 โดยใช้ public key เป็น secret พร้อม kid ของคีย์จริง
+	pub, ok := key.Public.(ed25519.PublicKey)
+	require.True(t, ok)
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": domain.NewID().String(),
+		"email":   "attacker@evil.com",
+		"role":    "admin",
+		"jti":     "forged",
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	forged.Header["kid"] = key.ID
+	forgedString, err := forged.SignedString([]byte(pub))
+	require.NoError(t, err)
+
+	_, err = svc.ValidateToken(context.Background(), forgedString)
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestTokenService_LegacyHS256TokenWithoutKid(t *testing.T) {
+	// token ที่ออกก่อนย้ายไป asymmetric key ไม่มี kid
+	legacy := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id": domain.NewID().String(),
+		"email":   "legacy@company.com",
+		"role":    "employee",
+		"jti":     "legacy-jti",
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	})
+	legacyString, err := legacy.SignedString([]byte(testJWTSecret))
+	require.NoError(t, err)
+
+	ring, err := NewKeyRing(newEd25519Key(t), NewHMACSigningKey(testJWTSecret))
+	require.NoError(t, err)
+	svc := NewTokenService(ring, 15*time.Minute, newMockTokenRevocationStore())
+
+	claims, err := svc.ValidateToken(context.Background(), legacyString)
+
+	require.NoError(t, err)
+	assert.Equal(t, "legacy@company.com", claims.Email)
+}
+
+func TestKeyRing_PublicJWKS(t *testing.T) {
+	active := newEd25519Key(t)
+	retired := newEd25519Key(t)
+	ring, err := NewKeyRing(active, retired, NewHMACSigningKey(testJWTSecret))
+	require.NoError(t, err)
+
+	jwks := ring.PublicJWKS()
+
+	require.Len(t, jwks.Keys, 2, "ต้องไม่เผยแพร่คีย์ HMAC")
+	assert.Equal(t, active.ID, jwks.Keys[0].Kid, "คีย์ active ต้องอยู่ลำดับแรก")
+	assert.Equal(t, "OKP", jwks.Keys[0].Kty)
+	assert.Equal(t, "EdDSA", jwks.Keys[0].Alg)
+	assert.Equal(t, retired.ID, jwks.Keys[1].Kid)
+}
+
+func TestNewKeyRing_RequiresPrivateKey(t *testing.T) {
+	key := newEd25519Key(t)
+	publicOnly := SigningKey{ID: key.ID, Method: key.Method, Public: key.Public}
+
+	_, err := NewKeyRing(publicOnly)
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,123 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // RFC 6238 กำหนด HMAC-SHA1 เป็นค่าเริ่มต้น และแอป authenticator ส่วนใหญ่รองรับเฉพาะแบบนี้
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ─── TOTP (RFC 6238) ────────────────────────────────────────────────────
+// รหัส 6 หลัก เปลี่ยนทุก 30 วินาที ยอมให้นาฬิกาคลาดเคลื่อน ±1 ช่วง
+// ─────────────────────────────────────────────────────────────────────────
+
+const (
+	totpPeriod        = 30 // วินาทีต่อ time step
+	totpDigits        = 6
+	totpSkew          = 1  // จำนวน step ก่อน/หลังที่ยอมรับ
+	totpSecretBytes   = 20 // 160 bits ตามที่ RFC 4226 แนะนำ
+	recoveryCodeCount = 10
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateTOTPSecret สุ่ม secret ใหม่ในรูปแบบ base32 (ไม่มี padding)
+func generateTOTPSecret() (string, error) {
+	buf := make([]byte, totpSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("สุ่ม TOTP secret ล้มเหลว: %w", err)
+	}
+	return base32NoPadding.EncodeToString(buf), nil
+}
+
+// totpURI สร้าง otpauth:// URI สำหรับแสดงเป็น QR code ในแอป authenticator
+func totpURI(issuer, account, secret string) string {
+	params := url.Values{}
+	params.Set("secret", secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprint(totpDigits))
+	params.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(issuer + ":" + account)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// totpStep คืน time step ของเวลาที่ระบุ
+func totpStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// totpCode คำนวณรหัส HOTP ของ step ที่ระบุ (RFC 4226 dynamic truncation)
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step)) //nolint:gosec // step มาจากเวลาปัจจุบัน เป็นบวกเสมอ
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1_000_000)
+}
+
+// verifyTOTP ตรวจรหัสกับ secret — คืน step ที่ตรงกัน (ใช้ป้องกันการใช้รหัสซ้ำ)
+func verifyTOTP(secret, code string, now time.Time) (int64, bool) {
+	key, err := base32NoPadding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := totpStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// isTOTPCode ตรวจว่าข้อความเป็นรหัส TOTP (ตัวเลข 6 หลัก) — ถ้าไม่ใช่จะถือว่าเป็น recovery code
+func isTOTPCode(code string) bool {
+	if len(code) != totpDigits {
+		return false
+	}
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRecoveryCodes สุ่ม recovery codes รูปแบบ xxxxx-xxxxx พร้อม hash สำหรับเก็บในฐานข้อมูล
+func generateRecoveryCodes() (codes, hashes []string, err error) {
+	codes = make([]string, 0, recoveryCodeCount)
+	hashes = make([]string, 0, recoveryCodeCount)
+
+	buf := make([]byte, 7) // 56 bits → base32 11 ตัวอักษร ใช้ 10 ตัว (50 bits)
+	for i := 0; i < recoveryCodeCount; i++ {
+		if _, err = rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("สุ่ม recovery code ล้มเหลว: %w", err)
+		}
+		raw := strings.ToLower(base32NoPadding.EncodeToString(buf))[:10]
+		codes = append(codes, raw[:5]+"-"+raw[5:])
+		hashes = append(hashes, hashRecoveryCode(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode hash recovery code หลังตัดขีด/ช่องว่างและแปลงเป็นตัวพิมพ์เล็ก — ผู้ใช้พิมพ์รูปแบบไหนก็ได้
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashOpaqueToken(normalized)
+}
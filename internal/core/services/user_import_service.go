@@ -0,0 +1,261 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// importRowErrors error ที่เกิดจากข้อมูลในแถว — รายงานเป็นรายแถวแล้วนำเข้าแถวถัดไปต่อ
+// error อื่น (เช่นฐานข้อมูลล่ม) หยุดการนำเข้าทั้งไฟล์
+var importRowErrors = []error{
+	domain.ErrInvalidImportRow,
+	domain.ErrEmailAlreadyExists,
+	domain.ErrRoleNotFound,
+	domain.ErrEntitlementBelowUsage,
+}
+
+type userImportService struct {
+	userRepo    ports.UserRepository
+	profileRepo ports.UserProfileRepository
+	balanceRepo ports.LeaveBalanceRepository
+	roleRepo    ports.RoleRepository
+	authorizer  ports.Authorizer
+	audit       ports.AuditLogger
+	tx          ports.TransactionManager
+}
+
+func NewUserImportService(
+	userRepo ports.UserRepository,
+	profileRepo ports.UserProfileRepository,
+	balanceRepo ports.LeaveBalanceRepository,
+	roleRepo ports.RoleRepository,
+	authorizer ports.Authorizer,
+	audit ports.AuditLogger,
+	tx ports.TransactionManager,
+) ports.UserImportService {
+	return &userImportService{
+		userRepo:    userRepo,
+		profileRepo: profileRepo,
+		balanceRepo: balanceRepo,
+		roleRepo:    roleRepo,
+		authorizer:  authorizer,
+		audit:       audit,
+		tx:          tx,
+	}
+}
+
+// ImportUsers ตรวจสิทธิ์ทั้งการจัดการผู้ใช้และการกำหนดวันลา เพราะไฟล์เดียวทำได้ทั้งสองอย่าง
+func (s *userImportService) ImportUsers(
+	ctx context.Context,
+	actorID domain.ID,
+	file domain.UserImportFile,
+	opts domain.UserImportOptions,
+) (*domain.UserImportReport, error) {
+	for _, permission := range []domain.Permission{domain.PermissionUserManage, domain.PermissionBalanceAdjust} {
+		if err := s.authorizer.Authorize(ctx, actorID, permission); err != nil {
+			return nil, err
+		}
+	}
+	return s.Import(ctx, file, opts)
+}
+
+// Import นำเข้าทีละแถว — แต่ละแถวบันทึกใน transaction ของตัวเอง แถวที่ไม่ผ่านจึงไม่กระทบแถวอื่น
+func (s *userImportService) Import(
+	ctx context.Context,
+	file domain.UserImportFile,
+	opts domain.UserImportOptions,
+) (*domain.UserImportReport, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	roles, err := s.roleNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Year == 0 {
+		opts.Year = time.Now().Year()
+	}
+
+	report := &domain.UserImportReport{DryRun: opts.DryRun, Upsert: opts.Upsert}
+	seen := make(map[string]int, len(file.Records)) // อีเมล → บรรทัดแรกที่พบ
+	for _, record := range file.Records {
+		result := domain.UserImportResult{Line: record.Line}
+		row, err := file.ParseRecord(record, opts.Year)
+		if err == nil {
+			result.Email = row.Email
+			result.Action, err = s.importRow(ctx, row, record.Line, opts, roles, seen)
+		}
+		if err != nil {
+			if !isImportRowError(err) {
+				return nil, fmt.Errorf("นำเข้าบรรทัดที่ %d ล้มเหลว: %w", record.Line, err)
+			}
+			result.Action, result.Error = domain.UserImportFailed, err.Error()
+		}
+		report.Add(result)
+	}
+	return report, nil
+}
+
+// importRow ตรวจแถวกับข้อมูลในระบบ แล้วบันทึกเมื่อไม่ใช่ dry run
+func (s *userImportService) importRow(
+	ctx context.Context,
+	row *domain.UserImportRow,
+	line int,
+	opts domain.UserImportOptions,
+	roles map[domain.Role]bool,
+	seen map[string]int,
+) (domain.UserImportAction, error) {
+	if first, ok := seen[row.Email]; ok {
+		return "", fmt.Errorf("%w: อีเมลซ้ำกับบรรทัดที่ %d", domain.ErrInvalidImportRow, first)
+	}
+	seen[row.Email] = line
+	if !roles[row.Role] {
+		return "", fmt.Errorf("%w: %s", domain.ErrRoleNotFound, row.Role)
+	}
+
+	existing, err := s.userRepo.FindByEmail(ctx, row.Email)
+	if err != nil && !errors.Is(err, domain.ErrUserNotFound) {
+		return "", err
+	}
+
+	action := domain.UserImportCreated
+	var balances []domain.LeaveBalance
+	if existing != nil {
+		if !opts.Upsert {
+			return "", domain.ErrEmailAlreadyExists
+		}
+		action = domain.UserImportUpdated
+		if balances, err = s.balanceRepo.FindByUserID(ctx, existing.ID); err != nil {
+			return "", fmt.Errorf("ดึงข้อมูลยอดวันลาล้มเหลว: %w", err)
+		}
+		// ตรวจก่อนบันทึก — บน standalone ที่ไม่มี transaction ผู้ใช้จะไม่ถูกแก้ถ้ายอดวันลาบันทึกไม่ได้
+		if err = checkOpeningBalances(row, balances); err != nil {
+			return "", err
+		}
+	}
+	if opts.DryRun {
+		return action, nil
+	}
+
+	err = s.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		return s.saveRow(ctx, row, existing, balances)
+	})
+	return action, err
+}
+
+// saveRow บันทึกผู้ใช้และยอดวันลายกมาพร้อม audit log — เรียกภายใน transaction
+// ไม่ส่งเหตุการณ์ balance.adjusted เข้า outbox เพื่อไม่ให้การย้ายระบบส่งอีเมล/webhook ถึงพนักงานทุกคน
+func (s *userImportService) saveRow(
+	ctx context.Context,
+	row *domain.UserImportRow,
+	existing *domain.User,
+	balances []domain.LeaveBalance,
+) error {
+	user, err := s.saveUser(ctx, row, existing)
+	if err != nil {
+		return err
+	}
+
+	for _, opening := range row.Balances {
+		before := findBalanceIn(balances, opening.LeaveType, row.Year)
+		after, err := s.balanceRepo.SetOpeningBalance(ctx, user.ID, row.Year, opening)
+		if err != nil {
+			return fmt.Errorf("%s: %w", opening.LeaveType, err)
+		}
+
+		event := domain.NewAuditEvent(
+			domain.AuditBalanceAdjusted, domain.AuditTargetLeaveBalance, after.ID.String(),
+			domain.DiffChanges(openingBalanceSnapshot(before), openingBalanceSnapshot(after)),
+		)
+		if err = recordAudit(ctx, s.audit, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// saveUser สร้างผู้ใช้ใหม่ หรือแก้ผู้ใช้เดิมเฉพาะเมื่อข้อมูลเปลี่ยน
+func (s *userImportService) saveUser(ctx context.Context, row *domain.UserImportRow, existing *domain.User) (*domain.User, error) {
+	if existing == nil {
+		user := row.NewUser()
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			return nil, err
+		}
+		event := domain.NewAuditEvent(domain.AuditUserImported, domain.AuditTargetUser, user.ID.String(),
+			domain.DiffChanges(nil, user.ImportSnapshot()))
+		return user, recordAudit(ctx, s.audit, event)
+	}
+
+	// แก้สำเนา — transaction ที่ถูกเรียกซ้ำต้องเทียบกับค่าเดิมในฐานข้อมูลเสมอ
+	user := *existing
+	row.ApplyTo(&user)
+	changes := domain.DiffChanges(existing.ImportSnapshot(), user.ImportSnapshot())
+	if len(changes) == 0 {
+		return &user, nil
+	}
+	if err := s.profileRepo.UpdateProfile(ctx, &user); err != nil {
+		return nil, err
+	}
+	event := domain.NewAuditEvent(domain.AuditUserImported, domain.AuditTargetUser, user.ID.String(), changes)
+	return &user, recordAudit(ctx, s.audit, event)
+}
+
+// roleNames ชื่อบทบาททั้งหมดในฐานข้อมูล — โหลดครั้งเดียวต่อไฟล์
+func (s *userImportService) roleNames(ctx context.Context) (map[domain.Role]bool, error) {
+	roles, err := s.roleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("ดึงข้อมูลบทบาทล้มเหลว: %w", err)
+	}
+	names := make(map[domain.Role]bool, len(roles))
+	for _, r := range roles {
+		names[r.Name] = true
+	}
+	return names, nil
+}
+
+// checkOpeningBalances วันที่ใช้ไปแล้วรวมกับวันที่จองไว้ในระบบต้องไม่เกินวันที่ได้รับใหม่
+func checkOpeningBalances(row *domain.UserImportRow, balances []domain.LeaveBalance) error {
+	for _, opening := range row.Balances {
+		current := findBalanceIn(balances, opening.LeaveType, row.Year)
+		if current != nil && opening.UsedDays+current.PendingDays > opening.TotalDays {
+			return fmt.Errorf("%s: %w", opening.LeaveType, domain.ErrEntitlementBelowUsage)
+		}
+	}
+	return nil
+}
+
+// findBalanceIn ยอดวันลาของประเภทและปีที่ระบุ — nil ถ้ายังไม่มี
+func findBalanceIn(balances []domain.LeaveBalance, leaveType domain.LeaveType, year int) *domain.LeaveBalance {
+	for i := range balances {
+		if balances[i].LeaveType == leaveType && balances[i].Year == year {
+			return &balances[i]
+		}
+	}
+	return nil
+}
+
+// openingBalanceSnapshot ค่าของยอดวันลาใน audit log — รวม used_days ที่การนำเข้ากำหนดด้วย
+func openingBalanceSnapshot(b *domain.LeaveBalance) map[string]any {
+	snapshot := b.AuditSnapshot()
+	if snapshot != nil {
+		snapshot["used_days"] = b.UsedDays
+	}
+	return snapshot
+}
+
+func isImportRowError(err error) bool {
+	for _, rowErr := range importRowErrors {
+		if errors.Is(err, rowErr) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+type userImportFixture struct {
+	users    map[string]*domain.User
+	created  []*domain.User
+	profiles *mockUserProfileRepository
+	balances []domain.LeaveBalance
+	opened   []domain.OpeningBalance
+	audit    *mockAuditLogger
+	tx       *mockTransactionManager
+}
+
+func newUserImportFixture(existing ...*domain.User) (*userImportFixture, *userImportService) {
+	f := &userImportFixture{
+		users:    make(map[string]*domain.User),
+		profiles: &mockUserProfileRepository{},
+		audit:    &mockAuditLogger{},
+		tx:       &mockTransactionManager{},
+	}
+	for _, u := range existing {
+		f.users[u.Email] = u
+	}
+
+	userRepo := &mockUserRepository{
+		findByEmailFn: func(_ context.Context, email string) (*domain.User, error) {
+			if u, ok := f.users[email]; ok {
+				return u, nil
+			}
+			return nil, domain.ErrUserNotFound
+		},
+		createFn: func(_ context.Context, user *domain.User) error {
+			f.created = append(f.created, user)
+			return nil
+		},
+	}
+	balanceRepo := &mockLeaveBalanceRepository{
+		findByUserIDFn: func(_ context.Context, _ domain.ID) ([]domain.LeaveBalance, error) {
+			return f.balances, nil
+		},
+		setOpeningBalanceFn: func(_ context.Context, userID domain.ID, year int, opening domain.OpeningBalance) (*domain.LeaveBalance, error) {
+			f.opened = append(f.opened, opening)
+			balance := domain.NewLeaveBalance(userID, opening.LeaveType, opening.TotalDays, year)
+			balance.UsedDays = opening.UsedDays
+			return balance, nil
+		},
+	}
+	roleRepo := newMockRoleRepository()
+	for _, def := range domain.DefaultRoles() {
+		roleRepo.roles[def.Name] = &def
+	}
+
+	svc := NewUserImportService(userRepo, f.profiles, balanceRepo, roleRepo, &mockAuthorizer{}, f.audit, f.tx)
+	return f, svc.(*userImportService)
+}
+
+func importFile(header []string, rows ...[]string) domain.UserImportFile {
+	file := domain.UserImportFile{Header: header}
+	for i, values := range rows {
+		file.Records = append(file.Records, domain.UserImportRecord{Line: i + 2, Values: values})
+	}
+	return file
+}
+
+var importHeader = []string{"email", "first_name", "last_name", "department", "role", "annual_leave", "annual_leave_used"}
+
+func TestUserImportService_Import_ReportsRowErrors(t *testing.T) {
+	existing := domain.NewUser("สมหญิง", "พนักงาน", "employee@company.com", "hash", domain.RoleEmployee)
+	f, svc := newUserImportFixture(existing)
+
+	file := importFile(importHeader,
+		[]string{"New@Company.com", "สมชาย", "ใจดี", "วิศวกรรม", "", "15", "3.5"},
+		[]string{"not-an-email", "ก", "ข", "", "", "", ""},
+		[]string{"new@company.com", "ซ้ำ", "ซ้ำ", "", "", "", ""},
+		[]string{"hr@company.com", "ฝ่าย", "บุคคล", "", "hr_officer", "", ""},
+		[]string{"employee@company.com", "สมหญิง", "พนักงาน", "", "", "10", ""},
+		[]string{"used@company.com", "ใช้", "เกิน", "", "", "5", "6"},
+	)
+
+	report, err := svc.Import(context.Background(), file, domain.UserImportOptions{Year: 2026})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Created)
+	assert.Equal(t, 5, report.Failed)
+	require.Len(t, report.Rows, 6)
+	assert.Equal(t, domain.UserImportResult{Line: 2, Email: "new@company.com", Action: domain.UserImportCreated}, report.Rows[0])
+	assert.Contains(t, report.Rows[2].Error, "บรรทัดที่ 2", "อีเมลซ้ำในไฟล์ต้องบอกบรรทัดแรก")
+	assert.Contains(t, report.Rows[3].Error, domain.ErrRoleNotFound.Error())
+	assert.Equal(t, domain.ErrEmailAlreadyExists.Error(), report.Rows[4].Error, "ไม่ใช่ upsert ต้องไม่แก้ผู้ใช้เดิม")
+
+	require.Len(t, f.created, 1)
+	assert.Equal(t, "วิศวกรรม", f.created[0].Department)
+	assert.Empty(t, f.created[0].PasswordHash)
+	assert.Equal(t, []domain.OpeningBalance{{LeaveType: domain.LeaveTypeAnnual, TotalDays: 15, UsedDays: 3.5}}, f.opened)
+	assert.Equal(t, []domain.AuditAction{domain.AuditUserImported, domain.AuditBalanceAdjusted}, f.audit.actions())
+}
+
+func TestUserImportService_Import_Upsert(t *testing.T) {
+	existing := domain.NewUser("สมหญิง", "พนักงาน", "employee@company.com", "hash", domain.RoleEmployee)
+	f, svc := newUserImportFixture(existing)
+
+	file := importFile(importHeader,
+		[]string{"employee@company.com", "สมหญิง", "หัวหน้า", "บัญชี", "manager", "12", "2"},
+	)
+	report, err := svc.Import(context.Background(), file, domain.UserImportOptions{Upsert: true, Year: 2026})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Empty(t, f.created)
+	require.Len(t, f.profiles.updated, 1)
+	assert.Equal(t, existing.ID, f.profiles.updated[0].ID)
+	assert.Equal(t, "สมหญิง หัวหน้า", f.profiles.updated[0].FullName)
+	assert.Equal(t, domain.RoleManager, f.profiles.updated[0].Role)
+	assert.Equal(t, domain.RoleEmployee, existing.Role, "ต้องไม่แก้ผู้ใช้ที่อ่านมาจากฐานข้อมูลโดยตรง")
+	assert.Len(t, f.opened, 1)
+}
+
+func TestUserImportService_Import_UpsertUnchangedSkipsProfile(t *testing.T) {
+	existing := domain.NewUser("สมหญิง", "พนักงาน", "employee@company.com", "hash", domain.RoleEmployee)
+	f, svc := newUserImportFixture(existing)
+
+	file := importFile([]string{"email", "first_name", "last_name"},
+		[]string{"employee@company.com", "สมหญิง", "พนักงาน"},
+	)
+	report, err := svc.Import(context.Background(), file, domain.UserImportOptions{Upsert: true})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Updated)
+	assert.Empty(t, f.profiles.updated)
+	assert.Empty(t, f.audit.events, "ข้อมูลไม่เปลี่ยนต้องไม่บันทึก audit log")
+}
+
+func TestUserImportService_Import_PendingDaysExceedOpeningBalance(t *testing.T) {
+	existing := domain.NewUser("สมหญิง", "พนักงาน", "employee@company.com", "hash", domain.RoleEmployee)
+	f, svc := newUserImportFixture(existing)
+	pending := domain.NewLeaveBalance(existing.ID, domain.LeaveTypeAnnual, 15, 2026)
+	pending.PendingDays = 5
+	f.balances = []domain.LeaveBalance{*pending}
+
+	file := importFile(importHeader,
+		[]string{"employee@company.com", "สมหญิง", "ใหม่", "", "", "10", "6"},
+	)
+	report, err := svc.Import(context.Background(), file, domain.UserImportOptions{Upsert: true, Year: 2026})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, report.Failed)
+	assert.Contains(t, report.Rows[0].Error, domain.ErrEntitlementBelowUsage.Error())
+	assert.Empty(t, f.profiles.updated, "ยอดวันลาไม่ผ่านต้องไม่แก้ผู้ใช้")
+	assert.Zero(t, f.tx.calls)
+}
+
+func TestUserImportService_Import_DryRunWritesNothing(t *testing.T) {
+	f, svc := newUserImportFixture()
+
+	file := importFile(importHeader,
+		[]string{"new@company.com", "สมชาย", "ใจดี", "", "", "15", ""},
+	)
+	report, err := svc.Import(context.Background(), file, domain.UserImportOptions{DryRun: true})
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created, "dry run รายงานสิ่งที่จะเกิดขึ้น")
+	assert.Empty(t, f.created)
+	assert.Empty(t, f.opened)
+	assert.Empty(t, f.audit.events)
+}
+
+func TestUserImportService_Import_InvalidHeader(t *testing.T) {
+	f, svc := newUserImportFixture()
+
+	file := importFile([]string{"email", "first_name", "surname"},
+		[]string{"new@company.com", "สมชาย", "ใจดี"},
+	)
+	_, err := svc.Import(context.Background(), file, domain.UserImportOptions{})
+
+	assert.ErrorIs(t, err, domain.ErrInvalidImportFile)
+	assert.Empty(t, f.created)
+}
+
+func TestUserImportService_Import_RepositoryErrorStops(t *testing.T) {
+	_, svc := newUserImportFixture()
+	errDB := errors.New("connection reset")
+	svc.userRepo.(*mockUserRepository).createFn = func(context.Context, *domain.User) error { return errDB }
+
+	file := importFile([]string{"email", "first_name", "last_name"},
+		[]string{"new@company.com", "สมชาย", "ใจดี"},
+	)
+	_, err := svc.Import(context.Background(), file, domain.UserImportOptions{})
+
+	assert.ErrorIs(t, err, errDB)
+}
+
+func TestUserImportService_ImportUsers_RequiresBalanceAdjust(t *testing.T) {
+	f, svc := newUserImportFixture()
+	svc.authorizer = &mockAuthorizer{denied: map[domain.Permission]bool{domain.PermissionBalanceAdjust: true}}
+
+	file := importFile([]string{"email", "first_name", "last_name"},
+		[]string{"new@company.com", "สมชาย", "ใจดี"},
+	)
+	_, err := svc.ImportUsers(context.Background(), domain.NewID(), file, domain.UserImportOptions{})
+
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	assert.Empty(t, f.created)
+}
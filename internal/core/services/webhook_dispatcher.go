@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+const (
+	defaultWebhookPollInterval = 5 * time.Second
+	defaultWebhookLease        = time.Minute
+	defaultWebhookSendTimeout  = 10 * time.Second
+)
+
+// header ที่ส่งไปกับ webhook ทุกครั้ง
+const (
+	WebhookHeaderEvent     = "X-Webhook-Event"     // ประเภทเหตุการณ์
+	WebhookHeaderEventID   = "X-Webhook-Event-Id"  // รหัสเหตุการณ์ — เหมือนเดิมทุกครั้งที่ส่งใหม่ ใช้ตัดรายการซ้ำ
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"  // รหัสรายการส่ง
+	WebhookHeaderTimestamp = "X-Webhook-Timestamp" // เวลาที่ sign (Unix seconds)
+	WebhookHeaderSignature = "X-Webhook-Signature" // sha256=<hex ของ HMAC-SHA256(secret, timestamp + "." + body)>
+)
+
+// WebhookDispatcherOptions ค่าตั้งค่าของ dispatcher — ค่าที่เป็นศูนย์ใช้ค่าเริ่มต้น
+type WebhookDispatcherOptions struct {
+	OnError      func(err error) // เรียกเมื่ออ่านหรือบันทึก outbox ไม่สำเร็จ (เช่น เขียน log)
+	PollInterval time.Duration   // ระยะห่างของการตรวจรายการที่ถึงเวลาส่ง
+	Lease        time.Duration   // เวลาที่จองรายการไว้ระหว่างส่ง — เกินแล้ว instance อื่นส่งซ้ำได้
+	SendTimeout  time.Duration   // เวลารอปลายทางตอบสูงสุด
+}
+
+type webhookDispatcher struct {
+	subscriptionRepo ports.WebhookSubscriptionRepository
+	deliveryRepo     ports.WebhookDeliveryRepository
+	sender           ports.WebhookSender
+	opts             WebhookDispatcherOptions
+}
+
+// NewWebhookDispatcher สร้าง dispatcher ที่ส่งรายการใน outbox — รันได้หลาย instance พร้อมกันเพราะจองรายการแบบ atomic
+func NewWebhookDispatcher(
+	subscriptionRepo ports.WebhookSubscriptionRepository,
+	deliveryRepo ports.WebhookDeliveryRepository,
+	sender ports.WebhookSender,
+	opts WebhookDispatcherOptions,
+) ports.WebhookDispatcher {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultWebhookPollInterval
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = defaultWebhookLease
+	}
+	if opts.SendTimeout <= 0 {
+		opts.SendTimeout = defaultWebhookSendTimeout
+	}
+	if opts.OnError == nil {
+		opts.OnError = func(error) {}
+	}
+	return &webhookDispatcher{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		sender:           sender,
+		opts:             opts,
+	}
+}
+
+// Run ส่งรายการที่ถึงเวลาทุก PollInterval จนกว่า ctx จะถูกยกเลิก
+func (d *webhookDispatcher) Run(ctx context.Context) {
+	pollUntilDone(ctx, d.opts.PollInterval, d.drain)
+}
+
+// drain ส่งรายการที่ถึงเวลาจนหมด
+func (d *webhookDispatcher) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivery, err := d.deliveryRepo.ClaimDue(ctx, time.Now(), d.opts.Lease)
+		if err != nil {
+			d.opts.OnError(err)
+			return
+		}
+		if delivery == nil {
+			return
+		}
+
+		d.attempt(ctx, delivery)
+		if err = d.deliveryRepo.Update(ctx, delivery); err != nil {
+			// ไม่ได้บันทึกผล — รายการจะถูกส่งซ้ำเมื่อหมด lease (at-least-once)
+			d.opts.OnError(fmt.Errorf("บันทึกผลการส่ง webhook %s ล้มเหลว: %w", delivery.ID, err))
+		}
+	}
+}
+
+// attempt ส่งรายการหนึ่งครั้งและบันทึกผลลงในรายการ
+func (d *webhookDispatcher) attempt(ctx context.Context, delivery *domain.WebhookDelivery) {
+	now := time.Now()
+	sub, err := d.subscriptionRepo.FindByID(ctx, delivery.SubscriptionID)
+	switch {
+	case errors.Is(err, domain.ErrWebhookNotFound):
+		delivery.Abandon("webhook ถูกลบแล้ว")
+		return
+	case err != nil:
+		delivery.RecordFailure(0, err.Error(), now)
+		return
+	case !sub.Active:
+		delivery.Abandon("webhook ถูกปิดใช้งาน")
+		return
+	}
+
+	body := []byte(delivery.Payload)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	headers := map[string]string{
+		"Content-Type":         "application/json",
+		WebhookHeaderEvent:     string(delivery.EventType),
+		WebhookHeaderEventID:   delivery.EventID.String(),
+		WebhookHeaderDelivery:  delivery.ID.String(),
+		WebhookHeaderTimestamp: timestamp,
+		WebhookHeaderSignature: SignWebhookPayload(sub.Secret, timestamp, body),
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, d.opts.SendTimeout)
+	status, err := d.sender.Send(sendCtx, sub.URL, headers, body)
+	cancel()
+
+	switch {
+	case err != nil:
+		delivery.RecordFailure(status, err.Error(), time.Now())
+	case status < 200 || status > 299:
+		delivery.RecordFailure(status, fmt.Sprintf("ปลายทางตอบ HTTP %d", status), time.Now())
+	default:
+		delivery.RecordSuccess(status, time.Now())
+	}
+}
+
+// SignWebhookPayload คำนวณค่า X-Webhook-Signature — ปลายทางคำนวณซ้ำด้วย secret เดียวกันเพื่อยืนยันว่ามาจากระบบนี้
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
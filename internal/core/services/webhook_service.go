@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+	"github/be2bag/leave-management-system/internal/core/ports"
+)
+
+// webhookPayload JSON ที่ส่งให้ปลายทาง — data คือใบลาหรือยอดวันลา ณ เวลาที่เกิดเหตุการณ์
+type webhookPayload struct {
+	OccurredAt time.Time             `json:"occurred_at"`
+	Data       any                   `json:"data"`
+	ID         string                `json:"id"`
+	Type       domain.LeaveEventType `json:"type"`
+	ActorID    string                `json:"actor_id,omitempty"`
+}
+
+type webhookService struct {
+	subscriptionRepo ports.WebhookSubscriptionRepository
+	deliveryRepo     ports.WebhookDeliveryRepository
+	authorizer       ports.Authorizer
+	audit            ports.AuditLogger
+}
+
+// NewWebhookService สร้าง WebhookService — การจัดการ webhook ทุกอย่างต้องมีสิทธิ์ user.manage
+func NewWebhookService(
+	subscriptionRepo ports.WebhookSubscriptionRepository,
+	deliveryRepo ports.WebhookDeliveryRepository,
+	authorizer ports.Authorizer,
+	audit ports.AuditLogger,
+) ports.WebhookService {
+	return &webhookService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		authorizer:       authorizer,
+		audit:            audit,
+	}
+}
+
+// HandlerName ชื่อของ handler ใน outbox
+func (s *webhookService) HandlerName() string {
+	return "webhook"
+}
+
+// Handle สร้าง payload ครั้งเดียวแล้วบันทึกรายการส่งของทุก webhook ที่รับเหตุการณ์นี้
+// — เหตุการณ์เดิมที่ถูกส่งต่อซ้ำไม่สร้างรายการส่งซ้ำ (unique ตามรหัสเหตุการณ์และ webhook)
+func (s *webhookService) Handle(ctx context.Context, event domain.LeaveEvent) error {
+	if !event.Type.IsValid() {
+		return nil // เหตุการณ์ที่ webhook สมัครรับไม่ได้ (comment.mentioned)
+	}
+	subs, err := s.subscriptionRepo.FindActiveByEvent(ctx, event.Type)
+	if err != nil {
+		return fmt.Errorf("ค้นหา webhook ล้มเหลว: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	payload, err := buildWebhookPayload(&event)
+	if err != nil {
+		return err
+	}
+
+	deliveries := make([]*domain.WebhookDelivery, 0, len(subs))
+	for i := range subs {
+		deliveries = append(deliveries, domain.NewWebhookDelivery(subs[i].ID, &event, payload))
+	}
+	return s.deliveryRepo.CreateMany(ctx, deliveries)
+}
+
+// buildWebhookPayload แปลงเหตุการณ์เป็น JSON ที่ส่งให้ปลายทาง
+func buildWebhookPayload(event *domain.LeaveEvent) (string, error) {
+	payload := webhookPayload{
+		ID:         event.ID.String(),
+		Type:       event.Type,
+		OccurredAt: event.OccurredAt.UTC(),
+	}
+	if event.ActorID != (domain.ID{}) {
+		payload.ActorID = event.ActorID.String()
+	}
+	if event.Balance != nil {
+		payload.Data = event.Balance
+	} else {
+		payload.Data = event.Request
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("สร้าง payload ของ webhook ล้มเหลว: %w", err)
+	}
+	return string(body), nil
+}
+
+// CreateSubscription สร้าง webhook ใหม่
+func (s *webhookService) CreateSubscription(
+	ctx context.Context,
+	actorID domain.ID,
+	url, secret string,
+	events []domain.LeaveEventType,
+) (*domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return nil, err
+	}
+
+	sub, err := domain.NewWebhookSubscription(url, secret, events, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.subscriptionRepo.Create(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewAuditEvent(
+		domain.AuditWebhookSaved, domain.AuditTargetWebhook, sub.ID.String(),
+		domain.DiffChanges(nil, sub.AuditSnapshot()),
+	).By(actorID)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// UpdateSubscription แก้ webhook — secret ว่างคือใช้ secret เดิม
+func (s *webhookService) UpdateSubscription(
+	ctx context.Context,
+	actorID, id domain.ID,
+	url, secret string,
+	events []domain.LeaveEventType,
+	active bool,
+) (*domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return nil, err
+	}
+
+	sub, err := s.subscriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := sub.AuditSnapshot()
+	if err = sub.Update(url, secret, events, active); err != nil {
+		return nil, err
+	}
+	if err = s.subscriptionRepo.Update(ctx, sub); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewAuditEvent(
+		domain.AuditWebhookSaved, domain.AuditTargetWebhook, sub.ID.String(),
+		domain.DiffChanges(before, sub.AuditSnapshot()),
+	).By(actorID)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// DeleteSubscription ลบ webhook — dispatcher ย้ายรายการที่ค้างอยู่เป็น dead เมื่อถึงเวลาส่ง
+func (s *webhookService) DeleteSubscription(ctx context.Context, actorID, id domain.ID) error {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return err
+	}
+
+	sub, err := s.subscriptionRepo.FindByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err = s.subscriptionRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	event := domain.NewAuditEvent(
+		domain.AuditWebhookDeleted, domain.AuditTargetWebhook, id.String(),
+		domain.DiffChanges(sub.AuditSnapshot(), nil),
+	).By(actorID)
+	return recordAudit(ctx, s.audit, event)
+}
+
+// ListSubscriptions ดู webhook ทั้งหมด
+func (s *webhookService) ListSubscriptions(ctx context.Context, actorID domain.ID) ([]domain.WebhookSubscription, error) {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	return s.subscriptionRepo.FindAll(ctx)
+}
+
+// ListDeliveries ค้นหารายการส่ง webhook
+func (s *webhookService) ListDeliveries(
+	ctx context.Context,
+	actorID domain.ID,
+	filter domain.WebhookDeliveryFilter,
+	params domain.PaginationParams,
+) (*domain.PaginatedResult[domain.WebhookDelivery], error) {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return nil, err
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, domain.ErrInvalidWebhookFilter
+	}
+	return s.deliveryRepo.Search(ctx, filter, params)
+}
+
+// Redeliver สั่งส่งใหม่ — ใช้ payload และรหัสเหตุการณ์เดิม ปลายทางจึงตัดรายการซ้ำได้
+func (s *webhookService) Redeliver(ctx context.Context, actorID, deliveryID domain.ID) (*domain.WebhookDelivery, error) {
+	if err := s.authorizer.Authorize(ctx, actorID, domain.PermissionUserManage); err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.deliveryRepo.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	// webhook ที่ถูกลบแล้วส่งใหม่ไม่ได้
+	if _, err = s.subscriptionRepo.FindByID(ctx, delivery.SubscriptionID); err != nil {
+		return nil, err
+	}
+
+	before := map[string]any{"status": delivery.Status, "attempts": delivery.Attempts}
+	if err = delivery.Redeliver(time.Now()); err != nil {
+		return nil, err
+	}
+	if err = s.deliveryRepo.Update(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	event := domain.NewAuditEvent(
+		domain.AuditWebhookRedelivered, domain.AuditTargetWebhookDelivery, delivery.ID.String(),
+		domain.DiffChanges(before, map[string]any{"status": delivery.Status, "attempts": delivery.Attempts}),
+	).By(actorID)
+	if err = recordAudit(ctx, s.audit, event); err != nil {
+		return nil, err
+	}
+	return delivery, nil
+}
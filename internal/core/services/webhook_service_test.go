@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/be2bag/leave-management-system/internal/core/domain"
+)
+
+const testWebhookSecret = "0123456789abcdef"
+
+func newTestWebhookSubscription(t *testing.T, events ...domain.LeaveEventType) *domain.WebhookSubscription {
+	t.Helper()
+	sub, err := domain.NewWebhookSubscription("https://hr.example.com/hooks", testWebhookSecret, events, domain.NewID())
+	require.NoError(t, err)
+	return sub
+}
+
+func newTestLeaveEvent(eventType domain.LeaveEventType) domain.LeaveEvent {
+	request := domain.NewLeaveRequest(
+		domain.NewID(), domain.LeaveTypeAnnual,
+		time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), "ไปเที่ยวกับครอบครัว",
+	)
+	return domain.NewLeaveEvent(eventType, request, domain.NewID())
+}
+
+func TestWebhookService_Handle_EnqueuesMatchingSubscriptions(t *testing.T) {
+	approved := newTestWebhookSubscription(t, domain.LeaveEventApproved)
+	submitted := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	disabled := newTestWebhookSubscription(t, domain.LeaveEventApproved)
+	disabled.Active = false
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	svc := NewWebhookService(newMockWebhookSubscriptionRepository(approved, submitted, disabled), deliveryRepo, &mockAuthorizer{}, &mockAuditLogger{})
+
+	event := newTestLeaveEvent(domain.LeaveEventApproved)
+	require.NoError(t, svc.Handle(context.Background(), event))
+
+	require.Len(t, deliveryRepo.deliveries, 1, "ต้องสร้างรายการส่งเฉพาะ webhook ที่เปิดอยู่และรับเหตุการณ์นี้")
+	delivery := deliveryRepo.deliveries[0]
+	assert.Equal(t, approved.ID, delivery.SubscriptionID)
+	assert.Equal(t, event.ID, delivery.EventID)
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal([]byte(delivery.Payload), &payload))
+	assert.Equal(t, event.ID.String(), payload["id"])
+	assert.Equal(t, "leave.approved", payload["type"])
+	assert.Equal(t, event.Request.ID.String(), payload["data"].(map[string]any)["id"])
+}
+
+func TestWebhookService_Handle_RedispatchedEventIsNotDuplicated(t *testing.T) {
+	sub := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	svc := NewWebhookService(newMockWebhookSubscriptionRepository(sub), deliveryRepo, &mockAuthorizer{}, &mockAuditLogger{})
+
+	event := newTestLeaveEvent(domain.LeaveEventSubmitted)
+	require.NoError(t, svc.Handle(context.Background(), event))
+	require.NoError(t, svc.Handle(context.Background(), event))
+
+	assert.Len(t, deliveryRepo.deliveries, 1, "outbox ส่งเหตุการณ์เดิมซ้ำได้ ต้องไม่สร้างรายการส่งซ้ำ")
+}
+
+func TestWebhookDispatcher_SignsAndDelivers(t *testing.T) {
+	sub := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	subRepo := newMockWebhookSubscriptionRepository(sub)
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	require.NoError(t, NewWebhookService(subRepo, deliveryRepo, &mockAuthorizer{}, &mockAuditLogger{}).Handle(context.Background(), newTestLeaveEvent(domain.LeaveEventSubmitted)))
+
+	sender := &mockWebhookSender{status: 204}
+	dispatcher := NewWebhookDispatcher(subRepo, deliveryRepo, sender, WebhookDispatcherOptions{}).(*webhookDispatcher)
+	dispatcher.drain(context.Background())
+
+	require.Len(t, sender.requests, 1)
+	sent := sender.requests[0]
+	delivery := deliveryRepo.deliveries[0]
+	assert.Equal(t, sub.URL, sent.url)
+	assert.Equal(t, delivery.Payload, string(sent.body))
+	assert.Equal(t, delivery.EventID.String(), sent.headers[WebhookHeaderEventID])
+	assert.Equal(t,
+		SignWebhookPayload(testWebhookSecret, sent.headers[WebhookHeaderTimestamp], sent.body),
+		sent.headers[WebhookHeaderSignature],
+		"ปลายทางต้องคำนวณ signature ซ้ำจาก secret, timestamp และ body ได้ตรงกัน",
+	)
+	assert.Equal(t, domain.WebhookDeliveryDelivered, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+}
+
+func TestWebhookDispatcher_RetriesWithBackoffThenDead(t *testing.T) {
+	sub := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	subRepo := newMockWebhookSubscriptionRepository(sub)
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	require.NoError(t, NewWebhookService(subRepo, deliveryRepo, &mockAuthorizer{}, &mockAuditLogger{}).Handle(context.Background(), newTestLeaveEvent(domain.LeaveEventSubmitted)))
+
+	sender := &mockWebhookSender{status: 500}
+	dispatcher := NewWebhookDispatcher(subRepo, deliveryRepo, sender, WebhookDispatcherOptions{}).(*webhookDispatcher)
+	dispatcher.drain(context.Background())
+
+	delivery := deliveryRepo.deliveries[0]
+	require.Len(t, sender.requests, 1, "ส่งไม่สำเร็จแล้วต้องรอ backoff ไม่ส่งซ้ำทันที")
+	assert.Equal(t, domain.WebhookDeliveryPending, delivery.Status)
+	assert.Equal(t, 500, delivery.LastStatusCode)
+	assert.WithinDuration(t, time.Now().Add(domain.WebhookRetryDelay(1)), delivery.NextAttemptAt, 5*time.Second)
+
+	delivery.Attempts = domain.MaxWebhookAttempts - 1
+	delivery.NextAttemptAt = time.Now()
+	sender.status, sender.err = 0, errors.New("connection refused")
+	dispatcher.drain(context.Background())
+
+	assert.Equal(t, domain.WebhookDeliveryDead, delivery.Status)
+	assert.Equal(t, "connection refused", delivery.LastError)
+}
+
+func TestWebhookDispatcher_AbandonsDeletedSubscription(t *testing.T) {
+	sub := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	subRepo := newMockWebhookSubscriptionRepository(sub)
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	require.NoError(t, NewWebhookService(subRepo, deliveryRepo, &mockAuthorizer{}, &mockAuditLogger{}).Handle(context.Background(), newTestLeaveEvent(domain.LeaveEventSubmitted)))
+	delete(subRepo.subs, sub.ID)
+
+	sender := &mockWebhookSender{status: 200}
+	NewWebhookDispatcher(subRepo, deliveryRepo, sender, WebhookDispatcherOptions{}).(*webhookDispatcher).drain(context.Background())
+
+	assert.Empty(t, sender.requests)
+	assert.Equal(t, domain.WebhookDeliveryDead, deliveryRepo.deliveries[0].Status)
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	sub := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	subRepo := newMockWebhookSubscriptionRepository(sub)
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	audit := &mockAuditLogger{}
+	svc := NewWebhookService(subRepo, deliveryRepo, &mockAuthorizer{}, audit)
+	require.NoError(t, svc.Handle(context.Background(), newTestLeaveEvent(domain.LeaveEventSubmitted)))
+	delivery := deliveryRepo.deliveries[0]
+
+	_, err := svc.Redeliver(context.Background(), domain.NewID(), delivery.ID)
+	assert.ErrorIs(t, err, domain.ErrWebhookDeliveryPending, "รายการที่ยังรอส่งต้องสั่งส่งใหม่ไม่ได้")
+
+	delivery.Abandon("webhook ถูกปิดใช้งาน")
+	redelivered, err := svc.Redeliver(context.Background(), domain.NewID(), delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WebhookDeliveryPending, redelivered.Status)
+	assert.Equal(t, []domain.AuditAction{domain.AuditWebhookRedelivered}, audit.actions())
+
+	delivery.Abandon("webhook ถูกลบแล้ว")
+	delete(subRepo.subs, sub.ID)
+	_, err = svc.Redeliver(context.Background(), domain.NewID(), delivery.ID)
+	assert.ErrorIs(t, err, domain.ErrWebhookNotFound)
+}
+
+func TestWebhookService_ListDeliveries_InvalidStatus(t *testing.T) {
+	svc := NewWebhookService(newMockWebhookSubscriptionRepository(), &mockWebhookDeliveryRepository{}, &mockAuthorizer{}, &mockAuditLogger{})
+
+	_, err := svc.ListDeliveries(context.Background(), domain.NewID(), domain.WebhookDeliveryFilter{Status: "failed"}, domain.NewPaginationParams(1, 10))
+
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookFilter)
+}
+
+func TestWebhookService_ManagementRequiresUserManage(t *testing.T) {
+	sub := newTestWebhookSubscription(t, domain.LeaveEventSubmitted)
+	subRepo := newMockWebhookSubscriptionRepository(sub)
+	deliveryRepo := &mockWebhookDeliveryRepository{}
+	audit := &mockAuditLogger{}
+	require.NoError(t, NewWebhookService(subRepo, deliveryRepo, &mockAuthorizer{}, audit).
+		Handle(context.Background(), newTestLeaveEvent(domain.LeaveEventSubmitted)))
+	deliveryRepo.deliveries[0].Abandon("webhook ถูกปิดใช้งาน")
+
+	denied := &mockAuthorizer{denied: map[domain.Permission]bool{domain.PermissionUserManage: true}}
+	svc := NewWebhookService(subRepo, deliveryRepo, denied, audit)
+	ctx, actorID := context.Background(), domain.NewID()
+	events := []domain.LeaveEventType{domain.LeaveEventApproved}
+
+	_, err := svc.CreateSubscription(ctx, actorID, "https://evil.example.com/hooks", testWebhookSecret, events)
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	_, err = svc.UpdateSubscription(ctx, actorID, sub.ID, "https://evil.example.com/hooks", "", events, true)
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	assert.ErrorIs(t, svc.DeleteSubscription(ctx, actorID, sub.ID), domain.ErrPermissionDenied)
+	_, err = svc.ListSubscriptions(ctx, actorID)
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	_, err = svc.ListDeliveries(ctx, actorID, domain.WebhookDeliveryFilter{}, domain.NewPaginationParams(1, 10))
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	_, err = svc.Redeliver(ctx, actorID, deliveryRepo.deliveries[0].ID)
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+
+	assert.Equal(t, "https://hr.example.com/hooks", subRepo.subs[sub.ID].URL, "ผู้ไม่มีสิทธิ์ต้องแก้ webhook ไม่ได้")
+	assert.Empty(t, audit.actions(), "คำขอที่ถูกปฏิเสธต้องไม่ถูกบันทึก audit")
+}
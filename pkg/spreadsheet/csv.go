@@ -0,0 +1,69 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// utf8BOM ทำให้ Excel เปิดไฟล์เป็น UTF-8 — ไม่มี BOM ภาษาไทยจะแสดงเป็นอักขระเพี้ยน
+const utf8BOM = "\ufeff"
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, fmt.Errorf("เขียน BOM ล้มเหลว: %w", err)
+	}
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true // ตาม RFC 4180 และที่ Excel คาดหวัง
+	return &csvWriter{w: cw}, nil
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = csvValue(v)
+	}
+	if err := c.w.Write(record); err != nil {
+		return fmt.Errorf("เขียนแถว CSV ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	if err := c.w.Error(); err != nil {
+		return fmt.Errorf("เขียนไฟล์ CSV ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+func csvValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(v)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return escapeFormula(fmt.Sprint(v))
+	}
+}
+
+// escapeFormula เติม ' หน้าข้อความที่ Excel จะตีความเป็นสูตร (CSV injection) เช่นเหตุผลการลาที่ขึ้นต้นด้วย =
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
@@ -0,0 +1,59 @@
+package spreadsheet
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Row แถวข้อมูลที่อ่านจากไฟล์ — Line คือบรรทัดที่แถวเริ่มต้น (แถวหัวตารางคือบรรทัด 1) ใช้รายงานข้อผิดพลาดให้ตรงกับไฟล์
+type Row struct {
+	Line   int
+	Values []string
+}
+
+// ReadCSV อ่าน CSV ทั้งไฟล์ — แถวแรกคือหัวตาราง, ข้าม UTF-8 BOM ที่ Excel เติมให้, ตัดช่องว่างหัวท้ายทุกค่า
+// และข้ามบรรทัดว่าง แถวที่จำนวนคอลัมน์ไม่ตรงกับหัวตารางยังถูกคืนมาให้ผู้เรียกรายงานเป็นรายแถว
+func ReadCSV(r io.Reader, maxRows int) (header []string, rows []Row, err error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(len(utf8BOM)); string(bom) == utf8BOM {
+		br.Discard(len(utf8BOM)) //nolint:errcheck // peek สำเร็จแล้วจึง discard ได้เสมอ
+	}
+
+	cr := csv.NewReader(br)
+	cr.FieldsPerRecord = -1
+
+	header, err = cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, ErrMissingHeader
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("อ่านหัวตาราง CSV ล้มเหลว: %w", err)
+	}
+	trimAll(header)
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return header, rows, nil
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("อ่านไฟล์ CSV ล้มเหลว: %w", err)
+		}
+		if len(rows) == maxRows {
+			return nil, nil, ErrTooManyRows
+		}
+		line, _ := cr.FieldPos(0)
+		trimAll(record)
+		rows = append(rows, Row{Line: line, Values: record})
+	}
+}
+
+func trimAll(values []string) {
+	for i, v := range values {
+		values[i] = strings.TrimSpace(v)
+	}
+}
@@ -0,0 +1,100 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSVWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatCSV, &out, "")
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow([]any{"full_name", "total_days", "reason"}))
+	require.NoError(t, w.WriteRow([]any{"สมชาย ใจดี", 1.5, "=HYPERLINK(\"x\")"}))
+	require.NoError(t, w.WriteRow([]any{"a,b", int64(2), nil}))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t,
+		"\ufefffull_name,total_days,reason\r\n"+
+			"สมชาย ใจดี,1.5,\"'=HYPERLINK(\"\"x\"\")\"\r\n"+
+			"\"a,b\",2,\r\n",
+		out.String(),
+	)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(FormatXLSX, &out, "ใบลา")
+	require.NoError(t, err)
+
+	require.NoError(t, w.WriteRow([]any{"full_name", "total_days"}))
+	require.NoError(t, w.WriteRow([]any{"สมชาย <ใจดี>", 1.5}))
+	require.NoError(t, w.Close())
+
+	zr, err := zip.NewReader(bytes.NewReader(out.Bytes()), int64(out.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(content)
+	}
+
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files["xl/workbook.xml"], `name="ใบลา"`)
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row r="2"><c r="A2" t="inlineStr"><is><t xml:space="preserve">สมชาย &lt;ใจดี&gt;</t></is></c><c r="B2"><v>1.5</v></c></row>`)
+	assert.Contains(t, sheet, `</sheetData></worksheet>`)
+}
+
+func TestNewWriter_UnsupportedFormat(t *testing.T) {
+	_, err := NewWriter("pdf", io.Discard, "")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", columnName(0))
+	assert.Equal(t, "Z", columnName(25))
+	assert.Equal(t, "AA", columnName(26))
+	assert.Equal(t, "AZ", columnName(51))
+	assert.Equal(t, "BA", columnName(52))
+}
+
+func TestReadCSV(t *testing.T) {
+	input := "\ufeffemail, first_name\r\n" +
+		"a@company.com,\"สมชาย\"\r\n" +
+		"\r\n" +
+		"b@company.com,\"หลาย\nบรรทัด\",เกิน\r\n" +
+		"c@company.com\r\n"
+
+	header, rows, err := ReadCSV(strings.NewReader(input), 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"email", "first_name"}, header)
+	require.Len(t, rows, 3)
+	assert.Equal(t, Row{Line: 2, Values: []string{"a@company.com", "สมชาย"}}, rows[0])
+	assert.Equal(t, 4, rows[1].Line, "ข้ามบรรทัดว่าง")
+	assert.Len(t, rows[1].Values, 3, "จำนวนคอลัมน์ไม่ตรงให้ผู้เรียกตรวจเอง")
+	assert.Equal(t, 6, rows[2].Line, "ค่าที่มีขึ้นบรรทัดใหม่นับบรรทัดตามไฟล์")
+}
+
+func TestReadCSV_Errors(t *testing.T) {
+	_, _, err := ReadCSV(strings.NewReader(""), 10)
+	assert.ErrorIs(t, err, ErrMissingHeader)
+
+	_, _, err = ReadCSV(strings.NewReader("email\na\nb\nc\n"), 2)
+	assert.ErrorIs(t, err, ErrTooManyRows)
+
+	_, _, err = ReadCSV(strings.NewReader("email\n\"a\n"), 2)
+	assert.Error(t, err)
+}
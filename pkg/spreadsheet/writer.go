@@ -0,0 +1,56 @@
+// Package spreadsheet เขียนตารางเป็น CSV หรือ XLSX ทีละแถว — ไม่เก็บทั้งไฟล์ไว้ในหน่วยความจำ — และอ่านตารางจาก CSV
+package spreadsheet
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("ไม่รองรับรูปแบบไฟล์นี้")
+	ErrTooManyRows       = errors.New("จำนวนแถวเกินกว่าที่ไฟล์รองรับ")
+	ErrMissingHeader     = errors.New("ไฟล์ไม่มีแถวหัวตาราง")
+)
+
+// Format รูปแบบไฟล์ที่เขียนได้
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatCSV, FormatXLSX:
+		return true
+	}
+	return false
+}
+
+// ContentType MIME type ของไฟล์
+func (f Format) ContentType() string {
+	if f == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Writer เขียนตารางทีละแถว — ค่าในแถวเป็น string หรือตัวเลข (int, int64, float64), nil คือช่องว่าง
+// ต้องเรียก Close เพื่อเขียนส่วนท้ายของไฟล์ (Close ไม่ปิด io.Writer ที่ส่งเข้ามา)
+type Writer interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+// NewWriter สร้าง Writer ตามรูปแบบไฟล์ — sheetName ใช้เฉพาะ XLSX
+func NewWriter(format Format, w io.Writer, sheetName string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w)
+	case FormatXLSX:
+		return newXLSXWriter(w, sheetName)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
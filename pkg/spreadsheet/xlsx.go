@@ -0,0 +1,160 @@
+package spreadsheet
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// maxXLSXRows จำนวนแถวสูงสุดของ worksheet ใน Excel
+const maxXLSXRows = 1 << 20
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxWriter เขียน workbook ที่มี worksheet เดียว — ส่วนคงที่เขียนก่อน แล้ว worksheet ถูก deflate ลง zip ทีละแถว
+// ข้อความใช้ inline string จึงไม่ต้องเก็บ shared strings ทั้งไฟล์ไว้ในหน่วยความจำ และ Excel ไม่ตีความเป็นสูตร
+type xlsxWriter struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	buf   bytes.Buffer
+	rows  int
+}
+
+func newXLSXWriter(w io.Writer, sheetName string) (*xlsxWriter, error) {
+	x := &xlsxWriter{zip: zip.NewWriter(w)}
+
+	var name bytes.Buffer
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return nil, fmt.Errorf("ชื่อ worksheet ไม่ถูกต้อง: %w", err)
+	}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, p := range parts {
+		if err := x.writePart(p.name, p.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheet, err := x.zip.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("สร้าง worksheet ล้มเหลว: %w", err)
+	}
+	if _, err := io.WriteString(sheet, xlsxSheetStart); err != nil {
+		return nil, fmt.Errorf("เขียน worksheet ล้มเหลว: %w", err)
+	}
+	x.sheet = sheet
+	return x, nil
+}
+
+func (x *xlsxWriter) writePart(name, content string) error {
+	part, err := x.zip.Create(name)
+	if err != nil {
+		return fmt.Errorf("สร้าง %s ล้มเหลว: %w", name, err)
+	}
+	if _, err := io.WriteString(part, content); err != nil {
+		return fmt.Errorf("เขียน %s ล้มเหลว: %w", name, err)
+	}
+	return nil
+}
+
+func (x *xlsxWriter) WriteRow(values []any) error {
+	if x.rows >= maxXLSXRows {
+		return ErrTooManyRows
+	}
+	x.rows++
+
+	x.buf.Reset()
+	row := strconv.Itoa(x.rows)
+	x.buf.WriteString(`<row r="` + row + `">`)
+	for i, v := range values {
+		ref := columnName(i) + row
+		if err := x.writeCell(ref, v); err != nil {
+			return err
+		}
+	}
+	x.buf.WriteString(`</row>`)
+
+	if _, err := x.sheet.Write(x.buf.Bytes()); err != nil {
+		return fmt.Errorf("เขียนแถว XLSX ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+func (x *xlsxWriter) writeCell(ref string, v any) error {
+	var number string
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case int:
+		number = strconv.Itoa(v)
+	case int64:
+		number = strconv.FormatInt(v, 10)
+	case float64:
+		number = strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return x.writeString(ref, v)
+	default:
+		return x.writeString(ref, fmt.Sprint(v))
+	}
+	x.buf.WriteString(`<c r="` + ref + `"><v>` + number + `</v></c>`)
+	return nil
+}
+
+// writeString เขียนข้อความเป็น inline string — xml.EscapeText แทนอักขระที่ XML ไม่รองรับด้วย U+FFFD
+func (x *xlsxWriter) writeString(ref, s string) error {
+	x.buf.WriteString(`<c r="` + ref + `" t="inlineStr"><is><t xml:space="preserve">`)
+	if err := xml.EscapeText(&x.buf, []byte(s)); err != nil {
+		return fmt.Errorf("เขียนข้อความ XLSX ล้มเหลว: %w", err)
+	}
+	x.buf.WriteString(`</t></is></c>`)
+	return nil
+}
+
+func (x *xlsxWriter) Close() error {
+	if _, err := io.WriteString(x.sheet, xlsxSheetEnd); err != nil {
+		return fmt.Errorf("เขียน worksheet ล้มเหลว: %w", err)
+	}
+	if err := x.zip.Close(); err != nil {
+		return fmt.Errorf("เขียนไฟล์ XLSX ล้มเหลว: %w", err)
+	}
+	return nil
+}
+
+// columnName แปลงลำดับคอลัมน์ (เริ่มจาก 0) เป็นชื่อแบบ Excel: A, B, …, Z, AA, AB, …
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
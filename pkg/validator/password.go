@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// PasswordPolicy นโยบายความแข็งแรงของรหัสผ่าน — ใช้กับ tag `password`
+type PasswordPolicy struct {
+	MinLength     int  // ความยาวขั้นต่ำ (นับเป็นตัวอักษร)
+	RequireUpper  bool // ต้องมีตัวพิมพ์ใหญ่
+	RequireLower  bool // ต้องมีตัวพิมพ์เล็ก
+	RequireDigit  bool // ต้องมีตัวเลข
+	RequireSymbol bool // ต้องมีสัญลักษณ์หรือเครื่องหมายวรรคตอน
+}
+
+// DefaultPasswordPolicy นโยบายเริ่มต้น: อย่างน้อย 10 ตัวอักษร มีตัวพิมพ์ใหญ่ ตัวพิมพ์เล็ก และตัวเลข
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:    10,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}
+}
+
+// ParsePasswordPolicy สร้างนโยบายจากความยาวขั้นต่ำและรายการชนิดตัวอักษรที่บังคับ
+// (คั่นด้วย comma: upper, lower, digit, symbol)
+func ParsePasswordPolicy(minLength int, classes string) (PasswordPolicy, error) {
+	policy := PasswordPolicy{MinLength: minLength}
+
+	for _, class := range strings.Split(classes, ",") {
+		switch strings.TrimSpace(strings.ToLower(class)) {
+		case "":
+		case "upper":
+			policy.RequireUpper = true
+		case "lower":
+			policy.RequireLower = true
+		case "digit":
+			policy.RequireDigit = true
+		case "symbol":
+			policy.RequireSymbol = true
+		default:
+			return PasswordPolicy{}, fmt.Errorf("ชนิดตัวอักษรไม่ถูกต้อง: %q (ใช้ได้: upper, lower, digit, symbol)", class)
+		}
+	}
+
+	return policy, nil
+}
+
+// Check ตรวจสอบว่ารหัสผ่านผ่านนโยบายหรือไม่
+func (p PasswordPolicy) Check(password string) bool {
+	var length int
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+
+	for _, r := range password {
+		length++
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r):
+			hasSymbol = true
+		}
+	}
+
+	return length >= p.MinLength &&
+		(!p.RequireUpper || hasUpper) &&
+		(!p.RequireLower || hasLower) &&
+		(!p.RequireDigit || hasDigit) &&
+		(!p.RequireSymbol || hasSymbol)
+}
+
+// Describe อธิบายนโยบายเป็นข้อความภาษาอังกฤษ (ใช้ต่อท้ายชื่อ field ใน validation error)
+func (p PasswordPolicy) Describe() string {
+	required := make([]string, 0, 4)
+	if p.RequireUpper {
+		required = append(required, "an uppercase letter")
+	}
+	if p.RequireLower {
+		required = append(required, "a lowercase letter")
+	}
+	if p.RequireDigit {
+		required = append(required, "a digit")
+	}
+	if p.RequireSymbol {
+		required = append(required, "a symbol")
+	}
+
+	msg := fmt.Sprintf("must be at least %d characters", p.MinLength)
+	if len(required) > 0 {
+		msg += " and contain " + strings.Join(required, ", ")
+	}
+	return msg
+}
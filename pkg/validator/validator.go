@@ -7,13 +7,27 @@ import (
 )
 
 type Validator struct {
-	validate *validator.Validate
+	validate       *validator.Validate
+	passwordPolicy PasswordPolicy
 }
 
+// New สร้าง Validator ที่ใช้นโยบายรหัสผ่านเริ่มต้น
 func New() *Validator {
-	return &Validator{
-		validate: validator.New(),
+	return NewWithPasswordPolicy(DefaultPasswordPolicy())
+}
+
+// NewWithPasswordPolicy สร้าง Validator พร้อมนโยบายรหัสผ่านสำหรับ tag `password`
+func NewWithPasswordPolicy(policy PasswordPolicy) *Validator {
+	v := &Validator{
+		validate:       validator.New(),
+		passwordPolicy: policy,
 	}
+
+	v.validate.RegisterValidation("password", func(fl validator.FieldLevel) bool { //nolint:errcheck // tag name และ function คงที่ ไม่มีทาง error
+		return policy.Check(fl.Field().String())
+	})
+
+	return v
 }
 
 // Validate ตรวจสอบ struct ตาม validation tags
@@ -31,6 +45,10 @@ func (v *Validator) Validate(s any) []string {
 
 	errs := make([]string, 0, len(validationErrors))
 	for _, e := range validationErrors {
+		if e.Tag() == "password" {
+			errs = append(errs, fmt.Sprintf("%s %s", e.Field(), v.passwordPolicy.Describe()))
+			continue
+		}
 		errs = append(errs, formatValidationError(e))
 	}
 
@@ -50,6 +68,10 @@ func formatValidationError(e validator.FieldError) string {
 		return fmt.Sprintf("%s must be at least %s characters", field, e.Param())
 	case "max":
 		return fmt.Sprintf("%s must be at most %s characters", field, e.Param())
+	case "gte":
+		return fmt.Sprintf("%s must be greater than or equal to %s", field, e.Param())
+	case "lte":
+		return fmt.Sprintf("%s must be less than or equal to %s", field, e.Param())
 	case "oneof":
 		return fmt.Sprintf("%s must be one of: %s", field, e.Param())
 	default:
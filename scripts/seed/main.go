@@ -19,6 +19,7 @@ import (
 // สร้างข้อมูลเริ่มต้นสำหรับทดสอบระบบ
 // - 1 Manager: manager@company.com / password123
 // - 1 Employee: employee@company.com / password123
+// - 1 Admin: admin@company.com / password123 (ไม่มียอดวันลา — ใช้จัดการระบบ)
 // - ยอดวันลาเริ่มต้นสำหรับทั้งสองคน
 //
 // วิธีใช้: go run scripts/seed/main.go
@@ -49,8 +50,9 @@ func main() {
 
 	managerID := uuid.New()
 	employeeID := uuid.New()
+	adminID := uuid.New()
 
-	createUsers(ctx, db, managerID, employeeID)
+	createUsers(ctx, db, managerID, employeeID, adminID)
 	createLeaveBalances(ctx, db, managerID, employeeID)
 
 	fmt.Println("")
@@ -67,12 +69,22 @@ func main() {
 	fmt.Println("   Email:    employee@company.com")
 	fmt.Println("   Password: password123")
 	fmt.Printf("   UserID:   %s\n", employeeID)
+	fmt.Println("")
+	fmt.Println("🛡️  Admin:")
+	fmt.Println("   Email:    admin@company.com")
+	fmt.Println("   Password: password123")
+	fmt.Printf("   UserID:   %s\n", adminID)
 	fmt.Println("─────────────────────────────────────────────────")
 }
 
 // dropCollections ลบ collections ทั้งหมดเพื่อเริ่มต้นใหม่
 func dropCollections(ctx context.Context, db *mongo.Database) {
-	collections := []string{"users", "leave_balances", "leave_requests"}
+	collections := []string{
+		"users", "leave_balances", "leave_requests", "refresh_tokens", "token_revocations", "password_reset_tokens",
+		"login_attempts", "security_events", "user_mfa", "mfa_challenges", "oidc_states",
+		"service_accounts", "roles", "audit_events", "leave_request_history", "leave_comments",
+		"webhook_subscriptions", "webhook_deliveries", "outbox", "idempotency_keys",
+	}
 	for _, name := range collections {
 		if err := db.Collection(name).Drop(ctx); err != nil {
 			log.Printf("คำเตือน: ลบ collection %s ไม่สำเร็จ: %v", name, err)
@@ -81,10 +93,11 @@ func dropCollections(ctx context.Context, db *mongo.Database) {
 	fmt.Println("🗑️  ลบข้อมูลเก่าสำเร็จ")
 }
 
-// createUsers สร้างผู้ใช้ตัวอย่าง (Manager + Employee)
-func createUsers(ctx context.Context, db *mongo.Database, managerID, employeeID uuid.UUID) {
+// createUsers สร้างผู้ใช้ตัวอย่าง (Manager + Employee + Admin)
+func createUsers(ctx context.Context, db *mongo.Database, managerID, employeeID, adminID uuid.UUID) {
 	managerHash := hashPassword("password123")
 	employeeHash := hashPassword("password123")
+	adminHash := hashPassword("password123")
 	now := time.Now()
 
 	users := []interface{}{
@@ -94,6 +107,7 @@ func createUsers(ctx context.Context, db *mongo.Database, managerID, employeeID
 			"last_name":     "ผู้จัดการ",
 			"full_name":     "สมชาย ผู้จัดการ",
 			"email":         "manager@company.com",
+			"department":    "ฝ่ายบุคคล",
 			"password_hash": managerHash,
 			"role":          "manager",
 			"created_at":    now,
@@ -105,11 +119,24 @@ func createUsers(ctx context.Context, db *mongo.Database, managerID, employeeID
 			"last_name":     "พนักงาน",
 			"full_name":     "สมหญิง พนักงาน",
 			"email":         "employee@company.com",
+			"department":    "ฝ่ายบุคคล",
 			"password_hash": employeeHash,
 			"role":          "employee",
 			"created_at":    now,
 			"updated_at":    now,
 		},
+		bson.M{
+			"_id":           adminID,
+			"first_name":    "สมศักดิ์",
+			"last_name":     "ผู้ดูแลระบบ",
+			"full_name":     "สมศักดิ์ ผู้ดูแลระบบ",
+			"email":         "admin@company.com",
+			"department":    "ฝ่ายเทคโนโลยีสารสนเทศ",
+			"password_hash": adminHash,
+			"role":          "admin",
+			"created_at":    now,
+			"updated_at":    now,
+		},
 	}
 
 	col := db.Collection("users")
@@ -125,7 +152,7 @@ func createUsers(ctx context.Context, db *mongo.Database, managerID, employeeID
 		log.Printf("คำเตือน: สร้าง index email ไม่สำเร็จ: %v", err)
 	}
 
-	fmt.Println("👥 สร้างผู้ใช้ตัวอย่างสำเร็จ (Manager + Employee)")
+	fmt.Println("👥 สร้างผู้ใช้ตัวอย่างสำเร็จ (Manager + Employee + Admin)")
 }
 
 // createLeaveBalances สร้างยอดวันลาเริ่มต้น